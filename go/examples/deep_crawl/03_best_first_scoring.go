@@ -69,15 +69,26 @@ func bestFirstForDocumentation() {
 	}
 	defer crawler.Close()
 
+	scorers, err := (&crawl4ai.CompositeScorer{
+		Keywords: &crawl4ai.KeywordScorer{
+			Keywords: []string{"api", "reference", "method", "function", "parameter"},
+			Weight:   3.0,
+		},
+		Depth: &crawl4ai.DepthScorer{OptimalDepth: 2, Weight: 1.0},
+		PathPatterns: &crawl4ai.PathPatternScorer{Patterns: []crawl4ai.PathPattern{
+			{Pattern: "/api/*", Score: 1.0},
+			{Pattern: "/blog/*", Score: 0.1},
+		}},
+	}).ToMap()
+	if err != nil {
+		log.Fatalf("Invalid scorers: %v", err)
+	}
+
 	result, err := crawler.DeepCrawl("https://docs.crawl4ai.com", &crawl4ai.DeepCrawlOptions{
 		Strategy: "best_first",
 		MaxDepth: 3,
 		MaxURLs:  30,
-		Scorers: map[string]interface{}{
-			"keywords":      []string{"api", "reference", "method", "function", "parameter"},
-			"optimal_depth": 2,
-			"weights":       map[string]float64{"keywords": 3.0, "depth": 1.0},
-		},
+		Scorers:  scorers,
 		Filters: map[string]interface{}{
 			"patterns": []string{"/api/*", "/reference/*", "/docs/*"},
 		},
@@ -89,6 +100,14 @@ func bestFirstForDocumentation() {
 
 	if result.CrawlJob != nil {
 		fmt.Printf("API docs found: %d\n", result.CrawlJob.Progress.Completed)
+
+		// Verify prioritization: each result carries the score it was queued with.
+		crawl4ai.SortByScore(result.CrawlJob.Results)
+		for _, r := range result.CrawlJob.Results {
+			if score, ok := r.Score(); ok {
+				fmt.Printf("  %.2f  %s\n", score, r.URL)
+			}
+		}
 	}
 }
 
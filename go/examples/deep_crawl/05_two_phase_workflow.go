@@ -59,20 +59,21 @@ func basicTwoPhase() {
 	// ========== PHASE 2: EXTRACT ==========
 	fmt.Println("Phase 2: Extracting from cached HTML...")
 
+	strategy, err := crawl4ai.JSONCSSExtraction(&crawl4ai.Schema{
+		Name:         "PageContent",
+		BaseSelector: "main, article, .content",
+		Fields: []crawl4ai.Field{
+			crawl4ai.NewTextField("title", "h1"),
+			crawl4ai.NewListField("headings", "h2, h3", crawl4ai.NewTextField("text", "")),
+		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid schema: %v", err)
+	}
 	extractResult, err := crawler.DeepCrawl("", &crawl4ai.DeepCrawlOptions{
 		SourceJob: scanResult.DeepResult.JobID, // Use cached HTML
 		Config: &crawl4ai.CrawlerRunConfig{
-			ExtractionStrategy: map[string]interface{}{
-				"type": "json_css",
-				"schema": map[string]interface{}{
-					"name":         "PageContent",
-					"baseSelector": "main, article, .content",
-					"fields": []map[string]interface{}{
-						{"name": "title", "selector": "h1", "type": "text"},
-						{"name": "headings", "selector": "h2, h3", "type": "list"},
-					},
-				},
-			},
+			ExtractionStrategy: strategy,
 		},
 		Wait: true,
 	})
@@ -116,19 +117,20 @@ func multipleExtractions() {
 
 	// EXTRACT #1: Titles only
 	fmt.Println("Extraction 1: Titles...")
+	titles, err := crawl4ai.JSONCSSExtraction(&crawl4ai.Schema{
+		Name:         "Titles",
+		BaseSelector: "body",
+		Fields: []crawl4ai.Field{
+			crawl4ai.NewTextField("title", "h1"),
+		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid schema: %v", err)
+	}
 	job1, _ := crawler.DeepCrawl("", &crawl4ai.DeepCrawlOptions{
 		SourceJob: scanJobID,
 		Config: &crawl4ai.CrawlerRunConfig{
-			ExtractionStrategy: map[string]interface{}{
-				"type": "json_css",
-				"schema": map[string]interface{}{
-					"name":         "Titles",
-					"baseSelector": "body",
-					"fields": []map[string]interface{}{
-						{"name": "title", "selector": "h1", "type": "text"},
-					},
-				},
-			},
+			ExtractionStrategy: titles,
 		},
 		Wait: true,
 	})
@@ -138,19 +140,20 @@ func multipleExtractions() {
 
 	// EXTRACT #2: Links
 	fmt.Println("Extraction 2: Links...")
+	links, err := crawl4ai.JSONCSSExtraction(&crawl4ai.Schema{
+		Name:         "Links",
+		BaseSelector: "body",
+		Fields: []crawl4ai.Field{
+			crawl4ai.NewListField("links", "a[href]", crawl4ai.NewAttributeField("href", "", "href")),
+		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid schema: %v", err)
+	}
 	job2, _ := crawler.DeepCrawl("", &crawl4ai.DeepCrawlOptions{
 		SourceJob: scanJobID,
 		Config: &crawl4ai.CrawlerRunConfig{
-			ExtractionStrategy: map[string]interface{}{
-				"type": "json_css",
-				"schema": map[string]interface{}{
-					"name":         "Links",
-					"baseSelector": "body",
-					"fields": []map[string]interface{}{
-						{"name": "links", "selector": "a[href]", "type": "list", "attribute": "href"},
-					},
-				},
-			},
+			ExtractionStrategy: links,
 		},
 		Wait: true,
 	})
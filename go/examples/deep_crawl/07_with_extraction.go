@@ -30,15 +30,18 @@ func cssExtraction() {
 	defer crawler.Close()
 
 	// Define CSS extraction schema
-	schema := map[string]interface{}{
-		"name":         "Documentation",
-		"baseSelector": "main, article, .content",
-		"fields": []map[string]interface{}{
-			{"name": "title", "selector": "h1", "type": "text"},
-			{"name": "description", "selector": "p.description, .intro, meta[name='description']", "type": "text"},
-			{"name": "headings", "selector": "h2, h3", "type": "list"},
-			{"name": "code_blocks", "selector": "pre code, .highlight", "type": "list"},
+	strategy, err := crawl4ai.JSONCSSExtraction(&crawl4ai.Schema{
+		Name:         "Documentation",
+		BaseSelector: "main, article, .content",
+		Fields: []crawl4ai.Field{
+			crawl4ai.NewTextField("title", "h1"),
+			crawl4ai.NewTextField("description", "p.description, .intro, meta[name='description']"),
+			crawl4ai.NewListField("headings", "h2, h3", crawl4ai.NewTextField("text", "")),
+			crawl4ai.NewListField("code_blocks", "pre code, .highlight", crawl4ai.NewTextField("code", "")),
 		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid schema: %v", err)
 	}
 
 	result, err := crawler.DeepCrawl("https://docs.crawl4ai.com", &crawl4ai.DeepCrawlOptions{
@@ -46,10 +49,7 @@ func cssExtraction() {
 		MaxDepth: 1,
 		MaxURLs:  5,
 		Config: &crawl4ai.CrawlerRunConfig{
-			ExtractionStrategy: map[string]interface{}{
-				"type":   "json_css",
-				"schema": schema,
-			},
+			ExtractionStrategy: strategy,
 		},
 		Wait: true,
 	})
@@ -100,23 +100,23 @@ func extractionWithAttributes() {
 	}
 	defer crawler.Close()
 
-	schema := map[string]interface{}{
-		"name":         "PageAssets",
-		"baseSelector": "body",
-		"fields": []map[string]interface{}{
-			{"name": "links", "selector": "a[href]", "type": "list", "attribute": "href"},
-			{"name": "images", "selector": "img[src]", "type": "list", "attribute": "src"},
+	strategy, err := crawl4ai.JSONCSSExtraction(&crawl4ai.Schema{
+		Name:         "PageAssets",
+		BaseSelector: "body",
+		Fields: []crawl4ai.Field{
+			crawl4ai.NewListField("links", "a[href]", crawl4ai.NewAttributeField("href", "", "href")),
+			crawl4ai.NewListField("images", "img[src]", crawl4ai.NewAttributeField("src", "", "src")),
 		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid schema: %v", err)
 	}
 
 	result, err := crawler.DeepCrawl("https://docs.crawl4ai.com", &crawl4ai.DeepCrawlOptions{
 		Strategy: "map",
 		MaxURLs:  3,
 		Config: &crawl4ai.CrawlerRunConfig{
-			ExtractionStrategy: map[string]interface{}{
-				"type":   "json_css",
-				"schema": schema,
-			},
+			ExtractionStrategy: strategy,
 		},
 		Wait: true,
 	})
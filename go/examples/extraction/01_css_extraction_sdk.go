@@ -28,21 +28,20 @@ func main() {
 	defer crawler.Close()
 
 	// Define CSS extraction schema
-	config := &crawl4ai.CrawlerRunConfig{
-		ExtractionStrategy: map[string]interface{}{
-			"type": "json_css",
-			"schema": map[string]interface{}{
-				"name":         "HackerNewsStories",
-				"baseSelector": ".athing",
-				"fields": []map[string]interface{}{
-					{"name": "title", "selector": ".titleline > a", "type": "text"},
-					{"name": "url", "selector": ".titleline > a", "type": "attribute", "attribute": "href"},
-					{"name": "points", "selector": "+ tr .score", "type": "text"},
-					{"name": "author", "selector": "+ tr .hnuser", "type": "text"},
-				},
-			},
+	strategy, err := crawl4ai.JSONCSSExtraction(&crawl4ai.Schema{
+		Name:         "HackerNewsStories",
+		BaseSelector: ".athing",
+		Fields: []crawl4ai.Field{
+			crawl4ai.NewTextField("title", ".titleline > a"),
+			crawl4ai.NewAttributeField("url", ".titleline > a", "href"),
+			crawl4ai.NewTextField("points", "+ tr .score"),
+			crawl4ai.NewTextField("author", "+ tr .hnuser"),
 		},
+	})
+	if err != nil {
+		log.Fatalf("Invalid schema: %v", err)
 	}
+	config := &crawl4ai.CrawlerRunConfig{ExtractionStrategy: strategy}
 
 	fmt.Println("Crawling Hacker News with CSS extraction...")
 	result, err := crawler.Run("https://news.ycombinator.com", &crawl4ai.RunOptions{
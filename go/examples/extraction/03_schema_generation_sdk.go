@@ -59,12 +59,15 @@ func main() {
 
 	// Now use the generated schema for extraction
 	fmt.Println("\n\nTesting generated schema...")
-	extractConfig := &crawl4ai.CrawlerRunConfig{
-		ExtractionStrategy: map[string]interface{}{
-			"type":   "json_css",
-			"schema": schemaResult.Schema,
-		},
+	schema, err := crawl4ai.SchemaFromMap(schemaResult.Schema)
+	if err != nil {
+		log.Fatalf("Unexpected schema shape: %v", err)
+	}
+	strategy, err := crawl4ai.JSONCSSExtraction(schema)
+	if err != nil {
+		log.Fatalf("Generated schema is invalid: %v", err)
 	}
+	extractConfig := &crawl4ai.CrawlerRunConfig{ExtractionStrategy: strategy}
 
 	extractResult, err := crawler.Run("https://news.ycombinator.com", &crawl4ai.RunOptions{
 		Strategy: "http",
@@ -0,0 +1,156 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Escalation tiers used by RunAdaptive, cheapest first.
+const (
+	TierHTTP    = "http"
+	TierBrowser = "browser"
+	TierStealth = "stealth"
+)
+
+// AdaptiveOptions configure RunAdaptive.
+type AdaptiveOptions struct {
+	// RunOptions are the base options for every tier. Strategy and Proxy
+	// are set per tier; anything else (Config, Headers, Fields...) is kept.
+	RunOptions *RunOptions
+	// Tiers to try, in order. Default TierHTTP, TierBrowser, TierStealth.
+	Tiers []string
+	// MinWords is the markdown length below which a page is treated as
+	// not rendered (e.g. a JavaScript app shell). Default 50; negative
+	// disables the check.
+	MinWords int
+	// Validator adds a content check of your own to the built-in ones.
+	Validator ResultValidator
+}
+
+// AdaptiveAttempt records one tier RunAdaptive tried.
+type AdaptiveAttempt struct {
+	Tier string
+	// Reason says why the tier's result was rejected; "" for the
+	// accepted attempt.
+	Reason string
+}
+
+// AdaptiveResult is the outcome of RunAdaptive.
+type AdaptiveResult struct {
+	*CrawlResult
+	// Tier is the tier that produced CrawlResult.
+	Tier     string
+	Attempts []AdaptiveAttempt
+}
+
+// RunAdaptive crawls url with the cheapest strategy that works. It starts
+// with the http strategy and escalates to the browser, then to a stealth
+// browser behind a residential proxy, only when a tier's result failed,
+// was blocked (see BlockInfo), came back with a 4xx/5xx status, or was too
+// thin to be the rendered page. Across a mixed URL set most pages stop at
+// the cheap tier.
+//
+//	res, err := crawler.RunAdaptive(url, nil)
+//	fmt.Println(res.Tier, res.Markdown.RawMarkdown)
+//
+// When every tier is rejected the last result is returned along with a
+// *ResultRejectedError.
+func (c *AsyncWebCrawler) RunAdaptive(url string, opts *AdaptiveOptions) (*AdaptiveResult, error) {
+	o := AdaptiveOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	base := RunOptions{}
+	if o.RunOptions != nil {
+		base = *o.RunOptions
+	}
+	tiers := o.Tiers
+	if len(tiers) == 0 {
+		tiers = []string{TierHTTP, TierBrowser, TierStealth}
+	}
+	minWords := o.MinWords
+	if minWords == 0 {
+		minWords = 50
+	}
+
+	out := &AdaptiveResult{}
+	var lastErr error
+	for i, tier := range tiers {
+		run, err := adaptiveTierOptions(base, tier)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			// A cached result is the one we just rejected.
+			run.BypassCache = true
+		}
+		result, err := c.runOnce(url, &run)
+		if err != nil {
+			return nil, err
+		}
+		out.CrawlResult, out.Tier = result, tier
+		lastErr = adaptiveCheck(result, minWords, o.Validator)
+		if lastErr == nil {
+			out.Attempts = append(out.Attempts, AdaptiveAttempt{Tier: tier})
+			return out, nil
+		}
+		out.Attempts = append(out.Attempts, AdaptiveAttempt{Tier: tier, Reason: lastErr.Error()})
+	}
+	return out, &ResultRejectedError{Result: out.CrawlResult, Attempts: len(out.Attempts), Err: lastErr}
+}
+
+// adaptiveTierOptions applies a tier's strategy, proxy and stealth flags to
+// a copy of base.
+func adaptiveTierOptions(base RunOptions, tier string) (RunOptions, error) {
+	switch tier {
+	case TierHTTP:
+		base.Strategy = "http"
+	case TierBrowser:
+		base.Strategy = "browser"
+	case TierStealth:
+		base.Strategy = "browser"
+		base.Proxy = "residential"
+		cfg := CrawlerRunConfig{}
+		if base.Config != nil {
+			cfg = *base.Config
+		}
+		cfg.Magic, cfg.SimulateUser, cfg.OverrideNavigator = true, true, true
+		base.Config = &cfg
+		bc := BrowserConfig{}
+		if base.BrowserConfig != nil {
+			bc = *base.BrowserConfig
+		}
+		if bc.UserAgentMode == "" {
+			bc.UserAgentMode = "random"
+		}
+		base.BrowserConfig = &bc
+	default:
+		return base, fmt.Errorf("run adaptive: unknown tier %q", tier)
+	}
+	return base, nil
+}
+
+// adaptiveCheck decides whether a tier's result is good enough to stop.
+func adaptiveCheck(r *CrawlResult, minWords int, extra ResultValidator) error {
+	if !r.Success {
+		if r.ErrorMessage != "" {
+			return fmt.Errorf("crawl failed: %s", r.ErrorMessage)
+		}
+		return fmt.Errorf("crawl failed")
+	}
+	if err := NotBlocked(r); err != nil {
+		return err
+	}
+	if r.StatusCode >= 400 {
+		return fmt.Errorf("HTTP %d %s", r.StatusCode, http.StatusText(r.StatusCode))
+	}
+	if minWords > 0 {
+		if err := MinWords(minWords)(r); err != nil {
+			return err
+		}
+	}
+	if extra != nil {
+		return extra(r)
+	}
+	return nil
+}
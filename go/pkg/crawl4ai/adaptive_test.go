@@ -0,0 +1,64 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunAdaptive_Escalates(t *testing.T) {
+	article := strings.Repeat("word ", 60)
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		resp := map[string]interface{}{"url": "https://a.com", "success": true, "status_code": 200}
+		cfg, _ := body["crawler_config"].(map[string]interface{})
+		switch {
+		case body["strategy"] == "http":
+			resp["markdown"] = "Loading..."
+		case cfg["magic"] == true:
+			resp["markdown"] = article
+		default:
+			resp["status_code"] = 403
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := c.RunAdaptive("https://a.com", nil)
+	if err != nil {
+		t.Fatalf("RunAdaptive: %v", err)
+	}
+	if res.Tier != TierStealth || len(res.Attempts) != 3 {
+		t.Fatalf("expected stealth on the third attempt, got %s after %+v", res.Tier, res.Attempts)
+	}
+	if res.Attempts[0].Reason == "" || !strings.Contains(res.Attempts[1].Reason, "403") || res.Attempts[2].Reason != "" {
+		t.Fatalf("unexpected attempt reasons: %+v", res.Attempts)
+	}
+	stealth := bodies[2]
+	if proxy, _ := stealth["proxy"].(map[string]interface{}); proxy["mode"] != "residential" || stealth["bypass_cache"] != true {
+		t.Fatalf("unexpected stealth request: %v", stealth)
+	}
+
+	bodies = nil
+	res, err = c.RunAdaptive("https://a.com", &AdaptiveOptions{Tiers: []string{TierHTTP}})
+	var rejected *ResultRejectedError
+	if !errors.As(err, &rejected) || res.Tier != TierHTTP || len(bodies) != 1 {
+		t.Fatalf("expected rejection after the http tier only, got %v", err)
+	}
+
+	if _, err := c.RunAdaptive("https://a.com", &AdaptiveOptions{Tiers: []string{"turbo"}}); err == nil {
+		t.Fatal("expected error for unknown tier")
+	}
+}
@@ -0,0 +1,204 @@
+// Package alert sends operational alerts — monitor changes, failed jobs,
+// storage thresholds — to Slack, email or PagerDuty without a separate glue
+// service.
+//
+//	n := alert.Multi(alert.Slack(os.Getenv("SLACK_WEBHOOK")), alert.PagerDuty(os.Getenv("PD_ROUTING_KEY")))
+//	mon := monitor.New(crawler, monitor.Options{Sinks: []monitor.Sink{alert.MonitorSink(n)}})
+//	go crawler.WatchStorage(ctx, time.Hour, alert.StorageHook(ctx, n, nil))
+//	job, err := crawler.WaitJobWithProgress(id, 0, 0, alert.JobHook(ctx, n, nil))
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai/monitor"
+)
+
+// Severity ranks an alert. The values match PagerDuty's.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityError    Severity = "error"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert is one notification, rendered by each Notifier in its own format.
+type Alert struct {
+	Title    string
+	Text     string
+	Severity Severity
+	// Source names what raised the alert: "monitor", "job" or "storage".
+	Source string
+	// Key identifies the underlying condition so repeats can be grouped
+	// (PagerDuty's dedup key), e.g. the job ID or watch ID.
+	Key string
+	// URL links to the affected page, if any.
+	URL    string
+	Fields map[string]string
+	Time   time.Time
+}
+
+// body renders Text, URL and Fields as plain text lines, fields sorted.
+func (a Alert) body() string {
+	var b strings.Builder
+	b.WriteString(a.Text)
+	if a.URL != "" {
+		fmt.Fprintf(&b, "\nURL: %s", a.URL)
+	}
+	keys := make([]string, 0, len(a.Fields))
+	for k := range a.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n%s: %s", k, a.Fields[k])
+	}
+	return strings.TrimPrefix(b.String(), "\n")
+}
+
+// Notifier delivers alerts.
+type Notifier interface {
+	Notify(ctx context.Context, a Alert) error
+}
+
+// NotifierFunc adapts a function to a Notifier.
+type NotifierFunc func(ctx context.Context, a Alert) error
+
+// Notify implements Notifier.
+func (f NotifierFunc) Notify(ctx context.Context, a Alert) error { return f(ctx, a) }
+
+// Multi returns a Notifier that delivers to every notifier in turn. One
+// failing does not stop the rest; their errors are joined.
+func Multi(notifiers ...Notifier) Notifier {
+	return NotifierFunc(func(ctx context.Context, a Alert) error {
+		var errs []error
+		for _, n := range notifiers {
+			if err := n.Notify(ctx, a); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// MonitorSink returns a monitor.Sink that raises an info alert for every
+// change a monitor detects.
+func MonitorSink(n Notifier) monitor.Sink {
+	return monitor.SinkFunc(func(ctx context.Context, ev monitor.ChangeEvent) error {
+		return n.Notify(ctx, ChangeAlert(ev))
+	})
+}
+
+// ChangeAlert builds the alert for a monitor change.
+func ChangeAlert(ev monitor.ChangeEvent) Alert {
+	return Alert{
+		Title:    "Change detected: " + ev.WatchID,
+		Text:     fmt.Sprintf("%s changed on %s.", ev.Comparator, ev.URL),
+		Severity: SeverityInfo,
+		Source:   "monitor",
+		Key:      ev.WatchID,
+		URL:      ev.URL,
+		Fields:   map[string]string{"old": truncate(ev.Old, 200), "new": truncate(ev.New, 200)},
+		Time:     ev.DetectedAt,
+	}
+}
+
+// JobAlert builds the alert for a job that failed, was cancelled or only
+// partly succeeded; ok is false for any other job.
+func JobAlert(job *crawl4ai.CrawlJob) (a Alert, ok bool) {
+	var sev Severity
+	switch job.Status {
+	case crawl4ai.JobStatusFailed:
+		sev = SeverityCritical
+	case crawl4ai.JobStatusPartial:
+		sev = SeverityWarning
+	case crawl4ai.JobStatusCancelled:
+		sev = SeverityInfo
+	default:
+		return Alert{}, false
+	}
+	text := fmt.Sprintf("%d of %d URLs failed.", job.Progress.Failed, job.Progress.Total)
+	if job.Error != "" {
+		text += " " + job.Error
+	}
+	at := job.CompletedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return Alert{
+		Title:    fmt.Sprintf("Crawl job %s %s", job.JobID, job.Status),
+		Text:     text,
+		Severity: sev,
+		Source:   "job",
+		Key:      job.JobID,
+		Fields:   map[string]string{"job_id": job.JobID, "status": string(job.Status)},
+		Time:     at,
+	}, true
+}
+
+// JobHook returns a progress callback for WaitJobWithProgress (or any other
+// job poller) that notifies n once, when the job ends unsuccessfully.
+// Delivery errors go to onErr when it is non-nil.
+func JobHook(ctx context.Context, n Notifier, onErr func(error)) func(*crawl4ai.CrawlJob) {
+	sent := false
+	return func(job *crawl4ai.CrawlJob) {
+		if sent || !job.IsComplete() {
+			return
+		}
+		a, ok := JobAlert(job)
+		if !ok {
+			return
+		}
+		sent = true
+		if err := n.Notify(ctx, a); err != nil && onErr != nil {
+			onErr(err)
+		}
+	}
+}
+
+// StorageAlert builds the alert for storage usage crossing a threshold:
+// critical from 90% up, a warning below.
+func StorageAlert(u crawl4ai.StorageUsage) Alert {
+	sev := SeverityWarning
+	if u.PercentUsed >= 90 {
+		sev = SeverityCritical
+	}
+	return Alert{
+		Title:    fmt.Sprintf("Storage %.0f%% full", u.PercentUsed),
+		Text:     fmt.Sprintf("%.1f MB of %.1f MB used, %.1f MB remaining.", u.UsedMB, u.MaxMB, u.RemainingMB),
+		Severity: sev,
+		Source:   "storage",
+		Key:      "storage",
+		Time:     time.Now(),
+	}
+}
+
+// StorageHook returns a WatchStorage callback that notifies n of every
+// threshold crossing. Delivery errors go to onErr when it is non-nil.
+func StorageHook(ctx context.Context, n Notifier, onErr func(error)) func(crawl4ai.StorageUsage) {
+	return func(u crawl4ai.StorageUsage) {
+		if err := n.Notify(ctx, StorageAlert(u)); err != nil && onErr != nil {
+			onErr(err)
+		}
+	}
+}
+
+// truncate shortens s to n runes, never splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	runes := 0
+	for i := range s {
+		if runes == n {
+			return s[:i] + "…"
+		}
+		runes++
+	}
+	return s
+}
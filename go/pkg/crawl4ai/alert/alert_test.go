@@ -0,0 +1,135 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai/monitor"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+// recorder is a Notifier keeping every alert it receives.
+type recorder struct {
+	alerts []Alert
+	err    error
+}
+
+func (r *recorder) Notify(_ context.Context, a Alert) error {
+	r.alerts = append(r.alerts, a)
+	return r.err
+}
+
+func TestMulti_DeliversToAllAndJoinsErrors(t *testing.T) {
+	a, b, c := &recorder{err: errors.New("a down")}, &recorder{}, &recorder{err: errors.New("c down")}
+	err := Multi(a, b, c).Notify(context.Background(), Alert{Title: "x"})
+	if err == nil || !strings.Contains(err.Error(), "a down") || !strings.Contains(err.Error(), "c down") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(a.alerts) != 1 || len(b.alerts) != 1 || len(c.alerts) != 1 {
+		t.Errorf("deliveries = %d %d %d", len(a.alerts), len(b.alerts), len(c.alerts))
+	}
+}
+
+func TestMonitorSink(t *testing.T) {
+	rec := &recorder{}
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := MonitorSink(rec).Emit(context.Background(), monitor.ChangeEvent{
+		WatchID: "price", URL: "https://shop.example.com/p/1", Comparator: "price",
+		Old: "$10", New: "$12", DetectedAt: at,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := rec.alerts[0]
+	if a.Source != "monitor" || a.Key != "price" || a.Severity != SeverityInfo || !a.Time.Equal(at) {
+		t.Errorf("alert = %+v", a)
+	}
+	if a.Fields["old"] != "$10" || a.Fields["new"] != "$12" || a.URL != "https://shop.example.com/p/1" {
+		t.Errorf("fields = %v url = %q", a.Fields, a.URL)
+	}
+}
+
+func TestJobAlert_Severity(t *testing.T) {
+	cases := map[crawl4ai.JobStatus]Severity{
+		crawl4ai.JobStatusFailed:    SeverityCritical,
+		crawl4ai.JobStatusPartial:   SeverityWarning,
+		crawl4ai.JobStatusCancelled: SeverityInfo,
+	}
+	for status, want := range cases {
+		a, ok := JobAlert(&crawl4ai.CrawlJob{JobID: "job_1", Status: status})
+		if !ok || a.Severity != want || a.Key != "job_1" {
+			t.Errorf("%s: ok=%v alert=%+v", status, ok, a)
+		}
+	}
+	for _, status := range []crawl4ai.JobStatus{crawl4ai.JobStatusCompleted, crawl4ai.JobStatusRunning} {
+		if _, ok := JobAlert(&crawl4ai.CrawlJob{Status: status}); ok {
+			t.Errorf("%s raised an alert", status)
+		}
+	}
+}
+
+func TestJobHook_NotifiesOnceOnFailure(t *testing.T) {
+	rec := &recorder{}
+	hook := JobHook(context.Background(), rec, nil)
+	hook(&crawl4ai.CrawlJob{JobID: "j", Status: crawl4ai.JobStatusRunning})
+	failed := &crawl4ai.CrawlJob{JobID: "j", Status: crawl4ai.JobStatusFailed, Error: "worker crashed",
+		Progress: crawl4ai.JobProgress{Total: 3, Failed: 3}}
+	hook(failed)
+	hook(failed)
+	if len(rec.alerts) != 1 {
+		t.Fatalf("alerts = %d, want 1", len(rec.alerts))
+	}
+	if !strings.Contains(rec.alerts[0].Text, "3 of 3 URLs failed. worker crashed") {
+		t.Errorf("text = %q", rec.alerts[0].Text)
+	}
+}
+
+func TestJobHook_ReportsDeliveryError(t *testing.T) {
+	var got error
+	hook := JobHook(context.Background(), &recorder{err: errors.New("boom")}, func(err error) { got = err })
+	hook(&crawl4ai.CrawlJob{Status: crawl4ai.JobStatusFailed})
+	if got == nil || got.Error() != "boom" {
+		t.Errorf("onErr got %v", got)
+	}
+}
+
+func TestStorageHook(t *testing.T) {
+	rec := &recorder{}
+	hook := StorageHook(context.Background(), rec, nil)
+	hook(crawl4ai.StorageUsage{UsedMB: 80, MaxMB: 100, RemainingMB: 20, PercentUsed: 80})
+	hook(crawl4ai.StorageUsage{UsedMB: 95, MaxMB: 100, RemainingMB: 5, PercentUsed: 95})
+	if len(rec.alerts) != 2 {
+		t.Fatalf("alerts = %d", len(rec.alerts))
+	}
+	if rec.alerts[0].Severity != SeverityWarning || rec.alerts[1].Severity != SeverityCritical {
+		t.Errorf("severities = %s, %s", rec.alerts[0].Severity, rec.alerts[1].Severity)
+	}
+	if rec.alerts[1].Title != "Storage 95% full" {
+		t.Errorf("title = %q", rec.alerts[1].Title)
+	}
+}
+
+func TestAlertBody_SortsFields(t *testing.T) {
+	a := Alert{Text: "t", URL: "https://example.com", Fields: map[string]string{"b": "2", "a": "1"}}
+	if got, want := a.body(), "t\nURL: https://example.com\na: 1\nb: 2"; got != want {
+		t.Errorf("body = %q, want %q", got, want)
+	}
+}
+
+func TestTruncate_KeepsRunesWhole(t *testing.T) {
+	if got := truncate("héllo wörld", 5); got != "héllo…" {
+		t.Errorf("truncate = %q", got)
+	}
+	if got := truncate("日本語", 2); got != "日本…" || !utf8.ValidString(got) {
+		t.Errorf("truncate = %q", got)
+	}
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("truncate = %q", got)
+	}
+}
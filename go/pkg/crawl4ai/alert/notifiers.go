@@ -0,0 +1,167 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// PagerDutyEndpoint is the Events API v2 enqueue URL.
+const PagerDutyEndpoint = "https://events.pagerduty.com/v2/enqueue"
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Slack returns a Notifier posting to a Slack incoming-webhook URL.
+func Slack(webhookURL string) Notifier {
+	return NotifierFunc(func(ctx context.Context, a Alert) error {
+		text := fmt.Sprintf("%s *%s*\n%s", slackEmoji(a.Severity), a.Title, a.body())
+		return postJSON(ctx, "slack", webhookURL, map[string]string{"text": text})
+	})
+}
+
+func slackEmoji(s Severity) string {
+	switch s {
+	case SeverityCritical, SeverityError:
+		return ":rotating_light:"
+	case SeverityWarning:
+		return ":warning:"
+	}
+	return ":information_source:"
+}
+
+// PagerDuty returns a Notifier triggering PagerDuty incidents through the
+// Events API v2 with the integration's routing key. Alerts with the same
+// Key are grouped into one incident.
+func PagerDuty(routingKey string) Notifier {
+	return pagerDuty(routingKey, PagerDutyEndpoint)
+}
+
+func pagerDuty(routingKey, endpoint string) Notifier {
+	return NotifierFunc(func(ctx context.Context, a Alert) error {
+		sev := a.Severity
+		if sev == "" {
+			sev = SeverityError
+		}
+		source := a.Source
+		if source == "" {
+			source = "crawl4ai"
+		}
+		payload := map[string]interface{}{
+			"summary":  a.Title,
+			"source":   source,
+			"severity": sev,
+		}
+		if !a.Time.IsZero() {
+			payload["timestamp"] = a.Time.UTC().Format(time.RFC3339)
+		}
+		details := map[string]string{"text": a.Text}
+		if a.URL != "" {
+			details["url"] = a.URL
+		}
+		for k, v := range a.Fields {
+			details[k] = v
+		}
+		payload["custom_details"] = details
+		event := map[string]interface{}{
+			"routing_key":  routingKey,
+			"event_action": "trigger",
+			"payload":      payload,
+		}
+		if a.Key != "" {
+			key := a.Key
+			if a.Source != "" {
+				key = a.Source + ":" + key
+			}
+			event["dedup_key"] = key
+		}
+		return postJSON(ctx, "pagerduty", endpoint, event)
+	})
+}
+
+func postJSON(ctx context.Context, name, url string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: HTTP %d", name, resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTPConfig configures the Email notifier.
+type SMTPConfig struct {
+	// Addr is the server's host:port, e.g. "smtp.example.com:587". STARTTLS
+	// is used when the server offers it.
+	Addr string
+	// Username and Password enable PLAIN auth when set.
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// sendMail is smtp.SendMail, swapped out in tests.
+var sendMail = smtp.SendMail
+
+// Email returns a Notifier sending plain-text mail through an SMTP server.
+func Email(cfg SMTPConfig) Notifier {
+	return NotifierFunc(func(ctx context.Context, a Alert) error {
+		if len(cfg.To) == 0 {
+			return fmt.Errorf("email: no recipients")
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var auth smtp.Auth
+		if cfg.Username != "" {
+			host := cfg.Addr
+			if i := strings.LastIndex(host, ":"); i >= 0 {
+				host = host[:i]
+			}
+			auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+		}
+		if err := sendMail(cfg.Addr, auth, cfg.From, cfg.To, emailMessage(cfg, a)); err != nil {
+			return fmt.Errorf("email: %w", err)
+		}
+		return nil
+	})
+}
+
+func emailMessage(cfg SMTPConfig, a Alert) []byte {
+	at := a.Time
+	if at.IsZero() {
+		at = time.Now()
+	}
+	subject := a.Title
+	if a.Severity != "" {
+		subject = fmt.Sprintf("[%s] %s", strings.ToUpper(string(a.Severity)), a.Title)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", at.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(a.body(), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,160 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+// capture serves status and records the decoded JSON body of each POST.
+func capture(t *testing.T, status int) (*httptest.Server, *[]map[string]interface{}) {
+	t.Helper()
+	var got []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		got = append(got, body)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func TestSlack_PostsText(t *testing.T) {
+	srv, got := capture(t, http.StatusOK)
+	err := Slack(srv.URL).Notify(context.Background(), Alert{
+		Title: "Crawl job j1 failed", Text: "3 of 3 URLs failed.", Severity: SeverityCritical,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, _ := (*got)[0]["text"].(string)
+	if !strings.HasPrefix(text, ":rotating_light: *Crawl job j1 failed*\n3 of 3 URLs failed.") {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestSlack_Non2xxIsError(t *testing.T) {
+	srv, _ := capture(t, http.StatusForbidden)
+	err := Slack(srv.URL).Notify(context.Background(), Alert{Title: "x"})
+	if err == nil || err.Error() != "slack: HTTP 403" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestPagerDuty_TriggerEvent(t *testing.T) {
+	srv, got := capture(t, http.StatusAccepted)
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	err := pagerDuty("rk_123", srv.URL).Notify(context.Background(), Alert{
+		Title: "Storage 95% full", Text: "95 MB of 100 MB used.", Severity: SeverityCritical,
+		Source: "storage", Key: "storage", Time: at, Fields: map[string]string{"plan": "pro"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev := (*got)[0]
+	if ev["routing_key"] != "rk_123" || ev["event_action"] != "trigger" || ev["dedup_key"] != "storage:storage" {
+		t.Errorf("event = %v", ev)
+	}
+	p := ev["payload"].(map[string]interface{})
+	if p["summary"] != "Storage 95% full" || p["severity"] != "critical" || p["source"] != "storage" ||
+		p["timestamp"] != "2026-10-01T12:00:00Z" {
+		t.Errorf("payload = %v", p)
+	}
+	details := p["custom_details"].(map[string]interface{})
+	if details["plan"] != "pro" || details["text"] != "95 MB of 100 MB used." {
+		t.Errorf("custom_details = %v", details)
+	}
+}
+
+func TestPagerDuty_Defaults(t *testing.T) {
+	srv, got := capture(t, http.StatusAccepted)
+	if err := pagerDuty("rk", srv.URL).Notify(context.Background(), Alert{Title: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	ev := (*got)[0]
+	p := ev["payload"].(map[string]interface{})
+	if p["severity"] != "error" || p["source"] != "crawl4ai" {
+		t.Errorf("payload = %v", p)
+	}
+	if _, ok := ev["dedup_key"]; ok {
+		t.Errorf("dedup_key set without Key: %v", ev)
+	}
+}
+
+func TestPagerDuty_DedupKeyWithoutSource(t *testing.T) {
+	srv, got := capture(t, http.StatusAccepted)
+	if err := pagerDuty("rk", srv.URL).Notify(context.Background(), Alert{Title: "x", Key: "k1"}); err != nil {
+		t.Fatal(err)
+	}
+	if ev := (*got)[0]; ev["dedup_key"] != "k1" {
+		t.Errorf("dedup_key = %v, want k1", ev["dedup_key"])
+	}
+}
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func TestEmail_SendsMessage(t *testing.T) {
+	var addr, from string
+	var to []string
+	var msg []byte
+	var auth smtp.Auth
+	sendMail = func(a string, au smtp.Auth, f string, t []string, m []byte) error {
+		addr, auth, from, to, msg = a, au, f, t, m
+		return nil
+	}
+	t.Cleanup(func() { sendMail = smtp.SendMail })
+
+	cfg := SMTPConfig{Addr: "smtp.example.com:587", Username: "u", Password: "p",
+		From: "alerts@example.com", To: []string{"ops@example.com", "dev@example.com"}}
+	err := Email(cfg).Notify(context.Background(), Alert{
+		Title: "Crawl job j1 partial", Text: "1 of 4 URLs failed.", Severity: SeverityWarning,
+		Fields: map[string]string{"job_id": "j1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if addr != "smtp.example.com:587" || from != "alerts@example.com" || len(to) != 2 || auth == nil {
+		t.Errorf("addr=%q from=%q to=%v auth=%v", addr, from, to, auth)
+	}
+	s := string(msg)
+	for _, want := range []string{
+		"To: ops@example.com, dev@example.com\r\n",
+		"Subject: [WARNING] Crawl job j1 partial\r\n",
+		"\r\n\r\n1 of 4 URLs failed.\r\njob_id: j1\r\n",
+	} {
+		if !strings.Contains(s, want) {
+			t.Errorf("message missing %q:\n%s", want, s)
+		}
+	}
+}
+
+func TestEmail_NoRecipients(t *testing.T) {
+	err := Email(SMTPConfig{Addr: "localhost:25"}).Notify(context.Background(), Alert{})
+	if err == nil || err.Error() != "email: no recipients" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestEmailMessage_SubjectHasNoNewlines(t *testing.T) {
+	msg := string(emailMessage(SMTPConfig{To: []string{"a@b"}}, Alert{Title: "x\r\nBcc: evil@example.com"}))
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("header injection:\n%s", msg)
+	}
+}
+
+func TestEmailMessage_EncodesNonASCIISubject(t *testing.T) {
+	msg := string(emailMessage(SMTPConfig{To: []string{"a@b"}}, Alert{Title: "Prix changé"}))
+	if !strings.Contains(msg, "Subject: =?utf-8?q?Prix_chang=C3=A9?=\r\n") {
+		t.Errorf("expected a Q-encoded subject:\n%s", msg)
+	}
+}
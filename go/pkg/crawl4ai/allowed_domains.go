@@ -0,0 +1,178 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// OutOfScopeError is returned, before anything is submitted, when URLs fall
+// outside CrawlerOptions.AllowedDomains. It unwraps to its *ValidationError,
+// so callers matching validation failures see it too.
+type OutOfScopeError struct {
+	*ValidationError
+	// URLs are the rejected URLs, in submission order. Empty when a
+	// deep-crawl domain filter, rather than a URL, was out of scope.
+	URLs []string
+}
+
+// Unwrap exposes the embedded *ValidationError to errors.As.
+func (e *OutOfScopeError) Unwrap() error {
+	return e.ValidationError
+}
+
+func newOutOfScopeError(urls []string, allowed []string) *OutOfScopeError {
+	shown := urls
+	if len(shown) > 5 {
+		shown = shown[:5]
+	}
+	msg := fmt.Sprintf("%d URL(s) outside allowed domains %v: %s",
+		len(urls), allowed, strings.Join(shown, ", "))
+	if len(urls) > len(shown) {
+		msg += fmt.Sprintf(" (and %d more)", len(urls)-len(shown))
+	}
+	return &OutOfScopeError{
+		ValidationError: NewValidationError(msg, map[string]interface{}{"out_of_scope_urls": urls}, nil),
+		URLs:            urls,
+	}
+}
+
+// domainAllowed reports whether host is one of allowed or a subdomain of
+// one. Matching is case-insensitive and ignores a trailing dot.
+func domainAllowed(host string, allowed []string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for _, d := range allowed {
+		d = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(d)), ".")
+		if d != "" && (host == d || strings.HasSuffix(host, "."+d)) {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckAllowedDomains returns an *OutOfScopeError listing every URL whose
+// host is outside CrawlerOptions.AllowedDomains, or nil. Inline "raw:"
+// HTML is always allowed. With no AllowedDomains configured every URL
+// passes.
+//
+// Every API call checks the URLs its request body submits automatically;
+// DeepCrawl and Site also send the allowlist as their domain filter so
+// discovered links are never followed off-scope.
+func (c *AsyncWebCrawler) CheckAllowedDomains(urls ...string) error {
+	if len(c.allowedDomains) == 0 {
+		return nil
+	}
+	var rejected []string
+	for _, raw := range urls {
+		if strings.HasPrefix(raw, "raw:") {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" || !domainAllowed(u.Hostname(), c.allowedDomains) {
+			rejected = append(rejected, raw)
+		}
+	}
+	if len(rejected) > 0 {
+		return newOutOfScopeError(rejected, c.allowedDomains)
+	}
+	return nil
+}
+
+// checkRequestScope is the HTTPClient scope hook: it checks the URLs a
+// request body submits under "url", "urls" or "extra_urls".
+func (c *AsyncWebCrawler) checkRequestScope(body map[string]interface{}) error {
+	var urls []string
+	for _, key := range []string{"url", "urls", "extra_urls"} {
+		if s, ok := body[key].(string); ok {
+			urls = append(urls, s)
+			continue
+		}
+		urls = append(urls, toStringSlice(body[key])...)
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	return c.CheckAllowedDomains(urls...)
+}
+
+// scopeFilters returns deep-crawl filters with allowed_domains restricted
+// to the crawler's allowlist (see narrowDomains). The input map is never
+// modified.
+func (c *AsyncWebCrawler) scopeFilters(filters map[string]interface{}) (map[string]interface{}, error) {
+	if len(c.allowedDomains) == 0 {
+		return filters, nil
+	}
+	allowed, err := c.narrowDomains(toStringSlice(filters["allowed_domains"]))
+	if err != nil {
+		return nil, err
+	}
+	out := copyMap(filters)
+	out["allowed_domains"] = allowed
+	return out, nil
+}
+
+// scopeSiteFilters is scopeFilters for the /v1/site filter shape, where
+// the allowlist lives at domains.allowed.
+func (c *AsyncWebCrawler) scopeSiteFilters(filters map[string]interface{}) (map[string]interface{}, error) {
+	if len(c.allowedDomains) == 0 {
+		return filters, nil
+	}
+	domains, _ := filters["domains"].(map[string]interface{})
+	allowed, err := c.narrowDomains(toStringSlice(domains["allowed"]))
+	if err != nil {
+		return nil, err
+	}
+	scoped := copyMap(domains)
+	scoped["allowed"] = allowed
+	out := copyMap(filters)
+	out["domains"] = scoped
+	return out, nil
+}
+
+// narrowDomains keeps the in-scope entries of an explicit domain allowlist,
+// using the crawler's allowlist when none is given. An explicit list with
+// no in-scope entry is an *OutOfScopeError: widening it to the crawler's
+// allowlist would crawl more than the caller asked for.
+func (c *AsyncWebCrawler) narrowDomains(explicit []string) ([]string, error) {
+	if len(explicit) == 0 {
+		return c.allowedDomains, nil
+	}
+	var narrowed []string
+	for _, d := range explicit {
+		if domainAllowed(d, c.allowedDomains) {
+			narrowed = append(narrowed, d)
+		}
+	}
+	if len(narrowed) == 0 {
+		msg := fmt.Sprintf("domain filter %v has no entry inside allowed domains %v", explicit, c.allowedDomains)
+		return nil, &OutOfScopeError{
+			ValidationError: NewValidationError(msg, map[string]interface{}{"out_of_scope_domains": explicit}, nil),
+		}
+	}
+	return narrowed, nil
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// toStringSlice accepts []string or a decoded JSON []interface{}.
+func toStringSlice(v interface{}) []string {
+	switch x := v.(type) {
+	case []string:
+		return x
+	case []interface{}:
+		out := make([]string, 0, len(x))
+		for _, e := range x {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
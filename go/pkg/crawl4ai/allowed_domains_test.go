@@ -0,0 +1,143 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestAllowedDomains_Check(t *testing.T) {
+	c := &AsyncWebCrawler{allowedDomains: []string{"Example.com"}}
+	err := c.CheckAllowedDomains(
+		"https://example.com/a", "https://docs.example.com/b", "raw:<html></html>",
+		"https://notexample.com/", "https://evil.com/?u=example.com", "/relative",
+	)
+	var scope *OutOfScopeError
+	if !errors.As(err, &scope) {
+		t.Fatalf("expected *OutOfScopeError, got %v", err)
+	}
+	want := []string{"https://notexample.com/", "https://evil.com/?u=example.com", "/relative"}
+	if !reflect.DeepEqual(scope.URLs, want) {
+		t.Fatalf("expected %v rejected, got %v", want, scope.URLs)
+	}
+	var ve *ValidationError
+	if !errors.As(err, &ve) || ve.StatusCode != 400 {
+		t.Fatalf("expected error to match *ValidationError, got %v", err)
+	}
+
+	if err := (&AsyncWebCrawler{}).CheckAllowedDomains("https://anything.com"); err != nil {
+		t.Fatalf("expected no guard without AllowedDomains, got %v", err)
+	}
+}
+
+func TestAllowedDomains_ScopeFilters(t *testing.T) {
+	c := &AsyncWebCrawler{allowedDomains: []string{"example.com"}}
+	in := map[string]interface{}{"allowed_domains": []string{"blog.example.com", "other.com"}}
+	out, err := c.scopeFilters(in)
+	if err != nil || !reflect.DeepEqual(out["allowed_domains"], []string{"blog.example.com"}) {
+		t.Fatalf("expected explicit filter narrowed to in-scope entries, got %v %v", out["allowed_domains"], err)
+	}
+	if len(in["allowed_domains"].([]string)) != 2 {
+		t.Fatal("input filters were modified")
+	}
+	if got, _ := c.scopeFilters(nil); !reflect.DeepEqual(got["allowed_domains"], []string{"example.com"}) {
+		t.Fatalf("expected allowlist injected, got %v", got)
+	}
+	site, err := c.scopeSiteFilters(map[string]interface{}{
+		"domains": map[string]interface{}{"blocked": []string{"x.example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	domains := site["domains"].(map[string]interface{})
+	if !reflect.DeepEqual(domains["allowed"], []string{"example.com"}) || domains["blocked"] == nil {
+		t.Fatalf("expected domains.allowed injected beside blocked, got %v", domains)
+	}
+}
+
+func TestAllowedDomains_DisjointFilterIsRejected(t *testing.T) {
+	c := &AsyncWebCrawler{allowedDomains: []string{"example.com"}}
+	var scope *OutOfScopeError
+	if _, err := c.scopeFilters(map[string]interface{}{"allowed_domains": []string{"other.com"}}); !errors.As(err, &scope) {
+		t.Fatalf("expected *OutOfScopeError rather than a widened filter, got %v", err)
+	}
+	site := map[string]interface{}{"domains": map[string]interface{}{"allowed": []interface{}{"other.com"}}}
+	if _, err := c.scopeSiteFilters(site); !errors.As(err, &scope) {
+		t.Fatalf("expected *OutOfScopeError for site filters, got %v", err)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestAllowedDomains_RunManyAndDeepCrawl(t *testing.T) {
+	var posts []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		posts = append(posts, body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "pending"})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{
+		APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, AllowedDomains: []string{"example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.RunMany([]string{"https://example.com", "https://tracker.io"}, nil); err == nil {
+		t.Fatal("expected RunMany to reject an out-of-scope URL")
+	}
+	if len(posts) != 0 {
+		t.Fatal("nothing should be submitted when the guard fails")
+	}
+
+	if _, err := c.DeepCrawl("https://example.com", &DeepCrawlOptions{Strategy: "bfs"}); err != nil {
+		t.Fatalf("DeepCrawl: %v", err)
+	}
+	filters, _ := posts[0]["filters"].(map[string]interface{})
+	if !reflect.DeepEqual(filters["allowed_domains"], []interface{}{"example.com"}) {
+		t.Fatalf("expected allowed_domains follow rule, got %v", posts[0]["filters"])
+	}
+}
+
+func TestAllowedDomains_EnforcedOnEveryEndpoint(t *testing.T) {
+	posts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "pending"})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{
+		APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, AllowedDomains: []string{"example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	off := "https://tracker.io/"
+	calls := map[string]func() error{
+		"Scrape":      func() error { _, err := c.Scrape(off, nil); return err },
+		"Markdown":    func() error { _, err := c.Markdown(off, nil); return err },
+		"Screenshot":  func() error { _, err := c.Screenshot(off, nil); return err },
+		"Map":         func() error { _, err := c.Map(off, nil); return err },
+		"Scan":        func() error { _, err := c.Scan(off, nil); return err },
+		"ScrapeAsync": func() error { _, err := c.ScrapeAsync([]string{"https://example.com", off}, nil); return err },
+		"WithContext": func() error { _, err := c.WithContext(context.Background()).Run(off, nil); return err },
+	}
+	for name, call := range calls {
+		var scope *OutOfScopeError
+		if err := call(); !errors.As(err, &scope) {
+			t.Errorf("%s: expected *OutOfScopeError, got %v", name, err)
+		}
+	}
+	if posts != 0 {
+		t.Fatalf("nothing should be submitted when the guard fails, got %d requests", posts)
+	}
+}
@@ -0,0 +1,204 @@
+package crawl4ai
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Artifact types reported in Artifact.Type.
+const (
+	ArtifactScreenshot = "screenshot"
+	ArtifactPDF        = "pdf"
+	ArtifactHTML       = "html"
+	ArtifactDownload   = "download"
+)
+
+// Artifact is a file a crawl job produced for one of its pages.
+type Artifact struct {
+	// Type is one of the Artifact* constants.
+	Type string `json:"type"`
+	// SourceURL is the page the artifact was captured from.
+	SourceURL string `json:"source_url"`
+	// Name is the file name, when the server or a download URL gives one.
+	Name string `json:"name,omitempty"`
+	// SizeBytes is the artifact's size, or 0 when unknown.
+	SizeBytes int64 `json:"size_bytes,omitempty"`
+	// DownloadURL is a presigned URL for the file. Artifacts assembled
+	// from inline result data have none; DownloadArtifacts writes those
+	// from the data directly.
+	DownloadURL string `json:"download_url,omitempty"`
+
+	inline []byte
+}
+
+// ListJobArtifacts lists the screenshots, PDFs, HTML dumps and downloaded
+// files of a crawl job. It asks the server for the job's artifact index and,
+// when the server doesn't offer one, assembles the list from the job's
+// results (see JobResults).
+//
+//	artifacts, err := crawler.ListJobArtifacts(jobID)
+//	for _, a := range artifacts {
+//	    fmt.Println(a.Type, a.SourceURL, a.SizeBytes)
+//	}
+func (c *AsyncWebCrawler) ListJobArtifacts(jobID string) ([]Artifact, error) {
+	data, err := c.http.Get(fmt.Sprintf("/v1/crawl/jobs/%s/artifacts", jobID), nil)
+	var nf *NotFoundError
+	switch {
+	case err == nil:
+		raw, _ := data["artifacts"].([]interface{})
+		out := make([]Artifact, 0, len(raw))
+		for _, item := range raw {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			a, err := unmarshalWrapper[Artifact](m)
+			if err != nil {
+				return nil, fmt.Errorf("job %s artifacts: %w", jobID, err)
+			}
+			out = append(out, *a)
+		}
+		return out, nil
+	case errors.As(err, &nf):
+		// Older deployments have no artifact index; derive it.
+	default:
+		return nil, err
+	}
+
+	results, err := c.JobResults(jobID)
+	if err != nil {
+		return nil, err
+	}
+	var out []Artifact
+	for _, r := range results {
+		out = append(out, resultArtifacts(r)...)
+	}
+	return out, nil
+}
+
+// resultArtifacts lists the artifacts carried inline by one result.
+func resultArtifacts(r *CrawlResult) []Artifact {
+	var out []Artifact
+	inline := func(kind, b64 string) {
+		if b64 == "" {
+			return
+		}
+		data, err := base64.StdEncoding.DecodeString(b64)
+		if err != nil {
+			return
+		}
+		out = append(out, Artifact{Type: kind, SourceURL: r.URL, SizeBytes: int64(len(data)), inline: data})
+	}
+	inline(ArtifactScreenshot, r.Screenshot)
+	inline(ArtifactPDF, r.PDF)
+	if r.HTML != "" {
+		out = append(out, Artifact{Type: ArtifactHTML, SourceURL: r.URL, SizeBytes: int64(len(r.HTML)), inline: []byte(r.HTML)})
+	}
+	for _, f := range r.DownloadedFiles {
+		a := Artifact{Type: ArtifactDownload, SourceURL: r.URL, DownloadURL: f}
+		if u, err := url.Parse(f); err == nil {
+			a.Name = path.Base(u.Path)
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// DownloadArtifacts saves every artifact of a job into dir (created if
+// needed) and returns the written paths. Files are named
+// "<index>-<page>-<type>.<ext>" so artifacts of the same page sort
+// together.
+func (c *AsyncWebCrawler) DownloadArtifacts(jobID, dir string) ([]string, error) {
+	artifacts, err := c.ListJobArtifacts(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(artifacts))
+	for i, a := range artifacts {
+		p := filepath.Join(dir, artifactFileName(i, a))
+		if err := c.saveArtifact(a, p); err != nil {
+			return paths, fmt.Errorf("artifact %d (%s of %s): %w", i, a.Type, a.SourceURL, err)
+		}
+		paths = append(paths, p)
+	}
+	return paths, nil
+}
+
+func (c *AsyncWebCrawler) saveArtifact(a Artifact, p string) error {
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	if err := c.writeArtifact(a, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeArtifact copies an artifact's content to w, fetching its presigned
+// URL when it has no inline data.
+func (c *AsyncWebCrawler) writeArtifact(a Artifact, w io.Writer) error {
+	if a.inline != nil || a.DownloadURL == "" {
+		_, err := w.Write(a.inline)
+		return err
+	}
+	return c.http.fetchPresigned(a.DownloadURL, w)
+}
+
+// fetchPresigned streams a presigned storage URL to w. The API key is not
+// sent: the URL carries its own signature.
+func (c *HTTPClient) fetchPresigned(rawURL string, w io.Writer) error {
+	resp, err := c.client.Get(rawURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download: HTTP %d", resp.StatusCode)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func artifactFileName(i int, a Artifact) string {
+	return fmt.Sprintf("%03d-%s-%s%s", i, pageSlug(a.SourceURL), a.Type, artifactExt(a))
+}
+
+func artifactExt(a Artifact) string {
+	if ext := path.Ext(a.Name); ext != "" {
+		return ext
+	}
+	return map[string]string{
+		ArtifactScreenshot: ".png",
+		ArtifactPDF:        ".pdf",
+		ArtifactHTML:       ".html",
+	}[a.Type]
+}
+
+// pageSlug turns a page URL into a file-name-safe host+path string.
+func pageSlug(rawURL string) string {
+	page := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		page = u.Host + u.Path
+	}
+	page = strings.Trim(unsafeFileChars.ReplaceAllString(page, "_"), "_")
+	if len(page) > 80 {
+		page = page[:80]
+	}
+	return page
+}
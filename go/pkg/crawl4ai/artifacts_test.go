@@ -0,0 +1,86 @@
+package crawl4ai
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestListJobArtifacts_ServerIndex(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/crawl/jobs/job_1/artifacts":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"artifacts": []interface{}{
+				map[string]interface{}{"type": "screenshot", "source_url": "https://a.com/p?x=1", "size_bytes": 3, "download_url": srv.URL + "/files/shot.png?sig=abc"},
+			}})
+		case "/files/shot.png":
+			if r.Header.Get("X-API-Key") != "" {
+				t.Error("API key sent to presigned URL")
+			}
+			_, _ = w.Write([]byte("png"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	artifacts, err := c.ListJobArtifacts("job_1")
+	if err != nil {
+		t.Fatalf("ListJobArtifacts: %v", err)
+	}
+	if len(artifacts) != 1 || artifacts[0].Type != ArtifactScreenshot || artifacts[0].SizeBytes != 3 {
+		t.Fatalf("unexpected artifacts: %+v", artifacts)
+	}
+
+	dir := t.TempDir()
+	paths, err := c.DownloadArtifacts("job_1", dir)
+	if err != nil {
+		t.Fatalf("DownloadArtifacts: %v", err)
+	}
+	want := []string{filepath.Join(dir, "000-a.com_p-screenshot.png")}
+	if !reflect.DeepEqual(paths, want) {
+		t.Fatalf("got %v, want %v", paths, want)
+	}
+	if b, _ := os.ReadFile(paths[0]); string(b) != "png" {
+		t.Fatalf("unexpected file content %q", b)
+	}
+}
+
+func TestListJobArtifacts_FromResults(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_2": map[string]interface{}{
+			"job_id": "job_2", "status": "completed", "urls_count": 1,
+			"results": []interface{}{map[string]interface{}{
+				"url": "https://a.com/", "success": true, "html": "<p>hi</p>",
+				"screenshot":       base64.StdEncoding.EncodeToString([]byte("img")),
+				"downloaded_files": []interface{}{"https://s3.example.com/d/report.csv?sig=1"},
+			}},
+		},
+	})
+	artifacts, err := c.ListJobArtifacts("job_2")
+	if err != nil {
+		t.Fatalf("ListJobArtifacts: %v", err)
+	}
+	var types []string
+	for _, a := range artifacts {
+		types = append(types, a.Type)
+	}
+	if !reflect.DeepEqual(types, []string{ArtifactScreenshot, ArtifactHTML, ArtifactDownload}) {
+		t.Fatalf("unexpected artifact types: %v", types)
+	}
+	if artifacts[0].SizeBytes != 3 || artifacts[2].Name != "report.csv" {
+		t.Fatalf("unexpected artifacts: %+v", artifacts)
+	}
+}
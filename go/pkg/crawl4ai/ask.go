@@ -0,0 +1,246 @@
+package crawl4ai
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// AskOptions configures AskJob and AskResults.
+type AskOptions struct {
+	// LLM writes the answer. Required.
+	LLM LLM
+	// Embedder, when set, ranks chunks by embedding similarity instead of
+	// BM25. Chunks already embedded by CrawlAndEmbed are reused.
+	Embedder Embedder
+	// TopK is how many chunks are given to the LLM. Default 6.
+	TopK int
+	// ChunkSize and ChunkOverlap control chunking; see EmbedOptions.
+	ChunkSize    int
+	ChunkOverlap int
+}
+
+// Source is a chunk retrieved for a question. N is the number the answer
+// cites it by, as [N].
+type Source struct {
+	N     int     `json:"n"`
+	URL   string  `json:"url"`
+	Text  string  `json:"text"`
+	Score float64 `json:"score"`
+}
+
+// Answer is the outcome of AskJob.
+type Answer struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+	// Citations are the sources the answer cites, by number.
+	Citations []Source `json:"citations"`
+	// Sources are all chunks given to the LLM, best match first.
+	Sources []Source `json:"sources"`
+}
+
+const askSystem = `Answer the question using only the numbered sources below. Cite every claim with the source number in brackets, like [2]. If the sources do not contain the answer, say so.`
+
+// AskJob answers a question from an async crawl job's pages: it retrieves
+// the most relevant markdown chunks and has the LLM answer with citations
+// to their URLs — any crawl becomes a Q&A corpus.
+//
+//	ans, err := crawler.AskJob(ctx, jobID, "What does the Pro plan cost?",
+//	    crawl4ai.AskOptions{LLM: crawler.PlatformLLM(nil)})
+//	fmt.Println(ans.Answer)
+//	for _, c := range ans.Citations {
+//	    fmt.Printf("[%d] %s\n", c.N, c.URL)
+//	}
+func (c *AsyncWebCrawler) AskJob(ctx context.Context, jobID, question string, opts AskOptions) (*Answer, error) {
+	if opts.LLM == nil {
+		return nil, fmt.Errorf("ask: LLM is required")
+	}
+	results, err := c.WithContext(ctx).JobResults(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return AskResults(ctx, results, question, opts)
+}
+
+// AskResults is AskJob over results already in hand.
+func AskResults(ctx context.Context, results []*CrawlResult, question string, opts AskOptions) (*Answer, error) {
+	if opts.LLM == nil {
+		return nil, fmt.Errorf("ask: LLM is required")
+	}
+	if strings.TrimSpace(question) == "" {
+		return nil, fmt.Errorf("ask: question is empty")
+	}
+	topK := opts.TopK
+	if topK <= 0 {
+		topK = 6
+	}
+
+	var chunks []Chunk
+	for _, r := range results {
+		if r == nil || !r.Success {
+			continue
+		}
+		if len(r.Chunks) > 0 {
+			chunks = append(chunks, r.Chunks...)
+		} else {
+			chunks = append(chunks, resultChunks(r, opts.ChunkSize, opts.ChunkOverlap)...)
+		}
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("ask: no page content to search")
+	}
+
+	var scores []float64
+	if opts.Embedder != nil {
+		var err error
+		if scores, err = embeddingScores(ctx, opts.Embedder, chunks, question); err != nil {
+			return nil, fmt.Errorf("ask: %w", err)
+		}
+	} else {
+		scores = bm25Scores(chunks, question)
+	}
+	order := make([]int, len(chunks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	ans := &Answer{Question: question}
+	var prompt strings.Builder
+	prompt.WriteString("Sources:\n\n")
+	for _, i := range order[:min(topK, len(order))] {
+		s := Source{N: len(ans.Sources) + 1, URL: chunks[i].URL, Text: chunks[i].Text, Score: scores[i]}
+		ans.Sources = append(ans.Sources, s)
+		fmt.Fprintf(&prompt, "[%d] %s\n%s\n\n", s.N, s.URL, s.Text)
+	}
+	fmt.Fprintf(&prompt, "Question: %s", question)
+
+	reply, err := opts.LLM.Complete(ctx, askSystem, prompt.String())
+	if err != nil {
+		return nil, fmt.Errorf("ask: %w", err)
+	}
+	ans.Answer = strings.TrimSpace(reply)
+	ans.Citations = citedSources(ans.Answer, ans.Sources)
+	return ans, nil
+}
+
+var citationRe = regexp.MustCompile(`\[(\d+)\]`)
+
+// citedSources returns the sources the answer references as [n], in
+// number order.
+func citedSources(answer string, sources []Source) []Source {
+	cited := map[int]bool{}
+	for _, m := range citationRe.FindAllStringSubmatch(answer, -1) {
+		n, _ := strconv.Atoi(m[1])
+		cited[n] = true
+	}
+	var out []Source
+	for _, s := range sources {
+		if cited[s.N] {
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].N < out[b].N })
+	return out
+}
+
+// tokenize lowercases text and splits it into letter/digit runs.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// bm25Scores ranks chunks against query with Okapi BM25 (k1 1.5, b 0.75).
+func bm25Scores(chunks []Chunk, query string) []float64 {
+	const k1, b = 1.5, 0.75
+	docs := make([]map[string]int, len(chunks))
+	lengths := make([]int, len(chunks))
+	df := map[string]int{}
+	total := 0
+	for i, ch := range chunks {
+		tf := map[string]int{}
+		for _, t := range tokenize(ch.Text) {
+			tf[t]++
+			lengths[i]++
+		}
+		for t := range tf {
+			df[t]++
+		}
+		docs[i] = tf
+		total += lengths[i]
+	}
+	avg := float64(total) / float64(len(chunks))
+	if avg == 0 {
+		avg = 1
+	}
+	n := float64(len(chunks))
+	scores := make([]float64, len(chunks))
+	for _, q := range tokenize(query) {
+		if df[q] == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-float64(df[q])+0.5)/(float64(df[q])+0.5))
+		for i, tf := range docs {
+			f := float64(tf[q])
+			if f == 0 {
+				continue
+			}
+			scores[i] += idf * f * (k1 + 1) / (f + k1*(1-b+b*float64(lengths[i])/avg))
+		}
+	}
+	return scores
+}
+
+// embeddingScores ranks chunks by cosine similarity to the question,
+// embedding the chunks that have no vector yet.
+func embeddingScores(ctx context.Context, e Embedder, chunks []Chunk, question string) ([]float64, error) {
+	texts := []string{question}
+	var missing []int
+	for i, ch := range chunks {
+		if len(ch.Embedding) == 0 {
+			missing = append(missing, i)
+			texts = append(texts, ch.Text)
+		}
+	}
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += 64 {
+		batch, err := e.Embed(ctx, texts[start:min(start+64, len(texts))])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("got %d vectors for %d texts", len(vectors), len(texts))
+	}
+	for j, i := range missing {
+		chunks[i].Embedding = vectors[j+1]
+	}
+	scores := make([]float64, len(chunks))
+	for i, ch := range chunks {
+		scores[i] = cosine(vectors[0], ch.Embedding)
+	}
+	return scores, nil
+}
+
+func cosine(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
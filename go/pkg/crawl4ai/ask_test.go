@@ -0,0 +1,125 @@
+package crawl4ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func askCorpus() []*CrawlResult {
+	page := func(url, md string) *CrawlResult {
+		return &CrawlResult{URL: url, Success: true, Markdown: &MarkdownResult{RawMarkdown: md}}
+	}
+	return []*CrawlResult{
+		page("https://a.com/pricing", "The Pro plan costs 20 dollars per month. The Team plan costs 50 dollars."),
+		page("https://a.com/about", "We were founded in 2019 by two engineers."),
+		page("https://a.com/blog", "Release notes: faster crawls and new export formats."),
+		{URL: "https://a.com/broken", Success: false, ErrorMessage: "timeout"},
+	}
+}
+
+func TestBM25Scores_RanksMatchingChunkFirst(t *testing.T) {
+	chunks := []Chunk{{Text: "founded in 2019"}, {Text: "Pro plan costs 20 dollars"}, {Text: "plan"}}
+	s := bm25Scores(chunks, "How much does the Pro plan cost?")
+	if !(s[1] > s[2] && s[2] > s[0]) || s[0] != 0 {
+		t.Errorf("scores = %v", s)
+	}
+}
+
+func TestAskResults_BM25WithCitations(t *testing.T) {
+	llm := &scriptedLLM{reply: "The Pro plan is $20/month [1]."}
+	ans, err := AskResults(context.Background(), askCorpus(), "What does the Pro plan cost?", AskOptions{LLM: llm, TopK: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ans.Sources) != 2 || ans.Sources[0].URL != "https://a.com/pricing" || ans.Sources[0].N != 1 {
+		t.Fatalf("sources = %+v", ans.Sources)
+	}
+	if len(ans.Citations) != 1 || ans.Citations[0].URL != "https://a.com/pricing" {
+		t.Errorf("citations = %+v", ans.Citations)
+	}
+	p := llm.prompts[0]
+	if !strings.Contains(p, "[1] https://a.com/pricing\n") || !strings.HasSuffix(p, "Question: What does the Pro plan cost?") {
+		t.Errorf("prompt = %q", p)
+	}
+	if strings.Contains(p, "broken") {
+		t.Error("failed page was searched")
+	}
+}
+
+// topicEmbedder maps text to a 2-d vector: money talk vs. everything else.
+type topicEmbedder struct{ texts int }
+
+func (e *topicEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.texts += len(texts)
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		if strings.Contains(t, "dollar") || strings.Contains(t, "cost") {
+			out[i] = []float32{1, 0}
+		} else {
+			out[i] = []float32{0, 1}
+		}
+	}
+	return out, nil
+}
+
+func TestAskResults_EmbeddingsReuseExistingChunks(t *testing.T) {
+	results := askCorpus()
+	results[1].Chunks = []Chunk{{URL: results[1].URL, Text: "founded in 2019", Embedding: []float32{0, 1}}}
+	e := &topicEmbedder{}
+	ans, err := AskResults(context.Background(), results, "What does it cost?", AskOptions{
+		LLM: &scriptedLLM{reply: "No idea."}, Embedder: e, TopK: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ans.Sources[0].URL != "https://a.com/pricing" || ans.Sources[0].Score < 0.99 {
+		t.Errorf("sources = %+v", ans.Sources)
+	}
+	// The question plus the pricing and blog chunks; the about chunk was already embedded.
+	if e.texts != 3 {
+		t.Errorf("embedded %d texts, want 3", e.texts)
+	}
+	if ans.Citations != nil {
+		t.Errorf("citations = %+v", ans.Citations)
+	}
+}
+
+func TestAskResults_Errors(t *testing.T) {
+	if _, err := AskResults(context.Background(), askCorpus(), "q", AskOptions{}); err == nil {
+		t.Error("missing LLM accepted")
+	}
+	if _, err := AskResults(context.Background(), askCorpus(), " ", AskOptions{LLM: &scriptedLLM{}}); err == nil {
+		t.Error("empty question accepted")
+	}
+	if _, err := AskResults(context.Background(), askCorpus()[3:], "q", AskOptions{LLM: &scriptedLLM{}}); err == nil {
+		t.Error("no content accepted")
+	}
+}
+
+func TestCitedSources_OrderAndUnknownNumbers(t *testing.T) {
+	sources := []Source{{N: 1, URL: "a"}, {N: 2, URL: "b"}, {N: 3, URL: "c"}}
+	got := citedSources("See [3] and [1], also [1] and [9].", sources)
+	if len(got) != 2 || got[0].N != 1 || got[1].N != 3 {
+		t.Errorf("cited = %+v", got)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+func TestAskJob(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_q": map[string]interface{}{"job_id": "job_q", "status": "completed", "urls_count": 1, "results": []interface{}{
+			map[string]interface{}{"url": "https://a.com/faq", "success": true, "markdown": map[string]interface{}{"raw_markdown": "Refunds take 5 days."}},
+		}},
+	})
+	ans, err := c.AskJob(context.Background(), "job_q", "How long do refunds take?", AskOptions{LLM: &scriptedLLM{reply: "Five days [1]."}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ans.Answer != "Five days [1]." || len(ans.Citations) != 1 || ans.Citations[0].URL != "https://a.com/faq" {
+		t.Errorf("answer = %+v", ans)
+	}
+}
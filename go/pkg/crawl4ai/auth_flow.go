@@ -0,0 +1,245 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// SecretProvider resolves credential names used by an AuthFlow, so
+// passwords live in the environment or a secret manager rather than in
+// code.
+type SecretProvider interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// EnvSecrets reads secrets from environment variables named Prefix+name.
+type EnvSecrets struct {
+	Prefix string
+}
+
+// Secret implements SecretProvider.
+func (e EnvSecrets) Secret(_ context.Context, name string) (string, error) {
+	v, ok := os.LookupEnv(e.Prefix + name)
+	if !ok {
+		return "", fmt.Errorf("secret %s: environment variable %s is not set", name, e.Prefix+name)
+	}
+	return v, nil
+}
+
+// StaticSecrets serves secrets from a map; handy in tests.
+type StaticSecrets map[string]string
+
+// Secret implements SecretProvider.
+func (s StaticSecrets) Secret(_ context.Context, name string) (string, error) {
+	v, ok := s[name]
+	if !ok {
+		return "", fmt.Errorf("secret %s is not defined", name)
+	}
+	return v, nil
+}
+
+// AuthStep is one interaction in an AuthFlow. Build steps with AuthFill,
+// AuthFillSecret, AuthClick and AuthWaitFor.
+type AuthStep struct {
+	Action   string // "fill", "click" or "wait"
+	Selector string
+	// Value is typed by a fill step; Secret, when set, names the
+	// SecretProvider entry to type instead.
+	Value  string
+	Secret string
+}
+
+// AuthFill types a literal value (e.g. a username that isn't secret) into the
+// element matching selector.
+func AuthFill(selector, value string) AuthStep {
+	return AuthStep{Action: "fill", Selector: selector, Value: value}
+}
+
+// AuthFillSecret types the named secret into the element matching selector.
+func AuthFillSecret(selector, secret string) AuthStep {
+	return AuthStep{Action: "fill", Selector: selector, Secret: secret}
+}
+
+// AuthClick clicks the element matching selector.
+func AuthClick(selector string) AuthStep {
+	return AuthStep{Action: "click", Selector: selector}
+}
+
+// AuthWaitFor pauses the flow until selector matches (e.g. a 2FA
+// field that appears after the first submit).
+func AuthWaitFor(selector string) AuthStep {
+	return AuthStep{Action: "wait", Selector: selector}
+}
+
+// AuthFlow is a login recipe: open LoginURL, run Steps in order, then wait
+// for SuccessSelector — an element only signed-in users see — and capture
+// the browser's storage state.
+//
+//	flow := &crawl4ai.AuthFlow{
+//	    LoginURL: "https://app.example.com/login",
+//	    Steps: []crawl4ai.AuthStep{
+//	        crawl4ai.AuthFillSecret("#email", "APP_USER"),
+//	        crawl4ai.AuthFillSecret("#password", "APP_PASSWORD"),
+//	        crawl4ai.AuthClick("button[type=submit]"),
+//	    },
+//	    SuccessSelector: "nav .account-menu",
+//	    Secrets:         crawl4ai.EnvSecrets{},
+//	}
+//	state, err := crawler.RunAuthFlow(ctx, flow)
+//	result, err := crawler.Run("https://app.example.com/reports", &crawl4ai.RunOptions{
+//	    BrowserConfig: state.ApplyTo(nil),
+//	})
+type AuthFlow struct {
+	LoginURL        string
+	Steps           []AuthStep
+	SuccessSelector string
+	Secrets         SecretProvider
+	// StepTimeout bounds how long each step waits for its selector.
+	// Default 15s.
+	StepTimeout time.Duration
+	// BrowserConfig applies to the login browser (viewport, user agent).
+	BrowserConfig *BrowserConfig
+}
+
+// AuthState is the captured browser storage state of a signed-in session,
+// in Playwright storage-state shape, so it can be saved as JSON and
+// reused across runs.
+type AuthState struct {
+	Cookies []map[string]interface{} `json:"cookies"`
+	Origins []interface{}            `json:"origins,omitempty"`
+}
+
+// ApplyTo returns a copy of bc (or a new BrowserConfig) carrying the
+// state's cookies, replacing any existing cookie with the same name and
+// domain.
+func (s *AuthState) ApplyTo(bc *BrowserConfig) *BrowserConfig {
+	out := &BrowserConfig{}
+	if bc != nil {
+		*out = *bc
+	}
+	out.Cookies = mergeCookies(out.Cookies, s.Cookies)
+	return out
+}
+
+// ExpiresAt returns the earliest expiry among the state's persistent
+// cookies, or the zero time when every cookie is session-scoped.
+func (s *AuthState) ExpiresAt() time.Time {
+	var earliest time.Time
+	for _, c := range s.Cookies {
+		exp, ok := c["expires"].(float64)
+		if !ok || exp <= 0 {
+			continue
+		}
+		t := time.Unix(int64(exp), 0)
+		if earliest.IsZero() || t.Before(earliest) {
+			earliest = t
+		}
+	}
+	return earliest
+}
+
+// RunAuthFlow executes flow in a one-off cloud browser and returns the
+// resulting AuthState. The login
+// request always bypasses the cache, so credentials are never stored with
+// cached pages, and errors never echo the generated script.
+func (c *AsyncWebCrawler) RunAuthFlow(ctx context.Context, flow *AuthFlow) (*AuthState, error) {
+	if flow == nil || flow.LoginURL == "" || flow.SuccessSelector == "" {
+		return nil, fmt.Errorf("auth flow: LoginURL and SuccessSelector are required")
+	}
+	if err := c.CheckAllowedDomains(flow.LoginURL); err != nil {
+		return nil, err
+	}
+	script, err := flow.script(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &CrawlerRunConfig{JsCode: script, WaitFor: WaitForSelector(flow.SuccessSelector).String()}
+	body := BuildCrawlRequest(map[string]interface{}{
+		"url":           flow.LoginURL,
+		"config":        config,
+		"browserConfig": flow.BrowserConfig,
+		"strategy":      "browser",
+		"bypassCache":   true,
+	})
+	body["capture_storage_state"] = true
+
+	data, err := c.http.Post("/v1/crawl", body, c.requestTimeout(0))
+	if err != nil {
+		return nil, fmt.Errorf("auth flow %s: %w", flow.LoginURL, err)
+	}
+	if ok, _ := data["success"].(bool); !ok {
+		msg, _ := data["error_message"].(string)
+		return nil, fmt.Errorf("auth flow %s: login did not reach %q: %s", flow.LoginURL, flow.SuccessSelector, msg)
+	}
+	raw, ok := data["storage_state"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("auth flow %s: response has no storage_state", flow.LoginURL)
+	}
+	return unmarshalWrapper[AuthState](raw)
+}
+
+// script compiles the steps into the js_code run on the login page.
+func (f *AuthFlow) script(ctx context.Context) (string, error) {
+	timeout := f.StepTimeout
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	var b strings.Builder
+	b.WriteString(interactionPrelude(timeout))
+	for i, step := range f.Steps {
+		sel, _ := json.Marshal(step.Selector)
+		switch step.Action {
+		case "fill":
+			value := step.Value
+			if step.Secret != "" {
+				if f.Secrets == nil {
+					return "", fmt.Errorf("auth flow: step %d needs secret %s but no SecretProvider is set", i, step.Secret)
+				}
+				v, err := f.Secrets.Secret(ctx, step.Secret)
+				if err != nil {
+					return "", fmt.Errorf("auth flow: %w", err)
+				}
+				value = v
+			}
+			val, _ := json.Marshal(value)
+			fmt.Fprintf(&b, "await __fill(%s, %s);\n", sel, val)
+		case "click":
+			fmt.Fprintf(&b, "(await __wait(%s)).click();\n", sel)
+		case "wait":
+			fmt.Fprintf(&b, "await __wait(%s);\n", sel)
+		default:
+			return "", fmt.Errorf("auth flow: step %d has unknown action %q", i, step.Action)
+		}
+	}
+	return b.String(), nil
+}
+
+// interactionPrelude defines the helpers generated page scripts use:
+// __wait polls for a selector for up to timeout, and __fill types into an
+// input the way frameworks such as React observe.
+func interactionPrelude(timeout time.Duration) string {
+	return fmt.Sprintf(`const __t = %d;
+const __wait = async (sel) => {
+  const end = Date.now() + __t;
+  let el;
+  while (!(el = document.querySelector(sel))) {
+    if (Date.now() > end) throw new Error("timed out waiting for " + sel);
+    await new Promise(r => setTimeout(r, 100));
+  }
+  return el;
+};
+const __fill = async (sel, v) => {
+  const el = await __wait(sel);
+  el.focus();
+  const d = Object.getOwnPropertyDescriptor(Object.getPrototypeOf(el), "value");
+  if (d && d.set) { d.set.call(el, v); } else { el.value = v; }
+  el.dispatchEvent(new Event("input", {bubbles: true}));
+  el.dispatchEvent(new Event("change", {bubbles: true}));
+};
+`, timeout.Milliseconds())
+}
@@ -0,0 +1,106 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestAuthFlow_Script(t *testing.T) {
+	flow := &AuthFlow{
+		Steps: []AuthStep{
+			AuthFill("#user", "alice"),
+			AuthFillSecret("#pass", "PW"),
+			AuthClick("button[type=submit]"),
+			AuthWaitFor("#otp"),
+		},
+		Secrets: StaticSecrets{"PW": `p"w`},
+	}
+	script, err := flow.script(context.Background())
+	if err != nil {
+		t.Fatalf("script: %v", err)
+	}
+	for _, want := range []string{
+		`await __fill("#user", "alice");`,
+		`await __fill("#pass", "p\"w");`,
+		`(await __wait("button[type=submit]")).click();`,
+		`await __wait("#otp");`,
+		"const __t = 15000;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+
+	flow.Secrets = EnvSecrets{Prefix: "CRAWL4AI_TEST_UNSET_"}
+	if _, err := flow.script(context.Background()); err == nil || !strings.Contains(err.Error(), "CRAWL4AI_TEST_UNSET_PW") {
+		t.Fatalf("expected missing env secret error, got %v", err)
+	}
+}
+
+func TestAuthState_ApplyToAndExpiry(t *testing.T) {
+	state := &AuthState{Cookies: []map[string]interface{}{
+		{"name": "sid", "value": "new", "domain": ".a.com", "expires": 2000000000.0},
+		{"name": "pref", "value": "x", "domain": ".a.com", "expires": -1.0},
+	}}
+	base := &BrowserConfig{UserAgent: "ua", Cookies: []map[string]interface{}{
+		{"name": "sid", "value": "old", "domain": ".a.com"},
+		{"name": "other", "value": "keep", "domain": ".b.com"},
+	}}
+	got := state.ApplyTo(base)
+	if got.UserAgent != "ua" || len(got.Cookies) != 3 || got.Cookies[1]["value"] != "new" {
+		t.Fatalf("unexpected cookies: %v", got.Cookies)
+	}
+	if len(base.Cookies) != 2 {
+		t.Fatal("ApplyTo modified its input")
+	}
+	if !state.ExpiresAt().Equal(time.Unix(2000000000, 0)) {
+		t.Fatalf("unexpected expiry %v", state.ExpiresAt())
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunAuthFlow_CapturesState(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "https://app.com/login", "success": true,
+			"storage_state": map[string]interface{}{
+				"cookies": []interface{}{map[string]interface{}{"name": "sid", "value": "abc", "domain": "app.com"}},
+			},
+		})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := c.RunAuthFlow(context.Background(), &AuthFlow{
+		LoginURL:        "https://app.com/login",
+		Steps:           []AuthStep{AuthFillSecret("#pass", "PW")},
+		SuccessSelector: ".account",
+		Secrets:         StaticSecrets{"PW": "hunter2"},
+	})
+	if err != nil {
+		t.Fatalf("RunAuthFlow: %v", err)
+	}
+	if len(state.Cookies) != 1 || state.Cookies[0]["value"] != "abc" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	cc := body["crawler_config"].(map[string]interface{})
+	if body["session_id"] != nil || body["bypass_cache"] != true || body["capture_storage_state"] != true {
+		t.Fatalf("unexpected request flags: %v", body)
+	}
+	if cc["wait_for"] != "css:.account" || !strings.Contains(cc["js_code"].(string), "hunter2") {
+		t.Fatalf("unexpected crawler config: %v", cc)
+	}
+}
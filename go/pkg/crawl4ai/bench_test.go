@@ -0,0 +1,188 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// largeResultJSON is a crawl result about the size of a long article page:
+// 512 KiB of HTML plus markdown, links and media.
+func largeResultJSON(tb testing.TB) []byte {
+	tb.Helper()
+	para := strings.Repeat("<p>Crawl4AI turns the web into LLM-ready Markdown. </p>\n", 9600)
+	links := make([]interface{}, 200)
+	for i := range links {
+		links[i] = map[string]interface{}{"href": "https://example.com/page/" + strings.Repeat("x", i%20), "text": "Page"}
+	}
+	raw, err := json.Marshal(map[string]interface{}{
+		"url":          "https://example.com/article",
+		"success":      true,
+		"status_code":  200,
+		"html":         "<!DOCTYPE html><HTML><body>" + para + "</body></HTML>",
+		"cleaned_html": para,
+		"markdown": map[string]interface{}{
+			"raw_markdown": strings.Repeat("Crawl4AI turns the web into LLM-ready Markdown.\n\n", 4800),
+			"fit_markdown": strings.Repeat("Crawl4AI turns the web into LLM-ready Markdown.\n\n", 1200),
+		},
+		"links":    map[string]interface{}{"internal": links, "external": links[:20]},
+		"media":    map[string]interface{}{"images": links[:50]},
+		"metadata": map[string]interface{}{"title": "Article", "description": "A long article"},
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return raw
+}
+
+// ─── Benchmarks ─────────────────────────────────────────────────────────────
+//
+// Run with: go test ./pkg/crawl4ai -run '^$' -bench . -benchmem
+// TestAllocationTargets keeps the allocs/op of the decode paths in check.
+
+func BenchmarkCrawlResultFromMap(b *testing.B) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(largeResultJSON(b), &data); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = CrawlResultFromMap(data)
+	}
+}
+
+func BenchmarkDecodeCrawlResult_Omit(b *testing.B) {
+	raw := largeResultJSON(b)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var data map[string]interface{}
+		_ = json.Unmarshal(raw, &data)
+		_ = decodeCrawlResult(data, HeavyResultFields, nil, nil)
+	}
+}
+
+func BenchmarkCrawlJobFromMap(b *testing.B) {
+	var result map[string]interface{}
+	if err := json.Unmarshal(largeResultJSON(b), &result); err != nil {
+		b.Fatal(err)
+	}
+	results := make([]interface{}, 20)
+	for i := range results {
+		results[i] = result
+	}
+	data := map[string]interface{}{"job_id": "job_1", "status": "completed", "urls_count": 20, "results": results}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = CrawlJobFromMap(data)
+	}
+}
+
+func BenchmarkBuildCrawlRequest(b *testing.B) {
+	options := map[string]interface{}{
+		"url":           "https://example.com",
+		"config":        &CrawlerRunConfig{Screenshot: true, WaitFor: "css:main", JsCode: strings.Repeat("x", 4096), PageTimeout: 30000},
+		"browserConfig": &BrowserConfig{Headless: true, ViewportWidth: 1920, Headers: map[string]string{"Accept-Language": "en"}},
+		"strategy":      "browser",
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = BuildCrawlRequest(options)
+	}
+}
+
+// benchServer answers every request with body.
+func benchServer(b *testing.B, body []byte) *AsyncWebCrawler {
+	b.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	b.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_bench", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return c
+}
+
+func BenchmarkRun_LargeResult(b *testing.B) {
+	raw := largeResultJSON(b)
+	c := benchServer(b, raw)
+	opts := &RunOptions{Config: &CrawlerRunConfig{JsCode: strings.Repeat("x", 64<<10)}}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Run("https://example.com/article", opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRequest_Retry measures the retry loop: a 503 then a success.
+// The backoff sleep is stubbed out, so only the per-attempt work counts.
+func BenchmarkRequest_Retry(b *testing.B) {
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n++
+		if n%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"detail": "busy"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	b.Cleanup(srv.Close)
+	hc, err := NewHTTPClient(HTTPClientOptions{APIKey: "sk_test_bench", BaseURL: srv.URL, MaxRetries: 2})
+	if err != nil {
+		b.Fatal(err)
+	}
+	body := map[string]interface{}{"url": "https://example.com", "config": map[string]interface{}{"js_code": strings.Repeat("x", 64<<10)}}
+	retrySleep = func(time.Duration) {}
+	b.Cleanup(func() { retrySleep = time.Sleep })
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := hc.Post("/v1/crawl", body, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWaitJob_Poll(b *testing.B) {
+	c := benchServer(b, []byte(`{"job_id": "job_1", "status": "completed", "urls_count": 20, "progress": {"total": 20, "completed": 20}}`))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.WaitJob("job_1", time.Millisecond, time.Second); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ─── Allocation targets ─────────────────────────────────────────────────────
+
+// TestAllocationTargets pins allocs/op for the hot decode paths so a
+// regression fails CI instead of waiting for a benchmark run. The limits
+// are the targets documented in the README, with some headroom.
+func TestAllocationTargets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation counts are slow to measure")
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(largeResultJSON(t), &data); err != nil {
+		t.Fatal(err)
+	}
+	if n := testing.AllocsPerRun(20, func() { _ = CrawlResultFromMap(data) }); n > 4 {
+		t.Errorf("CrawlResultFromMap: %.0f allocs/op, target <= 4", n)
+	}
+	if n := testing.AllocsPerRun(20, func() { _ = detectBlock(data) }); n > 0 {
+		t.Errorf("detectBlock: %.0f allocs/op, target 0", n)
+	}
+}
@@ -0,0 +1,168 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// Block kinds reported in BlockInfo.Kind.
+const (
+	BlockCaptcha   = "captcha"
+	BlockBlocked   = "blocked"
+	BlockLoginWall = "login_wall"
+)
+
+// BlockInfo explains why a result looks like a bot wall rather than the
+// requested page.
+type BlockInfo struct {
+	// Kind is BlockCaptcha, BlockBlocked or BlockLoginWall.
+	Kind string `json:"kind"`
+	// Reason is the signal that triggered the classification.
+	Reason string `json:"reason"`
+	// Source is "server" when the API reported the block, "heuristic"
+	// when the SDK inferred it from the status and content.
+	Source string `json:"source"`
+}
+
+// Blocked reports whether the result was classified as a captcha, block
+// page or login wall.
+func (r *CrawlResult) Blocked() bool {
+	return r.BlockInfo != nil
+}
+
+// NotBlocked is a ResultValidator that rejects blocked results, so Run
+// retries them through its escalation profiles (a residential proxy by
+// default).
+//
+//	result, err := crawler.Run(url, &crawl4ai.RunOptions{Validator: crawl4ai.NotBlocked})
+func NotBlocked(r *CrawlResult) error {
+	if b := r.BlockInfo; b != nil {
+		return fmt.Errorf("%s: %s", b.Kind, b.Reason)
+	}
+	return nil
+}
+
+// blockMarkers are HTML fragments that identify well-known captcha and
+// anti-bot interstitials. Widget markers are captchas that ordinary login,
+// contact and checkout forms embed too, so they only count on a page that
+// otherwise looks like a challenge (see looksLikeChallenge); they come
+// last so an interstitial's own marker wins.
+var blockMarkers = []struct {
+	marker, kind, reason string
+	widget               bool
+}{
+	{"challenges.cloudflare.com", BlockCaptcha, "Cloudflare challenge", false},
+	{"cf-browser-verification", BlockCaptcha, "Cloudflare browser check", false},
+	{"px-captcha", BlockCaptcha, "PerimeterX captcha", false},
+	{"captcha-delivery.com", BlockCaptcha, "DataDome captcha", false},
+	{"attention required! | cloudflare", BlockBlocked, "Cloudflare block page", false},
+	{"access denied</title>", BlockBlocked, "access denied page", false},
+	{"request unsuccessful. incapsula", BlockBlocked, "Imperva block page", false},
+	{"g-recaptcha", BlockCaptcha, "reCAPTCHA widget", true},
+	{"h-captcha", BlockCaptcha, "hCaptcha widget", true},
+	{"cf-turnstile", BlockCaptcha, "Cloudflare Turnstile challenge", true},
+}
+
+// thinPageBytes is the size below which a page carrying a captcha widget
+// is taken to be nothing but the challenge.
+const thinPageBytes = 4096
+
+// challengeTitle matches the titles captcha interstitials use.
+var challengeTitle = regexp.MustCompile(`(?i)<title[^>]*>[^<]*(captcha|robot|verify you are human|just a moment|security check|attention required)`)
+
+// looksLikeChallenge reports whether a page with a captcha widget is a
+// challenge rather than a normal page with a protected form: an error
+// status, a thin body or a challenge title.
+func looksLikeChallenge(status int, html string) bool {
+	switch status {
+	case 403, 429, 503:
+		return true
+	}
+	return len(html) < thinPageBytes || challengeTitle.MatchString(html)
+}
+
+// blockMarkerBytes holds blockMarkers' markers for bytes.Contains.
+var blockMarkerBytes = func() [][]byte {
+	out := make([][]byte, len(blockMarkers))
+	for i, m := range blockMarkers {
+		out[i] = []byte(m.marker)
+	}
+	return out
+}()
+
+// firstBlockMarker returns the index of the first blockMarkers entry found
+// in html, ignoring ASCII case, or -1. It lowercases html a window at a
+// time on the stack rather than copying a page that may run to megabytes;
+// windows overlap by the longest marker so none is split.
+func firstBlockMarker(html string) int {
+	var buf [8192]byte
+	const overlap = 64 // longer than any marker
+	found := -1
+	limit := len(blockMarkers)
+	for start := 0; start < len(html); start += len(buf) - overlap {
+		chunk := buf[:min(len(buf), len(html)-start)]
+		for i := range chunk {
+			c := html[start+i]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			chunk[i] = c
+		}
+		// Earlier markers win, so later windows only look for those.
+		for j := 0; j < limit; j++ {
+			if bytes.Contains(chunk, blockMarkerBytes[j]) {
+				found, limit = j, j
+				break
+			}
+		}
+		if found == 0 || start+len(chunk) == len(html) {
+			break
+		}
+	}
+	return found
+}
+
+// loginPath matches redirect targets that are sign-in pages.
+var loginPath = regexp.MustCompile(`(?i)/(login|log-in|signin|sign-in|sso|auth)(/|$|\?)`)
+
+// detectBlock classifies a raw result, preferring the server's own
+// block_info over local heuristics.
+func detectBlock(data map[string]interface{}) *BlockInfo {
+	if bi, ok := data["block_info"].(map[string]interface{}); ok {
+		info := &BlockInfo{Source: "server"}
+		info.Kind, _ = bi["kind"].(string)
+		info.Reason, _ = bi["reason"].(string)
+		if info.Kind == "" {
+			info.Kind = BlockBlocked
+		}
+		return info
+	}
+
+	rawHTML, _ := data["html"].(string)
+	pageURL, _ := data["url"].(string)
+	redirected, _ := data["redirected_url"].(string)
+	status, _ := data["status_code"].(float64)
+
+	if i := firstBlockMarker(rawHTML); i >= 0 {
+		m := blockMarkers[i]
+		if !m.widget || looksLikeChallenge(int(status), rawHTML) {
+			return &BlockInfo{Kind: m.kind, Reason: m.reason, Source: "heuristic"}
+		}
+	}
+	switch int(status) {
+	case 401:
+		return &BlockInfo{Kind: BlockLoginWall, Reason: "HTTP 401", Source: "heuristic"}
+	case 403, 429:
+		return &BlockInfo{Kind: BlockBlocked, Reason: fmt.Sprintf("HTTP %d", int(status)), Source: "heuristic"}
+	}
+	if redirected != "" && redirected != pageURL {
+		if u, err := url.Parse(redirected); err == nil && loginPath.MatchString(u.Path) {
+			if orig, err := url.Parse(pageURL); err != nil || !loginPath.MatchString(orig.Path) {
+				return &BlockInfo{Kind: BlockLoginWall, Reason: "redirected to " + u.Path, Source: "heuristic"}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package crawl4ai
+
+import (
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestBlockDetection(t *testing.T) {
+	cases := []struct {
+		name string
+		data map[string]interface{}
+		want *BlockInfo
+	}{
+		{"clean page", map[string]interface{}{"url": "https://a.com", "status_code": 200.0, "html": "<p>hello</p>"}, nil},
+		{"recaptcha", map[string]interface{}{"url": "https://a.com", "status_code": 200.0, "html": `<div class="g-recaptcha">`},
+			&BlockInfo{Kind: BlockCaptcha, Reason: "reCAPTCHA widget", Source: "heuristic"}},
+		{"rate limited", map[string]interface{}{"url": "https://a.com", "status_code": 429.0},
+			&BlockInfo{Kind: BlockBlocked, Reason: "HTTP 429", Source: "heuristic"}},
+		{"login redirect", map[string]interface{}{"url": "https://a.com/account", "redirected_url": "https://a.com/login?next=/account", "status_code": 200.0},
+			&BlockInfo{Kind: BlockLoginWall, Reason: "redirected to /login", Source: "heuristic"}},
+		{"login page itself", map[string]interface{}{"url": "https://a.com/login", "redirected_url": "https://a.com/login/", "status_code": 200.0}, nil},
+		{"server signal", map[string]interface{}{"url": "https://a.com", "status_code": 200.0, "block_info": map[string]interface{}{"kind": "captcha", "reason": "datadome"}},
+			&BlockInfo{Kind: BlockCaptcha, Reason: "datadome", Source: "server"}},
+	}
+	for _, tc := range cases {
+		r := CrawlResultFromMap(tc.data)
+		if (r.BlockInfo == nil) != (tc.want == nil) || (tc.want != nil && *r.BlockInfo != *tc.want) {
+			t.Errorf("%s: got %+v, want %+v", tc.name, r.BlockInfo, tc.want)
+		}
+		if (NotBlocked(r) == nil) == r.Blocked() {
+			t.Errorf("%s: NotBlocked disagrees with Blocked", tc.name)
+		}
+	}
+}
+
+func TestBlockDetection_CaptchaWidgetOnNormalPage(t *testing.T) {
+	article := strings.Repeat("<p>Our team answers within one business day.</p>", 200)
+	form := `<form action="/contact"><input name="email"><div class="g-recaptcha" data-sitekey="k"></div><button>Send</button></form>`
+	page := `<html><head><title>Contact us</title></head><body>` + article + form + `</body></html>`
+
+	r := CrawlResultFromMap(map[string]interface{}{"url": "https://a.com/contact", "status_code": 200.0, "html": page})
+	if r.Blocked() {
+		t.Errorf("contact form with reCAPTCHA classified as %+v", r.BlockInfo)
+	}
+	for _, status := range []float64{403, 503} {
+		r := CrawlResultFromMap(map[string]interface{}{"url": "https://a.com/contact", "status_code": status, "html": page})
+		if r.BlockInfo == nil || r.BlockInfo.Kind != BlockCaptcha {
+			t.Errorf("HTTP %v with a captcha widget: got %+v", status, r.BlockInfo)
+		}
+	}
+	challenge := `<html><head><title>Verify you are human</title></head><body>` + article + form + `</body></html>`
+	if r := CrawlResultFromMap(map[string]interface{}{"url": "https://a.com", "status_code": 200.0, "html": challenge}); !r.Blocked() {
+		t.Error("challenge title with a captcha widget not classified")
+	}
+}
+
+func TestFirstBlockMarker_LargePages(t *testing.T) {
+	for _, m := range blockMarkers {
+		if len(m.marker) >= 64 {
+			t.Fatalf("marker %q is longer than the window overlap", m.marker)
+		}
+	}
+	filler := strings.Repeat("<P>Lorem ipsum</P>", 2000)
+	// Markers straddling every window boundary, in any case.
+	for offset := 8192 - 70; offset < 8192; offset++ {
+		html := filler[:offset] + "<TITLE>Access Denied</TITLE>" + filler
+		if i := firstBlockMarker(html); i < 0 || blockMarkers[i].reason != "access denied page" {
+			t.Fatalf("offset %d: marker not found (%d)", offset, i)
+		}
+	}
+	// The earlier marker in the list wins wherever it appears.
+	html := `<div class="px-captcha">` + filler + `<div class="g-recaptcha">`
+	if i := firstBlockMarker(html); i < 0 || blockMarkers[i].marker != "px-captcha" {
+		t.Errorf("got marker %d, want px-captcha", i)
+	}
+	if i := firstBlockMarker(filler); i != -1 {
+		t.Errorf("clean page: got marker %d", i)
+	}
+}
+
+func TestBlockDetection_SurvivesOmittedHTML(t *testing.T) {
+	data := map[string]interface{}{"url": "https://a.com", "success": true, "html": `<iframe src="https://challenges.cloudflare.com/x">`}
+	r := decodeCrawlResult(data, []string{"html"}, nil, nil)
+	if r.HTML != "" || !r.Blocked() || r.BlockInfo.Kind != BlockCaptcha {
+		t.Fatalf("expected captcha detected before html was dropped, got %+v", r.BlockInfo)
+	}
+}
@@ -0,0 +1,162 @@
+package crawl4ai
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Bundle formats accepted by ExportJob.
+const (
+	BundleZip   = "zip"
+	BundleTarGz = "tar.gz"
+)
+
+// bundleManifest is manifest.json at the root of an ExportJob bundle.
+type bundleManifest struct {
+	JobID      string               `json:"job_id"`
+	ExportedAt time.Time            `json:"exported_at"`
+	Pages      []bundleManifestPage `json:"pages"`
+}
+
+type bundleManifestPage struct {
+	URL        string `json:"url"`
+	Dir        string `json:"dir"`
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+// ExportJob writes a whole job as one archive, organized per URL, for
+// handing a crawl to analysts or archiving it to cold storage:
+//
+//	manifest.json                 job ID and the page → directory index
+//	000-example.com/
+//	    result.json               the result minus the content files below
+//	    page.md, page.html
+//	    screenshot.png, page.pdf  when captured
+//	    files/…                   downloaded files
+//
+// format is BundleZip or BundleTarGz. Downloaded files are fetched from
+// their presigned URLs while the archive is written.
+//
+//	f, _ := os.Create("crawl.zip")
+//	defer f.Close()
+//	err := crawler.ExportJob(jobID, crawl4ai.BundleZip, f)
+func (c *AsyncWebCrawler) ExportJob(jobID, format string, w io.Writer) error {
+	var bw bundleWriter
+	switch format {
+	case BundleZip:
+		bw = &zipBundle{zw: zip.NewWriter(w)}
+	case BundleTarGz:
+		gz := gzip.NewWriter(w)
+		bw = &tarBundle{gz: gz, tw: tar.NewWriter(gz)}
+	default:
+		return fmt.Errorf("export job: unknown format %q (use %q or %q)", format, BundleZip, BundleTarGz)
+	}
+
+	results, err := c.JobResults(jobID)
+	if err != nil {
+		return err
+	}
+	manifest := bundleManifest{JobID: jobID, ExportedAt: time.Now().UTC()}
+	for i, r := range results {
+		dir := fmt.Sprintf("%03d-%s", i, pageSlug(r.URL))
+		manifest.Pages = append(manifest.Pages, bundleManifestPage{
+			URL: r.URL, Dir: dir, Success: r.Success, StatusCode: r.StatusCode,
+		})
+		if err := c.bundleResult(bw, dir, r); err != nil {
+			return fmt.Errorf("export job %s: %s: %w", jobID, r.URL, err)
+		}
+	}
+	m, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := bw.add("manifest.json", m); err != nil {
+		return err
+	}
+	return bw.close()
+}
+
+func (c *AsyncWebCrawler) bundleResult(bw bundleWriter, dir string, r *CrawlResult) error {
+	meta := *r
+	meta.HTML, meta.Markdown, meta.Screenshot, meta.PDF = "", nil, "", ""
+	b, err := json.MarshalIndent(&meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := bw.add(dir+"/result.json", b); err != nil {
+		return err
+	}
+	if r.Markdown != nil && r.Markdown.RawMarkdown != "" {
+		if err := bw.add(dir+"/page.md", []byte(r.Markdown.RawMarkdown)); err != nil {
+			return err
+		}
+	}
+	for i, a := range resultArtifacts(r) {
+		name := map[string]string{
+			ArtifactScreenshot: "screenshot.png",
+			ArtifactPDF:        "page.pdf",
+			ArtifactHTML:       "page.html",
+		}[a.Type]
+		if a.Type == ArtifactDownload {
+			name = a.Name
+			if name == "" || name == "/" || name == "." {
+				name = fmt.Sprintf("file-%d", i)
+			}
+			name = "files/" + name
+		}
+		var buf bytes.Buffer
+		if err := c.writeArtifact(a, &buf); err != nil {
+			return err
+		}
+		if err := bw.add(dir+"/"+name, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type bundleWriter interface {
+	add(name string, data []byte) error
+	close() error
+}
+
+type zipBundle struct{ zw *zip.Writer }
+
+func (z *zipBundle) add(name string, data []byte) error {
+	f, err := z.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+func (z *zipBundle) close() error { return z.zw.Close() }
+
+type tarBundle struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func (t *tarBundle) add(name string, data []byte) error {
+	hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: time.Now()}
+	if err := t.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := t.tw.Write(data)
+	return err
+}
+
+func (t *tarBundle) close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gz.Close()
+}
@@ -0,0 +1,108 @@
+package crawl4ai
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestExportJob_Bundles(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/crawl/jobs/job_1":
+			_, _ = io.WriteString(w, `{"job_id": "job_1", "status": "completed", "urls_count": 2, "results": [
+				{"url": "https://a.com/", "success": true, "status_code": 200, "markdown": "# A", "html": "<h1>A</h1>",
+				 "screenshot": "`+base64.StdEncoding.EncodeToString([]byte("img"))+`",
+				 "downloaded_files": ["`+srv.URL+`/files/data.csv?sig=1"]},
+				{"url": "https://a.com/b", "success": false, "error_message": "timeout"}]}`)
+		case "/files/data.csv":
+			_, _ = io.WriteString(w, "a,b\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantNames := []string{
+		"000-a.com/files/data.csv", "000-a.com/page.html", "000-a.com/page.md",
+		"000-a.com/result.json", "000-a.com/screenshot.png", "001-a.com_b/result.json", "manifest.json",
+	}
+
+	var zbuf bytes.Buffer
+	if err := c.ExportJob("job_1", BundleZip, &zbuf); err != nil {
+		t.Fatalf("ExportJob zip: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(zbuf.Bytes()), int64(zbuf.Len()))
+	if err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	if got := sortedKeys(files); !reflect.DeepEqual(got, wantNames) {
+		t.Fatalf("unexpected zip entries:\n%v\nwant:\n%v", got, wantNames)
+	}
+	if files["000-a.com/files/data.csv"] != "a,b\n" || files["000-a.com/screenshot.png"] != "img" {
+		t.Fatalf("unexpected artifact content: %q", files["000-a.com/files/data.csv"])
+	}
+	if strings.Contains(files["000-a.com/result.json"], "<h1>") || !strings.Contains(files["manifest.json"], `"dir": "001-a.com_b"`) {
+		t.Fatalf("unexpected metadata:\n%s\n%s", files["000-a.com/result.json"], files["manifest.json"])
+	}
+
+	var tbuf bytes.Buffer
+	if err := c.ExportJob("job_1", BundleTarGz, &tbuf); err != nil {
+		t.Fatalf("ExportJob tar.gz: %v", err)
+	}
+	gz, err := gzip.NewReader(&tbuf)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	tarFiles := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("tar: %v", err)
+		}
+		tarFiles[hdr.Name] = ""
+	}
+	if got := sortedKeys(tarFiles); !reflect.DeepEqual(got, wantNames) {
+		t.Fatalf("unexpected tar entries: %v", got)
+	}
+
+	if err := c.ExportJob("job_1", "rar", io.Discard); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
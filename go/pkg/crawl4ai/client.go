@@ -10,7 +10,10 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -28,6 +31,9 @@ const (
 	DefaultMaxRetries = 3
 )
 
+// retrySleep waits out the backoff between attempts; benchmarks stub it.
+var retrySleep = time.Sleep
+
 // HTTPClient is the internal HTTP client.
 type HTTPClient struct {
 	apiKey     string
@@ -35,6 +41,111 @@ type HTTPClient struct {
 	timeout    time.Duration
 	maxRetries int
 	client     *http.Client
+
+	rateMu   sync.Mutex
+	rate     RateLimitStatus
+	rateSeen bool
+
+	calls, attempts                            atomic.Int64
+	networkRetries, serverRetries, readRetries atomic.Int64
+
+	// gate, when set, runs before every call and may delay or refuse it
+	// (see ClientManager).
+	gate func(context.Context) error
+	// scope, when set, vets every request body before it is sent (see
+	// CrawlerOptions.AllowedDomains).
+	scope func(body map[string]interface{}) error
+
+	// ctx and root are set on clients made by withContext; root owns the
+	// shared rate-limit state and request counters.
+	ctx  context.Context
+	root *HTTPClient
+}
+
+// RateLimitStatus is the rate-limit window the API last reported through
+// x-ratelimit-* response headers.
+type RateLimitStatus struct {
+	Limit      int
+	Remaining  int
+	ResetAt    time.Time // zero when the server sent no reset hint
+	ObservedAt time.Time
+}
+
+// RateLimit returns the most recently observed rate-limit headers. ok is
+// false until a response carrying them has been seen.
+func (c *HTTPClient) RateLimit() (status RateLimitStatus, ok bool) {
+	if c.root != nil {
+		return c.root.RateLimit()
+	}
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rate, c.rateSeen
+}
+
+// RequestStats counts the API calls an HTTPClient has made since it was
+// created.
+type RequestStats struct {
+	// Calls are requests made through the client; Attempts are the HTTP
+	// requests sent for them, retries included.
+	Calls    int64
+	Attempts int64
+	// Retries by cause: a network error or timeout, a 5xx response, and a
+	// response body that failed to read.
+	NetworkRetries int64
+	ServerRetries  int64
+	ReadRetries    int64
+}
+
+// Retries is the total number of retried attempts.
+func (s RequestStats) Retries() int64 {
+	return s.NetworkRetries + s.ServerRetries + s.ReadRetries
+}
+
+// Stats returns the client's request counters.
+func (c *HTTPClient) Stats() RequestStats {
+	if c.root != nil {
+		return c.root.Stats()
+	}
+	return RequestStats{
+		Calls:          c.calls.Load(),
+		Attempts:       c.attempts.Load(),
+		NetworkRetries: c.networkRetries.Load(),
+		ServerRetries:  c.serverRetries.Load(),
+		ReadRetries:    c.readRetries.Load(),
+	}
+}
+
+// counters returns the client that owns the request counters.
+func (c *HTTPClient) counters() *HTTPClient {
+	if c.root != nil {
+		return c.root
+	}
+	return c
+}
+
+// observeRateLimit records x-ratelimit-limit / -remaining / -reset from a
+// response. Reset is seconds until the window resets, matching
+// RateLimitError.RetryAfter.
+func (c *HTTPClient) observeRateLimit(h http.Header) {
+	if c.root != nil {
+		c.root.observeRateLimit(h)
+		return
+	}
+	remaining := h.Get("X-Ratelimit-Remaining")
+	if remaining == "" {
+		return
+	}
+	now := time.Now()
+	status := RateLimitStatus{ObservedAt: now}
+	status.Remaining, _ = strconv.Atoi(remaining)
+	status.Limit, _ = strconv.Atoi(h.Get("X-Ratelimit-Limit"))
+	if secs, err := strconv.Atoi(h.Get("X-Ratelimit-Reset")); err == nil && secs > 0 {
+		status.ResetAt = now.Add(time.Duration(secs) * time.Second)
+	}
+	c.rateMu.Lock()
+	c.rate = status
+	c.rateSeen = true
+	c.rateMu.Unlock()
 }
 
 // HTTPClientOptions are options for creating an HTTPClient.
@@ -95,6 +206,12 @@ type RequestOptions struct {
 	Body    map[string]interface{}
 	Timeout time.Duration
 	Headers map[string]string
+	// Context, when set, cancels the request and may carry a request ID
+	// (see WithRequestID). Defaults to the client's context.
+	Context context.Context
+	// decode, when set, reads a success response's body in place of the
+	// buffered JSON read (see RunToWriter). Its errors are not retried.
+	decode func(io.Reader) (map[string]interface{}, error)
 }
 
 // Request makes an HTTP request with retries and error handling.
@@ -103,6 +220,11 @@ func (c *HTTPClient) Request(opts RequestOptions) (map[string]interface{}, error
 	if method == "" {
 		method = "GET"
 	}
+	if c.scope != nil && opts.Body != nil {
+		if err := c.scope(opts.Body); err != nil {
+			return nil, err
+		}
+	}
 
 	// Build URL
 	reqURL := c.baseURL + opts.Path
@@ -114,36 +236,55 @@ func (c *HTTPClient) Request(opts RequestOptions) (map[string]interface{}, error
 		reqURL += "?" + params.Encode()
 	}
 
-	// Build body
-	var bodyReader io.Reader
+	// Build body once; every attempt sends the same bytes.
+	var bodyBytes []byte
 	if opts.Body != nil {
-		bodyBytes, err := json.Marshal(opts.Body)
+		var err error
+		bodyBytes, err = json.Marshal(opts.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = c.ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// One ID per call, kept across retries, so every attempt correlates.
+	requestID, ok := RequestIDFromContext(ctx)
+	if !ok {
+		requestID = newRequestID()
+	}
+	if c.gate != nil {
+		if err := c.gate(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	// Retry loop
+	stats := c.counters()
+	stats.calls.Add(1)
 	var lastErr error
 	for attempt := 0; attempt < c.maxRetries; attempt++ {
+		stats.attempts.Add(1)
 		// Create request
-		req, err := http.NewRequest(method, reqURL, bodyReader)
+		var bodyReader io.Reader
+		if bodyBytes != nil {
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, reqURL, bodyReader)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
-		// Reset body reader for retries
-		if opts.Body != nil {
-			bodyBytes, _ := json.Marshal(opts.Body)
-			bodyReader = bytes.NewReader(bodyBytes)
-			req.Body = io.NopCloser(bodyReader)
-		}
-
 		// Set headers
 		req.Header.Set("X-API-Key", c.apiKey)
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("User-Agent", fmt.Sprintf("crawl4ai-cloud/%s", Version))
+		req.Header.Set(RequestIDHeader, requestID)
 		for k, v := range opts.Headers {
 			req.Header.Set(k, v)
 		}
@@ -151,54 +292,76 @@ func (c *HTTPClient) Request(opts RequestOptions) (map[string]interface{}, error
 		// Use custom timeout if provided
 		client := c.client
 		if opts.Timeout > 0 && opts.Timeout != c.timeout {
-			client = &http.Client{Timeout: opts.Timeout}
+			client = &http.Client{Transport: c.client.Transport, Timeout: opts.Timeout}
 		}
 
 		// Make request
+		started := time.Now()
 		resp, err := client.Do(req)
 		if err != nil {
 			lastErr = err
-			if attempt < c.maxRetries-1 {
-				time.Sleep(time.Duration(1<<attempt) * time.Second)
+			// A cancelled context would fail every retry the same way.
+			if attempt < c.maxRetries-1 && ctx.Err() == nil {
+				stats.networkRetries.Add(1)
+				retrySleep(time.Duration(1<<attempt) * time.Second)
 				continue
 			}
-			return nil, NewTimeoutError(fmt.Sprintf("request failed: %v", err))
+			return nil, withRequestID(NewTimeoutError(fmt.Sprintf("request failed after %s (timeout %s): %v",
+				elapsedSince(started), client.Timeout, err)), requestID)
 		}
 
 		defer resp.Body.Close()
+		c.observeRateLimit(resp.Header)
+		serverID := resp.Header.Get(RequestIDHeader)
+		if serverID != "" {
+			requestID = serverID
+		}
+
+		if resp.StatusCode < 400 && opts.decode != nil {
+			result, err := opts.decode(resp.Body)
+			if err != nil {
+				return nil, withRequestID(NewCloudError(fmt.Sprintf("failed to read response: %v", err), 0, nil, nil), requestID)
+			}
+			if _, ok := result["request_id"]; !ok && serverID != "" {
+				result["request_id"] = serverID
+			}
+			return result, nil
+		}
 
-		// Read response body
-		respBody, err := io.ReadAll(resp.Body)
+		// Read and parse response body
+		result, err := readResponseJSON(resp)
 		if err != nil {
 			lastErr = err
 			if attempt < c.maxRetries-1 {
-				time.Sleep(time.Duration(1<<attempt) * time.Second)
+				stats.readRetries.Add(1)
+				retrySleep(time.Duration(1<<attempt) * time.Second)
 				continue
 			}
 			return nil, NewCloudError(fmt.Sprintf("failed to read response: %v", err), 0, nil, nil)
 		}
 
-		// Parse response
-		var result map[string]interface{}
-		if len(respBody) > 0 {
-			if err := json.Unmarshal(respBody, &result); err != nil {
-				// Try to return as string if not JSON
-				result = map[string]interface{}{"raw": string(respBody)}
-			}
-		} else {
-			result = make(map[string]interface{})
-		}
-
 		// Success
 		if resp.StatusCode < 400 {
+			if _, ok := result["request_id"]; !ok && serverID != "" {
+				result["request_id"] = serverID
+			}
 			return result, nil
 		}
 
 		// Extract error detail — FastAPI 422 sends list/dict; coerce so
 		// downstream string ops (strings.Contains etc.) don't blow up.
 		detail := ""
+		fieldErrs := parseFieldErrors(result["detail"])
 		if d, ok := result["detail"].(string); ok {
 			detail = d
+		} else if d, ok := result["detail"].(map[string]interface{}); ok && d["message"] != nil {
+			detail = fmt.Sprint(d["message"])
+		} else if len(fieldErrs) > 0 {
+			parts := make([]string, len(fieldErrs))
+			for i, f := range fieldErrs {
+				parts[i] = f.String()
+			}
+			detail = "invalid request: " + strings.Join(parts, "; ")
 		} else if d, ok := result["detail"]; ok && d != nil {
 			if bs, err := json.Marshal(d); err == nil {
 				detail = string(bs)
@@ -220,28 +383,29 @@ func (c *HTTPClient) Request(opts RequestOptions) (map[string]interface{}, error
 		// Map status codes to errors
 		switch resp.StatusCode {
 		case 401:
-			return nil, NewAuthenticationError(detail, result, headers)
+			return nil, withRequestID(NewAuthenticationError(detail, result, headers), requestID)
 		case 404:
-			return nil, NewNotFoundError(detail, result, headers)
+			return nil, withRequestID(NewNotFoundError(detail, result, headers), requestID)
 		case 429:
 			if strings.Contains(strings.ToLower(detail), "rate limit") {
-				return nil, NewRateLimitError(detail, result, headers)
+				return nil, withRequestID(NewRateLimitError(detail, result, headers), requestID)
 			}
-			return nil, NewQuotaExceededError(detail, result, headers)
-		case 400:
-			return nil, NewValidationError(detail, result, headers)
+			return nil, withRequestID(NewQuotaExceededError(detail, result, headers), requestID)
+		case 400, 422:
+			return nil, withRequestID(NewValidationErrorWithStatus(detail, resp.StatusCode, result, headers), requestID)
 		case 504:
-			return nil, NewTimeoutError(detail)
+			return nil, withRequestID(NewTimeoutError(detail), requestID)
 		default:
 			if resp.StatusCode >= 500 {
-				lastErr = NewServerError(detail, resp.StatusCode, result, headers)
+				lastErr = withRequestID(NewServerError(detail, resp.StatusCode, result, headers), requestID)
 				if attempt < c.maxRetries-1 {
-					time.Sleep(time.Duration(1<<attempt) * time.Second)
+					stats.serverRetries.Add(1)
+					retrySleep(time.Duration(1<<attempt) * time.Second)
 					continue
 				}
 				return nil, lastErr
 			}
-			return nil, NewCloudError(detail, resp.StatusCode, result, headers)
+			return nil, withRequestID(NewCloudError(detail, resp.StatusCode, result, headers), requestID)
 		}
 	}
 
@@ -251,6 +415,43 @@ func (c *HTTPClient) Request(opts RequestOptions) (map[string]interface{}, error
 	return nil, NewCloudError("max retries exceeded", 0, nil, nil)
 }
 
+// bodyBuffers recycles response read buffers: a result carrying full HTML
+// runs to megabytes, and growing a fresh buffer for each was most of a
+// crawl's garbage.
+var bodyBuffers = sync.Pool{New: func() interface{} { return new(bytes.Buffer) }}
+
+// maxPooledBuffer keeps one outsized response from pinning its memory in
+// the pool.
+const maxPooledBuffer = 16 << 20
+
+// readResponseJSON reads and parses a response body. A body that isn't
+// JSON is returned as {"raw": body}; an empty one as an empty map.
+func readResponseJSON(resp *http.Response) (map[string]interface{}, error) {
+	buf := bodyBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			bodyBuffers.Put(buf)
+		}
+	}()
+	if resp.ContentLength > 0 && resp.ContentLength <= maxPooledBuffer {
+		buf.Grow(int(resp.ContentLength))
+	}
+	if _, err := buf.ReadFrom(resp.Body); err != nil {
+		return nil, err
+	}
+	if buf.Len() == 0 {
+		return make(map[string]interface{}), nil
+	}
+	// Unmarshal copies what it keeps, so buf can go back to the pool.
+	var result map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		// Try to return as string if not JSON
+		return map[string]interface{}{"raw": buf.String()}, nil
+	}
+	return result, nil
+}
+
 // Get makes a GET request.
 func (c *HTTPClient) Get(path string, params map[string]string) (map[string]interface{}, error) {
 	return c.Request(RequestOptions{
@@ -313,6 +514,11 @@ func (c *HTTPClient) StreamSse(ctx context.Context, path string, params map[stri
 	}
 	req.Header.Set("X-API-Key", c.apiKey)
 	req.Header.Set("Accept", "text/event-stream")
+	if id, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set(RequestIDHeader, id)
+	} else {
+		req.Header.Set(RequestIDHeader, newRequestID())
+	}
 	req.Header.Set("User-Agent", fmt.Sprintf("crawl4ai-cloud/%s", Version))
 
 	// Use a separate http.Client with no read timeout — SSE streams are open-ended.
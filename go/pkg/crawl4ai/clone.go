@@ -0,0 +1,174 @@
+package crawl4ai
+
+// Clone returns a deep copy of c. Nil returns nil.
+func (c *CrawlerRunConfig) Clone() *CrawlerRunConfig {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	out.ExcludeDomains = cloneStrings(c.ExcludeDomains)
+	out.ExtractionStrategy = cloneJSONMap(c.ExtractionStrategy)
+	if c.ExtractionStrategies != nil {
+		out.ExtractionStrategies = make(map[string]map[string]interface{}, len(c.ExtractionStrategies))
+		for name, s := range c.ExtractionStrategies {
+			out.ExtractionStrategies[name] = cloneJSONMap(s)
+		}
+	}
+	return &out
+}
+
+// Clone returns a deep copy of b. Nil returns nil.
+func (b *BrowserConfig) Clone() *BrowserConfig {
+	if b == nil {
+		return nil
+	}
+	out := *b
+	if b.Headers != nil {
+		out.Headers = make(map[string]string, len(b.Headers))
+		for k, v := range b.Headers {
+			out.Headers[k] = v
+		}
+	}
+	out.Cookies = cloneJSONMaps(b.Cookies)
+	return &out
+}
+
+// Clone returns a deep copy of r. Fields omitted at decode time can still
+// be loaded on the copy with LoadOmitted. Nil returns nil.
+func (r *CrawlResult) Clone() *CrawlResult {
+	if r == nil {
+		return nil
+	}
+	out := *r
+	if r.Markdown != nil {
+		md := *r.Markdown
+		out.Markdown = &md
+	}
+	out.Media = cloneJSONMap(r.Media)
+	out.Links = cloneJSONMap(r.Links)
+	out.Metadata = cloneJSONMap(r.Metadata)
+	if r.Tables != nil {
+		out.Tables = cloneJSON(r.Tables).([]interface{})
+	}
+	if r.RedirectChain != nil {
+		out.RedirectChain = append([]RedirectHop(nil), r.RedirectChain...)
+	}
+	out.DownloadedFiles = cloneStrings(r.DownloadedFiles)
+	out.Usage = r.Usage.clone()
+	if r.BlockInfo != nil {
+		block := *r.BlockInfo
+		out.BlockInfo = &block
+	}
+	if r.Chunks != nil {
+		out.Chunks = make([]Chunk, len(r.Chunks))
+		for i, ch := range r.Chunks {
+			ch.Embedding = append([]float32(nil), ch.Embedding...)
+			out.Chunks[i] = ch
+		}
+	}
+	if r.Warnings != nil {
+		out.Warnings = append([]Warning(nil), r.Warnings...)
+	}
+	return &out
+}
+
+// Clone returns a deep copy of j, results included. Nil returns nil.
+func (j *CrawlJob) Clone() *CrawlJob {
+	if j == nil {
+		return nil
+	}
+	out := *j
+	out.URLs = cloneStrings(j.URLs)
+	if j.Results != nil {
+		out.Results = make([]*CrawlResult, len(j.Results))
+		for i, r := range j.Results {
+			out.Results[i] = r.Clone()
+		}
+	}
+	out.Usage = j.Usage.clone()
+	return &out
+}
+
+func (u *Usage) clone() *Usage {
+	if u == nil {
+		return nil
+	}
+	out := *u
+	if u.Crawl != nil {
+		crawl := *u.Crawl
+		out.Crawl = &crawl
+	}
+	if u.LLM != nil {
+		llm := *u.LLM
+		out.LLM = &llm
+	}
+	if u.Storage != nil {
+		storage := *u.Storage
+		out.Storage = &storage
+	}
+	return &out
+}
+
+func cloneStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), s...)
+}
+
+func cloneJSONMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	return cloneJSON(m).(map[string]interface{})
+}
+
+func cloneJSONMaps(ms []map[string]interface{}) []map[string]interface{} {
+	if ms == nil {
+		return nil
+	}
+	out := make([]map[string]interface{}, len(ms))
+	for i, m := range ms {
+		out[i] = cloneJSONMap(m)
+	}
+	return out
+}
+
+// cloneJSON deep-copies a decoded JSON value. Maps and slices of other
+// types are copied one level deep; scalars are returned as is.
+func cloneJSON(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if x == nil {
+			return x
+		}
+		out := make(map[string]interface{}, len(x))
+		for k, e := range x {
+			out[k] = cloneJSON(e)
+		}
+		return out
+	case []interface{}:
+		if x == nil {
+			return x
+		}
+		out := make([]interface{}, len(x))
+		for i, e := range x {
+			out[i] = cloneJSON(e)
+		}
+		return out
+	case []map[string]interface{}:
+		return cloneJSONMaps(x)
+	case []string:
+		return cloneStrings(x)
+	case map[string]string:
+		if x == nil {
+			return x
+		}
+		out := make(map[string]string, len(x))
+		for k, e := range x {
+			out[k] = e
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+package crawl4ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestCrawlResult_Clone(t *testing.T) {
+	orig := CrawlResultFromMap(map[string]interface{}{
+		"url":      "https://example.com",
+		"success":  true,
+		"html":     "<p>hi</p>",
+		"markdown": map[string]interface{}{"raw_markdown": "hi"},
+		"links":    map[string]interface{}{"internal": []interface{}{map[string]interface{}{"href": "/a"}}},
+		"media":    map[string]interface{}{"images": []interface{}{}},
+		"metadata": map[string]interface{}{"title": "Hi"},
+		"tables":   []interface{}{map[string]interface{}{"rows": []interface{}{"x"}}},
+		"usage":    map[string]interface{}{"crawl": map[string]interface{}{"credits_used": 1.0}},
+	})
+	orig.Chunks = []Chunk{{Text: "hi", Embedding: []float32{1}}}
+	orig.Warnings = []Warning{{Field: "cache_mode"}}
+	cp := orig.Clone()
+	if !reflect.DeepEqual(cp, orig) {
+		t.Fatalf("clone differs:\n%+v\n%+v", cp, orig)
+	}
+
+	cp.HTML = ""
+	cp.Markdown.RawMarkdown = "changed"
+	cp.Links["internal"].([]interface{})[0].(map[string]interface{})["href"] = "/b"
+	cp.Metadata["title"] = "changed"
+	cp.Tables[0].(map[string]interface{})["rows"] = nil
+	cp.Usage.Crawl.CreditsUsed = 9
+	cp.Chunks[0].Embedding[0] = 9
+	cp.Warnings[0].Field = "changed"
+	if orig.HTML == "" || orig.Markdown.RawMarkdown != "hi" || orig.Metadata["title"] != "Hi" ||
+		orig.Links["internal"].([]interface{})[0].(map[string]interface{})["href"] != "/a" ||
+		orig.Tables[0].(map[string]interface{})["rows"] == nil || orig.Usage.Crawl.CreditsUsed != 1 ||
+		orig.Chunks[0].Embedding[0] != 1 || orig.Warnings[0].Field != "cache_mode" {
+		t.Errorf("mutating the clone changed the original: %+v", orig)
+	}
+
+	var nilResult *CrawlResult
+	if nilResult.Clone() != nil {
+		t.Error("nil.Clone() != nil")
+	}
+	job := &CrawlJob{JobID: "j", URLs: []string{"a"}, Results: []*CrawlResult{orig}}
+	jc := job.Clone()
+	jc.Results[0].Metadata["title"] = "job"
+	jc.URLs[0] = "b"
+	if orig.Metadata["title"] != "Hi" || job.URLs[0] != "a" {
+		t.Error("job clone shares its results")
+	}
+}
+
+func TestConfig_Clone(t *testing.T) {
+	cfg := &CrawlerRunConfig{
+		ExcludeDomains:       []string{"ads.example.com"},
+		ExtractionStrategy:   map[string]interface{}{"type": "json_css", "schema": map[string]interface{}{"fields": []interface{}{"a"}}},
+		ExtractionStrategies: map[string]map[string]interface{}{"llm": {"type": "llm"}},
+	}
+	cc := cfg.Clone()
+	if !reflect.DeepEqual(cc, cfg) {
+		t.Fatal("config clone differs")
+	}
+	cc.ExcludeDomains[0] = "x"
+	cc.ExtractionStrategy["schema"].(map[string]interface{})["fields"] = nil
+	cc.ExtractionStrategies["llm"]["type"] = "x"
+	if cfg.ExcludeDomains[0] != "ads.example.com" || cfg.ExtractionStrategy["schema"].(map[string]interface{})["fields"] == nil ||
+		cfg.ExtractionStrategies["llm"]["type"] != "llm" {
+		t.Errorf("mutating the clone changed the original: %+v", cfg)
+	}
+
+	bc := &BrowserConfig{Headers: map[string]string{"A": "1"}, Cookies: []map[string]interface{}{{"name": "s"}}}
+	bcc := bc.Clone()
+	bcc.Headers["A"] = "2"
+	bcc.Cookies[0]["name"] = "t"
+	if bc.Headers["A"] != "1" || bc.Cookies[0]["name"] != "s" {
+		t.Errorf("mutating the clone changed the original: %+v", bc)
+	}
+}
+
+// TestClone_CoversReferenceFields fails when a map, slice or pointer field
+// is added to a cloned type, as a reminder to deep-copy it in Clone.
+func TestClone_CoversReferenceFields(t *testing.T) {
+	covered := map[reflect.Type][]string{
+		reflect.TypeOf(CrawlResult{}): {"Markdown", "Media", "Links", "Metadata", "Tables", "RedirectChain",
+			"DownloadedFiles", "Usage", "BlockInfo", "Chunks", "Warnings", "lazy"},
+		reflect.TypeOf(CrawlerRunConfig{}): {"ExcludeDomains", "ExtractionStrategy", "ExtractionStrategies"},
+		reflect.TypeOf(BrowserConfig{}):    {"Headers", "Cookies"},
+		reflect.TypeOf(CrawlJob{}):         {"URLs", "Results", "Usage"},
+	}
+	for typ, fields := range covered {
+		known := map[string]bool{}
+		for _, f := range fields {
+			known[f] = true
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			switch f.Type.Kind() {
+			case reflect.Map, reflect.Slice, reflect.Ptr, reflect.Interface:
+				if !known[f.Name] {
+					t.Errorf("%s.%s is a reference field not handled by Clone", typ.Name(), f.Name)
+				}
+			}
+		}
+	}
+}
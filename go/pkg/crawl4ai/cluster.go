@@ -0,0 +1,161 @@
+package crawl4ai
+
+import (
+	"sort"
+	"strings"
+)
+
+// clusterPathDepth is how many ancestors a DOM shape feature spans.
+const clusterPathDepth = 3
+
+// ClusterOptions are options for ClusterPages.
+type ClusterOptions struct {
+	// Threshold is the template similarity (0–1, Jaccard over DOM shape
+	// features) a page needs to join a cluster. Default 0.6.
+	Threshold float64
+}
+
+// PageCluster is a group of pages sharing a template.
+type PageCluster struct {
+	// Representative is the page closest to the rest of the cluster: the
+	// one to hand GenerateSchema or InferSchema.
+	Representative *CrawlResult
+	Pages          []*CrawlResult
+	// Schema is free for the caller to attach the cluster's extraction
+	// schema to, for MatchCluster to hand back.
+	Schema *Schema
+
+	shape     map[string]bool
+	threshold float64
+}
+
+// URLs returns the URL of every page in the cluster.
+func (c *PageCluster) URLs() []string {
+	urls := make([]string, len(c.Pages))
+	for i, p := range c.Pages {
+		urls[i] = p.URL
+	}
+	return urls
+}
+
+// Similarity is how closely r's template matches the cluster's
+// representative, from 0 to 1.
+func (c *PageCluster) Similarity(r *CrawlResult) float64 {
+	return jaccard(c.shape, pageShape(r.HTML))
+}
+
+// ClusterPages groups crawled pages by template — product pages, listing
+// pages, articles — from the shape of their DOM, ignoring text. Use one
+// representative per cluster for schema generation instead of one per
+// page, then route each page to its cluster's schema.
+//
+//	clusters := crawl4ai.ClusterPages(deep.CrawlJob.Results, nil)
+//	for _, cl := range clusters {
+//	    cl.Schema, _ = crawl4ai.InferSchema(cl.Representative.HTML)
+//	}
+//	schema := crawl4ai.MatchCluster(clusters, newPage).Schema
+//
+// Clusters are returned largest first. Pages without HTML are skipped.
+func ClusterPages(results []*CrawlResult, opts *ClusterOptions) []*PageCluster {
+	threshold := 0.6
+	if opts != nil && opts.Threshold > 0 {
+		threshold = opts.Threshold
+	}
+	type page struct {
+		result *CrawlResult
+		shape  map[string]bool
+	}
+	var clusters []*PageCluster
+	var members [][]page
+	for _, r := range results {
+		if r == nil || r.HTML == "" {
+			continue
+		}
+		p := page{r, pageShape(r.HTML)}
+		best, bestSim := -1, 0.0
+		for i, c := range clusters {
+			if sim := jaccard(c.shape, p.shape); sim >= threshold && sim > bestSim {
+				best, bestSim = i, sim
+			}
+		}
+		if best < 0 {
+			clusters = append(clusters, &PageCluster{shape: p.shape, threshold: threshold})
+			members = append(members, nil)
+			best = len(clusters) - 1
+		}
+		members[best] = append(members[best], p)
+	}
+
+	for i, c := range clusters {
+		pages := members[i]
+		// The representative is the page most similar to the others.
+		rep, repScore := 0, -1.0
+		for j := range pages {
+			score := 0.0
+			for k := range pages {
+				if j != k {
+					score += jaccard(pages[j].shape, pages[k].shape)
+				}
+			}
+			if score > repScore {
+				rep, repScore = j, score
+			}
+		}
+		c.Representative, c.shape = pages[rep].result, pages[rep].shape
+		for _, p := range pages {
+			c.Pages = append(c.Pages, p.result)
+		}
+	}
+	sort.SliceStable(clusters, func(i, k int) bool { return len(clusters[i].Pages) > len(clusters[k].Pages) })
+	return clusters
+}
+
+// MatchCluster returns the cluster whose template r matches best, or nil
+// when none reaches the threshold the clusters were built with.
+func MatchCluster(clusters []*PageCluster, r *CrawlResult) *PageCluster {
+	shape := pageShape(r.HTML)
+	var best *PageCluster
+	bestSim := 0.0
+	for _, c := range clusters {
+		if sim := jaccard(c.shape, shape); sim >= c.threshold && sim > bestSim {
+			best, bestSim = c, sim
+		}
+	}
+	return best
+}
+
+// pageShape fingerprints a page's template: the set of element paths, each
+// an element with its nearest ancestors as tag.class. Text, attribute
+// values and how often a path repeats are ignored, so two product pages
+// match however many reviews they list.
+func pageShape(html string) map[string]bool {
+	shape := map[string]bool{}
+	parseHTML(html).walk(func(n *htmlNode) {
+		if n.Tag == "" {
+			return
+		}
+		segs := make([]string, 0, clusterPathDepth)
+		for cur := n; cur != nil && cur.Tag != "" && len(segs) < clusterPathDepth; cur = cur.Parent {
+			segs = append(segs, compoundSelector(cur))
+		}
+		for i, j := 0, len(segs)-1; i < j; i, j = i+1, j-1 {
+			segs[i], segs[j] = segs[j], segs[i]
+		}
+		shape[strings.Join(segs, ">")] = true
+	})
+	return shape
+}
+
+// jaccard is |a ∩ b| / |a ∪ b|, 1 for two empty sets.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	inter := 0
+	for k := range a {
+		if b[k] {
+			inter++
+		}
+	}
+	return float64(inter) / float64(len(a)+len(b)-inter)
+}
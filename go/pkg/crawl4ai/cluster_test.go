@@ -0,0 +1,62 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func productPage(i, reviews int) *CrawlResult {
+	var r strings.Builder
+	for k := 0; k < reviews; k++ {
+		fmt.Fprintf(&r, `<li class="review"><span class="stars">%d</span><p>Review %d</p></li>`, k%5, k)
+	}
+	return &CrawlResult{URL: fmt.Sprintf("https://shop.com/p/%d", i), HTML: fmt.Sprintf(`<html><body>
+<header class="site"><nav><a href="/">Home</a></nav></header>
+<main class="product"><h1 class="name">Product %d</h1><span class="price">$%d</span>
+<div class="gallery"><img src="/%d.jpg"></div><ul class="reviews">%s</ul></main>
+<footer class="site">©</footer></body></html>`, i, i, i, r.String())}
+}
+
+func articlePage(i int) *CrawlResult {
+	return &CrawlResult{URL: fmt.Sprintf("https://shop.com/blog/%d", i), HTML: fmt.Sprintf(`<html><body>
+<header class="site"><nav><a href="/">Home</a></nav></header>
+<article class="post"><h1 class="headline">Post %d</h1><time>2024-01-0%d</time>
+<section class="body"><p>Para one.</p><blockquote>Quote</blockquote><h2>Sub</h2><p>Para two.</p></section></article>
+<footer class="site">©</footer></body></html>`, i, i)}
+}
+
+func TestClusterPages(t *testing.T) {
+	results := []*CrawlResult{
+		productPage(1, 0), articlePage(1), productPage(2, 12), productPage(3, 3),
+		articlePage(2), {URL: "https://shop.com/empty"},
+	}
+	clusters := ClusterPages(results, nil)
+	if len(clusters) != 2 {
+		for _, c := range clusters {
+			t.Logf("cluster %v", c.URLs())
+		}
+		t.Fatalf("%d clusters, want 2", len(clusters))
+	}
+	products, articles := clusters[0], clusters[1]
+	if len(products.Pages) != 3 || len(articles.Pages) != 2 {
+		t.Fatalf("products %v, articles %v", products.URLs(), articles.URLs())
+	}
+	// The page without reviews is the odd one out.
+	if products.Representative.URL == "https://shop.com/p/1" {
+		t.Errorf("representative = %s", products.Representative.URL)
+	}
+
+	products.Schema = &Schema{Name: "product"}
+	if c := MatchCluster(clusters, productPage(9, 40)); c != products || c.Schema.Name != "product" {
+		t.Errorf("new product page matched %v", c)
+	}
+	if c := MatchCluster(clusters, &CrawlResult{HTML: `<table><tr><td>x</td></tr></table>`}); c != nil {
+		t.Errorf("unrelated page matched %v", c.URLs())
+	}
+	if sim := articles.Similarity(articlePage(7)); sim != 1 {
+		t.Errorf("similarity = %v", sim)
+	}
+}
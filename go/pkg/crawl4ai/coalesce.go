@@ -0,0 +1,88 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// runFlights coalesces identical in-flight /v1/crawl requests: the first
+// caller sends the request and later ones with the same body wait for its
+// response. See CrawlerOptions.CoalesceRuns.
+type runFlights struct {
+	mu    sync.Mutex
+	calls map[string]*runFlight
+}
+
+type runFlight struct {
+	done    chan struct{}
+	ctx     context.Context
+	data    map[string]interface{}
+	err     error
+	waiters int
+}
+
+// postCrawl posts a /v1/crawl body, sharing the response with concurrent
+// identical requests when CoalesceRuns is set.
+func (c *AsyncWebCrawler) postCrawl(body map[string]interface{}, timeout time.Duration) (map[string]interface{}, error) {
+	post := func() (map[string]interface{}, error) {
+		return c.http.Post("/v1/crawl", body, timeout)
+	}
+	if c.flights == nil {
+		return post()
+	}
+	// Maps marshal with sorted keys, so equal bodies give equal keys.
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return post()
+	}
+	ctx := c.http.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	data, err := c.flights.do(ctx, string(raw), post)
+	if err != nil {
+		return nil, err
+	}
+	// Decoding deletes omitted fields and callers may edit Metadata,
+	// Links and other nested maps, so every caller gets its own copy.
+	return cloneJSONMap(data), nil
+}
+
+func (g *runFlights) do(ctx context.Context, key string, fn func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.waiters++
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, NewTimeoutError("coalesced crawl: " + ctx.Err().Error())
+			}
+			return nil, ctx.Err()
+		}
+		// The leader's caller gave up, not the API: this caller still
+		// wants the page, so it asks for itself.
+		if call.err != nil && call.ctx.Err() != nil && ctx.Err() == nil {
+			return fn()
+		}
+		return call.data, call.err
+	}
+	call := &runFlight{done: make(chan struct{}), ctx: ctx}
+	if g.calls == nil {
+		g.calls = map[string]*runFlight{}
+	}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.data, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+	return call.data, call.err
+}
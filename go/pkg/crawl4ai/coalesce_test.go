@@ -0,0 +1,170 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n callers are waiting on an in-flight
+// request.
+func waitForWaiters(t *testing.T, g *runFlights, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		waiting := 0
+		for _, call := range g.calls {
+			waiting += call.waiters
+		}
+		g.mu.Unlock()
+		if waiting >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d coalesced callers", n)
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestCoalesceRuns(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": body["url"], "success": true, "markdown": "shared"})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, CoalesceRuns: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	results := make([]*CrawlResult, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.Run("https://example.com", &RunOptions{Config: &CrawlerRunConfig{Screenshot: true}, OmitFields: []string{"markdown"}})
+		}(i)
+	}
+	waitForWaiters(t, c.flights, callers-1)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Errorf("%d API requests for %d identical runs", n, callers)
+	}
+	for i := range results {
+		if errs[i] != nil || results[i].URL != "https://example.com" {
+			t.Errorf("caller %d: %+v, %v", i, results[i], errs[i])
+		}
+	}
+
+	// Different options are a different request; a finished request is
+	// not reused.
+	if _, err := c.Run("https://example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Run("https://example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 3 {
+		t.Errorf("hits = %d, want 3", n)
+	}
+}
+
+func TestCoalesceRuns_LeaderCancelled(t *testing.T) {
+	g := &runFlights{}
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	go func() {
+		_, _ = g.do(leaderCtx, "k", func() (map[string]interface{}, error) {
+			close(started)
+			<-leaderCtx.Done()
+			return nil, NewTimeoutError("cancelled")
+		})
+	}()
+	<-started
+
+	done := make(chan map[string]interface{})
+	go func() {
+		data, _ := g.do(context.Background(), "k", func() (map[string]interface{}, error) {
+			return map[string]interface{}{"own": true}, nil
+		})
+		done <- data
+	}()
+	waitForWaiters(t, g, 1)
+	cancel()
+	if data := <-done; data["own"] != true {
+		t.Errorf("follower got the leader's cancellation: %v", data)
+	}
+}
+
+func TestCoalesceRuns_CallersGetIndependentCopies(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "https://example.com", "success": true, "metadata": map[string]interface{}{"title": "shared"},
+		})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, CoalesceRuns: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(chan *CrawlResult, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			res, _ := c.Run("https://example.com", nil)
+			results <- res
+		}()
+	}
+	waitForWaiters(t, c.flights, 1)
+	close(release)
+	a, b := <-results, <-results
+	a.Metadata["title"] = "edited"
+	if b.Metadata["title"] != "shared" {
+		t.Errorf("editing one caller's Metadata changed another's: %v", b.Metadata)
+	}
+}
+
+func TestCoalesceRuns_WaiterCancelledReturnsContextError(t *testing.T) {
+	g := &runFlights{}
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	go func() {
+		_, _ = g.do(context.Background(), "k", func() (map[string]interface{}, error) {
+			close(started)
+			<-release
+			return nil, nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() {
+		_, err := g.do(ctx, "k", nil)
+		errc <- err
+	}()
+	waitForWaiters(t, g, 1)
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,108 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai/internal/yaml"
+)
+
+// LoadCrawlerConfig reads a CrawlerRunConfig from a .json, .yaml or .yml
+// file. Keys are the API's snake_case names, the same ones the Python SDK
+// uses, so one file can drive both SDKs. Unknown keys are an error rather
+// than silently dropped, so a typo or an option this SDK does not support
+// is caught when the file is loaded.
+//
+//	# crawl.yaml
+//	word_count_threshold: 10
+//	wait_for: css:main
+//	screenshot: true
+func LoadCrawlerConfig(path string) (*CrawlerRunConfig, error) {
+	var cfg CrawlerRunConfig
+	if err := loadConfigFile(path, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SaveCrawlerConfig writes cfg to path as JSON or YAML, chosen by the file
+// extension. Unset fields are left out.
+func SaveCrawlerConfig(path string, cfg *CrawlerRunConfig) error {
+	return saveConfigFile(path, cfg)
+}
+
+// LoadBrowserConfig is LoadCrawlerConfig for a BrowserConfig.
+func LoadBrowserConfig(path string) (*BrowserConfig, error) {
+	var cfg BrowserConfig
+	if err := loadConfigFile(path, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// SaveBrowserConfig is SaveCrawlerConfig for a BrowserConfig.
+func SaveBrowserConfig(path string, cfg *BrowserConfig) error {
+	return saveConfigFile(path, cfg)
+}
+
+// configFormat returns "json" or "yaml" for path's extension.
+func configFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	}
+	return "", fmt.Errorf("config file %s: unsupported extension (want .json, .yaml or .yml)", path)
+}
+
+func loadConfigFile(path string, v interface{}) error {
+	format, err := configFormat(path)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if format == "yaml" {
+		doc, err := yaml.Unmarshal(raw)
+		if err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		if doc == nil {
+			doc = map[string]interface{}{}
+		}
+		if raw, err = json.Marshal(doc); err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func saveConfigFile(path string, v interface{}) error {
+	format, err := configFormat(path)
+	if err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	raw = append(raw, '\n')
+	if format == "yaml" {
+		if raw, err = yaml.FromJSON(raw); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
@@ -0,0 +1,87 @@
+package crawl4ai
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestConfigFile_RoundTrip(t *testing.T) {
+	cfg := &CrawlerRunConfig{
+		WordCountThreshold: 10,
+		ExcludeDomains:     []string{"ads.example.com"},
+		Screenshot:         true,
+		WaitFor:            "css:main",
+		JsCode:             "window.scrollTo(0, document.body.scrollHeight);\nawait sleep(500);\n",
+		PageTimeout:        30000,
+		ExtractionStrategy: map[string]interface{}{
+			"type":   "json_css",
+			"schema": map[string]interface{}{"baseSelector": "article", "fields": []interface{}{map[string]interface{}{"name": "title", "selector": "h1"}}},
+		},
+	}
+	for _, name := range []string{"crawl.json", "crawl.yaml", "crawl.yml"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := SaveCrawlerConfig(path, cfg); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, err := LoadCrawlerConfig(path)
+		if err != nil {
+			raw, _ := os.ReadFile(path)
+			t.Fatalf("%s: %v\n%s", name, err, raw)
+		}
+		if !reflect.DeepEqual(got, cfg) {
+			t.Errorf("%s: got %+v", name, got)
+		}
+	}
+}
+
+func TestConfigFile_YAMLIsReadable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "browser.yaml")
+	if err := SaveBrowserConfig(path, &BrowserConfig{Headless: true, ViewportWidth: 1280, Headers: map[string]string{"Accept-Language": "en"}}); err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := os.ReadFile(path)
+	want := "headless: true\nviewport_width: 1280\nheaders:\n  Accept-Language: en\n"
+	if string(raw) != want {
+		t.Errorf("yaml =\n%s\nwant\n%s", raw, want)
+	}
+	got, err := LoadBrowserConfig(path)
+	if err != nil || !got.Headless || got.Headers["Accept-Language"] != "en" {
+		t.Errorf("got %+v, %v", got, err)
+	}
+}
+
+func TestConfigFile_Strict(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"typo.yaml":  "word_count_treshold: 10\n",
+		"typo.json":  `{"screenshot": true, "screenshoot": true}`,
+		"type.yaml":  "word_count_threshold: lots\n",
+		"bad.yaml":   "a: [1\n",
+		"config.txt": "screenshot: true\n",
+	}
+	wants := map[string]string{
+		"typo.yaml": `unknown field "word_count_treshold"`, "typo.json": `unknown field "screenshoot"`,
+		"type.yaml": "word_count_threshold", "bad.yaml": "bad.yaml", "config.txt": "unsupported extension",
+	}
+	for name, body := range cases {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := LoadCrawlerConfig(path)
+		if err == nil || !strings.Contains(err.Error(), wants[name]) {
+			t.Errorf("%s: err = %v, want %q", name, err, wants[name])
+		}
+	}
+
+	empty := filepath.Join(dir, "empty.yaml")
+	os.WriteFile(empty, []byte("# nothing set\n"), 0o644)
+	if cfg, err := LoadCrawlerConfig(empty); err != nil || !reflect.DeepEqual(cfg, &CrawlerRunConfig{}) {
+		t.Errorf("empty file = %+v, %v", cfg, err)
+	}
+}
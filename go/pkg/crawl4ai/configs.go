@@ -50,6 +50,16 @@ type CrawlerRunConfig struct {
 	// Simulate user
 	SimulateUser      bool `json:"simulate_user,omitempty"`
 	OverrideNavigator bool `json:"override_navigator,omitempty"`
+	// Locale the page sees, e.g. "de-DE" (navigator.language and
+	// Accept-Language).
+	Locale string `json:"locale,omitempty"`
+
+	// Extraction
+	ExtractionStrategy map[string]interface{} `json:"extraction_strategy,omitempty"`
+	// ExtractionStrategies runs several named strategies over one crawl
+	// (e.g. json_css for products + llm for a summary). ExtractedContent
+	// then holds a JSON object keyed by name; see CrawlResult.ExtractedFor.
+	ExtractionStrategies map[string]map[string]interface{} `json:"extraction_strategies,omitempty"`
 
 	// Cache (cloud-controlled, will be stripped)
 	CacheMode    string `json:"cache_mode,omitempty"`
@@ -203,6 +213,15 @@ func SanitizeCrawlerConfig(config *CrawlerRunConfig) map[string]interface{} {
 	if config.OverrideNavigator {
 		result["override_navigator"] = true
 	}
+	if config.Locale != "" {
+		result["locale"] = config.Locale
+	}
+	if config.ExtractionStrategy != nil {
+		result["extraction_strategy"] = config.ExtractionStrategy
+	}
+	if len(config.ExtractionStrategies) > 0 {
+		result["extraction_strategies"] = config.ExtractionStrategies
+	}
 
 	// Note: cache fields are NOT added (sanitized)
 
@@ -369,8 +388,11 @@ func BuildCrawlRequest(options map[string]interface{}) map[string]interface{} {
 	}
 
 	// Priority
-	if priority, ok := options["priority"].(int); ok {
+	switch priority := options["priority"].(type) {
+	case int:
 		body["priority"] = priority
+	case Priority:
+		body["priority"] = int(priority)
 	}
 
 	// Webhook URL
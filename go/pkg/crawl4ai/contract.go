@@ -0,0 +1,159 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Contract is an invariant crawled pages must satisfy, for catching
+// extraction drift in CI. It applies to pages whose URL matches Pattern
+// (MatchPattern syntax); zero fields are not checked. Contracts can be
+// kept in a JSON file next to the test.
+type Contract struct {
+	Pattern string `json:"pattern"`
+	// StatusCode is the status the page must answer with. 0 = any 2xx.
+	StatusCode int `json:"status_code,omitempty"`
+	// RequiredFields must be filled in every extracted record, and there
+	// must be at least one record.
+	RequiredFields []string `json:"required_fields,omitempty"`
+	// MinRecords is the fewest extracted records accepted.
+	MinRecords int `json:"min_records,omitempty"`
+	// MinWords is the fewest markdown words accepted.
+	MinWords int `json:"min_words,omitempty"`
+	// Validators are further checks; see ResultValidator.
+	Validators []ResultValidator `json:"-"`
+}
+
+// ContractViolation is one page breaking one contract rule.
+type ContractViolation struct {
+	URL     string
+	Pattern string
+	// Rule is "status", "required_field", "min_records", "min_words" or
+	// "validator".
+	Rule    string
+	Message string
+}
+
+// ContractReport is the outcome of CheckContracts.
+type ContractReport struct {
+	// Checked counts the pages at least one contract applied to.
+	Checked int
+	// Uncovered are the pages no contract applied to.
+	Uncovered  []string
+	Violations []ContractViolation
+}
+
+// OK reports whether every checked page met its contracts.
+func (r *ContractReport) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// String implements fmt.Stringer, one violation per line.
+func (r *ContractReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d pages checked, %d violations", r.Checked, len(r.Violations))
+	for _, v := range r.Violations {
+		fmt.Fprintf(&b, "\n  %s [%s %s]: %s", v.URL, v.Pattern, v.Rule, v.Message)
+	}
+	return b.String()
+}
+
+// CheckContracts validates results against contracts; every contract
+// whose pattern matches a page applies to it.
+//
+//	contracts := []crawl4ai.Contract{
+//	    {Pattern: "/products/*", RequiredFields: []string{"title", "price"}},
+//	    {Pattern: "/blog/*", MinWords: 200},
+//	}
+//	report, err := crawler.CheckJobContracts(jobID, contracts)
+//	if !report.OK() {
+//	    t.Fatal(report)
+//	}
+func CheckContracts(results []*CrawlResult, contracts []Contract) *ContractReport {
+	report := &ContractReport{}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		covered := false
+		for _, c := range contracts {
+			if !MatchPattern(c.Pattern, r.URL) {
+				continue
+			}
+			covered = true
+			report.Violations = append(report.Violations, c.check(r)...)
+		}
+		if covered {
+			report.Checked++
+		} else {
+			report.Uncovered = append(report.Uncovered, r.URL)
+		}
+	}
+	return report
+}
+
+// CheckJobContracts runs CheckContracts over a job's results (see
+// JobResults).
+func (c *AsyncWebCrawler) CheckJobContracts(jobID string, contracts []Contract) (*ContractReport, error) {
+	results, err := c.JobResults(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return CheckContracts(results, contracts), nil
+}
+
+// check returns r's violations of c.
+func (c *Contract) check(r *CrawlResult) []ContractViolation {
+	var out []ContractViolation
+	fail := func(rule, format string, args ...interface{}) {
+		out = append(out, ContractViolation{URL: r.URL, Pattern: c.Pattern, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+	switch {
+	case c.StatusCode != 0 && r.StatusCode != c.StatusCode:
+		fail("status", "status %d, want %d", r.StatusCode, c.StatusCode)
+	case c.StatusCode == 0 && (r.StatusCode < 200 || r.StatusCode >= 300) && r.StatusCode != 0:
+		fail("status", "status %d (%s)", r.StatusCode, http.StatusText(r.StatusCode))
+	case c.StatusCode == 0 && !r.Success:
+		fail("status", "crawl failed: %s", r.ErrorMessage)
+	}
+
+	if len(c.RequiredFields) > 0 || c.MinRecords > 0 {
+		schema := &Schema{}
+		for _, name := range c.RequiredFields {
+			schema.Fields = append(schema.Fields, NewTextField(name, ""))
+		}
+		q := &ExtractionQuality{}
+		if r.ExtractedContent != "" {
+			var err error
+			if q, err = MeasureExtraction(schema, []byte(r.ExtractedContent)); err != nil {
+				fail("min_records", "%v", err)
+				q = &ExtractionQuality{}
+			}
+		}
+		minRecords := c.MinRecords
+		if minRecords == 0 {
+			minRecords = 1
+		}
+		if q.Records < minRecords {
+			fail("min_records", "%d records extracted, want at least %d", q.Records, minRecords)
+		}
+		for _, f := range q.Fields {
+			if f.Empty > 0 {
+				fail("required_field", "%q empty in %d of %d records", f.Name, f.Empty, q.Records)
+			}
+		}
+	}
+
+	if c.MinWords > 0 {
+		if words := markdownWords(r); words < c.MinWords {
+			fail("min_words", "%d words, want at least %d", words, c.MinWords)
+		}
+	}
+	for _, v := range c.Validators {
+		if err := v(r); err != nil {
+			fail("validator", "%v", err)
+		}
+	}
+	return out
+}
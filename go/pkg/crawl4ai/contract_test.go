@@ -0,0 +1,67 @@
+package crawl4ai
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestCheckContracts(t *testing.T) {
+	contracts := []Contract{
+		{Pattern: "/products/*", RequiredFields: []string{"title", "price"}},
+		{Pattern: "/blog/*", MinWords: 5, Validators: []ResultValidator{RejectPhrases("lorem")}},
+		{Pattern: "/gone", StatusCode: 404},
+	}
+	results := []*CrawlResult{
+		{URL: "https://shop.com/products/1", Success: true, StatusCode: 200, ExtractedContent: `[{"title": "A", "price": "$1"}]`},
+		{URL: "https://shop.com/products/2", Success: true, StatusCode: 200, ExtractedContent: `[{"title": "B", "price": ""}, {"title": "C"}]`},
+		{URL: "https://shop.com/products/3", Success: true, StatusCode: 200, ExtractedContent: `[]`},
+		{URL: "https://shop.com/blog/post", Success: true, StatusCode: 200, Markdown: &MarkdownResult{RawMarkdown: "lorem ipsum"}},
+		{URL: "https://shop.com/gone", Success: true, StatusCode: 404},
+		{URL: "https://shop.com/products/4", Success: false, StatusCode: 503},
+		{URL: "https://shop.com/about", Success: true, StatusCode: 200},
+	}
+	report := CheckContracts(results, contracts)
+	if report.OK() || report.Checked != 6 || !reflect.DeepEqual(report.Uncovered, []string{"https://shop.com/about"}) {
+		t.Fatalf("report = %+v", report)
+	}
+	var got []string
+	for _, v := range report.Violations {
+		got = append(got, strings.TrimPrefix(v.URL, "https://shop.com")+" "+v.Rule)
+	}
+	want := []string{
+		"/products/2 required_field",
+		"/products/3 min_records",
+		"/blog/post min_words",
+		"/blog/post validator",
+		"/products/4 status",
+		"/products/4 min_records",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %v, want %v\n%s", got, want, report)
+	}
+	if !strings.Contains(report.String(), `"price" empty in 2 of 2 records`) {
+		t.Errorf("report:\n%s", report)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestCheckJobContracts(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{"job_id": "job_1", "status": "completed", "urls_count": 1, "results": []interface{}{
+			map[string]interface{}{"url": "https://shop.com/products/1", "success": true, "status_code": 200, "extracted_content": `[{"title": "A"}]`},
+		}},
+	})
+	report, err := c.CheckJobContracts("job_1", []Contract{{Pattern: "/products/*", RequiredFields: []string{"title"}}})
+	if err != nil || !report.OK() || report.Checked != 1 {
+		t.Errorf("report = %v, err = %v", report, err)
+	}
+	var notFound *NotFoundError
+	if _, err := c.CheckJobContracts("job_x", nil); !errors.As(err, &notFound) {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -4,13 +4,37 @@ package crawl4ai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 )
 
 // AsyncWebCrawler is the main client for Crawl4AI Cloud API.
 type AsyncWebCrawler struct {
-	http *HTTPClient
+	http        *HTTPClient
+	omitFields  []string
+	resultHooks []ResultHook
+	retention   *retentionTracker
+	// jobDone sees every finished job the crawler fetches; ClientManager
+	// charges its usage to the tenant.
+	jobDone func(*CrawlJob)
+
+	allowedDomains []string
+	domainProfiles *DomainProfiles
+
+	runTimeout  time.Duration
+	waitTimeout time.Duration
+
+	browserConfig *BrowserConfig
+	onWarning     func(Warning)
+	strictOptions bool
+	userAgents    *UserAgentRotator
+
+	fingerprintCheck string
+	flights          *runFlights
+	defaults         *RunDefaults
 }
 
 // CrawlerOptions are options for creating an AsyncWebCrawler.
@@ -19,6 +43,70 @@ type CrawlerOptions struct {
 	BaseURL    string
 	Timeout    time.Duration
 	MaxRetries int
+	// OmitFields drops these result fields (e.g. HeavyResultFields) from
+	// every CrawlResult this crawler decodes. Dropped fields can be fetched
+	// later with result.LoadOmitted().
+	OmitFields []string
+	// ResultHooks run, in order, on every CrawlResult this crawler decodes.
+	// See ResultHook.
+	ResultHooks []ResultHook
+	// AllowedDomains, when set, restricts every submitted URL and every
+	// deep-crawl follow to these domains and their subdomains. Every API
+	// call is checked, and violations fail before submission with an
+	// *OutOfScopeError.
+	AllowedDomains []string
+	// DomainProfiles, when set, records every Run/RunMany outcome and
+	// supplies learned Strategy/Proxy defaults. See DomainProfiles.
+	DomainProfiles *DomainProfiles
+	// RunTimeout bounds each synchronous crawl request (Run) when
+	// RunOptions.Timeout is unset. Default 120s.
+	RunTimeout time.Duration
+	// WaitTimeout bounds waits for job completion (RunMany and DeepCrawl
+	// with Wait) when the call's own Timeout is unset. Default: wait
+	// indefinitely.
+	WaitTimeout time.Duration
+	// Sandbox serves requests from local fixtures instead of the API: crawls
+	// of example.com-style URLs (see SandboxDomains) return deterministic
+	// results and cost nothing. Requires an sk_test_ key. Setting the
+	// CRAWL4AI_SANDBOX=1 environment variable has the same effect.
+	Sandbox bool
+	// BrowserConfig is used by Run, RunMany and RunAsync calls that pass
+	// none, like the open-source AsyncWebCrawler(config=browser_config).
+	// Calls read it concurrently; don't modify it afterwards.
+	BrowserConfig *BrowserConfig
+	// OnWarning receives a Warning whenever the SDK translates or drops an
+	// option (see SanitizeWarnings and ArunWithConfig). Default: nil, and
+	// the SDK stays silent.
+	OnWarning func(Warning)
+	// StrictOptions makes every call that would drop or translate an
+	// option fail with a *ValidationError instead of warning: cloud-managed
+	// fields such as cache_mode or cdp_url, or a BrowserConfig passed with
+	// the http strategy. Use it in development and tests to catch configs
+	// that don't do what they say.
+	StrictOptions bool
+	// UserAgents, when set, gives every crawl whose BrowserConfig sets
+	// neither UserAgent nor UserAgentMode a fingerprint from the rotator:
+	// user agent, matching viewport and headers. See NewUserAgentRotator.
+	UserAgents *UserAgentRotator
+	// FingerprintCheck runs browser crawls through CheckFingerprint:
+	// FingerprintWarn reports inconsistent user agent, client hints,
+	// locale and viewport through OnWarning and the result's Warnings;
+	// FingerprintFix corrects them first with FixFingerprint. Default: off.
+	FingerprintCheck string
+	// CoalesceRuns makes concurrent Run calls with the same URL and
+	// options share one API request, so fan-in traffic (many handlers
+	// asking for the same page at once) is crawled and billed once. Calls
+	// that start after the request returns send their own. Each caller
+	// gets its own copy of the response, so results can be modified
+	// freely.
+	CoalesceRuns bool
+	// ResponseCache, when set, revalidates GET responses (job status,
+	// storage, health) with ETag / Last-Modified instead of downloading
+	// unchanged payloads again. See NewResponseCache.
+	ResponseCache *ResponseCache
+	// Transport tunes keep-alive, TLS session reuse and connection
+	// warm-up for high call volumes. See TransportOptions.
+	Transport *TransportOptions
 }
 
 // NewAsyncWebCrawler creates a new AsyncWebCrawler.
@@ -32,45 +120,227 @@ func NewAsyncWebCrawler(opts CrawlerOptions) (*AsyncWebCrawler, error) {
 	if err != nil {
 		return nil, err
 	}
+	switch opts.FingerprintCheck {
+	case "", FingerprintWarn, FingerprintFix:
+	default:
+		return nil, fmt.Errorf("unknown FingerprintCheck %q (use %q or %q)", opts.FingerprintCheck, FingerprintWarn, FingerprintFix)
+	}
+	if opts.Transport != nil {
+		httpClient.client.Transport = opts.Transport.newTransport()
+	}
+	if sandboxEnabled(opts.Sandbox) {
+		if !strings.HasPrefix(httpClient.apiKey, "sk_test_") {
+			return nil, fmt.Errorf("sandbox mode requires an sk_test_ API key")
+		}
+		httpClient.client.Transport = newSandboxTransport()
+	}
+	if opts.ResponseCache != nil {
+		opts.ResponseCache.install(httpClient.client)
+	}
+
+	c := &AsyncWebCrawler{
+		http:           httpClient,
+		retention:      &retentionTracker{},
+		omitFields:     opts.OmitFields,
+		resultHooks:    opts.ResultHooks,
+		allowedDomains: opts.AllowedDomains,
+		domainProfiles: opts.DomainProfiles,
+		runTimeout:     opts.RunTimeout,
+		waitTimeout:    opts.WaitTimeout,
+		browserConfig:  opts.BrowserConfig,
+		onWarning:      opts.OnWarning,
+		strictOptions:  opts.StrictOptions,
+		userAgents:     opts.UserAgents,
 
-	return &AsyncWebCrawler{http: httpClient}, nil
+		fingerprintCheck: opts.FingerprintCheck,
+	}
+	if opts.CoalesceRuns {
+		c.flights = &runFlights{}
+	}
+	httpClient.scope = c.checkRequestScope
+	if opts.Transport != nil && opts.Transport.WarmUpConns > 0 {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			_ = c.WarmUp(ctx, opts.Transport.WarmUpConns)
+		}()
+	}
+	return c, nil
 }
 
-// RunOptions are options for the Run method.
+// requestTimeout resolves the timeout for one synchronous crawl request.
+func (c *AsyncWebCrawler) requestTimeout(perCall time.Duration) time.Duration {
+	if perCall > 0 {
+		return perCall
+	}
+	if c.runTimeout > 0 {
+		return c.runTimeout
+	}
+	return 120 * time.Second
+}
+
+// jobWaitTimeout resolves how long to wait for a job; 0 waits indefinitely.
+func (c *AsyncWebCrawler) jobWaitTimeout(perCall time.Duration) time.Duration {
+	if perCall > 0 {
+		return perCall
+	}
+	return c.waitTimeout
+}
+
+// elapsedSince reports time waited since start for timeout messages:
+// whole seconds for long waits, milliseconds for short ones.
+func elapsedSince(start time.Time) time.Duration {
+	d := time.Since(start)
+	if d >= time.Second {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Millisecond)
+}
+
+// RunOptions are options for the Run method. Run only reads them, so one
+// value may be shared by concurrent calls.
 type RunOptions struct {
 	Config        *CrawlerRunConfig
 	BrowserConfig *BrowserConfig
 	Strategy      string // "browser" or "http"
 	Proxy         interface{}
 	BypassCache   bool
+	// OmitFields overrides CrawlerOptions.OmitFields for this call.
+	OmitFields []string
+	// Fields projects the result down to these wire fields (e.g.
+	// "markdown", "metadata"). Sent to the server, and enforced client-side
+	// when the server returns more. url/success/error_message/status_code
+	// are always kept.
+	Fields []string
+	// Headers and Cookies are sent with this request only. With the
+	// browser strategy they are merged over BrowserConfig's (these win);
+	// with the http strategy, which takes no BrowserConfig, they are the
+	// only way to send custom headers.
+	Headers map[string]string
+	Cookies []map[string]interface{}
+	// HTTPRequest customizes the request made by the http strategy
+	// (method, body, redirects). Ignored by the browser strategy.
+	HTTPRequest *HTTPRequest
+	// Validator checks the result's content; when it fails, Run retries
+	// with each Escalation profile in turn. See ResultValidator.
+	Validator  ResultValidator
+	Escalation []RetryProfile
+	// Timeout bounds the crawl request; on expiry Run returns a
+	// *TimeoutError. Overrides CrawlerOptions.RunTimeout.
+	Timeout time.Duration
+
+	// stream decodes the response as it arrives; set by RunToWriter.
+	stream func(io.Reader) (map[string]interface{}, error)
 }
 
 // Run crawls a single URL.
 func (c *AsyncWebCrawler) Run(url string, opts *RunOptions) (*CrawlResult, error) {
+	if opts != nil && opts.Validator != nil {
+		return c.runValidated(url, opts)
+	}
+	return c.runOnce(url, opts)
+}
+
+func (c *AsyncWebCrawler) runOnce(url string, opts *RunOptions) (*CrawlResult, error) {
 	if opts == nil {
 		opts = &RunOptions{}
 	}
+	opts = c.defaults.applyRun(opts)
 
-	strategy := opts.Strategy
+	strategy, proxy := opts.Strategy, opts.Proxy
+	if strategy == "" && proxy == nil && c.domainProfiles != nil {
+		strategy, proxy, _ = c.domainProfiles.defaults(url)
+	}
 	if strategy == "" {
 		strategy = "browser"
 	}
 
+	warnings := SanitizeWarnings(opts.Config, opts.BrowserConfig, strategy)
+	if err := c.reportWarnings(warnings); err != nil {
+		return nil, err
+	}
+	browserConfig := opts.BrowserConfig
+	if browserConfig == nil {
+		browserConfig = c.browserConfig
+	}
+	var fingerprint *Fingerprint
+	if c.userAgents != nil && !picksUserAgent(browserConfig) {
+		fp := c.userAgents.Next(url)
+		fingerprint = &fp
+	}
+	if strategy != "http" {
+		if fingerprint != nil {
+			browserConfig = fingerprint.Apply(browserConfig)
+		}
+		browserConfig = withRequestHeaders(browserConfig, opts.Headers, opts.Cookies)
+		var fpWarnings []Warning
+		browserConfig, fpWarnings = c.checkFingerprint(opts.Config, browserConfig)
+		warnings = append(warnings, fpWarnings...)
+	}
 	body := BuildCrawlRequest(map[string]interface{}{
 		"url":           url,
 		"config":        opts.Config,
-		"browserConfig": opts.BrowserConfig,
+		"browserConfig": browserConfig,
 		"strategy":      strategy,
-		"proxy":         opts.Proxy,
+		"proxy":         proxy,
 		"bypassCache":   opts.BypassCache,
 	})
+	if strategy == "http" {
+		hc, err := httpRequestConfig(opts)
+		if err != nil {
+			return nil, err
+		}
+		if fingerprint != nil {
+			headers := fingerprint.requestHeaders()
+			for k, v := range opts.Headers {
+				headers[k] = v
+			}
+			hc["headers"] = headers
+		}
+		if len(hc) > 0 {
+			body["http_config"] = hc
+		}
+	}
+	// Copy before adding the projection so refetches get the full payload.
+	// The copy drops bypass_cache so a refetch may be answered from the
+	// cache the first crawl filled instead of forcing a fresh crawl.
+	full := make(map[string]interface{}, len(body))
+	for k, v := range body {
+		full[k] = v
+	}
+	delete(full, "bypass_cache")
+	if len(opts.Fields) > 0 {
+		body["fields"] = opts.Fields
+	}
 
-	data, err := c.http.Post("/v1/crawl", body, 120*time.Second)
+	timeout := c.requestTimeout(opts.Timeout)
+	var data map[string]interface{}
+	var err error
+	if opts.stream != nil {
+		data, err = c.http.Request(RequestOptions{Method: "POST", Path: "/v1/crawl", Body: body, Timeout: timeout, decode: opts.stream})
+	} else {
+		data, err = c.postCrawl(body, timeout)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	return CrawlResultFromMap(data), nil
+	omit := c.omitFields
+	if opts.OmitFields != nil {
+		omit = opts.OmitFields
+	}
+	refetch := func() (map[string]interface{}, error) {
+		return c.http.Post("/v1/crawl", full, timeout)
+	}
+	result := decodeCrawlResult(data, omit, opts.Fields, refetch)
+	result.Warnings = warnings
+	if c.domainProfiles != nil {
+		c.domainProfiles.Record(result, strategy, proxy)
+	}
+	if err := c.ApplyResultHooks(result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // Arun is an alias for Run (OSS compatibility).
@@ -88,14 +358,46 @@ type RunManyOptions struct {
 	Wait          bool
 	PollInterval  time.Duration
 	Timeout       time.Duration
-	Priority      int
+	Priority      Priority
 	WebhookURL    string
+	// KeepDuplicates submits the URL list as given. By default URLs equal
+	// after normalisation are submitted once and reported in
+	// RunManyResult.Duplicates, so a careless list isn't billed twice.
+	KeepDuplicates bool
+	// AlignResults, with Wait, orders Results to match the input URLs:
+	// Results[i] is the result for urls[i] (a duplicate shares its
+	// original's), or nil if the server returned none. See
+	// RunManyResult.MissingURLs.
+	AlignResults bool
+	// OnProgress, with Wait, is called on every poll; use
+	// job.Progress.ETA() for a time-remaining estimate.
+	OnProgress func(*CrawlJob)
+	// QuotaCheck, when set, verifies storage and credit headroom before the
+	// job is created and fails fast with an *InsufficientQuotaError instead
+	// of letting the job die halfway. See CheckQuota.
+	QuotaCheck *QuotaCheck
+	// HealthGate, when set, checks /health before the job is created and
+	// fails with a *DegradedAPIError if the queue is backed up.
+	HealthGate *HealthGate
+	// Retention bounds how long the job's results are stored. When the
+	// server doesn't apply it, the job is tracked for RunRetentionDaemon.
+	Retention *RetentionPolicy
 }
 
 // RunManyResult holds the result of RunMany.
 type RunManyResult struct {
-	Job     *CrawlJob
+	Job *CrawlJob
+	// Results holds the job's results once Wait completes: in server order,
+	// or aligned to the input URLs with RunManyOptions.AlignResults.
 	Results []*CrawlResult
+	// Duplicates lists the input URLs that were not submitted because an
+	// equivalent URL came earlier. See DedupeURLs.
+	Duplicates []DuplicateURL
+	// Warnings lists the options the SDK dropped before submitting the
+	// job. See SanitizeWarnings.
+	Warnings []Warning
+
+	inputs []string
 }
 
 // RunMany crawls multiple URLs.
@@ -115,27 +417,119 @@ func (c *AsyncWebCrawler) ArunMany(urls []string, opts *RunManyOptions) (*RunMan
 	return c.RunMany(urls, opts)
 }
 
+// RunAsyncOptions are options for RunAsync.
+type RunAsyncOptions struct {
+	Config        *CrawlerRunConfig
+	BrowserConfig *BrowserConfig
+	Strategy      string
+	Proxy         interface{}
+	BypassCache   bool
+	Priority      Priority
+	// WebhookURL is called when the job finishes, so the caller doesn't
+	// have to stay alive to poll.
+	WebhookURL string
+}
+
+// RunAsync submits a single-URL crawl as an async job and returns at once,
+// instead of holding the connection open for up to 120s like Run. Use it
+// from serverless functions with short execution limits: hand the job to
+// a webhook, or fetch it later with GetJob/WaitJob.
+//
+//	job, err := crawler.RunAsync("https://example.com", &crawl4ai.RunAsyncOptions{
+//	    WebhookURL: "https://hooks.example.com/crawl-done",
+//	})
+//	// later, elsewhere:
+//	job, err = crawler.WaitJob(job.JobID, 0, time.Minute)
+//	result := job.Results[0]
+func (c *AsyncWebCrawler) RunAsync(url string, opts *RunAsyncOptions) (*CrawlJob, error) {
+	if opts == nil {
+		opts = &RunAsyncOptions{}
+	}
+	res, err := c.runAsync([]string{url}, &RunManyOptions{
+		Config:        opts.Config,
+		BrowserConfig: opts.BrowserConfig,
+		Strategy:      opts.Strategy,
+		Proxy:         opts.Proxy,
+		BypassCache:   opts.BypassCache,
+		Priority:      opts.Priority,
+		WebhookURL:    opts.WebhookURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Job, nil
+}
+
 func (c *AsyncWebCrawler) runAsync(urls []string, opts *RunManyOptions) (*RunManyResult, error) {
+	opts = c.defaults.applyMany(opts)
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
+	}
+	original := urls
+	var duplicates []DuplicateURL
+	if !opts.KeepDuplicates {
+		urls, duplicates = DedupeURLs(urls)
+	}
+	// The crawler's default BrowserConfig is not the caller's intent, so
+	// only a per-call one is warned about.
+	callerBrowserConfig := opts.BrowserConfig
+	if opts.BrowserConfig == nil && c.browserConfig != nil {
+		withDefault := *opts
+		withDefault.BrowserConfig = c.browserConfig
+		opts = &withDefault
+	}
+	if c.domainProfiles != nil && opts.Strategy == "" && opts.Proxy == nil {
+		if strategy, proxy, ok := c.domainProfiles.sharedDefaults(urls); ok {
+			learned := *opts
+			learned.Strategy, learned.Proxy = strategy, proxy
+			opts = &learned
+		}
+	}
 	strategy := opts.Strategy
 	if strategy == "" {
 		strategy = "browser"
 	}
-
-	priority := opts.Priority
-	if priority == 0 {
-		priority = 5
+	warnings := SanitizeWarnings(opts.Config, callerBrowserConfig, strategy)
+	if err := c.reportWarnings(warnings); err != nil {
+		return nil, err
+	}
+	if c.userAgents != nil && strategy != "http" && !picksUserAgent(opts.BrowserConfig) && len(urls) > 0 {
+		// One job shares one BrowserConfig: per-request rotation is left
+		// to the cloud, other policies use the first URL's fingerprint.
+		withAgent := *opts
+		if c.userAgents.Policy() == RotatePerRequest {
+			bc := BrowserConfig{}
+			if opts.BrowserConfig != nil {
+				bc = *opts.BrowserConfig
+			}
+			bc.UserAgentMode = "random"
+			withAgent.BrowserConfig = &bc
+		} else {
+			withAgent.BrowserConfig = c.userAgents.Next(urls[0]).Apply(opts.BrowserConfig)
+		}
+		opts = &withAgent
+	}
+	if c.fingerprintCheck != "" && strategy != "http" {
+		checked := *opts
+		var fpWarnings []Warning
+		checked.BrowserConfig, fpWarnings = c.checkFingerprint(opts.Config, opts.BrowserConfig)
+		warnings = append(warnings, fpWarnings...)
+		opts = &checked
+	}
+	body := buildRunManyBody(urls, opts)
+	if opts.Retention != nil {
+		body["retention"] = opts.Retention.toMap()
+	}
+	if opts.QuotaCheck != nil {
+		if err := c.checkQuota(len(urls), body, opts.QuotaCheck); err != nil {
+			return nil, err
+		}
+	}
+	if opts.HealthGate != nil {
+		if err := c.checkHealth(len(urls), opts.HealthGate); err != nil {
+			return nil, err
+		}
 	}
-
-	body := BuildCrawlRequest(map[string]interface{}{
-		"urls":          urls,
-		"config":        opts.Config,
-		"browserConfig": opts.BrowserConfig,
-		"strategy":      strategy,
-		"proxy":         opts.Proxy,
-		"bypassCache":   opts.BypassCache,
-		"priority":      priority,
-		"webhookUrl":    opts.WebhookURL,
-	})
 
 	data, err := c.http.Post("/v1/crawl/async", body, 0)
 	if err != nil {
@@ -143,6 +537,9 @@ func (c *AsyncWebCrawler) runAsync(urls []string, opts *RunManyOptions) (*RunMan
 	}
 
 	job := CrawlJobFromMap(data)
+	if opts.Retention != nil && !retentionHonoured(data) {
+		c.TrackRetention(job.JobID, *opts.Retention)
+	}
 
 	if opts.Wait {
 		pollInterval := opts.PollInterval
@@ -150,16 +547,49 @@ func (c *AsyncWebCrawler) runAsync(urls []string, opts *RunManyOptions) (*RunMan
 			pollInterval = 2 * time.Second
 		}
 
-		job, err = c.WaitJob(job.JobID, pollInterval, opts.Timeout)
+		job, err = c.WaitJobWithProgress(job.JobID, pollInterval, c.jobWaitTimeout(opts.Timeout), opts.OnProgress)
 		if err != nil {
 			return nil, err
 		}
+		if c.domainProfiles != nil {
+			for _, r := range job.Results {
+				c.domainProfiles.Record(r, strategy, opts.Proxy)
+			}
+		}
+
+		// Full results are also available via DownloadURL() after job completes
+		results := job.Results
+		if opts.AlignResults {
+			results = alignResults(urls, results)
+			if len(duplicates) > 0 {
+				results = expandAligned(original, urls, results)
+			}
+		}
+		return &RunManyResult{Job: job, Results: results, Duplicates: duplicates, Warnings: warnings, inputs: urls}, nil
+	}
+
+	return &RunManyResult{Job: job, Duplicates: duplicates, Warnings: warnings, inputs: urls}, nil
+}
 
-		// Results are available via DownloadURL() after job completes
-		return &RunManyResult{Job: job}, nil
+// buildRunManyBody builds the /v1/crawl/async request for RunMany.
+func buildRunManyBody(urls []string, opts *RunManyOptions) map[string]interface{} {
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = "browser"
 	}
 
-	return &RunManyResult{Job: job}, nil
+	priority := opts.Priority.orDefault()
+
+	return BuildCrawlRequest(map[string]interface{}{
+		"urls":          urls,
+		"config":        opts.Config,
+		"browserConfig": opts.BrowserConfig,
+		"strategy":      strategy,
+		"proxy":         opts.Proxy,
+		"bypassCache":   opts.BypassCache,
+		"priority":      priority,
+		"webhookUrl":    opts.WebhookURL,
+	})
 }
 
 // GetJob gets job status.
@@ -170,82 +600,246 @@ func (c *AsyncWebCrawler) GetJob(jobID string) (*CrawlJob, error) {
 		return nil, err
 	}
 
-	return CrawlJobFromMap(data), nil
+	return c.applyJobResultHooks(CrawlJobFromMap(data))
+}
+
+// GetJobOptions are options for GetJobWithOptions.
+type GetJobOptions struct {
+	// Fields projects each inlined result down to these wire fields; see
+	// RunOptions.Fields.
+	Fields []string
+}
+
+// GetJobWithOptions is GetJob with a field projection applied to the
+// job's inlined results.
+func (c *AsyncWebCrawler) GetJobWithOptions(jobID string, opts *GetJobOptions) (*CrawlJob, error) {
+	if opts == nil || len(opts.Fields) == 0 {
+		return c.GetJob(jobID)
+	}
+	params := map[string]string{"fields": fieldsParam(opts.Fields)}
+	data, err := c.http.Get(fmt.Sprintf("/v1/crawl/jobs/%s", jobID), params)
+	if err != nil {
+		return nil, err
+	}
+	if results, ok := data["results"].([]interface{}); ok {
+		for _, r := range results {
+			if m, ok := r.(map[string]interface{}); ok {
+				omitResultFields(m, projectionOmits(m, opts.Fields))
+			}
+		}
+	}
+	return c.applyJobResultHooks(CrawlJobFromMap(data))
 }
 
 // WaitJob polls until job completes.
 // To get results after job completes, use DownloadURL() to get a presigned URL for the ZIP file.
+//
+// On timeout it returns the last job snapshot along with a *TimeoutError
+// (also in its Job field), so the caller can decide to keep waiting,
+// cancel, or detach:
+//
+//	job, err := crawler.WaitJob(jobID, 0, time.Minute)
+//	var timeout *crawl4ai.TimeoutError
+//	if errors.As(err, &timeout) && job.Progress.Percent() > 90 {
+//	    job, err = crawler.WaitJob(jobID, 0, time.Minute)
+//	}
 func (c *AsyncWebCrawler) WaitJob(jobID string, pollInterval, timeout time.Duration) (*CrawlJob, error) {
+	return c.WaitJobWithProgress(jobID, pollInterval, timeout, nil)
+}
+
+// WaitJobWithProgress is WaitJob with a callback invoked on every poll.
+// Each snapshot's Progress.ETA() estimates the time remaining from the
+// completion rate seen so far.
+func (c *AsyncWebCrawler) WaitJobWithProgress(jobID string, pollInterval, timeout time.Duration, onProgress func(*CrawlJob)) (*CrawlJob, error) {
 	if pollInterval == 0 {
 		pollInterval = 2 * time.Second
 	}
 
 	startTime := time.Now()
+	var tracker progressTracker
 
 	for {
 		job, err := c.GetJob(jobID)
 		if err != nil {
 			return nil, err
 		}
+		tracker.observe(&job.Progress, time.Now())
+		if onProgress != nil {
+			onProgress(job)
+		}
 
 		if job.IsComplete() {
 			return job, nil
 		}
 
 		if timeout > 0 && time.Since(startTime) > timeout {
-			return nil, NewTimeoutError(fmt.Sprintf(
-				"timeout waiting for job %s. Status: %s, Progress: %.1f%%",
-				jobID, job.Status, job.Progress.Percent(),
-			))
+			return job, jobTimeoutError(jobID, job, startTime)
 		}
 
 		time.Sleep(pollInterval)
 	}
 }
 
+// jobTimeoutError reports giving up on jobID after waiting since start;
+// job is the last snapshot.
+func jobTimeoutError(jobID string, job *CrawlJob, start time.Time) *TimeoutError {
+	err := NewTimeoutError(fmt.Sprintf(
+		"timeout waiting for job %s after %s. Status: %s, Progress: %.1f%%",
+		jobID, elapsedSince(start), job.Status, job.Progress.Percent(),
+	))
+	err.Job = job
+	return err
+}
+
 // ListJobsOptions are options for ListJobs.
 type ListJobsOptions struct {
 	Status string
 	Limit  int
 	Offset int
+	// CreatedAfter / CreatedBefore bound the job's created_at. Zero = unbounded.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// URLContains keeps only jobs with at least one URL containing this
+	// substring (case-insensitive).
+	URLContains string
 }
 
+// maxListJobsPages caps how many pages ListJobs walks when applying
+// filters client-side, so a selective filter can't dump the whole history.
+const maxListJobsPages = 50
+
 // ListJobs lists jobs with optional filtering.
+//
+// Date and URL filters are sent to the API and re-applied client-side; when
+// the server ignores them, ListJobs pages forward until Limit matching jobs
+// are found or the history runs out. It also pages forward when the server
+// returns fewer jobs per page than Limit.
 func (c *AsyncWebCrawler) ListJobs(opts *ListJobsOptions) ([]*CrawlJob, error) {
 	if opts == nil {
 		opts = &ListJobsOptions{}
 	}
 
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
 	params := make(map[string]string)
 	if opts.Status != "" {
 		params["status"] = opts.Status
 	}
-	if opts.Limit > 0 {
-		params["limit"] = fmt.Sprintf("%d", opts.Limit)
-	} else {
-		params["limit"] = "20"
+	params["limit"] = fmt.Sprintf("%d", limit)
+	if !opts.CreatedAfter.IsZero() {
+		params["created_after"] = opts.CreatedAfter.UTC().Format(time.RFC3339)
 	}
-	if opts.Offset > 0 {
-		params["offset"] = fmt.Sprintf("%d", opts.Offset)
+	if !opts.CreatedBefore.IsZero() {
+		params["created_before"] = opts.CreatedBefore.UTC().Format(time.RFC3339)
 	}
-
-	data, err := c.http.Get("/v1/crawl/jobs", params)
-	if err != nil {
-		return nil, err
+	if opts.URLContains != "" {
+		params["url_contains"] = opts.URLContains
 	}
 
+	filtered := opts.hasClientFilters()
+	offset := opts.Offset
 	jobs := make([]*CrawlJob, 0)
-	if rawJobs, ok := data["jobs"].([]interface{}); ok {
+	seen := map[string]bool{}
+	for page := 0; page < maxListJobsPages; page++ {
+		if offset > 0 {
+			params["offset"] = fmt.Sprintf("%d", offset)
+		}
+		data, err := c.http.Get("/v1/crawl/jobs", params)
+		if err != nil {
+			return nil, err
+		}
+
+		rawJobs, _ := data["jobs"].([]interface{})
+		fresh := 0
 		for _, j := range rawJobs {
-			if m, ok := j.(map[string]interface{}); ok {
-				jobs = append(jobs, CrawlJobFromMap(m))
+			m, ok := j.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			job := CrawlJobFromMap(m)
+			// A server that ignores offset repeats its first page.
+			if job.JobID != "" {
+				if seen[job.JobID] {
+					continue
+				}
+				seen[job.JobID] = true
+			}
+			fresh++
+			if filtered && !opts.matches(job) {
+				continue
+			}
+			jobs = append(jobs, job)
+			if len(jobs) == limit {
+				return jobs, nil
 			}
 		}
+
+		if fresh == 0 || !hasMoreJobs(data, len(rawJobs), offset) {
+			break
+		}
+		offset += len(rawJobs)
 	}
 
 	return jobs, nil
 }
 
+// hasMoreJobs reports whether a /v1/crawl/jobs page of got jobs, read at
+// offset, may be followed by another, going by the server's has_more or
+// total when it sends them. A short page alone proves nothing: the server
+// may cap page size below the requested limit.
+func hasMoreJobs(data map[string]interface{}, got, offset int) bool {
+	if got == 0 {
+		return false
+	}
+	if more, ok := data["has_more"].(bool); ok {
+		return more
+	}
+	if total, ok := data["total"].(float64); ok {
+		return offset+got < int(total)
+	}
+	return true
+}
+
+func (o *ListJobsOptions) hasClientFilters() bool {
+	return !o.CreatedAfter.IsZero() || !o.CreatedBefore.IsZero() || o.URLContains != ""
+}
+
+// matches applies the client-side filters. Jobs whose created_at can't be
+// parsed, or that carry no URLs to check, are kept — the server already
+// filtered them if it could.
+func (o *ListJobsOptions) matches(job *CrawlJob) bool {
+	if !o.CreatedAfter.IsZero() || !o.CreatedBefore.IsZero() {
+		if created := job.CreatedAt; !created.IsZero() {
+			if !o.CreatedAfter.IsZero() && created.Before(o.CreatedAfter) {
+				return false
+			}
+			if !o.CreatedBefore.IsZero() && !created.Before(o.CreatedBefore) {
+				return false
+			}
+		}
+	}
+	if o.URLContains != "" {
+		urls := job.URLs
+		for _, r := range job.Results {
+			urls = append(urls, r.URL)
+		}
+		if len(urls) == 0 {
+			return true
+		}
+		needle := strings.ToLower(o.URLContains)
+		for _, u := range urls {
+			if strings.Contains(strings.ToLower(u), needle) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
 // CancelJob cancels a pending or running job.
 func (c *AsyncWebCrawler) CancelJob(jobID string) error {
 	_, err := c.http.Delete(fmt.Sprintf("/v1/crawl/jobs/%s", jobID))
@@ -268,7 +862,7 @@ type SiteOptions struct {
 	BrowserConfig     *BrowserConfig
 	Proxy             interface{}
 	WebhookURL        string
-	Priority          int
+	Priority          Priority
 	Wait              bool
 	PollInterval      time.Duration
 	Timeout           time.Duration
@@ -287,10 +881,10 @@ func (c *AsyncWebCrawler) Site(url string, opts *SiteOptions) (*DeepCrawlResult,
 	if mode == "" {
 		mode = "traverse"
 	}
-	priority := opts.Priority
-	if priority == 0 {
-		priority = 5
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
 	}
+	priority := opts.Priority.orDefault()
 
 	body := map[string]interface{}{
 		"url":                url,
@@ -308,8 +902,12 @@ func (c *AsyncWebCrawler) Site(url string, opts *SiteOptions) (*DeepCrawlResult,
 	if len(opts.Patterns) > 0 {
 		body["patterns"] = opts.Patterns
 	}
-	if opts.Filters != nil {
-		body["filters"] = opts.Filters
+	filters, err := c.scopeSiteFilters(opts.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if filters != nil {
+		body["filters"] = filters
 	}
 	if opts.Scorers != nil {
 		body["scorers"] = opts.Scorers
@@ -372,7 +970,7 @@ func (c *AsyncWebCrawler) waitSiteJob(jobID string, pollInterval, timeout time.D
 			return result, nil
 		}
 		if timeout > 0 && time.Since(start) > timeout {
-			return result, fmt.Errorf("timeout waiting for site job %s (status: %s)", jobID, result.Status)
+			return result, fmt.Errorf("timeout waiting for site job %s after %s (status: %s)", jobID, elapsedSince(start), result.Status)
 		}
 		time.Sleep(pollInterval)
 	}
@@ -415,7 +1013,7 @@ type DeepCrawlOptions struct {
 	Scorers       map[string]interface{}
 	IncludeHTML   bool
 	WebhookURL    string
-	Priority      int
+	Priority      Priority
 	// Map strategy options
 	Source         string
 	Pattern        string
@@ -424,12 +1022,30 @@ type DeepCrawlOptions struct {
 	// URL filtering shortcuts
 	IncludePatterns []string
 	ExcludePatterns []string
+	// Budget controls. When any limit is hit the crawl stops gracefully and
+	// DeepCrawl returns the partial results with StopReason set. Limits are
+	// sent to the server and, with Wait, also enforced client-side.
+	MaxDuration time.Duration // wall-clock time from submission
+	MaxCredits  float64       // credits spent by the crawl phase
+	// StopOnErrorRate stops once this fraction (0-1) of finished URLs have
+	// failed, evaluated after at least 10 URLs.
+	StopOnErrorRate float64
+	// OnResult, with Wait, is called with each page result as soon as a
+	// poll sees it, while the rest of the crawl is still running, so
+	// extraction can be pipelined with crawling. Each page is delivered
+	// once, in poll order; the returned CrawlJob still holds every result.
+	OnResult func(*CrawlResult)
 }
 
 // DeepCrawlResult holds the result of DeepCrawl.
 type DeepCrawlResultWrapper struct {
 	DeepResult *DeepCrawlResult
 	CrawlJob   *CrawlJob
+	// StopReason is one of the StopReason* constants when a budget limit
+	// ended the crawl early, "" otherwise.
+	StopReason string
+
+	startURL string // root for DepthStats' path-depth fallback
 }
 
 // DeepCrawl performs a deep crawl starting from a URL.
@@ -448,7 +1064,6 @@ func (c *AsyncWebCrawler) DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCr
 	if url != "" && opts.SourceJob != "" {
 		return nil, fmt.Errorf("provide either 'url' or 'SourceJob', not both")
 	}
-
 	strategy := opts.Strategy
 	if strategy == "" {
 		strategy = "bfs"
@@ -459,10 +1074,10 @@ func (c *AsyncWebCrawler) DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCr
 		crawlStrategy = "auto"
 	}
 
-	priority := opts.Priority
-	if priority == 0 {
-		priority = 5
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
 	}
+	priority := opts.Priority.orDefault()
 
 	maxDepth := opts.MaxDepth
 	if maxDepth == 0 {
@@ -491,18 +1106,9 @@ func (c *AsyncWebCrawler) DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCr
 			body["max_depth"] = maxDepth
 			body["max_urls"] = maxURLs
 
-			// Build filters from IncludePatterns/ExcludePatterns or use provided filters
-			effectiveFilters := make(map[string]interface{})
-			if opts.Filters != nil {
-				for k, v := range opts.Filters {
-					effectiveFilters[k] = v
-				}
-			}
-			if len(opts.IncludePatterns) > 0 {
-				effectiveFilters["include_patterns"] = opts.IncludePatterns
-			}
-			if len(opts.ExcludePatterns) > 0 {
-				effectiveFilters["exclude_patterns"] = opts.ExcludePatterns
+			effectiveFilters, err := c.deepCrawlFilters(opts)
+			if err != nil {
+				return nil, err
 			}
 			if len(effectiveFilters) > 0 {
 				body["filters"] = effectiveFilters
@@ -563,6 +1169,10 @@ func (c *AsyncWebCrawler) DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCr
 	if opts.WebhookURL != "" {
 		body["webhook_url"] = opts.WebhookURL
 	}
+	budget := newDeepCrawlBudget(opts)
+	if budget != nil {
+		body["budget"] = budget.toMap()
+	}
 
 	data, err := c.http.Post("/v1/crawl/deep", body, 120*time.Second)
 	if err != nil {
@@ -581,10 +1191,17 @@ func (c *AsyncWebCrawler) DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCr
 		pollInterval = 2 * time.Second
 	}
 
-	result, err = c.waitScanJob(result.JobID, pollInterval, opts.Timeout)
+	timeout := c.jobWaitTimeout(opts.Timeout)
+	result, reason, err := c.waitScanWithinBudget(result.JobID, pollInterval, timeout, budget)
 	if err != nil {
 		return nil, err
 	}
+	if reason != "" {
+		if result.CrawlJobID != "" {
+			_ = c.CancelJob(result.CrawlJobID)
+		}
+		return &DeepCrawlResultWrapper{DeepResult: result, StopReason: reason}, nil
+	}
 
 	if opts.ScanOnly {
 		return &DeepCrawlResultWrapper{DeepResult: result}, nil
@@ -596,16 +1213,36 @@ func (c *AsyncWebCrawler) DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCr
 
 	// If crawl job was created, wait for it
 	if result.CrawlJobID != "" {
-		job, err := c.WaitJob(result.CrawlJobID, pollInterval, opts.Timeout)
+		var onPoll func(*CrawlJob)
+		if opts.OnResult != nil {
+			onPoll = streamNewResults(opts.OnResult)
+		}
+		job, reason, err := c.waitJobWithinBudget(result.CrawlJobID, pollInterval, timeout, budget, onPoll)
 		if err != nil {
 			return nil, err
 		}
-		return &DeepCrawlResultWrapper{DeepResult: result, CrawlJob: job}, nil
+		return &DeepCrawlResultWrapper{DeepResult: result, CrawlJob: job, StopReason: reason, startURL: url}, nil
 	}
 
 	return &DeepCrawlResultWrapper{DeepResult: result}, nil
 }
 
+// deepCrawlFilters builds the filters DeepCrawl sends: opts.Filters plus
+// the IncludePatterns/ExcludePatterns shortcuts, scoped to AllowedDomains.
+func (c *AsyncWebCrawler) deepCrawlFilters(opts *DeepCrawlOptions) (map[string]interface{}, error) {
+	effectiveFilters := make(map[string]interface{})
+	for k, v := range opts.Filters {
+		effectiveFilters[k] = v
+	}
+	if len(opts.IncludePatterns) > 0 {
+		effectiveFilters["include_patterns"] = opts.IncludePatterns
+	}
+	if len(opts.ExcludePatterns) > 0 {
+		effectiveFilters["exclude_patterns"] = opts.ExcludePatterns
+	}
+	return c.scopeFilters(effectiveFilters)
+}
+
 func (c *AsyncWebCrawler) waitScanJob(jobID string, pollInterval, timeout time.Duration) (*DeepCrawlResult, error) {
 	startTime := time.Now()
 
@@ -623,8 +1260,8 @@ func (c *AsyncWebCrawler) waitScanJob(jobID string, pollInterval, timeout time.D
 
 		if timeout > 0 && time.Since(startTime) > timeout {
 			return nil, NewTimeoutError(fmt.Sprintf(
-				"timeout waiting for scan job %s. Status: %s, Discovered: %d",
-				jobID, result.Status, result.DiscoveredCount,
+				"timeout waiting for scan job %s after %s. Status: %s, Discovered: %d",
+				jobID, elapsedSince(startTime), result.Status, result.DiscoveredCount,
 			))
 		}
 
@@ -782,8 +1419,8 @@ func (c *AsyncWebCrawler) waitScanJobV2(jobID string, pollInterval, timeout time
 		}
 		if timeout > 0 && time.Since(start) > timeout {
 			return nil, NewTimeoutError(fmt.Sprintf(
-				"timeout waiting for scan job %s. Status: %s, found: %d",
-				jobID, job.Status, job.TotalUrls,
+				"timeout waiting for scan job %s after %s. Status: %s, found: %d",
+				jobID, elapsedSince(start), job.Status, job.TotalUrls,
 			))
 		}
 		time.Sleep(pollInterval)
@@ -797,8 +1434,9 @@ func (c *AsyncWebCrawler) waitScanJobV2(jobID string, pollInterval, timeout time
 // The cloud GET endpoint returns URLStatuses for fan-out parents but never
 // inlines per-URL data — that lives in S3 and is fetched separately. Wait=true
 // callers expect job.Results populated, so we hydrate here. Failed URLs become
-// CrawlResult stubs (Success=false + ErrorMessage) so len(Results) always
-// equals len(URLStatuses).
+// CrawlResult stubs (Success=false + ErrorMessage) so len(Results) equals
+// len(URLStatuses) unless a ResultHook skipped some. A ResultHook error
+// other than ErrSkipResult is returned from the call.
 func (c *AsyncWebCrawler) waitWrapperJob(jobID, jobType string, pollInterval, timeout time.Duration) (*WrapperJob, error) {
 	if pollInterval == 0 {
 		pollInterval = 2 * time.Second
@@ -820,19 +1458,21 @@ func (c *AsyncWebCrawler) waitWrapperJob(jobID, jobType string, pollInterval, ti
 		}
 		if job.IsComplete() {
 			if len(job.URLStatuses) > 0 {
-				if hydrated, herr := c.hydrateResults(job); herr == nil {
-					job.Results = hydrated
+				// Per-URL fetch failures are non-fatal — they become stubs
+				// and the caller can still drive GetPerUrlResult with
+				// URLStatuses. Only hook errors abort.
+				hydrated, herr := c.hydrateResults(job)
+				if herr != nil {
+					return nil, herr
 				}
-				// Hydration failure is non-fatal — the parent job already
-				// terminalized successfully; caller can still drive
-				// GetPerUrlResult themselves with URLStatuses.
+				job.Results = hydrated
 			}
 			return job, nil
 		}
 		if timeout > 0 && time.Since(start) > timeout {
 			return nil, NewTimeoutError(fmt.Sprintf(
-				"timeout waiting for %s job %s (status: %s)",
-				jobType, jobID, job.Status,
+				"timeout waiting for %s job %s after %s (status: %s)",
+				jobType, jobID, elapsedSince(start), job.Status,
 			))
 		}
 		time.Sleep(pollInterval)
@@ -841,12 +1481,26 @@ func (c *AsyncWebCrawler) waitWrapperJob(jobID, jobType string, pollInterval, ti
 
 // hydrateResults fetches each URL's CrawlResult in parallel via the
 // recipe-agnostic per-URL endpoint. Failed URLs get a stub so the slice
-// stays aligned with URLStatuses.
+// stays aligned with URLStatuses; results a ResultHook skips are dropped.
+// The first other ResultHook error is returned, wrapped with its URL.
 func (c *AsyncWebCrawler) hydrateResults(job *WrapperJob) ([]CrawlResult, error) {
 	results := make([]CrawlResult, len(job.URLStatuses))
 	type fetchResult struct {
-		idx int
-		res CrawlResult
+		idx  int
+		res  CrawlResult
+		skip bool
+		err  error
+	}
+	// hooked runs the hooks on res and packages the outcome.
+	hooked := func(i int, res CrawlResult) fetchResult {
+		err := c.ApplyResultHooks(&res)
+		if errors.Is(err, ErrSkipResult) {
+			return fetchResult{idx: i, skip: true}
+		}
+		if err != nil {
+			return fetchResult{idx: i, err: fmt.Errorf("result hook for %s: %w", res.URL, err)}
+		}
+		return fetchResult{idx: i, res: res}
 	}
 	ch := make(chan fetchResult, len(job.URLStatuses))
 	for i, entry := range job.URLStatuses {
@@ -860,32 +1514,50 @@ func (c *AsyncWebCrawler) hydrateResults(job *WrapperJob) ([]CrawlResult, error)
 				if errMsg == "" {
 					errMsg = "URL failed"
 				}
-				ch <- fetchResult{idx: i, res: CrawlResult{
+				stub := CrawlResult{
 					URL: entry.URL, Success: false, ErrorMessage: errMsg, DurationMs: ms,
-				}}
+				}
+				// Stubs go through the hooks too so filters see failures.
+				ch <- hooked(i, stub)
 				return
 			}
-			r, err := c.GetPerUrlResult(job.JobID, entry.Index)
+			r, err := c.fetchPerUrlResult(job.JobID, entry.Index)
 			if err != nil {
 				ms := 0
 				if entry.DurationMs != nil {
 					ms = *entry.DurationMs
 				}
-				ch <- fetchResult{idx: i, res: CrawlResult{
+				stub := CrawlResult{
 					URL: entry.URL, Success: false,
 					ErrorMessage: fmt.Sprintf("per-URL fetch failed: %v", err),
 					DurationMs:   ms,
-				}}
+				}
+				ch <- hooked(i, stub)
 				return
 			}
-			ch <- fetchResult{idx: i, res: *r}
+			ch <- hooked(i, *r)
 		}(i, entry)
 	}
+	skipped := make([]bool, len(job.URLStatuses))
+	var hookErr error
 	for range job.URLStatuses {
 		fr := <-ch
 		results[fr.idx] = fr.res
+		skipped[fr.idx] = fr.skip
+		if fr.err != nil && hookErr == nil {
+			hookErr = fr.err
+		}
+	}
+	if hookErr != nil {
+		return nil, hookErr
 	}
-	return results, nil
+	kept := results[:0]
+	for i := range results {
+		if !skipped[i] {
+			kept = append(kept, results[i])
+		}
+	}
+	return kept, nil
 }
 
 // GetPerUrlResult fetches one URL's full result from a multi-URL fan-out
@@ -900,14 +1572,31 @@ func (c *AsyncWebCrawler) hydrateResults(job *WrapperJob) ([]CrawlResult, error)
 // Returns CrawlResult. Markdown is populated for scrape jobs, Screenshot
 // (base64) for screenshot jobs, ExtractedContent for extract jobs.
 func (c *AsyncWebCrawler) GetPerUrlResult(jobID string, urlIndex int) (*CrawlResult, error) {
-	data, err := c.http.Get(fmt.Sprintf("/v1/crawl/jobs/%s/result/%d", jobID, urlIndex), nil)
+	result, err := c.fetchPerUrlResult(jobID, urlIndex)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.ApplyResultHooks(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fetchPerUrlResult is GetPerUrlResult without the ResultHooks, so callers
+// can tell fetch failures from hook errors.
+func (c *AsyncWebCrawler) fetchPerUrlResult(jobID string, urlIndex int) (*CrawlResult, error) {
+	path := fmt.Sprintf("/v1/crawl/jobs/%s/result/%d", jobID, urlIndex)
+	data, err := c.http.Get(path, nil)
 	if err != nil {
 		return nil, err
 	}
 	// CrawlResultFromMap handles the string-or-object polymorphism on the
 	// `markdown` field (sync returns a struct, async returns a raw string).
 	// Plain json.Unmarshal would choke on the string form.
-	return CrawlResultFromMap(data), nil
+	refetch := func() (map[string]interface{}, error) {
+		return c.http.Get(path, nil)
+	}
+	return decodeCrawlResult(data, c.omitFields, nil, refetch), nil
 }
 
 // ScreenshotAsync submits an async screenshot job over a list of URLs.
@@ -920,10 +1609,10 @@ func (c *AsyncWebCrawler) ScreenshotAsync(urls []string, opts *ScreenshotAsyncOp
 	if opts.FullPage != nil {
 		fullPage = *opts.FullPage
 	}
-	priority := opts.Priority
-	if priority == 0 {
-		priority = 5
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
 	}
+	priority := opts.Priority.orDefault()
 
 	body := map[string]interface{}{"urls": urls, "full_page": fullPage, "priority": priority}
 	if opts.PDF {
@@ -980,10 +1669,10 @@ func (c *AsyncWebCrawler) ExtractAsync(url string, opts *ExtractAsyncOptions) (*
 	if strategy == "" {
 		strategy = "http"
 	}
-	priority := opts.Priority
-	if priority == 0 {
-		priority = 5
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
 	}
+	priority := opts.Priority.orDefault()
 
 	body := map[string]interface{}{"url": url, "method": method, "strategy": strategy, "priority": priority}
 	if len(opts.ExtraURLs) > 0 {
@@ -1225,10 +1914,10 @@ func (c *AsyncWebCrawler) ScrapeAsync(urls []string, opts *ScrapeAsyncOptions) (
 	if opts.Fit != nil {
 		fit = *opts.Fit
 	}
-	priority := opts.Priority
-	if priority == 0 {
-		priority = 5
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
 	}
+	priority := opts.Priority.orDefault()
 
 	body := map[string]interface{}{"urls": urls, "strategy": strategy, "fit": fit, "priority": priority}
 	if len(opts.Include) > 0 {
@@ -1468,6 +2157,9 @@ func (c *AsyncWebCrawler) Enrich(opts *EnrichOptions) (*EnrichJobStatus, error)
 	if opts == nil {
 		opts = &EnrichOptions{}
 	}
+	if err := opts.Priority.Validate(); err != nil {
+		return nil, err
+	}
 	body := buildEnrichRequest(opts)
 
 	data, err := c.http.Post("/v1/enrich/async", body, 0)
@@ -1663,10 +2355,7 @@ func buildEnrichRequest(o *EnrichOptions) map[string]interface{} {
 	if strategy == "" {
 		strategy = "http"
 	}
-	priority := o.Priority
-	if priority == 0 {
-		priority = 5
-	}
+	priority := o.Priority.orDefault()
 
 	body := map[string]interface{}{
 		"auto_confirm_plan": autoPlan,
@@ -0,0 +1,286 @@
+// Package dataset turns crawl results into training-ready JSONL: one clean
+// record per page, filtered by length and language and deduplicated by
+// content, for fine-tuning and evaluation corpora.
+//
+//	f, _ := os.Create("corpus.jsonl")
+//	defer f.Close()
+//	stats, err := dataset.Build(crawler, jobID, f, dataset.Options{
+//	    Fields:    []string{dataset.FieldURL, dataset.FieldTitle, dataset.FieldText},
+//	    MinLength: 500,
+//	    Languages: []string{"en"},
+//	    Labels:    map[string]interface{}{"source": "docs"},
+//	})
+package dataset
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ResultSource loads the results of a crawl job. *crawl4ai.AsyncWebCrawler
+// implements it.
+type ResultSource interface {
+	JobResults(jobID string) ([]*crawl4ai.CrawlResult, error)
+}
+
+// Record fields.
+const (
+	FieldURL         = "url"
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	// FieldText is the cleaned page text: FitMarkdown, or RawMarkdown when
+	// no content filter ran.
+	FieldText        = "text"
+	FieldMarkdown    = "markdown"
+	FieldLanguage    = "language"
+	FieldStatusCode  = "status_code"
+	FieldExtracted   = "extracted"
+	FieldContentHash = "content_hash"
+)
+
+// DefaultFields are written when Options.Fields is empty.
+var DefaultFields = []string{FieldURL, FieldTitle, FieldText}
+
+var knownFields = map[string]bool{
+	FieldURL: true, FieldTitle: true, FieldDescription: true, FieldText: true, FieldMarkdown: true,
+	FieldLanguage: true, FieldStatusCode: true, FieldExtracted: true, FieldContentHash: true,
+}
+
+// Options configures Build.
+type Options struct {
+	// Fields lists the record fields, in output order. Default
+	// DefaultFields.
+	Fields []string
+	// MinLength drops pages whose text has fewer characters.
+	MinLength int
+	// Languages keeps only pages in these languages (ISO 639-1 codes,
+	// e.g. "en"). A page's language comes from its metadata, or is guessed
+	// from the text; pages whose language can't be told are dropped.
+	Languages []string
+	// KeepDuplicates writes pages whose text repeats an earlier page's. By
+	// default only the first is kept.
+	KeepDuplicates bool
+	// Labels are added to every record, e.g. {"split": "train"}.
+	Labels map[string]interface{}
+	// Label, when set, adds per-page labels; its keys override Labels.
+	// Returning nil drops the page.
+	Label func(r *crawl4ai.CrawlResult, rec Record) map[string]interface{}
+}
+
+// Record is one JSONL line.
+type Record map[string]interface{}
+
+// Stats counts what Build did with each result.
+type Stats struct {
+	Total         int `json:"total"`
+	Written       int `json:"written"`
+	Failed        int `json:"failed"`
+	TooShort      int `json:"too_short"`
+	WrongLanguage int `json:"wrong_language"`
+	Duplicates    int `json:"duplicates"`
+	Unlabeled     int `json:"unlabeled"`
+}
+
+// Build writes the JSONL dataset for a crawl job to w.
+func Build(src ResultSource, jobID string, w io.Writer, opts Options) (Stats, error) {
+	if err := opts.validate(); err != nil {
+		return Stats{}, err
+	}
+	results, err := src.JobResults(jobID)
+	if err != nil {
+		return Stats{}, err
+	}
+	return BuildResults(results, w, opts)
+}
+
+// BuildResults is Build over results already in hand.
+func BuildResults(results []*crawl4ai.CrawlResult, w io.Writer, opts Options) (Stats, error) {
+	if err := opts.validate(); err != nil {
+		return Stats{}, err
+	}
+	fields := opts.Fields
+	if len(fields) == 0 {
+		fields = DefaultFields
+	}
+	langs := map[string]bool{}
+	for _, l := range opts.Languages {
+		langs[strings.ToLower(l)] = true
+	}
+
+	var st Stats
+	seen := map[string]bool{}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, r := range results {
+		st.Total++
+		if r == nil || !r.Success {
+			st.Failed++
+			continue
+		}
+		text := pageText(r)
+		if text == "" || len([]rune(text)) < opts.MinLength {
+			st.TooShort++
+			continue
+		}
+		lang := Language(r)
+		if len(langs) > 0 && !langs[lang] {
+			st.WrongLanguage++
+			continue
+		}
+		hash := contentHash(text)
+		if seen[hash] && !opts.KeepDuplicates {
+			st.Duplicates++
+			continue
+		}
+		seen[hash] = true
+
+		rec := Record{}
+		for _, f := range fields {
+			rec[f] = fieldValue(r, f, text, lang, hash)
+		}
+		for k, v := range opts.Labels {
+			rec[k] = v
+		}
+		if opts.Label != nil {
+			labels := opts.Label(r, rec)
+			if labels == nil {
+				st.Unlabeled++
+				continue
+			}
+			for k, v := range labels {
+				rec[k] = v
+			}
+		}
+		if err := enc.Encode(orderedRecord{rec: rec, order: fields}); err != nil {
+			return st, err
+		}
+		st.Written++
+	}
+	return st, nil
+}
+
+func (o Options) validate() error {
+	for _, f := range o.Fields {
+		if !knownFields[f] {
+			return fmt.Errorf("dataset: unknown field %q", f)
+		}
+	}
+	return nil
+}
+
+// pageText is FitMarkdown, or RawMarkdown when no content filter ran,
+// trimmed.
+func pageText(r *crawl4ai.CrawlResult) string {
+	if r.Markdown == nil {
+		return ""
+	}
+	if t := strings.TrimSpace(r.Markdown.FitMarkdown); t != "" {
+		return t
+	}
+	return strings.TrimSpace(r.Markdown.RawMarkdown)
+}
+
+// contentHash identifies text up to case and whitespace.
+func contentHash(text string) string {
+	norm := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(norm)))
+}
+
+func fieldValue(r *crawl4ai.CrawlResult, field, text, lang, hash string) interface{} {
+	switch field {
+	case FieldURL:
+		return r.URL
+	case FieldTitle:
+		return metaString(r, "title")
+	case FieldDescription:
+		return metaString(r, "description")
+	case FieldText:
+		return text
+	case FieldMarkdown:
+		if r.Markdown == nil {
+			return ""
+		}
+		return r.Markdown.RawMarkdown
+	case FieldLanguage:
+		return lang
+	case FieldStatusCode:
+		return r.StatusCode
+	case FieldExtracted:
+		var v interface{}
+		if r.ExtractedContent == "" || json.Unmarshal([]byte(r.ExtractedContent), &v) != nil {
+			return nil
+		}
+		return v
+	case FieldContentHash:
+		return hash
+	}
+	return nil
+}
+
+func metaString(r *crawl4ai.CrawlResult, key string) string {
+	s, _ := r.Metadata[key].(string)
+	return strings.TrimSpace(s)
+}
+
+// orderedRecord marshals a Record with the configured fields first, in
+// order, then any labels sorted by key — stable lines diff cleanly.
+type orderedRecord struct {
+	rec   Record
+	order []string
+}
+
+func (o orderedRecord) MarshalJSON() ([]byte, error) {
+	keys := append([]string{}, o.order...)
+	inOrder := map[string]bool{}
+	for _, k := range keys {
+		inOrder[k] = true
+	}
+	var extra []string
+	for k := range o.rec {
+		if !inOrder[k] {
+			extra = append(extra, k)
+		}
+	}
+	sort.Strings(extra)
+	keys = append(keys, extra...)
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		vb, err := marshalNoEscape(o.rec[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// marshalNoEscape is json.Marshal without HTML escaping, so markdown keeps
+// its < > & readable.
+func marshalNoEscape(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
@@ -0,0 +1,127 @@
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+type jobSource map[string][]*crawl4ai.CrawlResult
+
+func (s jobSource) JobResults(jobID string) ([]*crawl4ai.CrawlResult, error) {
+	r, ok := s[jobID]
+	if !ok {
+		return nil, errors.New("job not found")
+	}
+	return r, nil
+}
+
+const english = "The crawler fetches the page and it converts the content to markdown for the team."
+
+func page(url, md string, meta map[string]interface{}) *crawl4ai.CrawlResult {
+	return &crawl4ai.CrawlResult{URL: url, Success: true, StatusCode: 200, Metadata: meta,
+		Markdown: &crawl4ai.MarkdownResult{RawMarkdown: md}}
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if l == "" {
+			continue
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(l), &m); err != nil {
+			t.Fatalf("bad line %q: %v", l, err)
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func TestBuild_FiltersAndDedupes(t *testing.T) {
+	src := jobSource{"job_1": {
+		page("https://a.com/1", english, map[string]interface{}{"title": "One"}),
+		page("https://a.com/2", "  "+strings.ToUpper(english)+"\n", nil),
+		page("https://a.com/3", "short", nil),
+		page("https://a.com/4", "Der Crawler lädt die Seite und das ist nicht ein Problem für uns, sagt die Firma.", nil),
+		{URL: "https://a.com/5", ErrorMessage: "timeout"},
+	}}
+	var buf bytes.Buffer
+	st, err := Build(src, "job_1", &buf, Options{MinLength: 20, Languages: []string{"en"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Stats{Total: 5, Written: 1, Failed: 1, TooShort: 1, WrongLanguage: 1, Duplicates: 1}
+	if st != want {
+		t.Errorf("stats = %+v, want %+v", st, want)
+	}
+	recs := lines(t, &buf)
+	if len(recs) != 1 || recs[0]["url"] != "https://a.com/1" || recs[0]["title"] != "One" || recs[0]["text"] != english {
+		t.Errorf("records = %v", recs)
+	}
+}
+
+func TestBuildResults_FieldOrderAndLabels(t *testing.T) {
+	r := page("https://a.com", "<b>bold</b> & more", map[string]interface{}{"language": "en-US"})
+	r.ExtractedContent = `[{"price":"9.99"}]`
+	var buf bytes.Buffer
+	_, err := BuildResults([]*crawl4ai.CrawlResult{r}, &buf, Options{
+		Fields: []string{FieldText, FieldURL, FieldLanguage, FieldExtracted},
+		Labels: map[string]interface{}{"split": "train", "source": "docs"},
+		Label: func(r *crawl4ai.CrawlResult, rec Record) map[string]interface{} {
+			return map[string]interface{}{"source": "shop"}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"text":"<b>bold</b> & more","url":"https://a.com","language":"en","extracted":[{"price":"9.99"}],"source":"shop","split":"train"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("line =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestBuildResults_LabelNilDrops(t *testing.T) {
+	var buf bytes.Buffer
+	st, err := BuildResults([]*crawl4ai.CrawlResult{page("u", english, nil)}, &buf, Options{
+		Label: func(*crawl4ai.CrawlResult, Record) map[string]interface{} { return nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Unlabeled != 1 || st.Written != 0 || buf.Len() != 0 {
+		t.Errorf("stats = %+v, out = %q", st, buf.String())
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	if _, err := Build(jobSource{}, "job_1", &bytes.Buffer{}, Options{Fields: []string{"body"}}); err == nil {
+		t.Error("unknown field accepted")
+	}
+	if _, err := Build(jobSource{}, "missing", &bytes.Buffer{}, Options{}); err == nil {
+		t.Error("missing job accepted")
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		english: "en",
+		"Le robot télécharge la page et il la convertit pour les équipes dans le monde.":     "fr",
+		"El rastreador descarga la página y la convierte para los equipos de todo el mundo.": "es",
+		"Der Crawler lädt die Seite und das ist nicht ein Problem für uns.":                  "de",
+		"too short": "",
+		"これはテストの文章です。日本語のページです。とても長い文章": "",
+	}
+	for text, want := range cases {
+		if got := DetectLanguage(text); got != want {
+			t.Errorf("DetectLanguage(%.30q) = %q, want %q", text, got, want)
+		}
+	}
+}
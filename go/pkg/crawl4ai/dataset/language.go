@@ -0,0 +1,82 @@
+package dataset
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// stopwords holds frequent function words per language; DetectLanguage
+// picks the language whose words cover most of the text.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "that", "for", "it", "with", "as", "was", "on", "are", "this", "by", "be", "you", "or", "from"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "von", "auf", "für", "ich", "dem", "auch", "wir", "sie"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "un", "du", "dans", "pour", "que", "qui", "pas", "sur", "au", "avec", "nous", "vous", "ce"},
+	"es": {"el", "la", "los", "las", "y", "de", "que", "en", "un", "una", "es", "por", "para", "con", "del", "se", "no", "su", "al", "como"},
+	"it": {"il", "lo", "la", "gli", "le", "di", "che", "e", "è", "un", "una", "per", "non", "con", "del", "della", "sono", "si", "da", "come"},
+	"pt": {"o", "os", "a", "as", "de", "que", "e", "do", "da", "em", "um", "uma", "para", "com", "não", "por", "se", "mais", "dos", "das"},
+	"nl": {"de", "het", "een", "en", "van", "is", "dat", "op", "te", "in", "zijn", "niet", "met", "voor", "ook", "je", "er", "maar", "om", "wij"},
+}
+
+var stopwordSets = func() map[string]map[string]bool {
+	sets := map[string]map[string]bool{}
+	for lang, words := range stopwords {
+		sets[lang] = map[string]bool{}
+		for _, w := range words {
+			sets[lang][w] = true
+		}
+	}
+	return sets
+}()
+
+// Language returns a page's ISO 639-1 language: the one its metadata
+// declares (e.g. <html lang="en-US"> as "en"), or else DetectLanguage's
+// guess from its text.
+func Language(r *crawl4ai.CrawlResult) string {
+	for _, key := range []string{"language", "lang", "og:locale"} {
+		if v := metaString(r, key); v != "" {
+			return normalizeLang(v)
+		}
+	}
+	return DetectLanguage(pageText(r))
+}
+
+func normalizeLang(tag string) string {
+	tag = strings.ToLower(tag)
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// DetectLanguage guesses the language of text from its stopwords. It knows
+// en, de, fr, es, it, pt and nl, and returns "" for text it can't place:
+// too short, another language, or another script.
+func DetectLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) })
+	if len(words) < 5 {
+		return ""
+	}
+	best, bestHits, second := "", 0, 0
+	for lang, set := range stopwordSets {
+		hits := 0
+		for _, w := range words {
+			if set[w] {
+				hits++
+			}
+		}
+		switch {
+		case hits > bestHits || (hits == bestHits && lang < best):
+			second = bestHits
+			best, bestHits = lang, hits
+		case hits > second:
+			second = hits
+		}
+	}
+	// At least 10% stopwords, and a clear margin over the runner-up.
+	if bestHits*10 < len(words) || bestHits == second {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,80 @@
+package crawl4ai
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DuplicateURL is an input URL collapsed into an earlier equivalent one.
+type DuplicateURL struct {
+	URL string
+	// Index is URL's position in the original list.
+	Index int
+	// DuplicateOf is the earlier URL it was collapsed into, which is the
+	// one submitted.
+	DuplicateOf string
+}
+
+// DedupeURLs removes URLs equal to an earlier one after normalisation
+// (scheme and host case, default ports, fragments, a trailing slash and
+// query parameter order) and reports what it dropped. The first spelling
+// of each URL is kept, in its original position.
+//
+//	unique, dups := crawl4ai.DedupeURLs(urls)
+//	for _, d := range dups {
+//	    log.Printf("skipping %s: same page as %s", d.URL, d.DuplicateOf)
+//	}
+func DedupeURLs(urls []string) (unique []string, duplicates []DuplicateURL) {
+	first := make(map[string]string, len(urls))
+	unique = make([]string, 0, len(urls))
+	for i, u := range urls {
+		key := dedupeKey(u)
+		if prev, ok := first[key]; ok {
+			duplicates = append(duplicates, DuplicateURL{URL: u, Index: i, DuplicateOf: prev})
+			continue
+		}
+		first[key] = u
+		unique = append(unique, u)
+	}
+	return unique, duplicates
+}
+
+// dedupeKey normalises a URL for duplicate detection. Inline "raw:" HTML
+// and unparseable URLs only match themselves.
+func dedupeKey(raw string) string {
+	if strings.HasPrefix(raw, "raw:") {
+		return raw
+	}
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(u.Scheme == "http" && port == "80") && !(u.Scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	u.Host = host
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+	if u.RawQuery != "" {
+		u.RawQuery = u.Query().Encode()
+	}
+	return u.String()
+}
+
+// expandAligned maps results aligned to the deduplicated inputs back onto
+// the original list, giving each duplicate its canonical URL's result.
+func expandAligned(original, unique []string, aligned []*CrawlResult) []*CrawlResult {
+	byKey := make(map[string]*CrawlResult, len(unique))
+	for i, u := range unique {
+		byKey[dedupeKey(u)] = aligned[i]
+	}
+	out := make([]*CrawlResult, len(original))
+	for i, u := range original {
+		out[i] = byKey[dedupeKey(u)]
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestDedupeURLs(t *testing.T) {
+	urls := []string{
+		"https://a.com/page",
+		"https://A.com/page/",
+		"https://a.com:443/page#top",
+		"https://a.com/page?b=2&a=1",
+		"https://a.com/page?a=1&b=2",
+		"http://a.com/page",
+		"raw:<p>hi</p>",
+		"raw:<p>hi</p>",
+	}
+	unique, dups := DedupeURLs(urls)
+	want := []string{"https://a.com/page", "https://a.com/page?b=2&a=1", "http://a.com/page", "raw:<p>hi</p>"}
+	if len(unique) != len(want) {
+		t.Fatalf("unique = %v", unique)
+	}
+	for i := range want {
+		if unique[i] != want[i] {
+			t.Fatalf("unique[%d] = %q, want %q", i, unique[i], want[i])
+		}
+	}
+	if len(dups) != 4 || dups[0].Index != 1 || dups[0].DuplicateOf != "https://a.com/page" || dups[2].DuplicateOf != "https://a.com/page?b=2&a=1" {
+		t.Fatalf("unexpected duplicates: %+v", dups)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunMany_DedupesBeforeSubmission(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl/async": map[string]interface{}{"job_id": "job_1", "status": "pending"},
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{"job_id": "job_1", "status": "completed", "results": []interface{}{
+			map[string]interface{}{"url": "https://a.com", "success": true},
+		}},
+	})
+	res, err := c.RunMany([]string{"https://a.com", "https://a.com/"}, &RunManyOptions{Wait: true, AlignResults: true, PollInterval: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Duplicates) != 1 || res.Duplicates[0].URL != "https://a.com/" {
+		t.Fatalf("unexpected duplicates: %+v", res.Duplicates)
+	}
+	if len(res.Results) != 2 || res.Results[0] == nil || res.Results[1] != res.Results[0] {
+		t.Fatalf("duplicate should share its original's result: %+v", res.Results)
+	}
+	if len(res.MissingURLs()) != 0 {
+		t.Fatalf("no URL is missing: %v", res.MissingURLs())
+	}
+}
@@ -0,0 +1,161 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"time"
+)
+
+// Reasons a deep crawl stopped before running to completion, reported in
+// DeepCrawlResultWrapper.StopReason.
+const (
+	StopReasonMaxDuration = "max_duration"
+	StopReasonMaxCredits  = "max_credits"
+	StopReasonErrorRate   = "error_rate"
+)
+
+// minErrorRateSample is how many URLs must have finished before
+// StopOnErrorRate is evaluated, so one early failure can't end a crawl.
+const minErrorRateSample = 10
+
+// deepCrawlBudget is the time/credit/error-rate budget of one DeepCrawl.
+// It is sent to the server as "budget" and also enforced client-side while
+// DeepCrawl waits, by cancelling the job — cancellation keeps the results
+// collected so far.
+type deepCrawlBudget struct {
+	maxDuration time.Duration
+	maxCredits  float64
+	errorRate   float64
+	start       time.Time
+}
+
+func newDeepCrawlBudget(opts *DeepCrawlOptions) *deepCrawlBudget {
+	if opts.MaxDuration <= 0 && opts.MaxCredits <= 0 && opts.StopOnErrorRate <= 0 {
+		return nil
+	}
+	return &deepCrawlBudget{
+		maxDuration: opts.MaxDuration,
+		maxCredits:  opts.MaxCredits,
+		errorRate:   opts.StopOnErrorRate,
+		start:       time.Now(),
+	}
+}
+
+func (b *deepCrawlBudget) toMap() map[string]interface{} {
+	m := map[string]interface{}{}
+	if b.maxDuration > 0 {
+		m["max_duration_seconds"] = b.maxDuration.Seconds()
+	}
+	if b.maxCredits > 0 {
+		m["max_credits"] = b.maxCredits
+	}
+	if b.errorRate > 0 {
+		m["stop_on_error_rate"] = b.errorRate
+	}
+	return m
+}
+
+// durationExceeded reports whether the crawl has run past MaxDuration.
+func (b *deepCrawlBudget) durationExceeded() bool {
+	return b != nil && b.maxDuration > 0 && time.Since(b.start) > b.maxDuration
+}
+
+// exceeded returns the StopReason* the crawl job has hit, or "". Credits
+// are checked against the job's reported usage, which the API includes on
+// running jobs only when it tracks spend incrementally.
+func (b *deepCrawlBudget) exceeded(job *CrawlJob) string {
+	if b == nil {
+		return ""
+	}
+	if b.durationExceeded() {
+		return StopReasonMaxDuration
+	}
+	if b.maxCredits > 0 && job.Usage != nil && job.Usage.Crawl != nil &&
+		job.Usage.Crawl.CreditsUsed >= b.maxCredits {
+		return StopReasonMaxCredits
+	}
+	if b.errorRate > 0 {
+		done := job.Progress.Completed + job.Progress.Failed
+		if done >= minErrorRateSample && float64(job.Progress.Failed)/float64(done) >= b.errorRate {
+			return StopReasonErrorRate
+		}
+	}
+	return ""
+}
+
+// waitJobWithinBudget is WaitJob that cancels the job once the budget is
+// exhausted and returns its partial results along with the reason. budget
+// may be nil. onPoll, when set, sees every job snapshot fetched, including
+// the final one.
+func (c *AsyncWebCrawler) waitJobWithinBudget(jobID string, pollInterval, timeout time.Duration, budget *deepCrawlBudget, onPoll func(*CrawlJob)) (*CrawlJob, string, error) {
+	if budget == nil && onPoll == nil {
+		job, err := c.WaitJob(jobID, pollInterval, timeout)
+		return job, "", err
+	}
+	if onPoll == nil {
+		onPoll = func(*CrawlJob) {}
+	}
+	startTime := time.Now()
+	var tracker progressTracker
+	for {
+		job, err := c.GetJob(jobID)
+		if err != nil {
+			return nil, "", err
+		}
+		tracker.observe(&job.Progress, time.Now())
+		onPoll(job)
+		if job.IsComplete() {
+			return job, "", nil
+		}
+		if budget != nil {
+			if reason := budget.exceeded(job); reason != "" {
+				if err := c.CancelJob(jobID); err != nil {
+					return job, reason, err
+				}
+				// Cancellation lands at the next batch boundary; wait for it so
+				// the returned job carries every result gathered so far.
+				job, err = c.WaitJob(jobID, pollInterval, timeout)
+				if err == nil {
+					onPoll(job)
+				}
+				return job, reason, err
+			}
+		}
+		if timeout > 0 && time.Since(startTime) > timeout {
+			return job, "", jobTimeoutError(jobID, job, startTime)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// waitScanWithinBudget is waitScanJob that cancels the scan once
+// MaxDuration has passed, returning the URLs discovered so far.
+func (c *AsyncWebCrawler) waitScanWithinBudget(jobID string, pollInterval, timeout time.Duration, budget *deepCrawlBudget) (*DeepCrawlResult, string, error) {
+	if budget == nil || budget.maxDuration <= 0 {
+		result, err := c.waitScanJob(jobID, pollInterval, timeout)
+		return result, "", err
+	}
+	startTime := time.Now()
+	for {
+		result, err := c.GetDeepCrawlStatus(jobID)
+		if err != nil {
+			return nil, "", err
+		}
+		if result.IsComplete() {
+			return result, "", nil
+		}
+		if budget.durationExceeded() {
+			if _, err := c.CancelDeepCrawl(jobID); err != nil {
+				return result, StopReasonMaxDuration, err
+			}
+			result, err = c.waitScanJob(jobID, pollInterval, timeout)
+			return result, StopReasonMaxDuration, err
+		}
+		if timeout > 0 && time.Since(startTime) > timeout {
+			return nil, "", NewTimeoutError(fmt.Sprintf(
+				"timeout waiting for scan job %s after %s. Status: %s, Discovered: %d",
+				jobID, elapsedSince(startTime), result.Status, result.DiscoveredCount,
+			))
+		}
+		time.Sleep(pollInterval)
+	}
+}
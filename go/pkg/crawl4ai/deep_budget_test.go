@@ -0,0 +1,97 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestDeepCrawlBudget_Exceeded(t *testing.T) {
+	if newDeepCrawlBudget(&DeepCrawlOptions{}) != nil {
+		t.Fatal("expected no budget without limits")
+	}
+	b := newDeepCrawlBudget(&DeepCrawlOptions{MaxCredits: 5, StopOnErrorRate: 0.5})
+	if got := b.toMap(); got["max_credits"] != 5.0 || got["stop_on_error_rate"] != 0.5 || got["max_duration_seconds"] != nil {
+		t.Fatalf("unexpected budget body: %v", got)
+	}
+
+	few := &CrawlJob{Progress: JobProgress{Total: 100, Completed: 1, Failed: 4}}
+	if r := b.exceeded(few); r != "" {
+		t.Fatalf("expected no stop below the error-rate sample, got %q", r)
+	}
+	failing := &CrawlJob{Progress: JobProgress{Total: 100, Completed: 4, Failed: 6}}
+	if r := b.exceeded(failing); r != StopReasonErrorRate {
+		t.Fatalf("expected error_rate, got %q", r)
+	}
+	spent := &CrawlJob{Usage: &Usage{Crawl: &CrawlUsageMetrics{CreditsUsed: 5}}}
+	if r := b.exceeded(spent); r != StopReasonMaxCredits {
+		t.Fatalf("expected max_credits, got %q", r)
+	}
+
+	late := &deepCrawlBudget{maxDuration: time.Millisecond, start: time.Now().Add(-time.Second)}
+	if r := late.exceeded(&CrawlJob{}); r != StopReasonMaxDuration {
+		t.Fatalf("expected max_duration, got %q", r)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestDeepCrawl_StopsOnErrorRateWithPartialResults(t *testing.T) {
+	var cancelled int32
+	var sentBudget interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/crawl/deep":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			sentBudget = body["budget"]
+			resp = map[string]interface{}{"job_id": "scan_1", "status": "pending"}
+		case "GET /v1/crawl/deep/jobs/scan_1":
+			resp = map[string]interface{}{"job_id": "scan_1", "status": "completed", "discovered_urls": 50, "crawl_job_id": "job_1"}
+		case "DELETE /v1/crawl/jobs/job_1":
+			atomic.StoreInt32(&cancelled, 1)
+			resp = map[string]interface{}{}
+		case "GET /v1/crawl/jobs/job_1":
+			status := "running"
+			if atomic.LoadInt32(&cancelled) == 1 {
+				status = "cancelled"
+			}
+			resp = map[string]interface{}{
+				"job_id": "job_1", "status": status,
+				"progress": map[string]interface{}{"total": 50, "completed": 3, "failed": 9},
+				"results":  []interface{}{map[string]interface{}{"url": "https://a.com/1", "success": true}},
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := c.DeepCrawl("https://a.com", &DeepCrawlOptions{
+		Wait: true, PollInterval: time.Millisecond, StopOnErrorRate: 0.5,
+	})
+	if err != nil {
+		t.Fatalf("DeepCrawl: %v", err)
+	}
+	if out.StopReason != StopReasonErrorRate || atomic.LoadInt32(&cancelled) != 1 {
+		t.Fatalf("expected crawl cancelled for error rate, got %q", out.StopReason)
+	}
+	if out.CrawlJob == nil || out.CrawlJob.Status != "cancelled" || len(out.CrawlJob.Results) != 1 {
+		t.Fatalf("expected partial results from the cancelled job, got %+v", out.CrawlJob)
+	}
+	if b, ok := sentBudget.(map[string]interface{}); !ok || b["stop_on_error_rate"] != 0.5 {
+		t.Fatalf("expected budget sent to server, got %v", sentBudget)
+	}
+}
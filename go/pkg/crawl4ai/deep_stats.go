@@ -0,0 +1,75 @@
+package crawl4ai
+
+import "sort"
+
+// DepthStat summarises the pages a deep crawl fetched at one link depth.
+type DepthStat struct {
+	Depth     int
+	Pages     int
+	Succeeded int
+	Failed    int
+	// Words is the total markdown word count of successful pages — a rough
+	// measure of how much content lives at this depth.
+	Words int
+}
+
+// SuccessRate is Succeeded/Pages, or 0 for an empty depth.
+func (d DepthStat) SuccessRate() float64 {
+	if d.Pages == 0 {
+		return 0
+	}
+	return float64(d.Succeeded) / float64(d.Pages)
+}
+
+// DepthStats returns per-depth page counts for a finished deep crawl,
+// sorted by depth, or nil when the crawl job has no results (ScanOnly, or
+// Wait unset). Use it to tune MaxDepth: if depth 3 adds pages but few
+// words, MaxDepth 2 is probably enough.
+//
+//	for _, d := range res.DepthStats() {
+//	    fmt.Printf("depth %d: %d pages, %.0f%% ok, %d words\n", d.Depth, d.Pages, d.SuccessRate()*100, d.Words)
+//	}
+func (w *DeepCrawlResultWrapper) DepthStats() []DepthStat {
+	if w.CrawlJob == nil {
+		return nil
+	}
+	return DepthStats(w.startURL, w.CrawlJob.Results)
+}
+
+// DepthStats buckets results by link depth below startURL. The depth the
+// server records in each result's metadata is used when present; otherwise
+// the number of path segments below startURL stands in for it.
+func DepthStats(startURL string, results []*CrawlResult) []DepthStat {
+	if len(results) == 0 {
+		return nil
+	}
+	byDepth := map[int]*DepthStat{}
+	for _, r := range results {
+		depth := resultDepth(startURL, r)
+		d := byDepth[depth]
+		if d == nil {
+			d = &DepthStat{Depth: depth}
+			byDepth[depth] = d
+		}
+		d.Pages++
+		if r.Success {
+			d.Succeeded++
+			d.Words += markdownWords(r)
+		} else {
+			d.Failed++
+		}
+	}
+	stats := make([]DepthStat, 0, len(byDepth))
+	for _, d := range byDepth {
+		stats = append(stats, *d)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Depth < stats[j].Depth })
+	return stats
+}
+
+func resultDepth(startURL string, r *CrawlResult) int {
+	if v, ok := r.Metadata["depth"].(float64); ok && v >= 0 {
+		return int(v)
+	}
+	return pathDepthBelow(startURL, r.URL)
+}
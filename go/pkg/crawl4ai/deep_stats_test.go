@@ -0,0 +1,33 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestDepthStats_Buckets(t *testing.T) {
+	md := func(s string) *MarkdownResult { return &MarkdownResult{RawMarkdown: s} }
+	results := []*CrawlResult{
+		{URL: "https://a.com/docs", Success: true, Markdown: md("intro page")},
+		{URL: "https://a.com/docs/guide", Success: true, Markdown: md("one two three")},
+		{URL: "https://a.com/docs/api", Success: false},
+		// Server-reported depth wins over the path.
+		{URL: "https://a.com/blog/post", Success: true, Markdown: md("x"), Metadata: map[string]interface{}{"depth": 3.0}},
+	}
+	w := &DeepCrawlResultWrapper{CrawlJob: &CrawlJob{Results: results}, startURL: "https://a.com/docs"}
+	stats := w.DepthStats()
+	if len(stats) != 3 {
+		t.Fatalf("expected depths 0,1,3, got %+v", stats)
+	}
+	if s := stats[0]; s.Depth != 0 || s.Pages != 1 || s.Words != 2 {
+		t.Fatalf("depth 0: %+v", s)
+	}
+	if s := stats[1]; s.Depth != 1 || s.Pages != 2 || s.Succeeded != 1 || s.Failed != 1 || s.Words != 3 || s.SuccessRate() != 0.5 {
+		t.Fatalf("depth 1: %+v", s)
+	}
+	if s := stats[2]; s.Depth != 3 || s.Pages != 1 {
+		t.Fatalf("depth 3: %+v", s)
+	}
+	if (&DeepCrawlResultWrapper{}).DepthStats() != nil {
+		t.Fatal("expected nil without a crawl job")
+	}
+}
@@ -0,0 +1,74 @@
+package crawl4ai
+
+import (
+	"context"
+	"fmt"
+)
+
+// streamNewResults returns an onPoll func that passes each result not seen
+// in an earlier job snapshot to fn.
+func streamNewResults(fn func(*CrawlResult)) func(*CrawlJob) {
+	seen := map[string]bool{}
+	return func(job *CrawlJob) {
+		for i, r := range job.Results {
+			key := r.URL
+			if key == "" {
+				key = fmt.Sprintf("#%d", i)
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			fn(r)
+		}
+	}
+}
+
+// DeepCrawlStream runs DeepCrawl (with Wait) in the background and sends
+// each page result on the returned channel as soon as it is crawled. The
+// channel is closed when the crawl ends; wait then returns the same value
+// DeepCrawl would have. Polling pauses while a result is waiting to be
+// read; wait discards results still unread, which remain in the returned
+// job. Cancel ctx to abandon the stream: waiting stops and wait returns
+// the error.
+//
+//	results, wait := crawler.DeepCrawlStream(ctx, "https://docs.example.com", &crawl4ai.DeepCrawlOptions{MaxURLs: 200})
+//	for r := range results {
+//	    go extract(r) // runs while later pages are still crawling
+//	}
+//	deep, err := wait()
+//
+// opts.OnResult, if set, is called before each result is sent.
+func (c *AsyncWebCrawler) DeepCrawlStream(ctx context.Context, url string, opts *DeepCrawlOptions) (results <-chan *CrawlResult, wait func() (*DeepCrawlResultWrapper, error)) {
+	o := DeepCrawlOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	ch := make(chan *CrawlResult)
+	callback := o.OnResult
+	o.Wait = true
+	o.OnResult = func(r *CrawlResult) {
+		if callback != nil {
+			callback(r)
+		}
+		select {
+		case ch <- r:
+		case <-ctx.Done():
+		}
+	}
+
+	done := make(chan struct{})
+	var res *DeepCrawlResultWrapper
+	var err error
+	go func() {
+		defer close(done)
+		defer close(ch)
+		res, err = c.WithContext(ctx).DeepCrawl(url, &o)
+	}()
+	return ch, func() (*DeepCrawlResultWrapper, error) {
+		for range ch {
+		}
+		<-done
+		return res, err
+	}
+}
@@ -0,0 +1,124 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestDeepCrawlStream_DeliversResultsWhileRunning(t *testing.T) {
+	var polls int32
+	page := func(n string) map[string]interface{} {
+		return map[string]interface{}{"url": "https://a.com/" + n, "success": true}
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/crawl/deep":
+			resp = map[string]interface{}{"job_id": "scan_1", "status": "pending"}
+		case "GET /v1/crawl/deep/jobs/scan_1":
+			resp = map[string]interface{}{"job_id": "scan_1", "status": "completed", "discovered_urls": 3, "crawl_job_id": "job_1"}
+		case "GET /v1/crawl/jobs/job_1":
+			switch atomic.AddInt32(&polls, 1) {
+			case 1:
+				resp = map[string]interface{}{"job_id": "job_1", "status": "running", "results": []interface{}{page("1")}}
+			case 2:
+				resp = map[string]interface{}{"job_id": "job_1", "status": "running", "results": []interface{}{page("1"), page("2")}}
+			default:
+				resp = map[string]interface{}{"job_id": "job_1", "status": "completed", "results": []interface{}{page("1"), page("2"), page("3")}}
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var callbacks int
+	results, wait := c.DeepCrawlStream(context.Background(), "https://a.com", &DeepCrawlOptions{
+		PollInterval: time.Millisecond,
+		OnResult:     func(*CrawlResult) { callbacks++ },
+	})
+	var got []string
+	var pollsAtFirst int32
+	for r := range results {
+		if got == nil {
+			pollsAtFirst = atomic.LoadInt32(&polls)
+		}
+		got = append(got, r.URL)
+	}
+	out, err := wait()
+	if err != nil {
+		t.Fatalf("DeepCrawlStream: %v", err)
+	}
+	if len(got) != 3 || got[0] != "https://a.com/1" || got[2] != "https://a.com/3" || callbacks != 3 {
+		t.Fatalf("expected each page once in order, got %v (%d callbacks)", got, callbacks)
+	}
+	if pollsAtFirst != 1 {
+		t.Fatalf("expected the first page before the job finished, got it after %d polls", pollsAtFirst)
+	}
+	if out.CrawlJob == nil || len(out.CrawlJob.Results) != 3 {
+		t.Fatalf("expected the final job with every result, got %+v", out.CrawlJob)
+	}
+}
+
+func TestDeepCrawlStream_CancelEndsAbandonedStream(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/crawl/deep":
+			resp = map[string]interface{}{"job_id": "scan_1", "status": "pending"}
+		case "GET /v1/crawl/deep/jobs/scan_1":
+			resp = map[string]interface{}{"job_id": "scan_1", "status": "completed", "discovered_urls": 1000, "crawl_job_id": "job_1"}
+		case "GET /v1/crawl/jobs/job_1":
+			// A crawl that never finishes, one new page per poll.
+			n := atomic.AddInt32(&polls, 1)
+			var results []interface{}
+			for i := int32(1); i <= n; i++ {
+				results = append(results, map[string]interface{}{"url": fmt.Sprintf("https://a.com/%d", i), "success": true})
+			}
+			resp = map[string]interface{}{"job_id": "job_1", "status": "running", "results": results}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, wait := c.DeepCrawlStream(ctx, "https://a.com", &DeepCrawlOptions{PollInterval: time.Millisecond})
+	<-results // read one page, then walk away
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := wait()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected an error from an abandoned stream")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream goroutine still running after ctx was cancelled")
+	}
+}
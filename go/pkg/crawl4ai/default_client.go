@@ -0,0 +1,65 @@
+package crawl4ai
+
+import (
+	"os"
+	"sync"
+)
+
+var (
+	defaultMu      sync.RWMutex
+	defaultCrawler *AsyncWebCrawler
+)
+
+// SetDefault installs c as the crawler behind the package-level functions
+// (Run, RunMany, DeepCrawl, and CrawlToMarkdown/CrawlToFile with an empty
+// key). Passing nil restores the built-in default, created on first use
+// from CRAWL4AI_API_KEY.
+//
+//	c, _ := crawl4ai.NewAsyncWebCrawler(crawl4ai.CrawlerOptions{MaxRetries: 5})
+//	crawl4ai.SetDefault(c)
+//	result, err := crawl4ai.Run("https://example.com", nil)
+func SetDefault(c *AsyncWebCrawler) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultCrawler = c
+}
+
+// Default returns the crawler installed by SetDefault, or the built-in one
+// configured from the environment. It fails only when nothing was installed
+// and CRAWL4AI_API_KEY is missing or malformed.
+func Default() (*AsyncWebCrawler, error) {
+	defaultMu.RLock()
+	c := defaultCrawler
+	defaultMu.RUnlock()
+	if c != nil {
+		return c, nil
+	}
+	return keyedCrawler(os.Getenv("CRAWL4AI_API_KEY"))
+}
+
+// Run calls Run on the default crawler.
+func Run(url string, opts *RunOptions) (*CrawlResult, error) {
+	c, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	return c.Run(url, opts)
+}
+
+// RunMany calls RunMany on the default crawler.
+func RunMany(urls []string, opts *RunManyOptions) (*RunManyResult, error) {
+	c, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	return c.RunMany(urls, opts)
+}
+
+// DeepCrawl calls DeepCrawl on the default crawler.
+func DeepCrawl(url string, opts *DeepCrawlOptions) (*DeepCrawlResultWrapper, error) {
+	c, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	return c.DeepCrawl(url, opts)
+}
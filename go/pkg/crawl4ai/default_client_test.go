@@ -0,0 +1,51 @@
+package crawl4ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process sandbox) ─────────────────────────────────────
+
+func TestSetDefault_PackageFunctions(t *testing.T) {
+	c := newSandboxCrawler(t)
+	SetDefault(c)
+	defer SetDefault(nil)
+
+	if got, err := Default(); err != nil || got != c {
+		t.Fatalf("Default() = %p, %v; want %p", got, err, c)
+	}
+	result, err := Run("https://example.com", nil)
+	if err != nil || !result.Success {
+		t.Fatalf("Run: %+v, %v", result, err)
+	}
+	many, err := RunMany([]string{"https://example.com/a", "https://example.com/b"},
+		&RunManyOptions{Wait: true, PollInterval: time.Millisecond})
+	if err != nil || len(many.Successful()) != 2 {
+		t.Fatalf("RunMany: %+v, %v", many, err)
+	}
+	md, err := CrawlToMarkdown(context.Background(), "", "https://example.com/about")
+	if err != nil || !strings.Contains(md, "Example Domain - about") {
+		t.Fatalf("CrawlToMarkdown with empty key should use the default: %q, %v", md, err)
+	}
+}
+
+func TestDefault_FromEnvironment(t *testing.T) {
+	SetDefault(nil)
+	t.Setenv("CRAWL4AI_API_KEY", "")
+	if _, err := Run("https://example.com", nil); err == nil || !strings.Contains(err.Error(), "API key is required") {
+		t.Fatalf("expected missing key error, got %v", err)
+	}
+
+	t.Setenv("CRAWL4AI_API_KEY", "sk_test_default_env")
+	first, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := Default()
+	if first != second || first.http.apiKey != "sk_test_default_env" {
+		t.Fatal("built-in default should be created once from CRAWL4AI_API_KEY")
+	}
+}
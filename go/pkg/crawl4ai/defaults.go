@@ -0,0 +1,114 @@
+package crawl4ai
+
+import "encoding/json"
+
+// RunDefaults are the options a crawler made by WithDefaults gives every
+// Run, RunMany and RunAsync call.
+type RunDefaults struct {
+	Config        *CrawlerRunConfig
+	BrowserConfig *BrowserConfig
+	Strategy      string
+	Proxy         interface{}
+}
+
+// WithDefaults returns a crawler whose calls start from d: a call's Config
+// and BrowserConfig are laid over d's field by field, and its Strategy and
+// Proxy, when set, replace d's. The copy shares everything else with c;
+// calling WithDefaults on it again layers the new defaults on top.
+//
+//	stealth := crawler.WithDefaults(crawl4ai.RunDefaults{
+//	    BrowserConfig: &crawl4ai.BrowserConfig{UserAgentMode: "random"},
+//	    Config:        &crawl4ai.CrawlerRunConfig{Magic: true, PageTimeout: 60000},
+//	    Proxy:         "datacenter",
+//	})
+//	result, err := stealth.Run(url, &crawl4ai.RunOptions{Config: &crawl4ai.CrawlerRunConfig{Screenshot: true}})
+//	// crawls with magic, a 60s timeout, a screenshot, a random UA and the proxy
+//
+// Fields are merged through their JSON form, so a call can add or change a
+// default but not clear one back to its zero value, and maps such as
+// BrowserConfig.Headers are replaced as a whole.
+func (c *AsyncWebCrawler) WithDefaults(d RunDefaults) *AsyncWebCrawler {
+	out := *c
+	if c.defaults != nil {
+		d = RunDefaults{
+			Config:        overlayConfig(c.defaults.Config, d.Config),
+			BrowserConfig: overlayConfig(c.defaults.BrowserConfig, d.BrowserConfig),
+			Strategy:      firstNonEmpty(d.Strategy, c.defaults.Strategy),
+			Proxy:         d.Proxy,
+		}
+		if d.Proxy == nil {
+			d.Proxy = c.defaults.Proxy
+		}
+	}
+	out.defaults = &d
+	return &out
+}
+
+// applyRun returns opts with the defaults filled in; opts is not modified.
+func (d *RunDefaults) applyRun(opts *RunOptions) *RunOptions {
+	if d == nil {
+		return opts
+	}
+	o := *opts
+	o.Config = overlayConfig(d.Config, opts.Config)
+	o.BrowserConfig = overlayConfig(d.BrowserConfig, opts.BrowserConfig)
+	o.Strategy = firstNonEmpty(opts.Strategy, d.Strategy)
+	if o.Proxy == nil {
+		o.Proxy = d.Proxy
+	}
+	return &o
+}
+
+// applyMany is applyRun for RunMany and RunAsync.
+func (d *RunDefaults) applyMany(opts *RunManyOptions) *RunManyOptions {
+	if d == nil {
+		return opts
+	}
+	o := *opts
+	o.Config = overlayConfig(d.Config, opts.Config)
+	o.BrowserConfig = overlayConfig(d.BrowserConfig, opts.BrowserConfig)
+	o.Strategy = firstNonEmpty(opts.Strategy, d.Strategy)
+	if o.Proxy == nil {
+		o.Proxy = d.Proxy
+	}
+	return &o
+}
+
+// overlayConfig lays over's set (non-zero) fields over base's, via their
+// JSON form. Either may be nil; the result is a new value unless one is.
+func overlayConfig[T any](base, over *T) *T {
+	if base == nil {
+		return over
+	}
+	if over == nil {
+		return base
+	}
+	merged := map[string]interface{}{}
+	for _, v := range []*T{base, over} {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return over
+		}
+		if err := json.Unmarshal(raw, &merged); err != nil {
+			return over
+		}
+	}
+	raw, err := json.Marshal(merged)
+	if err != nil {
+		return over
+	}
+	var out T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return over
+	}
+	return &out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
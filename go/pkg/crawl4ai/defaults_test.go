@@ -0,0 +1,85 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestOverlayConfig(t *testing.T) {
+	base := &CrawlerRunConfig{Magic: true, PageTimeout: 60000, ExcludeDomains: []string{"ads.example.com"}}
+	got := overlayConfig(base, &CrawlerRunConfig{Screenshot: true, PageTimeout: 30000})
+	if !got.Magic || !got.Screenshot || got.PageTimeout != 30000 || len(got.ExcludeDomains) != 1 {
+		t.Errorf("overlay = %+v", got)
+	}
+	if base.Screenshot || base.PageTimeout != 60000 {
+		t.Error("overlay modified the base")
+	}
+	if overlayConfig(nil, base) != base || overlayConfig(base, nil) != base {
+		t.Error("a nil side should return the other")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestWithDefaults(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		if r.URL.Path == "/v1/crawl/async" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": body["url"], "success": true})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scoped := c.WithDefaults(RunDefaults{
+		Config:        &CrawlerRunConfig{Magic: true, PageTimeout: 60000},
+		BrowserConfig: &BrowserConfig{ViewportWidth: 1440},
+		Proxy:         "datacenter",
+	}).WithDefaults(RunDefaults{Config: &CrawlerRunConfig{WaitFor: "css:main"}})
+
+	if _, err := scoped.Run("https://example.com", &RunOptions{Config: &CrawlerRunConfig{Screenshot: true, PageTimeout: 5000}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scoped.RunAsync("https://example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Run("https://example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("%d requests", len(bodies))
+	}
+
+	cfg, _ := bodies[0]["crawler_config"].(map[string]interface{})
+	if cfg["magic"] != true || cfg["screenshot"] != true || cfg["wait_for"] != "css:main" || cfg["page_timeout"] != 5000.0 {
+		t.Errorf("run config = %v", cfg)
+	}
+	if bc, _ := bodies[0]["browser_config"].(map[string]interface{}); bc["viewport_width"] != 1440.0 {
+		t.Errorf("run browser config = %v", bodies[0]["browser_config"])
+	}
+	if bodies[0]["proxy"] == nil || bodies[1]["proxy"] == nil {
+		t.Errorf("default proxy not sent: %v / %v", bodies[0]["proxy"], bodies[1]["proxy"])
+	}
+	if cfg, _ := bodies[1]["crawler_config"].(map[string]interface{}); cfg["magic"] != true || cfg["page_timeout"] != 60000.0 {
+		t.Errorf("async config = %v", cfg)
+	}
+	if bodies[2]["crawler_config"] != nil || bodies[2]["proxy"] != nil {
+		t.Errorf("the parent crawler picked up the defaults: %v", bodies[2])
+	}
+}
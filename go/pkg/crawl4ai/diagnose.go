@@ -0,0 +1,197 @@
+package crawl4ai
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// diagnoseProbes is how many health checks Diagnose times.
+const diagnoseProbes = 10
+
+// Diagnosis is what Diagnose measured about the path to the API. Its
+// String form is meant for pasting into a bug report.
+type Diagnosis struct {
+	BaseURL string
+	// Protocol is the HTTP version the API negotiated, e.g. "HTTP/2.0".
+	Protocol string
+	// TLSVersion is e.g. "TLS 1.3"; empty over plain HTTP.
+	TLSVersion string
+	// TLSResumed reports whether a new connection resumed a TLS session.
+	TLSResumed bool
+	// DNSLookup is a fresh resolution of the API host, with the addresses
+	// it returned.
+	DNSLookup time.Duration
+	Addresses []string
+	// Connect and TLSHandshake time the first new connection a probe
+	// opened; both are zero when every probe reused a pooled connection.
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// Probes is how many health checks were sent; Reused of them went over
+	// an already open connection, Failed got an error.
+	Probes int
+	Reused int
+	Failed int
+	// LastError is the last probe failure.
+	LastError string
+	// Latency is the round trip of the successful probes, to the first
+	// response byte.
+	Latency LatencyStats
+	// Requests counts the client's calls since it was created, retries
+	// included, so a slow client can be told apart from one that retries.
+	Requests RequestStats
+}
+
+// LatencyStats summarises a set of round trips.
+type LatencyStats struct {
+	Min, P50, P90, P99, Max time.Duration
+}
+
+// Diagnose measures the connection to the API, for triaging "the SDK is
+// slow" reports: it resolves the host, sends a few health checks through
+// the crawler's own connection pool, and reports the negotiated protocol,
+// connection reuse, latency percentiles and the client's retry counts.
+// Errors reaching the API are recorded in the Diagnosis, not returned;
+// the error is only ctx's.
+func (c *AsyncWebCrawler) Diagnose(ctx context.Context) (*Diagnosis, error) {
+	d := &Diagnosis{BaseURL: c.http.baseURL}
+	if u, err := url.Parse(c.http.baseURL); err == nil {
+		started := time.Now()
+		addrs, err := net.DefaultResolver.LookupHost(ctx, u.Hostname())
+		d.DNSLookup = time.Since(started)
+		if err != nil {
+			d.LastError = fmt.Sprintf("DNS: %v", err)
+		}
+		d.Addresses = addrs
+	}
+
+	var rtts []time.Duration
+	for i := 0; i < diagnoseProbes; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		d.Probes++
+		rtt, err := c.probe(ctx, d)
+		if err != nil {
+			d.Failed++
+			d.LastError = err.Error()
+			continue
+		}
+		rtts = append(rtts, rtt)
+	}
+	d.Latency = latencyStats(rtts)
+	d.Requests = c.http.Stats()
+	return d, nil
+}
+
+// probe sends one health check, recording its connection in d, and returns
+// the time to the first response byte.
+func (c *AsyncWebCrawler) probe(ctx context.Context, d *Diagnosis) (time.Duration, error) {
+	var connectStart, tlsStart time.Time
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				d.Reused++
+			}
+		},
+		ConnectStart: func(string, string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil && d.Connect == 0 && !connectStart.IsZero() {
+				d.Connect = time.Since(connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			if err == nil && d.TLSHandshake == 0 && !tlsStart.IsZero() {
+				d.TLSHandshake = time.Since(tlsStart)
+				d.TLSResumed = state.DidResume
+			}
+		},
+	}
+	req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), "GET", c.http.baseURL+"/health", nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("X-API-Key", c.http.apiKey)
+	req.Header.Set("User-Agent", fmt.Sprintf("crawl4ai-cloud/%s", Version))
+	req.Header.Set(RequestIDHeader, newRequestID())
+
+	started := time.Now()
+	var firstByte time.Duration
+	trace.GotFirstResponseByte = func() { firstByte = time.Since(started) }
+	resp, err := c.http.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	d.Protocol = resp.Proto
+	if resp.TLS != nil {
+		d.TLSVersion = tls.VersionName(resp.TLS.Version)
+	}
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("health check: HTTP %d", resp.StatusCode)
+	}
+	if firstByte == 0 {
+		// Transports that don't trace, such as the sandbox.
+		firstByte = time.Since(started)
+	}
+	return firstByte, nil
+}
+
+// latencyStats computes nearest-rank percentiles of rtts.
+func latencyStats(rtts []time.Duration) LatencyStats {
+	if len(rtts) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), rtts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p int) time.Duration {
+		i := (p*len(sorted)+99)/100 - 1
+		return sorted[max(i, 0)]
+	}
+	return LatencyStats{Min: sorted[0], P50: rank(50), P90: rank(90), P99: rank(99), Max: sorted[len(sorted)-1]}
+}
+
+// String formats the diagnosis as a plain-text report.
+func (d *Diagnosis) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "API:        %s\n", d.BaseURL)
+	fmt.Fprintf(&b, "Protocol:   %s", orDash(d.Protocol))
+	if d.TLSVersion != "" {
+		fmt.Fprintf(&b, ", %s (resumed: %v)", d.TLSVersion, d.TLSResumed)
+	}
+	fmt.Fprintf(&b, "\nDNS:        %s -> %s\n", d.DNSLookup.Round(time.Microsecond), orDash(strings.Join(d.Addresses, ", ")))
+	fmt.Fprintf(&b, "Connect:    %s, TLS handshake %s\n", d.Connect.Round(time.Microsecond), d.TLSHandshake.Round(time.Microsecond))
+	fmt.Fprintf(&b, "Probes:     %d sent, %d reused a connection, %d failed\n", d.Probes, d.Reused, d.Failed)
+	l := d.Latency
+	fmt.Fprintf(&b, "Latency:    min %s, p50 %s, p90 %s, p99 %s, max %s\n",
+		l.Min.Round(time.Microsecond), l.P50.Round(time.Microsecond), l.P90.Round(time.Microsecond),
+		l.P99.Round(time.Microsecond), l.Max.Round(time.Microsecond))
+	r := d.Requests
+	fmt.Fprintf(&b, "Requests:   %d calls, %d attempts, %d retries (network %d, 5xx %d, read %d)\n",
+		r.Calls, r.Attempts, r.Retries(), r.NetworkRetries, r.ServerRetries, r.ReadRetries)
+	if d.LastError != "" {
+		fmt.Fprintf(&b, "Last error: %s\n", d.LastError)
+	}
+	return b.String()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package crawl4ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestLatencyStats(t *testing.T) {
+	var rtts []time.Duration
+	for i := 100; i >= 1; i-- {
+		rtts = append(rtts, time.Duration(i)*time.Millisecond)
+	}
+	got := latencyStats(rtts)
+	want := LatencyStats{Min: time.Millisecond, P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+	if got != want {
+		t.Errorf("latencyStats = %+v, want %+v", got, want)
+	}
+	if one := latencyStats([]time.Duration{time.Second}); one.P50 != time.Second || one.P99 != time.Second {
+		t.Errorf("single sample: %+v", one)
+	}
+	if (latencyStats(nil) != LatencyStats{}) {
+		t.Error("empty input should give zero stats")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestDiagnose(t *testing.T) {
+	failures := 1
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/crawl/jobs" && failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.http.client.Transport = srv.Client().Transport
+	retrySleep = func(time.Duration) {}
+	defer func() { retrySleep = time.Sleep }()
+	if _, err := c.http.Get("/v1/crawl/jobs", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := c.Diagnose(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Protocol != "HTTP/2.0" || d.TLSVersion != "TLS 1.3" {
+		t.Errorf("protocol = %q, %q", d.Protocol, d.TLSVersion)
+	}
+	if d.Probes != diagnoseProbes || d.Failed != 0 || d.Reused != diagnoseProbes {
+		t.Errorf("probes = %d, reused = %d, failed = %d (%s)", d.Probes, d.Reused, d.Failed, d.LastError)
+	}
+	if d.Latency.Min <= 0 || d.Latency.Max < d.Latency.P50 {
+		t.Errorf("latency = %+v", d.Latency)
+	}
+	if r := d.Requests; r.Calls != 1 || r.Attempts != 2 || r.ServerRetries != 1 || r.Retries() != 1 {
+		t.Errorf("requests = %+v", r)
+	}
+	for _, want := range []string{"HTTP/2.0", "p50", "1 retries (network 0, 5xx 1, read 0)"} {
+		if !strings.Contains(d.String(), want) {
+			t.Errorf("report lacks %q:\n%s", want, d)
+		}
+	}
+
+	// A failing API is reported, not returned.
+	srv.Close()
+	d, err = c.Diagnose(context.Background())
+	if err != nil || d.Failed != diagnoseProbes || d.LastError == "" {
+		t.Errorf("after close: %+v, %v", d, err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Diagnose(ctx); err == nil {
+		t.Error("expected the context's error")
+	}
+}
@@ -0,0 +1,218 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DomainProfile is what the crawler has learned about one domain.
+type DomainProfile struct {
+	Domain string `json:"domain"`
+	// Strategy and Proxy are the cheapest settings that last produced a
+	// good result ("" Proxy means direct). Empty Strategy means nothing
+	// has worked yet.
+	Strategy string `json:"strategy,omitempty"`
+	Proxy    string `json:"proxy,omitempty"`
+
+	Runs      int `json:"runs"`
+	Successes int `json:"successes"`
+	Blocks    int `json:"blocks"`
+	// AvgLatencyMs is the mean crawl duration of successful runs.
+	AvgLatencyMs float64   `json:"avg_latency_ms"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// BlockRate is the fraction of runs that were blocked (see BlockInfo).
+func (p DomainProfile) BlockRate() float64 {
+	if p.Runs == 0 {
+		return 0
+	}
+	return float64(p.Blocks) / float64(p.Runs)
+}
+
+// DomainProfiles learns per-domain crawl settings from outcomes. Set it as
+// CrawlerOptions.DomainProfiles and Run/RunMany record every result into
+// it and, when the call leaves Strategy and Proxy unset, use the domain's
+// learned settings instead of the defaults. Export and Import let a fleet
+// of workers share what each has learned.
+//
+//	profiles := crawl4ai.NewDomainProfiles()
+//	if f, err := os.Open("profiles.json"); err == nil {
+//	    profiles.Import(f)
+//	    f.Close()
+//	}
+//	crawler, _ := crawl4ai.NewAsyncWebCrawler(crawl4ai.CrawlerOptions{DomainProfiles: profiles})
+//
+// It is safe for concurrent use.
+type DomainProfiles struct {
+	mu       sync.Mutex
+	profiles map[string]*DomainProfile
+}
+
+// NewDomainProfiles returns an empty store.
+func NewDomainProfiles() *DomainProfiles {
+	return &DomainProfiles{profiles: map[string]*DomainProfile{}}
+}
+
+// Get returns the profile for rawURL's domain (a URL or bare host).
+func (d *DomainProfiles) Get(rawURL string) (DomainProfile, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p, ok := d.profiles[profileDomain(rawURL)]
+	if !ok {
+		return DomainProfile{}, false
+	}
+	return *p, true
+}
+
+// All returns every profile, sorted by domain.
+func (d *DomainProfiles) All() []DomainProfile {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]DomainProfile, 0, len(d.profiles))
+	for _, p := range d.profiles {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Domain < out[j].Domain })
+	return out
+}
+
+// Record folds one crawl outcome into the result's domain profile.
+// strategy and proxy are the settings the crawl ran with; proxy is a mode
+// string, ProxyConfig or proxy map as accepted by RunOptions.Proxy.
+func (d *DomainProfiles) Record(r *CrawlResult, strategy string, proxy interface{}) {
+	domain := profileDomain(r.URL)
+	if domain == "" {
+		return
+	}
+	mode := proxyMode(proxy)
+	good := r.Success && !r.Blocked() && r.StatusCode < 400
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p := d.profiles[domain]
+	if p == nil {
+		p = &DomainProfile{Domain: domain}
+		d.profiles[domain] = p
+	}
+	p.Runs++
+	p.UpdatedAt = time.Now().UTC()
+	if r.Blocked() {
+		p.Blocks++
+	}
+	if !good {
+		if p.Strategy == strategy && p.Proxy == mode {
+			// The learned settings stopped working; relearn.
+			p.Strategy, p.Proxy = "", ""
+		}
+		return
+	}
+	p.AvgLatencyMs = (p.AvgLatencyMs*float64(p.Successes) + float64(r.DurationMs)) / float64(p.Successes+1)
+	p.Successes++
+	if p.Strategy == "" || settingsCost(strategy, mode) < settingsCost(p.Strategy, p.Proxy) {
+		p.Strategy, p.Proxy = strategy, mode
+	}
+}
+
+// Export writes every profile as a JSON array.
+func (d *DomainProfiles) Export(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d.All())
+}
+
+// Import merges profiles written by Export. For a domain present in both,
+// the more recently updated profile wins.
+func (d *DomainProfiles) Import(r io.Reader) error {
+	var in []DomainProfile
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return fmt.Errorf("import domain profiles: %w", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i := range in {
+		p := in[i]
+		p.Domain = profileDomain(p.Domain)
+		if p.Domain == "" {
+			continue
+		}
+		if cur, ok := d.profiles[p.Domain]; ok && !p.UpdatedAt.After(cur.UpdatedAt) {
+			continue
+		}
+		d.profiles[p.Domain] = &p
+	}
+	return nil
+}
+
+// defaults returns the learned strategy and proxy for rawURL, if any.
+func (d *DomainProfiles) defaults(rawURL string) (string, interface{}, bool) {
+	p, ok := d.Get(rawURL)
+	if !ok || p.Strategy == "" {
+		return "", nil, false
+	}
+	var proxy interface{}
+	if p.Proxy != "" {
+		proxy = p.Proxy
+	}
+	return p.Strategy, proxy, true
+}
+
+// sharedDefaults returns learned settings for urls when they all belong
+// to one profiled domain.
+func (d *DomainProfiles) sharedDefaults(urls []string) (string, interface{}, bool) {
+	if len(urls) == 0 {
+		return "", nil, false
+	}
+	domain := profileDomain(urls[0])
+	for _, u := range urls[1:] {
+		if profileDomain(u) != domain {
+			return "", nil, false
+		}
+	}
+	return d.defaults(urls[0])
+}
+
+// profileDomain reduces a URL or host to its lowercased host without
+// "www.".
+func profileDomain(raw string) string {
+	host := raw
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return ""
+		}
+		host = u.Hostname()
+	}
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+func proxyMode(proxy interface{}) string {
+	m, err := NormalizeProxy(proxy)
+	if err != nil || m == nil {
+		return ""
+	}
+	mode, _ := m["mode"].(string)
+	return mode
+}
+
+// settingsCost ranks strategy/proxy pairs from cheapest to dearest.
+func settingsCost(strategy, proxy string) int {
+	cost := 0
+	if strategy != "http" {
+		cost += 10
+	}
+	switch proxy {
+	case "", "none":
+	case "residential":
+		cost += 2
+	default:
+		cost++
+	}
+	return cost
+}
@@ -0,0 +1,97 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestDomainProfiles_Learning(t *testing.T) {
+	d := NewDomainProfiles()
+	ok := &CrawlResult{URL: "https://www.Shop.com/a", Success: true, StatusCode: 200, DurationMs: 300}
+	blocked := &CrawlResult{URL: "https://shop.com/b", Success: true, StatusCode: 403, BlockInfo: &BlockInfo{Kind: BlockBlocked}}
+
+	d.Record(ok, "browser", "residential")
+	d.Record(&CrawlResult{URL: "https://shop.com/c", Success: true, StatusCode: 200, DurationMs: 100}, "browser", "datacenter")
+	p, found := d.Get("shop.com")
+	if !found || p.Strategy != "browser" || p.Proxy != "datacenter" || p.AvgLatencyMs != 200 {
+		t.Fatalf("expected cheaper datacenter setting learned, got %+v", p)
+	}
+
+	// A more expensive success doesn't replace a cheaper working setting.
+	d.Record(ok, "browser", "residential")
+	if p, _ = d.Get("shop.com"); p.Proxy != "datacenter" {
+		t.Fatalf("expected datacenter kept, got %+v", p)
+	}
+
+	// The learned setting getting blocked forgets it.
+	d.Record(blocked, "browser", &ProxyConfig{Mode: "datacenter"})
+	if p, _ = d.Get("https://shop.com/"); p.Strategy != "" || p.Blocks != 1 || p.Runs != 4 || p.BlockRate() != 0.25 {
+		t.Fatalf("expected learned setting cleared after block, got %+v", p)
+	}
+}
+
+func TestDomainProfiles_ExportImport(t *testing.T) {
+	old := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewDomainProfiles()
+	a.Record(&CrawlResult{URL: "https://a.com", Success: true, StatusCode: 200}, "http", nil)
+
+	b := NewDomainProfiles()
+	b.profiles["a.com"] = &DomainProfile{Domain: "a.com", Strategy: "browser", UpdatedAt: old}
+	b.profiles["b.com"] = &DomainProfile{Domain: "b.com", Strategy: "browser", UpdatedAt: old}
+
+	var buf bytes.Buffer
+	if err := a.Export(&buf); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if err := b.Import(&buf); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	all := b.All()
+	if len(all) != 2 || all[0].Strategy != "http" || all[1].Domain != "b.com" {
+		t.Fatalf("expected newer a.com to win and b.com kept, got %+v", all)
+	}
+	if err := b.Import(bytes.NewBufferString("{")); err == nil {
+		t.Fatal("expected error for malformed input")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRun_UsesDomainProfile(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://a.com/x", "success": true, "status_code": 200})
+	}))
+	defer srv.Close()
+	profiles := NewDomainProfiles()
+	profiles.Record(&CrawlResult{URL: "https://a.com", Success: true, StatusCode: 200}, "http", "datacenter")
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, DomainProfiles: profiles})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Run("https://a.com/x", nil); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if proxy, _ := body["proxy"].(map[string]interface{}); body["strategy"] != "http" || proxy["mode"] != "datacenter" {
+		t.Fatalf("expected learned defaults, got %v", body)
+	}
+	if p, _ := profiles.Get("a.com"); p.Runs != 2 {
+		t.Fatalf("expected the run recorded, got %+v", p)
+	}
+
+	if _, err := c.Run("https://a.com/x", &RunOptions{Strategy: "browser"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if body["strategy"] != "browser" || body["proxy"] != nil {
+		t.Fatalf("explicit options should bypass the profile, got %v", body)
+	}
+}
@@ -0,0 +1,293 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultChunkSize and DefaultChunkOverlap, in characters, are the
+// chunking defaults for embeddings and retrieval.
+const (
+	DefaultChunkSize    = 2000
+	DefaultChunkOverlap = 200
+)
+
+// Embedder turns texts into vectors, one per text, in order.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// OpenAIEmbedder calls an OpenAI-compatible POST /embeddings endpoint:
+// OpenAI itself, or a local server such as Ollama, vLLM or LM Studio.
+//
+//	local := &crawl4ai.OpenAIEmbedder{BaseURL: "http://localhost:11434/v1", Model: "nomic-embed-text"}
+type OpenAIEmbedder struct {
+	// BaseURL defaults to https://api.openai.com/v1.
+	BaseURL string
+	// APIKey is sent as a bearer token; defaults to $OPENAI_API_KEY. Local
+	// servers usually need none.
+	APIKey string
+	// Model defaults to text-embedding-3-small.
+	Model string
+	// Dimensions requests shortened vectors from models that support it.
+	Dimensions int
+	HTTPClient *http.Client
+}
+
+// Embed implements Embedder.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	base := e.BaseURL
+	if base == "" {
+		base = "https://api.openai.com/v1"
+	}
+	model := e.Model
+	if model == "" {
+		model = "text-embedding-3-small"
+	}
+	key := e.APIKey
+	if key == "" {
+		key = os.Getenv("OPENAI_API_KEY")
+	}
+	client := e.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	payload := map[string]interface{}{"model": model, "input": texts}
+	if e.Dimensions > 0 {
+		payload["dimensions"] = e.Dimensions
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(base, "/")+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings: %w", err)
+	}
+	var out struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	decodeErr := json.Unmarshal(raw, &out)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if decodeErr == nil && out.Error != nil && out.Error.Message != "" {
+			return nil, fmt.Errorf("embeddings: HTTP %d: %s", resp.StatusCode, out.Error.Message)
+		}
+		return nil, fmt.Errorf("embeddings: HTTP %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("embeddings: decode response: %w", decodeErr)
+	}
+	vectors := make([][]float32, len(texts))
+	for _, d := range out.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embeddings: response index %d out of range", d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	for i, v := range vectors {
+		if v == nil {
+			return nil, fmt.Errorf("embeddings: no vector for input %d", i)
+		}
+	}
+	return vectors, nil
+}
+
+// Chunk is one piece of a result's markdown and, once embedded, its vector.
+type Chunk struct {
+	URL       string    `json:"url"`
+	Index     int       `json:"index"`
+	Text      string    `json:"text"`
+	Embedding []float32 `json:"embedding,omitempty"`
+}
+
+// EmbedOptions configures CrawlResult.Embed and CrawlAndEmbed.
+type EmbedOptions struct {
+	// Embedder is required.
+	Embedder Embedder
+	// ChunkSize and ChunkOverlap are in characters. Defaults
+	// DefaultChunkSize and DefaultChunkOverlap; a negative overlap turns
+	// it off.
+	ChunkSize    int
+	ChunkOverlap int
+	// BatchSize caps the texts per Embed call. Default 64.
+	BatchSize int
+	// Sink, when set, receives every embedded chunk as soon as its batch
+	// returns — write them to a vector store here. Chunks are then not kept
+	// on the result. An error stops embedding.
+	Sink func(Chunk) error
+}
+
+// ChunkMarkdown splits markdown into chunks of at most size characters,
+// breaking between paragraphs where possible. Each chunk after the first
+// starts with up to overlap characters from the end of the previous one,
+// when they fit, so a sentence cut at a boundary is seen whole at least
+// once. Paragraphs longer than size are cut hard.
+func ChunkMarkdown(md string, size, overlap int) []string {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+	var pieces [][]rune
+	for _, p := range strings.Split(md, "\n\n") {
+		r := []rune(strings.TrimSpace(p))
+		for len(r) > size {
+			pieces = append(pieces, r[:size])
+			r = r[size:]
+		}
+		if len(r) > 0 {
+			pieces = append(pieces, r)
+		}
+	}
+
+	var chunks []string
+	var cur []rune
+	for _, p := range pieces {
+		if len(cur) > 0 && len(cur)+2+len(p) > size {
+			chunks = append(chunks, string(cur))
+			tail := cur[len(cur)-min(overlap, len(cur)):]
+			cur = nil
+			if len(tail) > 0 && len(tail)+2+len(p) <= size {
+				cur = append(cur, tail...)
+			}
+		}
+		if len(cur) > 0 {
+			cur = append(cur, '\n', '\n')
+		}
+		cur = append(cur, p...)
+	}
+	if len(cur) > 0 {
+		chunks = append(chunks, string(cur))
+	}
+	return chunks
+}
+
+// embedText is the markdown embeddings and retrieval work on: FitMarkdown,
+// falling back to RawMarkdown when no content filter ran.
+func embedText(r *CrawlResult) string {
+	if r.Markdown == nil {
+		return ""
+	}
+	if r.Markdown.FitMarkdown != "" {
+		return r.Markdown.FitMarkdown
+	}
+	return r.Markdown.RawMarkdown
+}
+
+// resultChunks splits r's markdown into un-embedded chunks.
+func resultChunks(r *CrawlResult, size, overlap int) []Chunk {
+	if overlap == 0 {
+		overlap = DefaultChunkOverlap
+	}
+	texts := ChunkMarkdown(embedText(r), size, overlap)
+	chunks := make([]Chunk, len(texts))
+	for i, t := range texts {
+		chunks[i] = Chunk{URL: r.URL, Index: i, Text: t}
+	}
+	return chunks
+}
+
+// Embed chunks the result's FitMarkdown (RawMarkdown when empty) and embeds
+// every chunk. The chunks are returned and, unless opts.Sink is set,
+// stored in r.Chunks.
+//
+//	chunks, err := result.Embed(ctx, crawl4ai.EmbedOptions{Embedder: &crawl4ai.OpenAIEmbedder{}})
+func (r *CrawlResult) Embed(ctx context.Context, opts EmbedOptions) ([]Chunk, error) {
+	if opts.Embedder == nil {
+		return nil, fmt.Errorf("embed: Embedder is required")
+	}
+	batch := opts.BatchSize
+	if batch <= 0 {
+		batch = 64
+	}
+	chunks := resultChunks(r, opts.ChunkSize, opts.ChunkOverlap)
+	for start := 0; start < len(chunks); start += batch {
+		end := min(start+batch, len(chunks))
+		texts := make([]string, 0, end-start)
+		for _, ch := range chunks[start:end] {
+			texts = append(texts, ch.Text)
+		}
+		vectors, err := opts.Embedder.Embed(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("embed %s: %w", r.URL, err)
+		}
+		if len(vectors) != len(texts) {
+			return nil, fmt.Errorf("embed %s: got %d vectors for %d chunks", r.URL, len(vectors), len(texts))
+		}
+		for i, v := range vectors {
+			chunks[start+i].Embedding = v
+			if opts.Sink != nil {
+				if err := opts.Sink(chunks[start+i]); err != nil {
+					return nil, fmt.Errorf("embed %s: sink: %w", r.URL, err)
+				}
+			}
+		}
+	}
+	if opts.Sink == nil {
+		r.Chunks = chunks
+	}
+	return chunks, nil
+}
+
+// CrawlAndEmbed crawls urls as one job, waits for it and embeds every
+// successful result — crawl, embed and store in one call. Failed pages are
+// skipped. On an embedding error the crawl result is returned with it.
+//
+//	res, err := crawler.CrawlAndEmbed(ctx, urls, nil, crawl4ai.EmbedOptions{
+//	    Embedder: &crawl4ai.OpenAIEmbedder{},
+//	    Sink:     func(ch crawl4ai.Chunk) error { return store.Upsert(ch) },
+//	})
+func (c *AsyncWebCrawler) CrawlAndEmbed(ctx context.Context, urls []string, opts *RunManyOptions, embed EmbedOptions) (*RunManyResult, error) {
+	if embed.Embedder == nil {
+		return nil, fmt.Errorf("embed: Embedder is required")
+	}
+	o := RunManyOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	o.Wait = true
+	res, err := c.WithContext(ctx).RunMany(urls, &o)
+	if err != nil {
+		return nil, err
+	}
+	done := map[*CrawlResult]bool{}
+	for _, r := range res.Results {
+		// With AlignResults a duplicate URL shares its original's result.
+		if r == nil || !r.Success || done[r] {
+			continue
+		}
+		done[r] = true
+		if _, err := r.Embed(ctx, embed); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,177 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+// lenEmbedder embeds each text as [len(text)] and counts its calls.
+type lenEmbedder struct {
+	calls int
+}
+
+func (e *lenEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.calls++
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		out[i] = []float32{float32(len(t))}
+	}
+	return out, nil
+}
+
+func TestChunkMarkdown_PacksParagraphs(t *testing.T) {
+	md := "aaaa\n\nbbbb\n\ncccc\n\n\n\ndddd"
+	got := ChunkMarkdown(md, 10, 0)
+	want := []string{"aaaa\n\nbbbb", "cccc\n\ndddd"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("chunks = %q, want %q", got, want)
+	}
+}
+
+func TestChunkMarkdown_Overlap(t *testing.T) {
+	got := ChunkMarkdown("aaaaaa\n\nbbbbbb", 10, 2)
+	want := []string{"aaaaaa", "aa\n\nbbbbbb"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("chunks = %q, want %q", got, want)
+	}
+}
+
+func TestChunkMarkdown_CutsLongParagraphs(t *testing.T) {
+	got := ChunkMarkdown(strings.Repeat("é", 25), 10, 0)
+	if len(got) != 3 || len([]rune(got[0])) != 10 || len([]rune(got[2])) != 5 {
+		t.Errorf("chunks = %q", got)
+	}
+	if ChunkMarkdown("  \n\n ", 10, 0) != nil {
+		t.Error("blank markdown produced chunks")
+	}
+}
+
+func TestCrawlResultEmbed_AttachesChunks(t *testing.T) {
+	r := &CrawlResult{URL: "https://a.com", Markdown: &MarkdownResult{
+		RawMarkdown: "raw", FitMarkdown: "one\n\ntwo\n\nthree",
+	}}
+	e := &lenEmbedder{}
+	chunks, err := r.Embed(context.Background(), EmbedOptions{Embedder: e, ChunkSize: 5, ChunkOverlap: -1, BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) != 3 || e.calls != 2 {
+		t.Fatalf("chunks = %d, calls = %d", len(chunks), e.calls)
+	}
+	if chunks[2].Text != "three" || chunks[2].Index != 2 || chunks[2].URL != "https://a.com" || chunks[2].Embedding[0] != 5 {
+		t.Errorf("chunk = %+v", chunks[2])
+	}
+	if len(r.Chunks) != 3 {
+		t.Errorf("r.Chunks = %d", len(r.Chunks))
+	}
+}
+
+func TestCrawlResultEmbed_SinkStreams(t *testing.T) {
+	r := &CrawlResult{URL: "u", Markdown: &MarkdownResult{RawMarkdown: "a\n\nb"}}
+	var got []Chunk
+	_, err := r.Embed(context.Background(), EmbedOptions{
+		Embedder: &lenEmbedder{}, ChunkSize: 1, ChunkOverlap: -1,
+		Sink: func(ch Chunk) error { got = append(got, ch); return nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || r.Chunks != nil {
+		t.Errorf("sunk %d chunks, r.Chunks = %v", len(got), r.Chunks)
+	}
+
+	boom := errors.New("store down")
+	_, err = r.Embed(context.Background(), EmbedOptions{Embedder: &lenEmbedder{}, Sink: func(Chunk) error { return boom }})
+	if !errors.Is(err, boom) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+func TestOpenAIEmbedder_Request(t *testing.T) {
+	var body map[string]interface{}
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		// Out of order on purpose: vectors are placed by index.
+		_, _ = w.Write([]byte(`{"data":[{"index":1,"embedding":[0.5]},{"index":0,"embedding":[0.25,1]}]}`))
+	}))
+	defer srv.Close()
+
+	e := &OpenAIEmbedder{BaseURL: srv.URL + "/v1/", APIKey: "sk-x", Model: "m", Dimensions: 2}
+	vecs, err := e.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vecs) != 2 || vecs[0][0] != 0.25 || vecs[1][0] != 0.5 {
+		t.Errorf("vectors = %v", vecs)
+	}
+	if auth != "Bearer sk-x" || body["model"] != "m" || body["dimensions"] != float64(2) {
+		t.Errorf("auth = %q body = %v", auth, body)
+	}
+}
+
+func TestOpenAIEmbedder_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"index":0,"embedding":[1]}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad key"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := (&OpenAIEmbedder{BaseURL: srv.URL, APIKey: "bad"}).Embed(context.Background(), []string{"a"})
+	if err == nil || err.Error() != "embeddings: HTTP 401: bad key" {
+		t.Errorf("err = %v", err)
+	}
+	t.Setenv("OPENAI_API_KEY", "")
+	_, err = (&OpenAIEmbedder{BaseURL: srv.URL}).Embed(context.Background(), []string{"a", "b"})
+	if err == nil || err.Error() != "embeddings: no vector for input 1" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// ─── Unit tests (in-process sandbox) ──────────────────────────────────────
+
+func TestCrawlAndEmbed(t *testing.T) {
+	c := newSandboxCrawler(t)
+	e := &lenEmbedder{}
+	res, err := c.CrawlAndEmbed(context.Background(),
+		[]string{"https://example.com/a", "https://example.com/b", "https://blocked.invalid/"}, nil,
+		EmbedOptions{Embedder: e})
+	if err != nil {
+		t.Fatal(err)
+	}
+	embedded := 0
+	for _, r := range res.Results {
+		if r.Success && len(r.Chunks) == 0 {
+			t.Errorf("%s: no chunks", r.URL)
+		}
+		if !r.Success && r.Chunks != nil {
+			t.Errorf("%s: failed page was embedded", r.URL)
+		}
+		if len(r.Chunks) > 0 {
+			embedded++
+		}
+	}
+	if embedded != 2 {
+		t.Errorf("embedded %d results, want 2", embedded)
+	}
+	if _, err := c.CrawlAndEmbed(context.Background(), []string{"https://example.com"}, nil, EmbedOptions{}); err == nil {
+		t.Error("missing Embedder accepted")
+	}
+}
@@ -0,0 +1,87 @@
+package crawl4ai
+
+import "strings"
+
+// Machine-readable error codes carried by CloudError.Code. The server's
+// own code is used when the response has one; otherwise the SDK infers one
+// of these from the status and message. Codes not listed here may appear
+// as the API grows.
+const (
+	ErrCodeInvalidAPIKey        = "invalid_api_key"
+	ErrCodeRateLimited          = "rate_limited"
+	ErrCodeQuotaExceeded        = "quota_exceeded"
+	ErrCodeStorageQuotaExceeded = "storage_quota_exceeded"
+	ErrCodeInsufficientCredits  = "insufficient_credits"
+	ErrCodeNotFound             = "not_found"
+	ErrCodeValidation           = "validation_error"
+	ErrCodeInvalidSelector      = "invalid_selector"
+	ErrCodeTimeout              = "timeout"
+	ErrCodeServerError          = "server_error"
+	ErrCodeSandboxUnsupported   = "sandbox_unsupported"
+)
+
+// remediations maps codes to the next step a user should take.
+var remediations = map[string]string{
+	ErrCodeInvalidAPIKey:        "Check the API key: it must start with sk_live_ or sk_test_ and not be revoked. Set CRAWL4AI_API_KEY or CrawlerOptions.APIKey.",
+	ErrCodeRateLimited:          "Slow down: retry after RateLimitError.RetryAfter() seconds, or use a Scheduler to pace requests.",
+	ErrCodeQuotaExceeded:        "The plan's usage quota is used up. Wait for the quota window to reset or upgrade the plan.",
+	ErrCodeStorageQuotaExceeded: "Stored results fill the storage quota. Delete old jobs (see RetentionPolicy) or pass QuotaCheck to fail before submitting.",
+	ErrCodeInsufficientCredits:  "The account has too few credits for this request. Top up, or price the job first with Estimate.",
+	ErrCodeNotFound:             "The job or resource doesn't exist or has expired. Check the ID and the result retention period.",
+	ErrCodeValidation:           "The request was rejected as invalid. See ValidationError.Fields for the rejected keys.",
+	ErrCodeInvalidSelector:      "A CSS selector or wait condition in the config doesn't parse. Check CrawlerRunConfig selectors and WaitFor.",
+	ErrCodeTimeout:              "The request took too long. Raise RunOptions.Timeout, or submit with RunAsync/RunMany and poll.",
+	ErrCodeServerError:          "The API failed internally. Retry later and quote the request ID if it persists.",
+	ErrCodeSandboxUnsupported:   "Sandbox mode only simulates the crawl and job endpoints. Use a live key without Sandbox for this call.",
+}
+
+// Remediation returns a short hint on how to fix the error, or "" for
+// codes without one.
+func (e *CloudError) Remediation() string {
+	return remediations[e.Code]
+}
+
+// errorCode reads the server's error code from a response — top-level
+// "code"/"error_code", or "code" inside a "detail" or "error" object —
+// and otherwise infers one from the status and message.
+func errorCode(message string, status int, response map[string]interface{}) string {
+	for _, key := range []string{"code", "error_code"} {
+		if v, ok := response[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	for _, key := range []string{"detail", "error"} {
+		if m, ok := response[key].(map[string]interface{}); ok {
+			if v, ok := m["code"].(string); ok && v != "" {
+				return v
+			}
+		}
+	}
+
+	lower := strings.ToLower(message)
+	switch {
+	case status == 401:
+		return ErrCodeInvalidAPIKey
+	case status == 402:
+		return ErrCodeInsufficientCredits
+	case status == 404:
+		return ErrCodeNotFound
+	case status == 429 && strings.Contains(lower, "rate limit"):
+		return ErrCodeRateLimited
+	case status == 429 && strings.Contains(lower, "storage"):
+		return ErrCodeStorageQuotaExceeded
+	case status == 429 && strings.Contains(lower, "credit"):
+		return ErrCodeInsufficientCredits
+	case status == 429:
+		return ErrCodeQuotaExceeded
+	case (status == 400 || status == 422) && strings.Contains(lower, "selector"):
+		return ErrCodeInvalidSelector
+	case status == 400 || status == 422:
+		return ErrCodeValidation
+	case status == 504:
+		return ErrCodeTimeout
+	case status >= 500:
+		return ErrCodeServerError
+	}
+	return ""
+}
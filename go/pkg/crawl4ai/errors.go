@@ -1,7 +1,11 @@
 // Package crawl4ai provides a Go SDK for Crawl4AI Cloud API
 package crawl4ai
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // CloudError is the base error type for all API errors.
 type CloudError struct {
@@ -9,13 +13,37 @@ type CloudError struct {
 	StatusCode int
 	Response   map[string]interface{}
 	Headers    map[string]string
+	// Code is a machine-readable error code (see the ErrCode* constants)
+	// to branch on instead of Message; Remediation suggests a fix.
+	Code string
+	// RequestID identifies the failed call for support: the ID the server
+	// echoed, or the one the SDK sent.
+	RequestID string
 }
 
 func (e *CloudError) Error() string {
+	msg := e.Message
 	if e.StatusCode > 0 {
-		return fmt.Sprintf("[%d] %s", e.StatusCode, e.Message)
+		msg = fmt.Sprintf("[%d] %s", e.StatusCode, e.Message)
 	}
-	return e.Message
+	if e.RequestID != "" {
+		msg += " (request_id: " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// cloudError lets withRequestID reach the CloudError embedded in every
+// typed error.
+func (e *CloudError) cloudError() *CloudError { return e }
+
+// AsCloudError returns the CloudError in err's chain: a *CloudError or any
+// of the typed errors embedding one.
+func AsCloudError(err error) (*CloudError, bool) {
+	var ce interface{ cloudError() *CloudError }
+	if errors.As(err, &ce) {
+		return ce.cloudError(), true
+	}
+	return nil, false
 }
 
 // NewCloudError creates a new CloudError.
@@ -27,6 +55,7 @@ func NewCloudError(message string, statusCode int, response map[string]interface
 		headers = make(map[string]string)
 	}
 	return &CloudError{
+		Code:       errorCode(message, statusCode, response),
 		Message:    message,
 		StatusCode: statusCode,
 		Response:   response,
@@ -92,21 +121,81 @@ func NewNotFoundError(message string, response map[string]interface{}, headers m
 	}
 }
 
-// ValidationError represents a 400 error.
+// ValidationError represents a 400 or 422 error.
 type ValidationError struct {
 	*CloudError
+	// Fields lists the rejected request fields when the API returned
+	// field-level details.
+	Fields []FieldError
 }
 
-// NewValidationError creates a new ValidationError.
+// FieldError is one field-level validation failure.
+type FieldError struct {
+	// Field is the dotted path of the rejected key, e.g.
+	// "crawler_config.word_count_threshold".
+	Field   string
+	Message string
+	Type    string
+}
+
+func (f FieldError) String() string {
+	if f.Field == "" {
+		return f.Message
+	}
+	return f.Field + ": " + f.Message
+}
+
+// NewValidationError creates a new 400 ValidationError, parsing
+// field-level details from the response's "detail" list when present.
 func NewValidationError(message string, response map[string]interface{}, headers map[string]string) *ValidationError {
+	return NewValidationErrorWithStatus(message, 400, response, headers)
+}
+
+// NewValidationErrorWithStatus is NewValidationError for a 400 or 422
+// response, so Code is derived from the status the API actually sent.
+func NewValidationErrorWithStatus(message string, statusCode int, response map[string]interface{}, headers map[string]string) *ValidationError {
 	return &ValidationError{
-		CloudError: NewCloudError(message, 400, response, headers),
+		CloudError: NewCloudError(message, statusCode, response, headers),
+		Fields:     parseFieldErrors(response["detail"]),
+	}
+}
+
+// parseFieldErrors reads FastAPI-style validation details:
+// [{"loc": ["body", "crawler_config", "x"], "msg": "...", "type": "..."}].
+func parseFieldErrors(detail interface{}) []FieldError {
+	items, ok := detail.([]interface{})
+	if !ok {
+		return nil
+	}
+	var fields []FieldError
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var path []string
+		if loc, ok := m["loc"].([]interface{}); ok {
+			for i, p := range loc {
+				if i == 0 && (p == "body" || p == "query" || p == "path") {
+					continue
+				}
+				path = append(path, fmt.Sprint(p))
+			}
+		}
+		msg, _ := m["msg"].(string)
+		typ, _ := m["type"].(string)
+		fields = append(fields, FieldError{Field: strings.Join(path, "."), Message: msg, Type: typ})
 	}
+	return fields
 }
 
 // TimeoutError represents a timeout error.
 type TimeoutError struct {
 	*CloudError
+	// Job is the last snapshot fetched when waiting for a job timed out,
+	// so the caller can keep waiting, cancel it or hand it off; nil for
+	// other timeouts. WaitJob also returns it alongside the error.
+	Job *CrawlJob
 }
 
 // NewTimeoutError creates a new TimeoutError.
@@ -0,0 +1,143 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestValidationError_ParsesFieldDetails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"detail": [
+			{"loc": ["body", "crawler_config", "word_count_threshold"], "msg": "Input should be a valid integer", "type": "int_parsing"},
+			{"loc": ["body", "urls", 0], "msg": "Field required", "type": "missing"}
+		]}`))
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Run("https://a.com", nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+	if verr.StatusCode != 422 || len(verr.Fields) != 2 {
+		t.Fatalf("unexpected error: status %d fields %+v", verr.StatusCode, verr.Fields)
+	}
+	if f := verr.Fields[0]; f.Field != "crawler_config.word_count_threshold" || f.Type != "int_parsing" {
+		t.Fatalf("unexpected field: %+v", f)
+	}
+	if verr.Fields[1].Field != "urls.0" {
+		t.Fatalf("unexpected field: %+v", verr.Fields[1])
+	}
+	if !strings.Contains(err.Error(), "crawler_config.word_count_threshold: Input should be a valid integer") {
+		t.Fatalf("message should name the rejected key: %v", err)
+	}
+	if want := errorCode(verr.Message, 422, verr.Response); verr.Code != want {
+		t.Fatalf("Code = %q, want %q for a 422", verr.Code, want)
+	}
+}
+
+func TestValidationError_StringDetail(t *testing.T) {
+	verr := NewValidationError("bad url", map[string]interface{}{"detail": "bad url"}, nil)
+	if verr.Fields != nil || verr.Error() != "[400] bad url" {
+		t.Fatalf("unexpected: %+v %q", verr.Fields, verr.Error())
+	}
+}
+
+func TestValidationError_WithStatus(t *testing.T) {
+	verr := NewValidationErrorWithStatus("invalid selector", 422, map[string]interface{}{"detail": "invalid selector"}, nil)
+	if verr.StatusCode != 422 || verr.Code != ErrCodeInvalidSelector || verr.Error() != "[422] invalid selector" {
+		t.Fatalf("unexpected: %d %q %q", verr.StatusCode, verr.Code, verr.Error())
+	}
+}
+
+func TestErrorCode_FromResponse(t *testing.T) {
+	err := NewCloudError("over quota", 429, map[string]interface{}{
+		"detail": map[string]interface{}{"code": "storage_quota_exceeded", "message": "over quota"},
+	}, nil)
+	if err.Code != ErrCodeStorageQuotaExceeded {
+		t.Fatalf("Code = %q", err.Code)
+	}
+	if !strings.Contains(err.Remediation(), "storage quota") {
+		t.Fatalf("Remediation = %q", err.Remediation())
+	}
+	if got := NewCloudError("x", 400, map[string]interface{}{"error_code": "custom"}, nil).Code; got != "custom" {
+		t.Fatalf("error_code not used: %q", got)
+	}
+}
+
+func TestErrorCode_Inferred(t *testing.T) {
+	cases := []struct {
+		status  int
+		message string
+		want    string
+	}{
+		{401, "bad key", ErrCodeInvalidAPIKey},
+		{402, "payment required", ErrCodeInsufficientCredits},
+		{404, "job not found", ErrCodeNotFound},
+		{429, "Rate limit exceeded", ErrCodeRateLimited},
+		{429, "Storage limit reached", ErrCodeStorageQuotaExceeded},
+		{429, "Daily quota exceeded", ErrCodeQuotaExceeded},
+		{400, "invalid CSS selector 'div[='", ErrCodeInvalidSelector},
+		{422, "bad field", ErrCodeValidation},
+		{504, "gateway timeout", ErrCodeTimeout},
+		{500, "boom", ErrCodeServerError},
+		{418, "teapot", ""},
+	}
+	for _, tc := range cases {
+		err := NewCloudError(tc.message, tc.status, nil, nil)
+		if err.Code != tc.want {
+			t.Errorf("%d %q: Code = %q, want %q", tc.status, tc.message, err.Code, tc.want)
+		}
+		if (err.Remediation() == "") != (tc.want == "") {
+			t.Errorf("%d: Remediation = %q", tc.status, err.Remediation())
+		}
+	}
+}
+
+func TestErrorCode_ThroughClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"detail": {"code": "invalid_selector", "message": "wait_for selector does not parse"}}`))
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Run("https://a.com", nil)
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+	if verr.Code != ErrCodeInvalidSelector || verr.Remediation() == "" {
+		t.Fatalf("Code = %q, Remediation = %q", verr.Code, verr.Remediation())
+	}
+	if !strings.Contains(err.Error(), "wait_for selector does not parse") {
+		t.Fatalf("message should come from detail.message: %v", err)
+	}
+}
+
+func TestAsCloudError(t *testing.T) {
+	wrapped := fmt.Errorf("crawl: %w", NewNotFoundError("job gone", nil, nil))
+	ce, ok := AsCloudError(wrapped)
+	if !ok || ce.StatusCode != 404 || ce.Message != "job gone" {
+		t.Fatalf("AsCloudError = %+v, %v", ce, ok)
+	}
+	if _, ok := AsCloudError(errors.New("plain")); ok {
+		t.Error("plain error matched")
+	}
+}
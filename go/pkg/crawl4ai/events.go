@@ -0,0 +1,202 @@
+package crawl4ai
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventType classifies an account event.
+type EventType string
+
+// Account event types.
+const (
+	EventJobCreated   EventType = "job.created"
+	EventJobCompleted EventType = "job.completed"
+	EventJobFailed    EventType = "job.failed"
+	EventJobCancelled EventType = "job.cancelled"
+	// EventKeyUsed records an API key authenticating a request, with the
+	// caller's IP where the API reports it.
+	EventKeyUsed EventType = "key.used"
+	// EventQuotaWarning is a credit, storage or rate quota nearing its
+	// limit.
+	EventQuotaWarning EventType = "quota.warning"
+)
+
+// Event is one entry of the account's audit log.
+type Event struct {
+	ID   string
+	Type EventType
+	At   time.Time
+	// JobID is set for job events.
+	JobID string
+	// KeyPrefix identifies the API key involved (e.g. "sk_live_ab12"),
+	// never the full key.
+	KeyPrefix string
+	IP        string
+	Message   string
+	// Data holds the event's remaining fields as sent by the API.
+	Data map[string]interface{}
+}
+
+// ListEventsOptions filters ListEvents.
+type ListEventsOptions struct {
+	// Types keeps only these event types. Empty = all.
+	Types []EventType
+	// Since / Until bound the event time. Zero = unbounded.
+	Since time.Time
+	Until time.Time
+	// Limit is the page size. Default 100.
+	Limit int
+	// Cursor continues from a previous page's NextCursor.
+	Cursor string
+}
+
+// EventPage is one page of events, newest first.
+type EventPage struct {
+	Events []Event
+	// NextCursor fetches the following page; empty on the last one.
+	NextCursor string
+	// Derived is set when the deployment has no audit feed and the events
+	// were rebuilt from the job list instead: only job events are
+	// available, without key or IP details, and there is a single page.
+	Derived bool
+}
+
+// ListEvents returns the account's audit log: jobs created, finished and
+// cancelled, API key use and quota warnings, for compliance logging and
+// spotting unexpected key use.
+//
+//	page, err := crawler.ListEvents(&crawl4ai.ListEventsOptions{
+//	    Types: []crawl4ai.EventType{crawl4ai.EventKeyUsed},
+//	    Since: time.Now().Add(-24 * time.Hour),
+//	})
+//	for _, e := range page.Events {
+//	    log.Printf("%s %s from %s", e.At, e.KeyPrefix, e.IP)
+//	}
+//
+// Deployments without the audit feed answer with job events derived from
+// ListJobs; see EventPage.Derived.
+func (c *AsyncWebCrawler) ListEvents(opts *ListEventsOptions) (*EventPage, error) {
+	if opts == nil {
+		opts = &ListEventsOptions{}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	params := map[string]string{"limit": strconv.Itoa(limit)}
+	if len(opts.Types) > 0 {
+		types := make([]string, len(opts.Types))
+		for i, t := range opts.Types {
+			types[i] = string(t)
+		}
+		params["types"] = strings.Join(types, ",")
+	}
+	if !opts.Since.IsZero() {
+		params["since"] = opts.Since.UTC().Format(time.RFC3339)
+	}
+	if !opts.Until.IsZero() {
+		params["until"] = opts.Until.UTC().Format(time.RFC3339)
+	}
+	if opts.Cursor != "" {
+		params["cursor"] = opts.Cursor
+	}
+
+	data, err := c.http.Get("/v1/events", params)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) && opts.Cursor == "" {
+		return c.derivedEvents(opts, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	page := &EventPage{}
+	page.NextCursor, _ = data["next_cursor"].(string)
+	raw, _ := data["events"].([]interface{})
+	for _, r := range raw {
+		if m, ok := r.(map[string]interface{}); ok {
+			page.Events = append(page.Events, EventFromMap(m))
+		}
+	}
+	return page, nil
+}
+
+// EventFromMap creates an Event from API response map.
+func EventFromMap(data map[string]interface{}) Event {
+	e := Event{Data: map[string]interface{}{}}
+	for k, v := range data {
+		switch k {
+		case "id", "event_id":
+			e.ID, _ = v.(string)
+		case "type":
+			s, _ := v.(string)
+			e.Type = EventType(s)
+		case "created_at", "timestamp":
+			if t, ok := apiTimeValue(v); ok {
+				e.At = t
+			}
+		case "job_id":
+			e.JobID, _ = v.(string)
+		case "key_prefix", "api_key_prefix":
+			e.KeyPrefix, _ = v.(string)
+		case "ip", "ip_address":
+			e.IP, _ = v.(string)
+		case "message":
+			e.Message, _ = v.(string)
+		default:
+			e.Data[k] = v
+		}
+	}
+	return e
+}
+
+// derivedEvents rebuilds job events from the job list.
+func (c *AsyncWebCrawler) derivedEvents(opts *ListEventsOptions, limit int) (*EventPage, error) {
+	jobs, err := c.ListJobs(&ListJobsOptions{Limit: limit, CreatedAfter: opts.Since, CreatedBefore: opts.Until})
+	if err != nil {
+		return nil, err
+	}
+	want := func(t EventType) bool {
+		if len(opts.Types) == 0 {
+			return true
+		}
+		for _, w := range opts.Types {
+			if w == t {
+				return true
+			}
+		}
+		return false
+	}
+	inRange := func(at time.Time) bool {
+		return !at.IsZero() && (opts.Since.IsZero() || !at.Before(opts.Since)) && (opts.Until.IsZero() || at.Before(opts.Until))
+	}
+
+	page := &EventPage{Derived: true}
+	add := func(t EventType, at time.Time, job *CrawlJob) {
+		if want(t) && inRange(at) {
+			page.Events = append(page.Events, Event{
+				ID: job.JobID + ":" + string(t), Type: t, At: at, JobID: job.JobID,
+				Data: map[string]interface{}{"status": string(job.Status), "urls_count": job.URLsCount},
+			})
+		}
+	}
+	for _, job := range jobs {
+		add(EventJobCreated, job.CreatedAt, job)
+		switch job.Status {
+		case JobStatusCompleted, JobStatusPartial:
+			add(EventJobCompleted, job.CompletedAt, job)
+		case JobStatusFailed:
+			add(EventJobFailed, job.CompletedAt, job)
+		case JobStatusCancelled:
+			add(EventJobCancelled, job.CompletedAt, job)
+		}
+	}
+	sort.SliceStable(page.Events, func(i, k int) bool { return page.Events[i].At.After(page.Events[k].At) })
+	if len(page.Events) > limit {
+		page.Events = page.Events[:limit]
+	}
+	return page, nil
+}
@@ -0,0 +1,75 @@
+package crawl4ai
+
+import (
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestListEvents(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/events": map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{"id": "ev_2", "type": "key.used", "created_at": "2026-03-01T10:00:00Z",
+					"key_prefix": "sk_live_ab12", "ip": "203.0.113.7", "endpoint": "/v1/crawl"},
+				map[string]interface{}{"id": "ev_1", "type": "job.created", "timestamp": 1772272800.0, "job_id": "job_1"},
+			},
+			"next_cursor": "c2",
+		},
+	})
+	page, err := c.ListEvents(&ListEventsOptions{Types: []EventType{EventKeyUsed, EventJobCreated}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Derived || page.NextCursor != "c2" || len(page.Events) != 2 {
+		t.Fatalf("page = %+v", page)
+	}
+	e := page.Events[0]
+	if e.Type != EventKeyUsed || e.KeyPrefix != "sk_live_ab12" || e.IP != "203.0.113.7" ||
+		!e.At.Equal(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)) || e.Data["endpoint"] != "/v1/crawl" {
+		t.Errorf("event = %+v", e)
+	}
+	if e := page.Events[1]; e.JobID != "job_1" || e.At.IsZero() {
+		t.Errorf("event = %+v", e)
+	}
+}
+
+func TestListEvents_DerivedFromJobs(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs": map[string]interface{}{"jobs": []interface{}{
+			map[string]interface{}{"job_id": "job_2", "status": "cancelled", "created_at": "2026-03-02T09:00:00Z", "completed_at": "2026-03-02T09:05:00Z"},
+			map[string]interface{}{"job_id": "job_1", "status": "completed", "created_at": "2026-03-01T09:00:00Z", "completed_at": "2026-03-01T09:30:00Z"},
+			map[string]interface{}{"job_id": "job_3", "status": "running", "created_at": "2026-03-03T09:00:00Z"},
+		}},
+	})
+	page, err := c.ListEvents(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !page.Derived {
+		t.Error("expected derived events")
+	}
+	var got []string
+	for _, e := range page.Events {
+		got = append(got, e.JobID+" "+string(e.Type))
+	}
+	want := []string{"job_3 job.created", "job_2 job.cancelled", "job_2 job.created", "job_1 job.completed", "job_1 job.created"}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("events = %v, want %v", got, want)
+			break
+		}
+	}
+
+	page, err = c.ListEvents(&ListEventsOptions{Types: []EventType{EventJobCompleted, EventKeyUsed}})
+	if err != nil || len(page.Events) != 1 || page.Events[0].JobID != "job_1" {
+		t.Errorf("filtered = %+v, %v", page, err)
+	}
+	if _, err := c.ListEvents(&ListEventsOptions{Cursor: "c2"}); err == nil {
+		t.Error("a cursor without a feed should be an error")
+	}
+}
@@ -0,0 +1,314 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Result-level columns available to a Column.Field alongside the extracted
+// item's own keys.
+const (
+	ColumnURL        = "$url"
+	ColumnSuccess    = "$success"
+	ColumnStatusCode = "$status_code"
+	ColumnError      = "$error"
+)
+
+// Column maps one table column to a value. Field is a dotted path into an
+// extracted item ("price", "seller.name") or one of the Column* result-level
+// names. Non-scalar values are written as JSON.
+type Column struct {
+	Header string
+	Field  string
+}
+
+// TableOptions control how results are flattened.
+type TableOptions struct {
+	// Columns to emit, in order. Empty = ColumnURL followed by every
+	// scalar key seen in the extracted items, sorted.
+	Columns []Column
+	// Strategy picks one named strategy's output from a multi-extraction
+	// result (CrawlerRunConfig.ExtractionStrategies).
+	Strategy string
+}
+
+// Table is flattened extraction output: one row per extracted item, or one
+// row per result that extracted nothing.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// FlattenResults turns the extracted content of many results into a Table.
+// A result whose extraction is a JSON array contributes one row per
+// element; an object contributes one row; failed or empty results
+// contribute a single row carrying just the result-level columns, so
+// analysts can see what didn't extract.
+func FlattenResults(results []*CrawlResult, opts *TableOptions) (*Table, error) {
+	if opts == nil {
+		opts = &TableOptions{}
+	}
+	type row struct {
+		result *CrawlResult
+		item   map[string]interface{}
+	}
+	var rows []row
+	for _, r := range results {
+		items, err := extractedItems(r, opts.Strategy)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) == 0 {
+			rows = append(rows, row{result: r})
+			continue
+		}
+		for _, item := range items {
+			rows = append(rows, row{result: r, item: item})
+		}
+	}
+
+	columns := opts.Columns
+	if len(columns) == 0 {
+		seen := map[string]bool{}
+		var keys []string
+		for _, rw := range rows {
+			for k, v := range rw.item {
+				if !seen[k] && isScalar(v) {
+					seen[k] = true
+					keys = append(keys, k)
+				}
+			}
+		}
+		sort.Strings(keys)
+		columns = []Column{{Header: "url", Field: ColumnURL}}
+		for _, k := range keys {
+			columns = append(columns, Column{Header: k, Field: k})
+		}
+	}
+
+	t := &Table{Headers: make([]string, len(columns))}
+	for i, col := range columns {
+		t.Headers[i] = col.Header
+		if t.Headers[i] == "" {
+			t.Headers[i] = col.Field
+		}
+	}
+	for _, rw := range rows {
+		cells := make([]string, len(columns))
+		for i, col := range columns {
+			cells[i] = cellValue(rw.result, rw.item, col.Field)
+		}
+		t.Rows = append(t.Rows, cells)
+	}
+	return t, nil
+}
+
+// extractedItems decodes a result's extraction into objects.
+func extractedItems(r *CrawlResult, strategy string) ([]map[string]interface{}, error) {
+	raw := []byte(r.ExtractedContent)
+	if strategy != "" && len(bytes.TrimSpace(raw)) > 0 {
+		byName, err := r.ExtractedByStrategy()
+		if err != nil {
+			return nil, fmt.Errorf("extracted content for %s: %w", r.URL, err)
+		}
+		raw = byName[strategy]
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return nil, nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, fmt.Errorf("extracted content for %s is not JSON: %w", r.URL, err)
+	}
+	switch x := v.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{x}, nil
+	case []interface{}:
+		items := make([]map[string]interface{}, 0, len(x))
+		for _, e := range x {
+			if m, ok := e.(map[string]interface{}); ok {
+				items = append(items, m)
+			} else {
+				items = append(items, map[string]interface{}{"value": e})
+			}
+		}
+		return items, nil
+	}
+	return []map[string]interface{}{{"value": v}}, nil
+}
+
+func cellValue(r *CrawlResult, item map[string]interface{}, field string) string {
+	switch field {
+	case ColumnURL:
+		return r.URL
+	case ColumnSuccess:
+		return strconv.FormatBool(r.Success)
+	case ColumnStatusCode:
+		if r.StatusCode == 0 {
+			return ""
+		}
+		return strconv.Itoa(r.StatusCode)
+	case ColumnError:
+		return r.ErrorMessage
+	}
+	var v interface{} = item
+	for _, part := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		v = m[part]
+	}
+	return formatCell(v)
+}
+
+func formatCell(v interface{}) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// WriteCSV writes the table, header first.
+func (t *Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// JobResults collects every result of an async crawl job: the results the
+// job inlines, or — when it inlines none — each URL fetched through
+// GetPerUrlResult. Results pass through the crawler's ResultHooks.
+func (c *AsyncWebCrawler) JobResults(jobID string) ([]*CrawlResult, error) {
+	job, err := c.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if len(job.Results) > 0 || job.URLsCount == 0 {
+		return job.Results, nil
+	}
+	results := make([]*CrawlResult, 0, job.URLsCount)
+	for i := 0; i < job.URLsCount; i++ {
+		r, err := c.GetPerUrlResult(jobID, i)
+		if errors.Is(err, ErrSkipResult) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("job %s result %d: %w", jobID, i, err)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// ExportJobCSV flattens a job's extracted content and writes it as CSV.
+//
+//	f, _ := os.Create("products.csv")
+//	defer f.Close()
+//	err := crawler.ExportJobCSV(jobID, f, &TableOptions{Columns: []Column{
+//	    {Header: "Page", Field: ColumnURL},
+//	    {Header: "Product", Field: "title"},
+//	    {Header: "Price", Field: "price"},
+//	}})
+func (c *AsyncWebCrawler) ExportJobCSV(jobID string, w io.Writer, opts *TableOptions) error {
+	results, err := c.JobResults(jobID)
+	if err != nil {
+		return err
+	}
+	t, err := FlattenResults(results, opts)
+	if err != nil {
+		return err
+	}
+	return t.WriteCSV(w)
+}
+
+// ─── Google Sheets ──────────────────────────────────────────────────────
+
+// DefaultSheetsEndpoint is the Google Sheets API base URL.
+const DefaultSheetsEndpoint = "https://sheets.googleapis.com"
+
+// SheetsExporter appends tables to a Google Sheet through the Sheets v4
+// values:append API. The SDK carries no Google auth dependency: supply an
+// HTTPClient that authorizes requests, e.g. one built with
+// golang.org/x/oauth2/google.
+//
+//	client, _ := google.DefaultClient(ctx, "https://www.googleapis.com/auth/spreadsheets")
+//	sheets := &SheetsExporter{SpreadsheetID: "1AbC...", Range: "Crawl!A1", HTTPClient: client}
+//	err := sheets.Append(table, true)
+type SheetsExporter struct {
+	SpreadsheetID string
+	// Range is the A1 range to append after, e.g. "Sheet1!A1".
+	Range      string
+	HTTPClient *http.Client
+	// Endpoint overrides DefaultSheetsEndpoint (tests, proxies).
+	Endpoint string
+}
+
+// Append writes the table's rows below any existing data in Range, with
+// the header row first when includeHeader is set.
+func (s *SheetsExporter) Append(t *Table, includeHeader bool) error {
+	if s.SpreadsheetID == "" || s.Range == "" {
+		return fmt.Errorf("sheets export: SpreadsheetID and Range are required")
+	}
+	client := s.HTTPClient
+	if client == nil {
+		return fmt.Errorf("sheets export: HTTPClient with Google credentials is required")
+	}
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultSheetsEndpoint
+	}
+
+	values := make([][]string, 0, len(t.Rows)+1)
+	if includeHeader {
+		values = append(values, t.Headers)
+	}
+	values = append(values, t.Rows...)
+	body, err := json.Marshal(map[string]interface{}{"values": values})
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/v4/spreadsheets/%s/values/%s:append?valueInputOption=RAW&insertDataOption=INSERT_ROWS",
+		strings.TrimSuffix(endpoint, "/"), url.PathEscape(s.SpreadsheetID), url.PathEscape(s.Range))
+	resp, err := client.Post(u, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("sheets export: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("sheets export: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
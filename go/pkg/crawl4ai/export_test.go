@@ -0,0 +1,122 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func exportResults() []*CrawlResult {
+	return []*CrawlResult{
+		{
+			URL: "https://shop.com/a", Success: true, StatusCode: 200,
+			ExtractedContent: `[{"title": "Widget", "price": 9.5, "seller": {"name": "Acme"}}, {"title": "Gadget, XL", "price": 12}]`,
+		},
+		{URL: "https://shop.com/b", Success: false, ErrorMessage: "timeout"},
+	}
+}
+
+func TestFlattenResults_AutoColumns(t *testing.T) {
+	table, err := FlattenResults(exportResults(), nil)
+	if err != nil {
+		t.Fatalf("FlattenResults: %v", err)
+	}
+	if got := strings.Join(table.Headers, ","); got != "url,price,title" {
+		t.Fatalf("unexpected headers: %s", got)
+	}
+	if len(table.Rows) != 3 {
+		t.Fatalf("expected 2 item rows + 1 empty-result row, got %d", len(table.Rows))
+	}
+	if table.Rows[0][1] != "9.5" || table.Rows[2][0] != "https://shop.com/b" || table.Rows[2][2] != "" {
+		t.Fatalf("unexpected rows: %v", table.Rows)
+	}
+}
+
+func TestFlattenResults_ColumnMappingAndCSV(t *testing.T) {
+	table, err := FlattenResults(exportResults(), &TableOptions{Columns: []Column{
+		{Header: "Page", Field: ColumnURL},
+		{Header: "Product", Field: "title"},
+		{Header: "Seller", Field: "seller.name"},
+		{Field: ColumnError},
+	}})
+	if err != nil {
+		t.Fatalf("FlattenResults: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := table.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	want := "Page,Product,Seller,$error\n" +
+		"https://shop.com/a,Widget,Acme,\n" +
+		"https://shop.com/a,\"Gadget, XL\",,\n" +
+		"https://shop.com/b,,,timeout\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestFlattenResults_NamedStrategy(t *testing.T) {
+	r := &CrawlResult{URL: "https://x.com", ExtractedContent: `{"products": [{"sku": "1"}], "summary": {"text": "hi"}}`}
+	table, err := FlattenResults([]*CrawlResult{r}, &TableOptions{Strategy: "products"})
+	if err != nil || len(table.Rows) != 1 || table.Rows[0][1] != "1" {
+		t.Fatalf("expected products strategy row, got %+v %v", table, err)
+	}
+}
+
+func TestFlattenResults_MalformedNamedStrategyErrors(t *testing.T) {
+	r := &CrawlResult{URL: "https://x.com", ExtractedContent: `[{"sku": "1"}]`}
+	if _, err := FlattenResults([]*CrawlResult{r}, &TableOptions{Strategy: "products"}); err == nil || !strings.Contains(err.Error(), "https://x.com") {
+		t.Fatalf("expected an error naming the URL, got %v", err)
+	}
+	empty := &CrawlResult{URL: "https://y.com"}
+	if _, err := FlattenResults([]*CrawlResult{empty}, &TableOptions{Strategy: "products"}); err != nil {
+		t.Errorf("result without extraction: %v", err)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestExportJobCSV_FetchesPerURLResults(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1":          map[string]interface{}{"job_id": "job_1", "status": "completed", "urls_count": 1},
+		"GET /v1/crawl/jobs/job_1/result/0": map[string]interface{}{"url": "https://a.com", "success": true, "extracted_content": `[{"t": "x"}]`},
+	})
+	var buf bytes.Buffer
+	if err := c.ExportJobCSV("job_1", &buf, nil); err != nil {
+		t.Fatalf("ExportJobCSV: %v", err)
+	}
+	if buf.String() != "url,t\nhttps://a.com,x\n" {
+		t.Fatalf("unexpected CSV: %q", buf.String())
+	}
+}
+
+func TestSheetsExporter_Append(t *testing.T) {
+	var gotPath string
+	var gotBody map[string][][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	s := &SheetsExporter{SpreadsheetID: "sheet1", Range: "Crawl!A1", HTTPClient: srv.Client(), Endpoint: srv.URL}
+	table := &Table{Headers: []string{"url"}, Rows: [][]string{{"https://a.com"}}}
+	if err := s.Append(table, true); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if gotPath != "/v4/spreadsheets/sheet1/values/Crawl!A1:append" {
+		t.Fatalf("unexpected path %q", gotPath)
+	}
+	if len(gotBody["values"]) != 2 || gotBody["values"][0][0] != "url" {
+		t.Fatalf("unexpected body: %v", gotBody)
+	}
+	if err := (&SheetsExporter{SpreadsheetID: "s", Range: "A1"}).Append(table, false); err == nil {
+		t.Fatal("expected error without an authorized client")
+	}
+}
@@ -0,0 +1,41 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExtractedByStrategy splits a multi-extraction ExtractedContent into the raw
+// JSON produced by each named strategy in
+// CrawlerRunConfig.ExtractionStrategies.
+func (r *CrawlResult) ExtractedByStrategy() (map[string]json.RawMessage, error) {
+	if r.ExtractedContent == "" {
+		return nil, fmt.Errorf("result for %s has no extracted content", r.URL)
+	}
+	var out map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(r.ExtractedContent), &out); err != nil {
+		return nil, fmt.Errorf("extracted content is not keyed by strategy: %w", err)
+	}
+	return out, nil
+}
+
+// ExtractedFor decodes the output of one named strategy into v.
+//
+//	var products []Product
+//	var summary struct{ Summary string `json:"summary"` }
+//	_ = result.ExtractedFor("products", &products)
+//	_ = result.ExtractedFor("summary", &summary)
+func (r *CrawlResult) ExtractedFor(name string, v interface{}) error {
+	byName, err := r.ExtractedByStrategy()
+	if err != nil {
+		return err
+	}
+	raw, ok := byName[name]
+	if !ok {
+		return fmt.Errorf("no extraction output for strategy %q", name)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("decode %q extraction: %w", name, err)
+	}
+	return nil
+}
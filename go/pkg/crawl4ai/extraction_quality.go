@@ -0,0 +1,265 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FieldQuality is how often one schema field came back filled.
+type FieldQuality struct {
+	Name   string
+	Filled int
+	Empty  int
+	// HitRate is Filled over the number of records: how often the field's
+	// selector matched something.
+	HitRate float64
+}
+
+// ExtractionQuality summarises a json_css / json_xpath extraction, so a
+// scraper can notice a site redesign that leaves its selectors matching
+// nothing instead of failing.
+type ExtractionQuality struct {
+	Records int
+	// IncompleteRecords have at least one empty field.
+	IncompleteRecords int
+	// EmptyFieldRate is the share of record fields that came back empty.
+	EmptyFieldRate float64
+	// Fields reports each field, in schema order.
+	Fields []FieldQuality
+	// Score is 1 - EmptyFieldRate, and 0 when nothing was extracted.
+	Score float64
+}
+
+// String implements fmt.Stringer.
+func (q *ExtractionQuality) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "score %.2f over %d records (%d incomplete)", q.Score, q.Records, q.IncompleteRecords)
+	for _, f := range q.Fields {
+		fmt.Fprintf(&b, "\n  %-20s %5.1f%%", f.Name, f.HitRate*100)
+	}
+	return b.String()
+}
+
+// MeasureExtraction scores raw extraction JSON (an array of records, or a
+// single record) against schema's top-level fields. A field is empty when
+// missing, null, blank text, or an empty list or object. With a nil
+// schema the fields are the keys seen across the records.
+func MeasureExtraction(schema *Schema, data []byte) (*ExtractionQuality, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("extracted content is not JSON: %w", err)
+	}
+	var records []map[string]interface{}
+	switch v := raw.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if m, ok := item.(map[string]interface{}); ok {
+				records = append(records, m)
+			}
+		}
+	case map[string]interface{}:
+		records = append(records, v)
+	}
+
+	var names []string
+	if schema != nil {
+		for _, f := range schema.Fields {
+			names = append(names, f.Name)
+		}
+	} else {
+		seen := map[string]bool{}
+		for _, r := range records {
+			for k := range r {
+				if !seen[k] {
+					seen[k] = true
+					names = append(names, k)
+				}
+			}
+		}
+		sort.Strings(names)
+	}
+
+	q := &ExtractionQuality{Records: len(records), Fields: make([]FieldQuality, len(names))}
+	empty := 0
+	for i, name := range names {
+		q.Fields[i].Name = name
+	}
+	for _, r := range records {
+		incomplete := false
+		for i, name := range names {
+			if isEmptyExtracted(r[name]) {
+				q.Fields[i].Empty++
+				empty++
+				incomplete = true
+			} else {
+				q.Fields[i].Filled++
+			}
+		}
+		if incomplete {
+			q.IncompleteRecords++
+		}
+	}
+	if cells := len(records) * len(names); cells > 0 {
+		q.EmptyFieldRate = float64(empty) / float64(cells)
+		q.Score = 1 - q.EmptyFieldRate
+		for i := range q.Fields {
+			q.Fields[i].HitRate = float64(q.Fields[i].Filled) / float64(len(records))
+		}
+	}
+	return q, nil
+}
+
+func isEmptyExtracted(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(x) == ""
+	case []interface{}:
+		return len(x) == 0
+	case map[string]interface{}:
+		return len(x) == 0
+	}
+	return false
+}
+
+// ExtractionQuality scores the result's extracted content against schema;
+// see MeasureExtraction.
+func (r *CrawlResult) ExtractionQuality(schema *Schema) (*ExtractionQuality, error) {
+	if r.ExtractedContent == "" {
+		return nil, fmt.Errorf("result for %s has no extracted content", r.URL)
+	}
+	return MeasureExtraction(schema, []byte(r.ExtractedContent))
+}
+
+// MinExtractionQuality rejects results whose extraction scores below
+// minScore against schema (see MeasureExtraction). Use it as
+// RunOptions.Validator to retry a page that rendered without its data.
+func MinExtractionQuality(schema *Schema, minScore float64) ResultValidator {
+	return func(r *CrawlResult) error {
+		q, err := r.ExtractionQuality(schema)
+		if err != nil {
+			return err
+		}
+		if q.Score < minScore {
+			return fmt.Errorf("extraction quality %.2f is below %.2f", q.Score, minScore)
+		}
+		return nil
+	}
+}
+
+// SchemaGuardOptions configure a SchemaGuard.
+type SchemaGuardOptions struct {
+	// MinScore is the quality below which the schema is regenerated.
+	// Default 0.8.
+	MinScore float64
+	// Regenerate builds a new schema from the page HTML, e.g.
+	// crawler.RegenerateSchema or InferSchema. Unset = report only.
+	Regenerate func(html string) (*Schema, error)
+	// OnDegraded, when set, is told about every result scoring below
+	// MinScore, with the replacement schema if one was generated (nil
+	// otherwise).
+	OnDegraded func(r *CrawlResult, q *ExtractionQuality, replacement *Schema)
+}
+
+// SchemaGuard keeps a long-running scraper's schema working: it scores
+// each result's extraction and, when quality drops below MinScore,
+// regenerates the schema from the page so the next crawl uses selectors
+// that match the redesigned site. Safe for concurrent use.
+//
+//	guard := crawl4ai.NewSchemaGuard(schema, crawl4ai.SchemaGuardOptions{
+//	    Regenerate: crawler.RegenerateSchema(&crawl4ai.GenerateSchemaOptions{Query: "products"}),
+//	})
+//	for range ticker.C {
+//	    strategy, _ := crawl4ai.JSONCSSExtraction(guard.Schema())
+//	    result, err := crawler.Run(url, &crawl4ai.RunOptions{Config: &crawl4ai.CrawlerRunConfig{ExtractionStrategy: strategy}})
+//	    quality, err := guard.Check(result)
+//	}
+//
+// Regeneration needs the page HTML, so don't omit "html" from results
+// the guard checks.
+type SchemaGuard struct {
+	opts SchemaGuardOptions
+
+	mu     sync.Mutex
+	schema *Schema
+}
+
+// NewSchemaGuard guards schema with opts.
+func NewSchemaGuard(schema *Schema, opts SchemaGuardOptions) *SchemaGuard {
+	if opts.MinScore <= 0 {
+		opts.MinScore = 0.8
+	}
+	return &SchemaGuard{opts: opts, schema: schema}
+}
+
+// Schema returns the schema to extract with, the regenerated one after a
+// quality drop.
+func (g *SchemaGuard) Schema() *Schema {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.schema
+}
+
+// Check scores r against the current schema and regenerates the schema if
+// the score is below MinScore. A failed regeneration is returned as the
+// error, with the quality report; the current schema stays in place.
+func (g *SchemaGuard) Check(r *CrawlResult) (*ExtractionQuality, error) {
+	schema := g.Schema()
+	// Nothing extracted at all is the clearest sign of a redesign.
+	q := &ExtractionQuality{}
+	if r.ExtractedContent != "" {
+		var err error
+		if q, err = MeasureExtraction(schema, []byte(r.ExtractedContent)); err != nil {
+			return nil, err
+		}
+	}
+	if q.Score >= g.opts.MinScore {
+		return q, nil
+	}
+
+	var replacement *Schema
+	var err error
+	if g.opts.Regenerate != nil {
+		if r.HTML == "" {
+			err = fmt.Errorf("regenerate schema for %s: result has no HTML", r.URL)
+		} else if replacement, err = g.opts.Regenerate(r.HTML); err == nil {
+			if err = replacement.Validate(); err != nil {
+				replacement = nil
+			}
+		}
+		if replacement != nil {
+			g.mu.Lock()
+			// Another Check may have replaced it already; keep the newer one.
+			if g.schema == schema {
+				g.schema = replacement
+			}
+			g.mu.Unlock()
+		}
+		if err != nil {
+			err = fmt.Errorf("regenerate schema: %w", err)
+		}
+	}
+	if g.opts.OnDegraded != nil {
+		g.opts.OnDegraded(r, q, replacement)
+	}
+	return q, err
+}
+
+// RegenerateSchema returns a SchemaGuardOptions.Regenerate function that
+// asks GenerateSchema for a new schema from the page.
+func (c *AsyncWebCrawler) RegenerateSchema(opts *GenerateSchemaOptions) func(html string) (*Schema, error) {
+	return func(html string) (*Schema, error) {
+		generated, err := c.GenerateSchema(html, opts)
+		if err != nil {
+			return nil, err
+		}
+		if !generated.Success {
+			return nil, fmt.Errorf("schema generation failed: %s", generated.Error)
+		}
+		return SchemaFromMap(generated.Schema)
+	}
+}
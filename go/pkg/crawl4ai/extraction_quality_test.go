@@ -0,0 +1,78 @@
+package crawl4ai
+
+import (
+	"errors"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestMeasureExtraction(t *testing.T) {
+	schema := &Schema{Name: "Products", BaseSelector: ".card", Fields: []Field{
+		NewTextField("title", "h3"),
+		NewTextField("price", ".price"),
+		NewListField("tags", ".tag", NewTextField("tag", "")),
+	}}
+	q, err := MeasureExtraction(schema, []byte(`[
+		{"title": "A", "price": "$1", "tags": [{"tag": "x"}]},
+		{"title": "B", "price": "  ", "tags": []},
+		{"title": "C", "price": null},
+		{"title": "D", "price": "$4", "tags": [{"tag": "y"}]}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Records != 4 || q.IncompleteRecords != 2 || q.EmptyFieldRate != 4.0/12 {
+		t.Errorf("quality = %+v", q)
+	}
+	if q.Fields[0].HitRate != 1 || q.Fields[1].HitRate != 0.5 || q.Fields[2].Empty != 2 {
+		t.Errorf("fields = %+v", q.Fields)
+	}
+
+	// Without a schema the fields are the keys seen.
+	q, _ = MeasureExtraction(nil, []byte(`{"b": "", "a": 1}`))
+	if len(q.Fields) != 2 || q.Fields[0].Name != "a" || q.Score != 0.5 {
+		t.Errorf("schemaless = %+v", q)
+	}
+	if q, _ := MeasureExtraction(schema, []byte(`[]`)); q.Score != 0 {
+		t.Errorf("no records should score 0: %+v", q)
+	}
+
+	r := &CrawlResult{URL: "https://a.com", ExtractedContent: `[{"title": "A", "price": ""}]`}
+	if err := MinExtractionQuality(schema, 0.5)(r); err == nil {
+		t.Error("a 1/3 extraction should be rejected")
+	}
+}
+
+func TestSchemaGuard(t *testing.T) {
+	old := &Schema{Name: "v1", BaseSelector: ".card", Fields: []Field{NewTextField("title", "h3")}}
+	redesigned := &Schema{Name: "v2", BaseSelector: ".tile", Fields: []Field{NewTextField("title", "h2")}}
+	var degraded int
+	g := NewSchemaGuard(old, SchemaGuardOptions{
+		Regenerate: func(html string) (*Schema, error) { return redesigned, nil },
+		OnDegraded: func(r *CrawlResult, q *ExtractionQuality, replacement *Schema) {
+			degraded++
+			if replacement != redesigned {
+				t.Errorf("replacement = %v", replacement)
+			}
+		},
+	})
+
+	good := &CrawlResult{ExtractedContent: `[{"title": "A"}, {"title": "B"}]`, HTML: "<div>"}
+	if q, err := g.Check(good); err != nil || q.Score != 1 || g.Schema() != old {
+		t.Fatalf("good page: %+v, %v", q, err)
+	}
+	if _, err := g.Check(&CrawlResult{HTML: "<div class=tile>"}); err != nil {
+		t.Fatal(err)
+	}
+	if g.Schema() != redesigned || degraded != 1 {
+		t.Errorf("schema = %s after %d degradations", g.Schema().Name, degraded)
+	}
+
+	failing := NewSchemaGuard(old, SchemaGuardOptions{
+		Regenerate: func(string) (*Schema, error) { return nil, errors.New("llm down") },
+	})
+	if _, err := failing.Check(&CrawlResult{HTML: "<div>", ExtractedContent: `[]`}); err == nil || failing.Schema() != old {
+		t.Errorf("err = %v, schema = %s", err, failing.Schema().Name)
+	}
+}
@@ -0,0 +1,56 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestSanitizeCrawlerConfig_KeepsExtractionStrategies(t *testing.T) {
+	config := &CrawlerRunConfig{
+		ExtractionStrategies: map[string]map[string]interface{}{
+			"products": {"type": "json_css", "schema": map[string]interface{}{"baseSelector": ".p"}},
+			"summary":  {"type": "llm", "instruction": "Summarize the page"},
+		},
+	}
+
+	sanitized := SanitizeCrawlerConfig(config)
+
+	strategies, ok := sanitized["extraction_strategies"].(map[string]map[string]interface{})
+	if !ok || len(strategies) != 2 {
+		t.Fatalf("expected 2 extraction strategies, got %v", sanitized["extraction_strategies"])
+	}
+}
+
+func TestExtractedFor_DecodesNamedStrategy(t *testing.T) {
+	r := &CrawlResult{
+		URL:              "https://shop.example",
+		ExtractedContent: `{"products":[{"name":"A"},{"name":"B"}],"summary":{"summary":"Two products"}}`,
+	}
+
+	var products []struct {
+		Name string `json:"name"`
+	}
+	if err := r.ExtractedFor("products", &products); err != nil {
+		t.Fatalf("ExtractedFor products: %v", err)
+	}
+	if len(products) != 2 || products[1].Name != "B" {
+		t.Fatalf("unexpected products: %+v", products)
+	}
+
+	var summary struct {
+		Summary string `json:"summary"`
+	}
+	if err := r.ExtractedFor("summary", &summary); err != nil || summary.Summary != "Two products" {
+		t.Fatalf("ExtractedFor summary: %v %+v", err, summary)
+	}
+
+	if err := r.ExtractedFor("missing", &summary); err == nil {
+		t.Fatal("expected error for unknown strategy")
+	}
+}
+
+func TestExtractedByStrategy_RejectsSingleStrategyArray(t *testing.T) {
+	r := &CrawlResult{ExtractedContent: `[{"name":"A"}]`}
+	if _, err := r.ExtractedByStrategy(); err == nil {
+		t.Fatal("expected error for non-keyed extracted content")
+	}
+}
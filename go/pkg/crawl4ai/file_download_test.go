@@ -177,3 +177,28 @@ func TestCrawlResultFromMap_MultipleFiles(t *testing.T) {
 		t.Fatalf("Expected 2 downloaded files, got %d", len(result.DownloadedFiles))
 	}
 }
+
+func TestCrawlResultFromMap_RedirectChain(t *testing.T) {
+	data := map[string]interface{}{
+		"url":            "http://example.com",
+		"success":        true,
+		"redirected_url": "https://www.example.com/",
+		"redirect_chain": []interface{}{
+			map[string]interface{}{"url": "http://example.com", "status_code": float64(301)},
+			map[string]interface{}{"url": "https://example.com", "status_code": float64(302)},
+			map[string]interface{}{"url": "https://www.example.com/", "status_code": float64(200)},
+		},
+	}
+
+	result := CrawlResultFromMap(data)
+
+	if !result.Redirects() || len(result.RedirectChain) != 3 {
+		t.Fatalf("Expected 3 hops, got %+v", result.RedirectChain)
+	}
+	if hop := result.RedirectChain[1]; hop.URL != "https://example.com" || hop.StatusCode != 302 {
+		t.Fatalf("Unexpected hop: %+v", hop)
+	}
+	if CrawlResultFromMap(map[string]interface{}{"url": "https://a.com"}).Redirects() {
+		t.Fatal("Expected no redirects without a chain")
+	}
+}
@@ -0,0 +1,207 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Modes for CrawlerOptions.FingerprintCheck.
+const (
+	// FingerprintWarn reports inconsistencies as warnings.
+	FingerprintWarn = "warn"
+	// FingerprintFix corrects them with FixFingerprint and reports what
+	// changed.
+	FingerprintFix = "fix"
+)
+
+// CheckFingerprint reports where the identity a browser crawl presents
+// disagrees with itself: client hints (sec-ch-ua and friends) that don't
+// match the user agent's browser, version, platform or device, a
+// User-Agent header that contradicts BrowserConfig.UserAgent, an
+// Accept-Language header in another language than config.Locale, or a
+// viewport sized for the wrong device. Sites compare these, and a crawl
+// that fails the comparison is blocked however good its proxy. Either
+// argument may be nil.
+func CheckFingerprint(config *CrawlerRunConfig, bc *BrowserConfig) []Warning {
+	_, warnings := checkFingerprint(config, bc, false)
+	return warnings
+}
+
+// FixFingerprint returns a copy of bc with the problems CheckFingerprint
+// finds corrected, and a warning for each correction. The user agent and
+// locale are taken as intended; headers and viewport change to match them.
+// bc itself is not modified.
+func FixFingerprint(config *CrawlerRunConfig, bc *BrowserConfig) (*BrowserConfig, []Warning) {
+	return checkFingerprint(config, bc, true)
+}
+
+// uaInfo is what a user agent claims to be.
+type uaInfo struct {
+	browser  string // chrome, edge, firefox, safari, or ""
+	version  int    // Chromium major version, or 0
+	mobile   bool
+	platform string // as sec-ch-ua-platform reports it
+}
+
+var chromeVersion = regexp.MustCompile(`Chrome/(\d+)`)
+
+func parseUserAgent(ua string) uaInfo {
+	var info uaInfo
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		info.browser = "edge"
+	case strings.Contains(ua, "Chrome/"):
+		info.browser = "chrome"
+	case strings.Contains(ua, "Firefox/"):
+		info.browser = "firefox"
+	case strings.Contains(ua, "Safari/") && strings.Contains(ua, "Version/"):
+		info.browser = "safari"
+	}
+	if m := chromeVersion.FindStringSubmatch(ua); m != nil {
+		info.version, _ = strconv.Atoi(m[1])
+	}
+	info.mobile = strings.Contains(ua, "Mobile")
+	switch {
+	case strings.Contains(ua, "Android"):
+		info.platform = "Android"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		info.platform = "iOS"
+	case strings.Contains(ua, "Windows"):
+		info.platform = "Windows"
+	case strings.Contains(ua, "Macintosh"):
+		info.platform = "macOS"
+	case strings.Contains(ua, "CrOS"):
+		info.platform = "Chrome OS"
+	case strings.Contains(ua, "Linux"), strings.Contains(ua, "X11"):
+		info.platform = "Linux"
+	}
+	return info
+}
+
+// headerKey finds name in headers case-insensitively.
+func headerKey(headers map[string]string, name string) (string, bool) {
+	for k := range headers {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+var hintVersion = regexp.MustCompile(`v="(\d+)"`)
+
+func checkFingerprint(config *CrawlerRunConfig, bc *BrowserConfig, fix bool) (*BrowserConfig, []Warning) {
+	if bc == nil {
+		return nil, nil
+	}
+	out := *bc
+	out.Headers = make(map[string]string, len(bc.Headers))
+	for k, v := range bc.Headers {
+		out.Headers[k] = v
+	}
+	var warnings []Warning
+	report := func(field, problem, fixed string) {
+		msg := problem
+		if fix {
+			msg += "; " + fixed
+		}
+		warnings = append(warnings, Warning{Field: field, Message: msg})
+	}
+
+	ua := out.UserAgent
+	if k, ok := headerKey(out.Headers, "User-Agent"); ok {
+		if ua == "" {
+			ua = out.Headers[k]
+		} else if out.Headers[k] != ua {
+			report(k, "header differs from BrowserConfig.UserAgent", "header removed")
+			delete(out.Headers, k)
+		}
+	}
+
+	if ua != "" {
+		info := parseUserAgent(ua)
+		if brand := chromiumBrand(info.browser); brand != "" {
+			want := clientHints(brand, info.version, info.mobile, info.platform)
+			wrong := false
+			if k, ok := headerKey(out.Headers, "sec-ch-ua"); ok {
+				m := hintVersion.FindStringSubmatch(out.Headers[k])
+				if info.version > 0 && (m == nil || m[1] != strconv.Itoa(info.version)) {
+					report(k, fmt.Sprintf("does not match the user agent's %s %d", info.browser, info.version), "regenerated")
+					wrong = true
+				}
+			}
+			if k, ok := headerKey(out.Headers, "sec-ch-ua-mobile"); ok && out.Headers[k] != want["sec-ch-ua-mobile"] {
+				report(k, fmt.Sprintf("%s contradicts the user agent", out.Headers[k]), "regenerated")
+				wrong = true
+			}
+			if k, ok := headerKey(out.Headers, "sec-ch-ua-platform"); ok && info.platform != "" &&
+				strings.Trim(out.Headers[k], `"`) != info.platform {
+				report(k, fmt.Sprintf("%s, but the user agent is %s", out.Headers[k], info.platform), "regenerated")
+				wrong = true
+			}
+			if wrong && fix && info.version > 0 && info.platform != "" {
+				for _, name := range []string{"sec-ch-ua", "sec-ch-ua-mobile", "sec-ch-ua-platform"} {
+					if k, ok := headerKey(out.Headers, name); ok {
+						delete(out.Headers, k)
+					}
+					out.Headers[name] = want[name]
+				}
+			}
+		} else if info.browser != "" {
+			for k := range out.Headers {
+				if strings.HasPrefix(strings.ToLower(k), "sec-ch-ua") {
+					report(k, info.browser+" sends no client hints", "header removed")
+					delete(out.Headers, k)
+				}
+			}
+		}
+
+		switch {
+		case info.mobile && out.ViewportWidth > 1024:
+			report("viewport_width", fmt.Sprintf("%dpx is a desktop width for a mobile user agent", out.ViewportWidth), "set to 390x844")
+			out.ViewportWidth, out.ViewportHeight = 390, 844
+		case !info.mobile && info.browser != "" && out.ViewportWidth > 0 && out.ViewportWidth < 768:
+			report("viewport_width", fmt.Sprintf("%dpx is a phone width for a desktop user agent", out.ViewportWidth), "set to 1920x1080")
+			out.ViewportWidth, out.ViewportHeight = 1920, 1080
+		}
+	}
+
+	if config != nil && config.Locale != "" {
+		if k, ok := headerKey(out.Headers, "Accept-Language"); ok {
+			first := strings.TrimSpace(strings.Split(strings.Split(out.Headers[k], ",")[0], ";")[0])
+			got, _, _ := strings.Cut(first, "-")
+			want, _, _ := strings.Cut(config.Locale, "-")
+			if !strings.EqualFold(got, want) {
+				report(k, fmt.Sprintf("%q does not match locale %q", out.Headers[k], config.Locale), "set from the locale")
+				out.Headers[k] = acceptLanguage(config.Locale)
+			}
+		}
+	}
+
+	if !fix {
+		return bc, warnings
+	}
+	if len(out.Headers) == 0 {
+		out.Headers = nil
+	}
+	return &out, warnings
+}
+
+// checkFingerprint applies CrawlerOptions.FingerprintCheck to bc, passing
+// each warning to OnWarning. Fingerprint problems are never fatal, even
+// under StrictOptions: the options are sent, just not consistently.
+func (c *AsyncWebCrawler) checkFingerprint(config *CrawlerRunConfig, bc *BrowserConfig) (*BrowserConfig, []Warning) {
+	var warnings []Warning
+	switch c.fingerprintCheck {
+	case FingerprintWarn:
+		warnings = CheckFingerprint(config, bc)
+	case FingerprintFix:
+		bc, warnings = FixFingerprint(config, bc)
+	}
+	for _, w := range warnings {
+		c.warn(w)
+	}
+	return bc, warnings
+}
@@ -0,0 +1,125 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const (
+	chrome126Windows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36"
+	firefoxMac       = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:128.0) Gecko/20100101 Firefox/128.0"
+	iPhoneSafari     = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestCheckFingerprint_Consistent(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		fp := RandomFingerprint(&UserAgentOptions{Locale: "de-DE"})
+		if w := CheckFingerprint(&CrawlerRunConfig{Locale: "de-DE"}, fp.Apply(nil)); len(w) != 0 {
+			t.Fatalf("generated fingerprint flagged: %v (%s)", w, fp.UserAgent)
+		}
+	}
+	if w := CheckFingerprint(nil, nil); w != nil {
+		t.Errorf("nil config: %v", w)
+	}
+}
+
+func TestCheckFingerprint_Mismatches(t *testing.T) {
+	bc := &BrowserConfig{
+		UserAgent:     chrome126Windows,
+		ViewportWidth: 390,
+		Headers: map[string]string{
+			"User-Agent":         firefoxMac,
+			"Sec-CH-UA":          `"Chromium";v="120", "Google Chrome";v="120"`,
+			"sec-ch-ua-platform": `"macOS"`,
+			"Accept-Language":    "en-US,en;q=0.9",
+		},
+	}
+	warnings := CheckFingerprint(&CrawlerRunConfig{Locale: "fr-FR"}, bc)
+	var fields []string
+	for _, w := range warnings {
+		fields = append(fields, w.Field)
+	}
+	want := "Accept-Language,Sec-CH-UA,User-Agent,sec-ch-ua-platform,viewport_width"
+	if sort.Strings(fields); strings.Join(fields, ",") != want {
+		t.Errorf("fields = %v, want %s", warnings, want)
+	}
+	if bc.Headers["User-Agent"] != firefoxMac {
+		t.Error("CheckFingerprint modified its input")
+	}
+}
+
+func TestFixFingerprint(t *testing.T) {
+	bc := &BrowserConfig{
+		UserAgent:     chrome126Windows,
+		ViewportWidth: 390, ViewportHeight: 844,
+		Headers: map[string]string{"Sec-CH-UA": `"Chromium";v="120"`, "Accept-Language": "en"},
+	}
+	fixed, warnings := FixFingerprint(&CrawlerRunConfig{Locale: "fr-FR"}, bc)
+	if len(warnings) != 3 || !strings.HasSuffix(warnings[0].Message, "regenerated") {
+		t.Errorf("warnings = %v", warnings)
+	}
+	if fixed.Headers["sec-ch-ua-platform"] != `"Windows"` || !strings.Contains(fixed.Headers["sec-ch-ua"], `v="126"`) {
+		t.Errorf("hints = %v", fixed.Headers)
+	}
+	if _, stale := fixed.Headers["Sec-CH-UA"]; stale {
+		t.Error("stale hint kept under its original casing")
+	}
+	if fixed.Headers["Accept-Language"] != "fr-FR,fr;q=0.9" || fixed.ViewportWidth != 1920 {
+		t.Errorf("fixed = %+v", fixed)
+	}
+	if w := CheckFingerprint(&CrawlerRunConfig{Locale: "fr-FR"}, fixed); len(w) != 0 {
+		t.Errorf("fixed config still flagged: %v", w)
+	}
+
+	// Safari sends no client hints: they are dropped, not regenerated.
+	fixed, _ = FixFingerprint(nil, &BrowserConfig{UserAgent: iPhoneSafari, Headers: map[string]string{"sec-ch-ua-mobile": "?1"}})
+	if len(fixed.Headers) != 0 {
+		t.Errorf("safari headers = %v", fixed.Headers)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestFingerprintCheck_Option(t *testing.T) {
+	if _, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", FingerprintCheck: "always"}); err == nil {
+		t.Error("unknown mode: want error")
+	}
+
+	var sent map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://example.com", "success": true})
+	}))
+	defer srv.Close()
+	var logged []Warning
+	c, err := NewAsyncWebCrawler(CrawlerOptions{
+		APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1,
+		FingerprintCheck: FingerprintFix,
+		OnWarning:        func(w Warning) { logged = append(logged, w) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := c.Run("https://example.com", &RunOptions{
+		Config:        &CrawlerRunConfig{Locale: "ja-JP"},
+		BrowserConfig: &BrowserConfig{UserAgent: firefoxMac},
+		Headers:       map[string]string{"Accept-Language": "en-US"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bc, _ := sent["browser_config"].(map[string]interface{})
+	headers, _ := bc["headers"].(map[string]interface{})
+	if headers["Accept-Language"] != "ja-JP,ja;q=0.9" {
+		t.Errorf("sent headers = %v", headers)
+	}
+	if len(result.Warnings) != 1 || len(logged) != 1 || result.Warnings[0].Field != "Accept-Language" {
+		t.Errorf("result warnings %v, logged %v", result.Warnings, logged)
+	}
+}
@@ -0,0 +1,101 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FormField is one input to fill before submitting a form. Value is typed
+// into text inputs and textareas and selects the matching option of a
+// <select>.
+type FormField struct {
+	Selector string
+	Value    string
+}
+
+// FormSpec describes a form submission for SubmitForm: fill Fields in
+// order, click SubmitSelector, then wait for SuccessSelector — an element
+// that only appears once the post-submit page (search results, a
+// dashboard) has rendered.
+//
+//	result, err := crawler.SubmitForm("https://shop.example.com/search", crawl4ai.FormSpec{
+//	    Fields:          []crawl4ai.FormField{{Selector: "input[name=q]", Value: "usb-c hub"}},
+//	    SubmitSelector:  "form.search button[type=submit]",
+//	    SuccessSelector: ".search-results .product-card",
+//	}, nil)
+type FormSpec struct {
+	Fields          []FormField
+	SubmitSelector  string
+	SuccessSelector string
+	// StepTimeout bounds how long each field and the submit button are
+	// waited for. Default 15s.
+	StepTimeout time.Duration
+}
+
+// SubmitForm opens url, fills and submits the form described by spec, and
+// returns the page it lands on. opts supplies the rest of the crawl
+// (browser config, session, proxy); any JsCode in opts.Config runs before
+// the form is filled, e.g. to dismiss a cookie banner. Submissions always
+// bypass the cache. Use RunAuthFlow instead for logins whose session you
+// want to keep.
+func (c *AsyncWebCrawler) SubmitForm(url string, spec FormSpec, opts *RunOptions) (*CrawlResult, error) {
+	if spec.SubmitSelector == "" || spec.SuccessSelector == "" {
+		return nil, fmt.Errorf("submit form: SubmitSelector and SuccessSelector are required")
+	}
+	script, err := spec.script()
+	if err != nil {
+		return nil, err
+	}
+
+	o := RunOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Strategy == "http" {
+		return nil, fmt.Errorf("submit form: the http strategy cannot run page scripts; use browser")
+	}
+	cfg := CrawlerRunConfig{}
+	if o.Config != nil {
+		cfg = *o.Config
+	}
+	if cfg.JsCode != "" {
+		script = cfg.JsCode + "\n" + script
+	}
+	cfg.JsCode = script
+	cfg.WaitFor = WaitForSelector(spec.SuccessSelector).String()
+	o.Config = &cfg
+	o.Strategy = "browser"
+	o.BypassCache = true
+
+	result, err := c.Run(url, &o)
+	if err != nil {
+		return nil, fmt.Errorf("submit form %s: %w", url, err)
+	}
+	if !result.Success {
+		return result, fmt.Errorf("submit form %s: page did not reach %q: %s", url, spec.SuccessSelector, result.ErrorMessage)
+	}
+	return result, nil
+}
+
+// script compiles the spec into the js_code run on the form page.
+func (f *FormSpec) script() (string, error) {
+	timeout := f.StepTimeout
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	var b strings.Builder
+	b.WriteString(interactionPrelude(timeout))
+	for i, field := range f.Fields {
+		if strings.TrimSpace(field.Selector) == "" {
+			return "", fmt.Errorf("submit form: field %d has no selector", i)
+		}
+		sel, _ := json.Marshal(field.Selector)
+		val, _ := json.Marshal(field.Value)
+		fmt.Fprintf(&b, "await __fill(%s, %s);\n", sel, val)
+	}
+	sel, _ := json.Marshal(f.SubmitSelector)
+	fmt.Fprintf(&b, "(await __wait(%s)).click();\n", sel)
+	return b.String(), nil
+}
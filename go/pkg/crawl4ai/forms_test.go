@@ -0,0 +1,103 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestFormSpec_Script(t *testing.T) {
+	spec := &FormSpec{
+		Fields: []FormField{
+			{Selector: "input[name=q]", Value: `usb "c"`},
+			{Selector: "select#sort", Value: "price_asc"},
+		},
+		SubmitSelector: "button[type=submit]",
+		StepTimeout:    5 * time.Second,
+	}
+	script, err := spec.script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"const __t = 5000;",
+		`await __fill("input[name=q]", "usb \"c\"");`,
+		`await __fill("select#sort", "price_asc");`,
+		`(await __wait("button[type=submit]")).click();`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+	if strings.Index(script, "select#sort") > strings.Index(script, ".click()") {
+		t.Error("fields must be filled before submitting")
+	}
+
+	spec.Fields = append(spec.Fields, FormField{Value: "x"})
+	if _, err := spec.script(); err == nil {
+		t.Fatal("expected error for field without selector")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestSubmitForm_BuildsRequest(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "https://shop.com/search", "success": true, "html": "<div class=results></div>",
+		})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.SubmitForm("https://shop.com/search", FormSpec{
+		Fields:          []FormField{{Selector: "#q", Value: "hub"}},
+		SubmitSelector:  "#go",
+		SuccessSelector: ".results",
+	}, &RunOptions{Config: &CrawlerRunConfig{JsCode: "closeBanner();", PageTimeout: 30000}})
+	if err != nil || !result.Success {
+		t.Fatalf("SubmitForm: %+v, %v", result, err)
+	}
+	cc := body["crawler_config"].(map[string]interface{})
+	js := cc["js_code"].(string)
+	if !strings.HasPrefix(js, "closeBanner();") || !strings.Contains(js, `await __fill("#q", "hub");`) {
+		t.Fatalf("unexpected js_code: %s", js)
+	}
+	if cc["wait_for"] != "css:.results" || cc["page_timeout"] != 30000.0 || body["bypass_cache"] != true {
+		t.Fatalf("unexpected request: %v", body)
+	}
+}
+
+func TestSubmitForm_Validation(t *testing.T) {
+	c, _ := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: "http://127.0.0.1:1", MaxRetries: 1})
+	if _, err := c.SubmitForm("https://a.com", FormSpec{SubmitSelector: "#go"}, nil); err == nil {
+		t.Fatal("expected error without SuccessSelector")
+	}
+	spec := FormSpec{SubmitSelector: "#go", SuccessSelector: ".ok"}
+	if _, err := c.SubmitForm("https://a.com", spec, &RunOptions{Strategy: "http"}); err == nil ||
+		!strings.Contains(err.Error(), "http strategy") {
+		t.Fatalf("expected http strategy error, got %v", err)
+	}
+}
+
+func TestSubmitForm_SuccessNotReached(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl": map[string]interface{}{
+			"url": "https://a.com", "success": false, "error_message": "Wait condition failed: timeout",
+		},
+	})
+	result, err := c.SubmitForm("https://a.com", FormSpec{SubmitSelector: "#go", SuccessSelector: ".ok"}, nil)
+	if err == nil || result == nil || !strings.Contains(err.Error(), `did not reach ".ok"`) {
+		t.Fatalf("expected failure with result, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,101 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// GetJobsOptions are options for GetJobs.
+type GetJobsOptions struct {
+	// Concurrency bounds the parallel GetJob calls made when the API has
+	// no batch endpoint. Default 8.
+	Concurrency int
+}
+
+// GetJobs fetches the status of many jobs at once, keyed by job ID, for
+// dashboards that would otherwise issue one GetJob per job per refresh.
+// Jobs that don't exist are left out of the map.
+//
+//	jobs, err := crawler.GetJobs(ids, nil)
+//	for _, id := range ids {
+//	    if job, ok := jobs[id]; ok {
+//	        fmt.Printf("%s %s %.0f%%\n", id, job.Status, job.Progress.Percent())
+//	    }
+//	}
+//
+// One request to POST /v1/crawl/jobs/batch covers every ID. Deployments
+// without it are asked job by job, concurrently; there, the jobs that
+// could be fetched are returned along with the errors of those that
+// couldn't.
+func (c *AsyncWebCrawler) GetJobs(jobIDs []string, opts *GetJobsOptions) (map[string]*CrawlJob, error) {
+	if opts == nil {
+		opts = &GetJobsOptions{}
+	}
+	jobs := make(map[string]*CrawlJob, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return jobs, nil
+	}
+
+	data, err := c.http.Post("/v1/crawl/jobs/batch", map[string]interface{}{"job_ids": jobIDs}, 0)
+	if ce, ok := AsCloudError(err); ok && (ce.StatusCode == http.StatusNotFound || ce.StatusCode == http.StatusMethodNotAllowed) {
+		return c.getJobsEach(jobIDs, opts.Concurrency)
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, _ := data["jobs"].([]interface{})
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		job, err := c.applyJobResultHooks(CrawlJobFromMap(m))
+		if err != nil {
+			return nil, fmt.Errorf("job %s: %w", job.JobID, err)
+		}
+		jobs[job.JobID] = job
+	}
+	return jobs, nil
+}
+
+// getJobsEach is GetJobs through concurrent GetJob calls.
+func (c *AsyncWebCrawler) getJobsEach(jobIDs []string, concurrency int) (map[string]*CrawlJob, error) {
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	jobs := make(map[string]*CrawlJob, len(jobIDs))
+	sem := make(chan struct{}, concurrency)
+	seen := make(map[string]bool, len(jobIDs))
+	for _, id := range jobIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			job, err := c.GetJob(id)
+			mu.Lock()
+			defer mu.Unlock()
+			var notFound *NotFoundError
+			switch {
+			case errors.As(err, &notFound):
+			case err != nil:
+				errs = append(errs, fmt.Errorf("job %s: %w", id, err))
+			default:
+				jobs[id] = job
+			}
+		}(id)
+	}
+	wg.Wait()
+	return jobs, errors.Join(errs...)
+}
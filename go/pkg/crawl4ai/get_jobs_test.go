@@ -0,0 +1,62 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestGetJobs_Batch(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var body struct {
+			JobIDs []string `json:"job_ids"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/crawl/jobs/batch" || len(body.JobIDs) != 3 {
+			t.Errorf("%s %s %v", r.Method, r.URL.Path, body.JobIDs)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jobs": []interface{}{
+			map[string]interface{}{"job_id": "job_1", "status": "running"},
+			map[string]interface{}{"job_id": "job_2", "status": "completed"},
+		}})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := c.GetJobs([]string{"job_1", "job_2", "job_gone"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || jobs["job_1"].Status != JobStatusRunning || jobs["job_2"].Status != JobStatusCompleted {
+		t.Errorf("jobs = %v", jobs)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("%d requests, want 1", requests.Load())
+	}
+}
+
+func TestGetJobs_Fallback(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{"job_id": "job_1", "status": "running"},
+		"GET /v1/crawl/jobs/job_2": map[string]interface{}{"job_id": "job_2", "status": "failed"},
+	})
+	jobs, err := c.GetJobs([]string{"job_1", "job_2", "job_gone", "job_1"}, &GetJobsOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || jobs["job_2"].Status != JobStatusFailed {
+		t.Errorf("jobs = %v", jobs)
+	}
+	if jobs, err := c.GetJobs(nil, nil); err != nil || len(jobs) != 0 {
+		t.Errorf("no IDs: %v, %v", jobs, err)
+	}
+}
@@ -0,0 +1,125 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HealthGate configures the pre-submission health check for async jobs.
+// When the API reports a backed-up or degraded queue, a large job would sit
+// waiting for hours; the gate refuses it up front instead.
+type HealthGate struct {
+	// MinURLs applies the gate only to jobs with at least this many URLs,
+	// so small jobs skip the extra /health round trip. Default 0: every job.
+	MinURLs int
+	// MaxQueueDepth refuses submission when more than this many jobs are
+	// queued. 0 ignores queue depth.
+	MaxQueueDepth int
+	// MaxQueueWait refuses submission when the reported queue wait exceeds
+	// it. 0 ignores queue wait.
+	MaxQueueWait time.Duration
+	// WarnOnly submits anyway and reports the problem to OnDegraded.
+	WarnOnly   bool
+	OnDegraded func(health *APIHealth, reason string)
+	// Force skips the gate entirely, e.g. for a retry the caller has
+	// decided must go through.
+	Force bool
+}
+
+// APIHealth is the parsed /health response.
+type APIHealth struct {
+	// Status is the server's overall status, e.g. "healthy" or "degraded".
+	Status string
+	// QueueDepth is the number of queued jobs; -1 when not reported.
+	QueueDepth int
+	// QueueWait is the server's estimate of how long a new job waits
+	// before starting; 0 when not reported.
+	QueueWait time.Duration
+	Raw       map[string]interface{}
+}
+
+// Degraded reports whether the server flagged itself as unhealthy.
+func (h *APIHealth) Degraded() bool {
+	switch strings.ToLower(h.Status) {
+	case "", "ok", "healthy":
+		return false
+	}
+	return true
+}
+
+// DegradedAPIError is returned by RunMany with HealthGate set when the API
+// is too backed up to accept the job. Resubmit later or set Force.
+type DegradedAPIError struct {
+	Health *APIHealth
+	Reason string
+}
+
+// Error implements error.
+func (e *DegradedAPIError) Error() string {
+	return "job not submitted: " + e.Reason
+}
+
+// HealthStatus fetches /health and parses the queue fields. Servers that
+// report only a status leave QueueDepth at -1.
+func (c *AsyncWebCrawler) HealthStatus() (*APIHealth, error) {
+	data, err := c.Health()
+	if err != nil {
+		return nil, err
+	}
+	h := &APIHealth{QueueDepth: -1, Raw: data}
+	h.Status, _ = data["status"].(string)
+	queue, _ := data["queue"].(map[string]interface{})
+	if queue == nil {
+		queue = data
+	}
+	for _, key := range []string{"depth", "queue_depth", "pending"} {
+		if v, ok := queue[key].(float64); ok {
+			h.QueueDepth = int(v)
+			break
+		}
+	}
+	for _, key := range []string{"estimated_wait_seconds", "queue_wait_seconds"} {
+		if v, ok := queue[key].(float64); ok {
+			h.QueueWait = time.Duration(v * float64(time.Second))
+			break
+		}
+	}
+	return h, nil
+}
+
+// checkHealth applies gate to a job of urlCount URLs.
+func (c *AsyncWebCrawler) checkHealth(urlCount int, gate *HealthGate) error {
+	if gate.Force || urlCount < gate.MinURLs {
+		return nil
+	}
+	h, err := c.HealthStatus()
+	if err != nil {
+		if gate.WarnOnly {
+			if gate.OnDegraded != nil {
+				gate.OnDegraded(nil, fmt.Sprintf("health check failed: %v", err))
+			}
+			return nil
+		}
+		return fmt.Errorf("health check: %w", err)
+	}
+
+	var reason string
+	switch {
+	case h.Degraded():
+		reason = fmt.Sprintf("API status is %q", h.Status)
+	case gate.MaxQueueDepth > 0 && h.QueueDepth > gate.MaxQueueDepth:
+		reason = fmt.Sprintf("%d jobs queued (limit %d)", h.QueueDepth, gate.MaxQueueDepth)
+	case gate.MaxQueueWait > 0 && h.QueueWait > gate.MaxQueueWait:
+		reason = fmt.Sprintf("queue wait is about %s (limit %s)", h.QueueWait.Round(time.Second), gate.MaxQueueWait)
+	default:
+		return nil
+	}
+	if gate.WarnOnly {
+		if gate.OnDegraded != nil {
+			gate.OnDegraded(h, reason)
+		}
+		return nil
+	}
+	return &DegradedAPIError{Health: h, Reason: reason}
+}
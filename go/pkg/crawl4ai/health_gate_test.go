@@ -0,0 +1,62 @@
+package crawl4ai
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestHealthGate_RefusesBackedUpQueue(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /health": map[string]interface{}{
+			"status": "healthy",
+			"queue":  map[string]interface{}{"depth": 1200.0, "estimated_wait_seconds": 5400.0},
+		},
+		"POST /v1/crawl/async": map[string]interface{}{"job_id": "job_1", "status": "pending"},
+	})
+	urls := []string{"https://a.com", "https://b.com"}
+
+	_, err := c.RunMany(urls, &RunManyOptions{HealthGate: &HealthGate{MaxQueueWait: time.Hour}})
+	var degraded *DegradedAPIError
+	if !errors.As(err, &degraded) || degraded.Health.QueueDepth != 1200 || degraded.Health.QueueWait != 90*time.Minute {
+		t.Fatalf("expected queue wait refusal, got %v", err)
+	}
+	if err.Error() != "job not submitted: queue wait is about 1h30m0s (limit 1h0m0s)" {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+
+	if _, err := c.RunMany(urls, &RunManyOptions{HealthGate: &HealthGate{MaxQueueWait: time.Hour, MinURLs: 3}}); err != nil {
+		t.Fatalf("small job should skip the gate: %v", err)
+	}
+	if _, err := c.RunMany(urls, &RunManyOptions{HealthGate: &HealthGate{MaxQueueDepth: 100, Force: true}}); err != nil {
+		t.Fatalf("Force should bypass the gate: %v", err)
+	}
+
+	var warned string
+	gate := &HealthGate{MaxQueueDepth: 100, WarnOnly: true, OnDegraded: func(_ *APIHealth, reason string) { warned = reason }}
+	if _, err := c.RunMany(urls, &RunManyOptions{HealthGate: gate}); err != nil {
+		t.Fatalf("WarnOnly should submit: %v", err)
+	}
+	if warned != "1200 jobs queued (limit 100)" {
+		t.Fatalf("unexpected warning: %q", warned)
+	}
+}
+
+func TestHealthGate_DegradedStatus(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /health": map[string]interface{}{"status": "degraded"},
+	})
+	h, err := c.HealthStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.Degraded() || h.QueueDepth != -1 {
+		t.Fatalf("unexpected health: %+v", h)
+	}
+	var degraded *DegradedAPIError
+	if err := c.checkHealth(10, &HealthGate{}); !errors.As(err, &degraded) {
+		t.Fatalf("expected degraded refusal, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResultHook runs on every CrawlResult the crawler decodes — from Run,
+// job fetches (GetJob, WaitJob, RunMany with Wait), per-URL results and
+// wrapper-job hydration. Hooks may mutate the result in place to enrich or
+// normalize it, or return ErrSkipResult to filter it out. Any other error
+// aborts the call that produced the result.
+//
+//	crawler, _ := NewAsyncWebCrawler(CrawlerOptions{
+//	    APIKey: key,
+//	    ResultHooks: []ResultHook{
+//	        func(r *CrawlResult) error {
+//	            if r.StatusCode == 404 {
+//	                return ErrSkipResult
+//	            }
+//	            r.URL = strings.TrimSuffix(r.URL, "/")
+//	            return nil
+//	        },
+//	    },
+//	})
+type ResultHook func(*CrawlResult) error
+
+// ErrSkipResult is returned by a ResultHook to drop a result. Skipped results
+// are removed from job result lists; single-result calls such as Run return
+// ErrSkipResult itself.
+var ErrSkipResult = errors.New("result skipped by hook")
+
+// ApplyResultHooks runs the crawler's ResultHooks on r, in order, stopping at
+// the first error. The crawler calls this itself; use it directly for
+// results decoded elsewhere, e.g. from a webhook payload:
+//
+//	result := CrawlResultFromMap(payload)
+//	if err := crawler.ApplyResultHooks(result); errors.Is(err, ErrSkipResult) {
+//	    return
+//	}
+func (c *AsyncWebCrawler) ApplyResultHooks(r *CrawlResult) error {
+	for _, hook := range c.resultHooks {
+		if err := hook(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyResultHooksAll runs the hooks over a result list, dropping skipped
+// results and wrapping the first hard failure with the offending URL.
+func (c *AsyncWebCrawler) applyResultHooksAll(results []*CrawlResult) ([]*CrawlResult, error) {
+	if len(c.resultHooks) == 0 {
+		return results, nil
+	}
+	kept := results[:0]
+	for _, r := range results {
+		err := c.ApplyResultHooks(r)
+		if errors.Is(err, ErrSkipResult) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("result hook for %s: %w", r.URL, err)
+		}
+		kept = append(kept, r)
+	}
+	return kept, nil
+}
+
+// applyJobResultHooks runs the hooks over a job's inlined results, then
+// hands a finished job to jobDone.
+func (c *AsyncWebCrawler) applyJobResultHooks(job *CrawlJob) (*CrawlJob, error) {
+	for _, r := range job.Results {
+		r.inJob = true
+	}
+	results, err := c.applyResultHooksAll(job.Results)
+	if err != nil {
+		return nil, err
+	}
+	job.Results = results
+	if c.jobDone != nil && job.IsComplete() {
+		c.jobDone(job)
+	}
+	return job, nil
+}
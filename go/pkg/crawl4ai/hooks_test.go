@@ -0,0 +1,102 @@
+package crawl4ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestResultHooks_RunMutatesResult(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl": map[string]interface{}{"url": "https://example.com/", "success": true},
+	})
+	c.resultHooks = []ResultHook{func(r *CrawlResult) error {
+		r.URL = strings.TrimSuffix(r.URL, "/")
+		return nil
+	}}
+	result, err := c.Run("https://example.com/", nil)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.URL != "https://example.com" {
+		t.Fatalf("expected hook to normalize URL, got %q", result.URL)
+	}
+}
+
+func TestResultHooks_RunPropagatesErrors(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl": map[string]interface{}{"url": "https://example.com", "success": false},
+	})
+	boom := errors.New("boom")
+	calls := 0
+	c.resultHooks = []ResultHook{
+		func(r *CrawlResult) error { calls++; return boom },
+		func(r *CrawlResult) error { calls++; return nil },
+	}
+	if _, err := c.Run("https://example.com", nil); !errors.Is(err, boom) {
+		t.Fatalf("expected hook error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected hooks to stop at the first error, ran %d", calls)
+	}
+}
+
+func TestResultHooks_GetJobSkipsResults(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{
+			"job_id": "job_1",
+			"status": "completed",
+			"results": []interface{}{
+				map[string]interface{}{"url": "https://a.com", "success": true},
+				map[string]interface{}{"url": "https://b.com", "success": false},
+			},
+		},
+	})
+	c.resultHooks = []ResultHook{func(r *CrawlResult) error {
+		if !r.Success {
+			return ErrSkipResult
+		}
+		return nil
+	}}
+	job, err := c.GetJob("job_1")
+	if err != nil {
+		t.Fatalf("GetJob: %v", err)
+	}
+	if len(job.Results) != 1 || job.Results[0].URL != "https://a.com" {
+		t.Fatalf("expected failed result skipped, got %+v", job.Results)
+	}
+}
+
+func TestResultHooks_ApplyWithoutHooks(t *testing.T) {
+	c := &AsyncWebCrawler{}
+	if err := c.ApplyResultHooks(&CrawlResult{}); err != nil {
+		t.Fatalf("expected no-op, got %v", err)
+	}
+}
+
+func TestResultHooks_WrapperHydrationPropagatesErrors(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/scrape/jobs/scr_1": map[string]interface{}{
+			"job_id": "scr_1",
+			"status": "completed",
+			"url_statuses": []interface{}{
+				map[string]interface{}{"index": 0, "url": "https://a.com", "status": "done"},
+				map[string]interface{}{"index": 1, "url": "https://b.com", "status": "failed"},
+			},
+		},
+		"GET /v1/crawl/jobs/scr_1/result/0": map[string]interface{}{"url": "https://a.com", "success": true},
+	})
+	boom := errors.New("boom")
+	c.resultHooks = []ResultHook{func(r *CrawlResult) error {
+		if r.URL == "https://a.com" {
+			return boom
+		}
+		return nil
+	}}
+	job, err := c.waitWrapperJob("scr_1", "markdown", 0, 0)
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected hook error, got job=%+v err=%v", job, err)
+	}
+}
@@ -0,0 +1,230 @@
+package crawl4ai
+
+import (
+	"html"
+	"strings"
+)
+
+// htmlNode is an element of the lightweight tree parseHTML builds for
+// client-side heuristics. Only elements are kept; Text is the element's
+// own text, whitespace-collapsed.
+type htmlNode struct {
+	Tag      string
+	Attrs    map[string]string
+	Classes  []string
+	Text     string
+	Parent   *htmlNode
+	Children []*htmlNode
+}
+
+// voidElements never have children or an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements hold text that isn't markup and isn't page content.
+var rawTextElements = map[string]bool{"script": true, "style": true, "template": true, "noscript": true}
+
+// impliedEnd lists, for a start tag, the open elements it implicitly
+// closes, as HTML's optional end tags do.
+var impliedEnd = map[string]map[string]bool{
+	"li":     {"li": true},
+	"p":      {"p": true},
+	"dt":     {"dt": true, "dd": true},
+	"dd":     {"dt": true, "dd": true},
+	"tr":     {"tr": true, "td": true, "th": true},
+	"td":     {"td": true, "th": true},
+	"th":     {"td": true, "th": true},
+	"option": {"option": true},
+}
+
+// parseHTML builds an element tree from a document or fragment. It is
+// forgiving rather than spec-complete: unknown end tags are ignored,
+// unclosed elements are closed by their parent's end tag, and script and
+// style contents are dropped. The returned root has an empty Tag.
+func parseHTML(doc string) *htmlNode {
+	root := &htmlNode{}
+	cur := root
+	var text []string
+	flush := func() {
+		if len(text) > 0 {
+			t := strings.Join(strings.Fields(html.UnescapeString(strings.Join(text, " "))), " ")
+			if t != "" {
+				cur.Text = strings.TrimSpace(cur.Text + " " + t)
+			}
+			text = text[:0]
+		}
+	}
+	for i := 0; i < len(doc); {
+		lt := strings.IndexByte(doc[i:], '<')
+		if lt < 0 {
+			text = append(text, doc[i:])
+			break
+		}
+		text = append(text, doc[i:i+lt])
+		i += lt
+		rest := doc[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			end := strings.Index(rest, "-->")
+			if end < 0 {
+				i = len(doc)
+			} else {
+				i += end + 3
+			}
+			continue
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			i += skipTo(rest, '>')
+			continue
+		case strings.HasPrefix(rest, "</"):
+			n := skipTo(rest, '>')
+			name := strings.ToLower(strings.TrimSpace(strings.TrimRight(rest[2:n], ">")))
+			flush()
+			for open := cur; open != root; open = open.Parent {
+				if open.Tag == name {
+					cur = open.Parent
+					break
+				}
+			}
+			i += n
+			continue
+		}
+		if len(rest) < 2 || !isASCIILetter(rest[1]) {
+			text = append(text, "<")
+			i++
+			continue
+		}
+
+		tag, attrs, selfClosing, n := parseStartTag(rest)
+		i += n
+		flush()
+		if closes := impliedEnd[tag]; closes != nil {
+			for cur != root && closes[cur.Tag] {
+				cur = cur.Parent
+			}
+		}
+		node := &htmlNode{Tag: tag, Attrs: attrs, Classes: strings.Fields(attrs["class"]), Parent: cur}
+		cur.Children = append(cur.Children, node)
+		if rawTextElements[tag] {
+			end := strings.Index(strings.ToLower(doc[i:]), "</"+tag)
+			if end < 0 {
+				i = len(doc)
+			} else {
+				i += end
+			}
+			continue
+		}
+		if !selfClosing && !voidElements[tag] {
+			cur = node
+		}
+	}
+	flush()
+	return root
+}
+
+// parseStartTag reads "<tag attr=value ...>" from the start of s, returning
+// the lower-cased tag, its attributes and how many bytes it spans.
+func parseStartTag(s string) (tag string, attrs map[string]string, selfClosing bool, n int) {
+	attrs = map[string]string{}
+	i := 1
+	for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	tag = strings.ToLower(s[1:i])
+	for i < len(s) {
+		for i < len(s) && isTagSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return tag, attrs, selfClosing, i + 1
+		}
+		if s[i] == '/' {
+			selfClosing = true
+			i++
+			continue
+		}
+		start := i
+		for i < len(s) && !isTagSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		name := strings.ToLower(s[start:i])
+		for i < len(s) && isTagSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isTagSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isTagSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if name != "" {
+			attrs[name] = html.UnescapeString(value)
+		}
+		selfClosing = false
+	}
+	return tag, attrs, selfClosing, len(s)
+}
+
+func skipTo(s string, c byte) int {
+	if n := strings.IndexByte(s, c); n >= 0 {
+		return n + 1
+	}
+	return len(s)
+}
+
+func isTagSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// walk calls fn for n and every element below it, depth first.
+func (n *htmlNode) walk(fn func(*htmlNode)) {
+	fn(n)
+	for _, c := range n.Children {
+		c.walk(fn)
+	}
+}
+
+// hasClass reports whether n carries class c.
+func (n *htmlNode) hasClass(c string) bool {
+	for _, have := range n.Classes {
+		if have == c {
+			return true
+		}
+	}
+	return false
+}
+
+// fullText is n's text including every descendant's.
+func (n *htmlNode) fullText() string {
+	var parts []string
+	n.walk(func(d *htmlNode) {
+		if d.Text != "" {
+			parts = append(parts, d.Text)
+		}
+	})
+	return strings.Join(parts, " ")
+}
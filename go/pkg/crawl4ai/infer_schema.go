@@ -0,0 +1,386 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrNoRepeatedStructure is returned by InferSchema when the page has no
+// group of similar elements to turn into a schema.
+var ErrNoRepeatedStructure = errors.New("crawl4ai: no repeated structure found")
+
+// minInferRepeats is how many similar siblings make a repeated structure.
+const minInferRepeats = 3
+
+// InferSchema proposes a json_css schema from html without an LLM or the
+// network: it finds the largest group of similar sibling elements (list
+// items, cards, table rows), takes it as BaseSelector, and turns the
+// text, links and images most items share into fields.
+//
+//	schema, err := crawl4ai.InferSchema(page.HTML)
+//	if errors.Is(err, crawl4ai.ErrNoRepeatedStructure) {
+//	    generated, err := crawler.GenerateSchema(page.HTML, &crawl4ai.GenerateSchemaOptions{Query: "products"})
+//	}
+//	strategy, _ := crawl4ai.JSONCSSExtraction(schema)
+//
+// It handles simple, regular pages. Field names are guessed from class
+// names and tags, so review them; use GenerateSchema when the page needs
+// judgement.
+func InferSchema(html string) (*Schema, error) {
+	root := parseHTML(html)
+	var best *inferCandidate
+	root.walk(func(parent *htmlNode) {
+		for _, group := range similarChildren(parent) {
+			c := newInferCandidate(group)
+			if c != nil && (best == nil || c.score > best.score) {
+				best = c
+			}
+		}
+	})
+	if best == nil {
+		return nil, ErrNoRepeatedStructure
+	}
+	schema := &Schema{
+		Name:         "Inferred " + best.itemSelector,
+		BaseSelector: baseSelector(root, best.items),
+		Fields:       best.fields,
+	}
+	if err := schema.Validate(); err != nil {
+		return nil, fmt.Errorf("infer schema: %w", err)
+	}
+	return schema, nil
+}
+
+// similarChildren groups parent's children by tag and shared class,
+// keeping the groups large enough to be a repeated structure.
+func similarChildren(parent *htmlNode) [][]*htmlNode {
+	groups := map[string][]*htmlNode{}
+	var order []string
+	for _, c := range parent.Children {
+		if rawTextElements[c.Tag] || voidElements[c.Tag] {
+			continue
+		}
+		key := c.Tag + "." + stableClass(c)
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+	var out [][]*htmlNode
+	for _, key := range order {
+		if len(groups[key]) >= minInferRepeats {
+			out = append(out, groups[key])
+		}
+	}
+	return out
+}
+
+type inferCandidate struct {
+	items        []*htmlNode
+	itemSelector string
+	fields       []Field
+	score        int
+}
+
+// inferField is one value found inside an item.
+type inferField struct {
+	selector  string
+	attribute string // "" for text
+	node      *htmlNode
+}
+
+// newInferCandidate derives fields from a group of similar items, or
+// returns nil when they share none.
+func newInferCandidate(items []*htmlNode) *inferCandidate {
+	seen := map[inferField]int{}
+	var order []inferField
+	for _, item := range items {
+		found := map[inferField]bool{}
+		for _, f := range itemFields(item) {
+			key := inferField{selector: f.selector, attribute: f.attribute}
+			if found[key] {
+				continue
+			}
+			found[key] = true
+			if seen[key] == 0 {
+				order = append(order, inferField{selector: f.selector, attribute: f.attribute, node: f.node})
+			}
+			seen[key]++
+		}
+	}
+	// A field must appear in at least half of the items.
+	var kept []inferField
+	for _, f := range order {
+		if seen[inferField{selector: f.selector, attribute: f.attribute}]*2 >= len(items) {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+
+	names := map[string]int{}
+	fields := make([]Field, 0, len(kept))
+	for _, f := range kept {
+		name := fieldName(f)
+		names[name]++
+		if n := names[name]; n > 1 {
+			name += "_" + strconv.Itoa(n)
+		}
+		if f.attribute != "" {
+			fields = append(fields, NewAttributeField(name, f.selector, f.attribute))
+		} else {
+			fields = append(fields, NewTextField(name, f.selector))
+		}
+	}
+	// Richer items beat longer lists: a 30-link menu shouldn't win over a
+	// grid of product cards.
+	return &inferCandidate{
+		items:        items,
+		itemSelector: compoundSelector(items[0]),
+		fields:       fields,
+		score:        len(fields) * len(fields) * min(len(items), 10),
+	}
+}
+
+// itemFields lists the text, link and image values inside item, in
+// document order, with selectors relative to item.
+func itemFields(item *htmlNode) []inferField {
+	var out []inferField
+	item.walk(func(n *htmlNode) {
+		sel := ""
+		if n != item {
+			sel = relativeSelector(item, n)
+		}
+		if n.Text != "" && (len(n.Children) == 0 || isInlineText(n)) {
+			out = append(out, inferField{selector: sel, node: n})
+		}
+		switch {
+		case n.Tag == "a" && n.Attrs["href"] != "":
+			out = append(out, inferField{selector: sel, attribute: "href", node: n})
+		case n.Tag == "img" && n.Attrs["src"] != "":
+			out = append(out, inferField{selector: sel, attribute: "src", node: n})
+		}
+	})
+	return out
+}
+
+// isInlineText reports whether n's text is a value of its own (a heading
+// or link with markup inside) rather than loose text between children.
+func isInlineText(n *htmlNode) bool {
+	switch n.Tag {
+	case "a", "h1", "h2", "h3", "h4", "h5", "h6", "td", "th", "li", "p", "span", "time":
+		return true
+	}
+	return false
+}
+
+// relativeSelector is the shortest simple selector for n within item: its
+// tag and class when that is unique in the item, otherwise the child path
+// from item with :nth-of-type where siblings are alike.
+func relativeSelector(item, n *htmlNode) string {
+	compound := compoundSelector(n)
+	if countMatches(item, n.Tag, stableClass(n)) == 1 {
+		return compound
+	}
+	var path []string
+	for cur := n; cur != item; cur = cur.Parent {
+		seg := compoundSelector(cur)
+		if alike := countAlike(cur); alike > 1 {
+			seg += ":nth-of-type(" + strconv.Itoa(typeIndex(cur)) + ")"
+		}
+		path = append(path, seg)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return strings.Join(path, " > ")
+}
+
+// baseSelector picks a selector for items that matches nothing else in
+// the document: their tag and class alone if that suffices, otherwise
+// anchored under their parent.
+func baseSelector(root *htmlNode, items []*htmlNode) string {
+	item := items[0]
+	compound := compoundSelector(item)
+	if stableClass(item) != "" && countMatches(root, item.Tag, stableClass(item)) == len(items) {
+		return compound
+	}
+	return anchorSelector(root, item.Parent) + combinator(item) + compound
+}
+
+// combinator joins n to its parent's selector. Browsers wrap rows written
+// straight under <table> in an implicit <tbody>, so such a row is matched
+// as a descendant ("table tr") rather than a child, which would match
+// nothing once the page is rendered.
+func combinator(n *htmlNode) string {
+	if n.Tag == "tr" && n.Parent != nil && n.Parent.Tag == "table" {
+		return " "
+	}
+	return " > "
+}
+
+// anchorSelector identifies n in the document by id, a unique class, or
+// its path from the nearest ancestor that has one.
+func anchorSelector(root, n *htmlNode) string {
+	if n == root || n.Parent == nil {
+		return ""
+	}
+	if id := n.Attrs["id"]; id != "" && isPlainIdent(id) {
+		return n.Tag + "#" + id
+	}
+	if cls := stableClass(n); cls != "" && countMatches(root, n.Tag, cls) == 1 {
+		return n.Tag + "." + cls
+	}
+	if n.Tag == "body" || n.Parent == root {
+		return n.Tag
+	}
+	seg := n.Tag
+	if cls := stableClass(n); cls != "" {
+		seg += "." + cls
+	}
+	if countAlike(n) > 1 {
+		seg += ":nth-of-type(" + strconv.Itoa(typeIndex(n)) + ")"
+	}
+	if parent := anchorSelector(root, n.Parent); parent != "" {
+		return parent + combinator(n) + seg
+	}
+	return seg
+}
+
+// compoundSelector is n's tag plus its first stable class.
+func compoundSelector(n *htmlNode) string {
+	if cls := stableClass(n); cls != "" {
+		return n.Tag + "." + cls
+	}
+	return n.Tag
+}
+
+// stableClass returns n's first class that looks hand-written rather than
+// generated by a CSS-in-JS tool or carrying state (e.g. "active").
+func stableClass(n *htmlNode) string {
+	for _, c := range n.Classes {
+		if isPlainIdent(c) && !looksGenerated(c) && !stateClasses[c] {
+			return c
+		}
+	}
+	return ""
+}
+
+var stateClasses = map[string]bool{
+	"active": true, "selected": true, "current": true, "open": true, "hidden": true,
+	"odd": true, "even": true, "first": true, "last": true, "disabled": true,
+}
+
+// looksGenerated spots hashed class names such as "css-1x2y3z" or
+// "sc-bdVaJa": a short prefix followed by a mix of digits and letters.
+func looksGenerated(c string) bool {
+	if strings.HasPrefix(c, "css-") || strings.HasPrefix(c, "sc-") || strings.HasPrefix(c, "jsx-") {
+		return true
+	}
+	digits := 0
+	for _, r := range c {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits >= 3 && digits*3 >= len(c)
+}
+
+func isPlainIdent(s string) bool {
+	for i, r := range s {
+		if !(r == '-' || r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// countMatches counts elements under root (inclusive) with tag and, when
+// set, class.
+func countMatches(root *htmlNode, tag, class string) int {
+	n := 0
+	root.walk(func(d *htmlNode) {
+		if d.Tag == tag && (class == "" || d.hasClass(class)) {
+			n++
+		}
+	})
+	return n
+}
+
+// countAlike counts n's siblings, itself included, of the same tag.
+func countAlike(n *htmlNode) int {
+	alike := 0
+	for _, s := range n.Parent.Children {
+		if s.Tag == n.Tag {
+			alike++
+		}
+	}
+	return alike
+}
+
+// typeIndex is n's 1-based position among its siblings of the same tag.
+func typeIndex(n *htmlNode) int {
+	i := 0
+	for _, s := range n.Parent.Children {
+		if s.Tag == n.Tag {
+			i++
+		}
+		if s == n {
+			break
+		}
+	}
+	return i
+}
+
+// fieldName guesses a snake_case name from the field's class or tag.
+func fieldName(f inferField) string {
+	n := f.node
+	name := ""
+	if cls := stableClass(n); cls != "" && f.node.Tag != "td" {
+		name = snakeCase(cls)
+	} else {
+		switch n.Tag {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			name = "title"
+		case "a":
+			name = "link"
+		case "img":
+			name = "image"
+		case "time":
+			name = "date"
+		case "p":
+			name = "description"
+		case "td", "th":
+			name = "column_" + strconv.Itoa(typeIndex(n))
+		default:
+			name = "text"
+		}
+	}
+	switch f.attribute {
+	case "href":
+		if name == "link" {
+			return "url"
+		}
+		return name + "_url"
+	case "src":
+		if name == "image" {
+			return name
+		}
+		return name + "_image"
+	}
+	return name
+}
+
+func snakeCase(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return '_'
+		}
+		return unicode.ToLower(r)
+	}, s)
+	return strings.Trim(s, "_")
+}
@@ -0,0 +1,114 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestParseHTML(t *testing.T) {
+	root := parseHTML(`<!DOCTYPE html><html><head><script>if (a < b) { x = "</div>" }</script></head>
+<body><ul id=list><li class="item active">One &amp; two<li class=item>Three<br/>four</ul><p>Unclosed <b>bold</p><!-- <div> --></body></html>`)
+	var tags []string
+	var texts []string
+	root.walk(func(n *htmlNode) {
+		if n.Tag != "" {
+			tags = append(tags, n.Tag)
+		}
+		if n.Text != "" {
+			texts = append(texts, n.Text)
+		}
+	})
+	if got := strings.Join(tags, ","); got != "html,head,script,body,ul,li,li,br,p,b" {
+		t.Errorf("tags = %s", got)
+	}
+	if got := strings.Join(texts, "|"); got != "One & two|Three four|Unclosed|bold" {
+		t.Errorf("texts = %s", got)
+	}
+	var ul *htmlNode
+	root.walk(func(n *htmlNode) {
+		if n.Tag == "ul" {
+			ul = n
+		}
+	})
+	if ul.Attrs["id"] != "list" || len(ul.Children) != 2 || !ul.Children[0].hasClass("active") {
+		t.Errorf("ul = %+v", ul)
+	}
+}
+
+func TestInferSchema_Cards(t *testing.T) {
+	var cards strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&cards, `<div class="product-card css-%d9x8">
+  <a href="/p/%d"><img src="/img/%d.jpg" alt=""></a>
+  <h3 class="product-title">Product %d</h3>
+  <span class="price">$%d.00</span>
+  <span class="badge">Sale</span>
+</div>`, i, i, i, i, i*10)
+	}
+	html := `<html><body>
+<nav><ul><li><a href="/">Home</a></li><li><a href="/shop">Shop</a></li><li><a href="/about">About</a></li><li><a href="/help">Help</a></li></ul></nav>
+<main class="grid">` + cards.String() + `</main></body></html>`
+
+	schema, err := InferSchema(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.BaseSelector != "div.product-card" {
+		t.Errorf("BaseSelector = %q", schema.BaseSelector)
+	}
+	got := map[string]string{}
+	for _, f := range schema.Fields {
+		got[f.Name] = f.Selector + "@" + f.Attribute
+	}
+	want := map[string]string{
+		"url":           "a@href",
+		"image":         "img@src",
+		"product_title": "h3.product-title@",
+		"price":         "span.price@",
+		"badge":         "span.badge@",
+	}
+	for name, sel := range want {
+		if got[name] != sel {
+			t.Errorf("field %s = %q, want %q (all: %v)", name, got[name], sel, got)
+		}
+	}
+}
+
+func TestInferSchema_Table(t *testing.T) {
+	html := `<table class="listing"><thead><tr><th>Name</th><th>Price</th></tr></thead><tbody>
+<tr><td>Alpha</td><td>1</td></tr><tr><td>Beta</td><td>2</td></tr><tr><td>Gamma</td><td>3</td></tr>
+</tbody></table>`
+	schema, err := InferSchema(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.BaseSelector != "table.listing > tbody > tr" {
+		t.Errorf("BaseSelector = %q", schema.BaseSelector)
+	}
+	if len(schema.Fields) != 2 || schema.Fields[0].Name != "column_1" || schema.Fields[1].Selector != "td:nth-of-type(2)" {
+		t.Errorf("fields = %+v", schema.Fields)
+	}
+}
+
+func TestInferSchema_TableWithoutTbody(t *testing.T) {
+	html := `<table class="listing">
+<tr><td>Alpha</td><td>1</td></tr><tr><td>Beta</td><td>2</td></tr><tr><td>Gamma</td><td>3</td></tr>
+</table>`
+	schema, err := InferSchema(html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.BaseSelector != "table.listing tr" {
+		t.Errorf("BaseSelector = %q", schema.BaseSelector)
+	}
+}
+
+func TestInferSchema_NoStructure(t *testing.T) {
+	if _, err := InferSchema(`<html><body><h1>Hello</h1><p>Just a paragraph.</p></body></html>`); !errors.Is(err, ErrNoRepeatedStructure) {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ScrollSpec configures CrawlInfinite.
+type ScrollSpec struct {
+	// MaxScrolls caps the scroll rounds. Default 20.
+	MaxScrolls int
+	// ItemSelector matches one feed entry or product card. Items are
+	// counted after every round. Without it the crawl falls back to the
+	// server's scan_full_page scroll and no rounds are reported.
+	ItemSelector string
+	// StopWhenNoNew ends scrolling at the first round that loads no new
+	// items.
+	StopWhenNoNew bool
+	// ScrollDelay is how long each round waits for content to load.
+	// Default 1s.
+	ScrollDelay time.Duration
+	// ContainerSelector scrolls this element instead of the window, for
+	// feeds inside a scrollable panel.
+	ContainerSelector string
+	// Virtual marks a virtualized list that removes off-screen items. Every
+	// item seen is then appended to the page in a hidden
+	// <div data-crawl4ai-scroll-items>, so the returned HTML holds the
+	// whole feed.
+	Virtual bool
+}
+
+// ScrollRound reports one scroll round: the distinct items seen so far and
+// how many of them this round added. Round 0 is the initial load.
+type ScrollRound struct {
+	Round int `json:"round"`
+	Items int `json:"items"`
+	New   int `json:"new"`
+}
+
+// ScrollResult is the outcome of CrawlInfinite.
+type ScrollResult struct {
+	Result *CrawlResult
+	Rounds []ScrollRound
+}
+
+// TotalItems returns the distinct items seen across all rounds.
+func (s *ScrollResult) TotalItems() int {
+	if len(s.Rounds) == 0 {
+		return 0
+	}
+	return s.Rounds[len(s.Rounds)-1].Items
+}
+
+// scrollRoundsMeta is the <meta> the scroll script records rounds in.
+const scrollRoundsMeta = "crawl4ai-scroll-rounds"
+
+// CrawlInfinite crawls a feed or endless product grid, scrolling until
+// MaxScrolls rounds have run or — with StopWhenNoNew — no new items load,
+// and reports item counts per round. The page timeout is raised to cover
+// every round.
+//
+//	out, err := crawler.CrawlInfinite("https://shop.example.com/new", crawl4ai.ScrollSpec{
+//	    ItemSelector:  ".product-card",
+//	    MaxScrolls:    30,
+//	    StopWhenNoNew: true,
+//	}, nil)
+//	for _, r := range out.Rounds {
+//	    fmt.Printf("round %d: %d items (+%d)\n", r.Round, r.Items, r.New)
+//	}
+func (c *AsyncWebCrawler) CrawlInfinite(url string, spec ScrollSpec, opts *RunOptions) (*ScrollResult, error) {
+	maxScrolls := spec.MaxScrolls
+	if maxScrolls <= 0 {
+		maxScrolls = 20
+	}
+	delay := spec.ScrollDelay
+	if delay <= 0 {
+		delay = time.Second
+	}
+	if spec.Virtual && spec.ItemSelector == "" {
+		return nil, fmt.Errorf("infinite scroll: Virtual needs an ItemSelector")
+	}
+
+	o := RunOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Strategy == "http" {
+		return nil, fmt.Errorf("infinite scroll: the http strategy cannot scroll; use browser")
+	}
+	cfg := CrawlerRunConfig{}
+	if o.Config != nil {
+		cfg = *o.Config
+	}
+	if spec.ItemSelector == "" {
+		cfg.ScanFullPage = true
+		cfg.ScrollDelay = delay.Seconds()
+	} else {
+		// The script does the scrolling; a server-side scan would repeat it.
+		cfg.ScanFullPage = false
+		if cfg.JsCode != "" {
+			cfg.JsCode += "\n"
+		}
+		cfg.JsCode += scrollScript(spec, maxScrolls, delay)
+		if o.OmitFields == nil {
+			o.OmitFields = []string{}
+		}
+	}
+	budget := int((time.Duration(maxScrolls)*delay + 30*time.Second).Milliseconds())
+	if cfg.PageTimeout < budget {
+		cfg.PageTimeout = budget
+	}
+	o.Config = &cfg
+	o.Strategy = "browser"
+
+	result, err := c.Run(url, &o)
+	if err != nil {
+		return nil, err
+	}
+	out := &ScrollResult{Result: result}
+	if spec.ItemSelector == "" || !result.Success {
+		return out, nil
+	}
+	rounds, err := parseScrollRounds(readPageMeta(result.HTML, scrollRoundsMeta))
+	if err != nil {
+		return out, fmt.Errorf("infinite scroll %s: %w", url, err)
+	}
+	out.Rounds = rounds
+	return out, nil
+}
+
+// scrollScript scrolls in rounds, tracking distinct items by their markup
+// so virtualized lists that recycle nodes are still counted correctly.
+func scrollScript(spec ScrollSpec, maxScrolls int, delay time.Duration) string {
+	item, _ := json.Marshal(spec.ItemSelector)
+	container, _ := json.Marshal(spec.ContainerSelector)
+	return fmt.Sprintf(`const __items = new Map();
+const __rounds = [];
+const __box = %s ? document.querySelector(%s) : null;
+const __collect = () => {
+  let added = 0;
+  document.querySelectorAll(%s).forEach(el => {
+    if (!__items.has(el.outerHTML)) { __items.set(el.outerHTML, true); added++; }
+  });
+  return added;
+};
+const __first = __collect();
+__rounds.push({round: 0, items: __items.size, new: __first});
+for (let i = 1; i <= %d; i++) {
+  if (__box) { __box.scrollTop = __box.scrollHeight; } else { window.scrollTo(0, document.body.scrollHeight); }
+  await new Promise(r => setTimeout(r, %d));
+  const added = __collect();
+  __rounds.push({round: i, items: __items.size, new: added});
+  if (%t && added === 0) break;
+}
+if (%t) {
+  const d = document.createElement("div");
+  d.hidden = true;
+  d.setAttribute("data-crawl4ai-scroll-items", "");
+  d.innerHTML = [...__items.keys()].join("");
+  document.body.appendChild(d);
+}
+const __m = document.createElement("meta");
+__m.name = %q;
+__m.content = JSON.stringify(__rounds);
+document.head.appendChild(__m);`,
+		container, container, item, maxScrolls, delay.Milliseconds(),
+		spec.StopWhenNoNew, spec.Virtual, scrollRoundsMeta)
+}
+
+func parseScrollRounds(raw string) ([]ScrollRound, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("page did not report scroll rounds; the scroll script may have failed")
+	}
+	var rounds []ScrollRound
+	if err := json.Unmarshal([]byte(raw), &rounds); err != nil {
+		return nil, fmt.Errorf("decode scroll rounds: %w", err)
+	}
+	return rounds, nil
+}
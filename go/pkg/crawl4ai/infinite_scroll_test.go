@@ -0,0 +1,100 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestScrollScript(t *testing.T) {
+	script := scrollScript(ScrollSpec{ItemSelector: ".card", ContainerSelector: "#feed", StopWhenNoNew: true}, 5, 250*time.Millisecond)
+	for _, want := range []string{
+		`document.querySelectorAll(".card")`,
+		`document.querySelector("#feed")`,
+		"i <= 5;",
+		"setTimeout(r, 250)",
+		"if (true && added === 0) break;",
+		"if (false) {",
+		`__m.name = "crawl4ai-scroll-rounds";`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestParseScrollRounds(t *testing.T) {
+	rounds, err := parseScrollRounds(`[{"round":0,"items":12,"new":12},{"round":1,"items":24,"new":12}]`)
+	if err != nil || len(rounds) != 2 || rounds[1] != (ScrollRound{Round: 1, Items: 24, New: 12}) {
+		t.Fatalf("rounds %+v, err %v", rounds, err)
+	}
+	if _, err := parseScrollRounds(""); err == nil {
+		t.Fatal("expected error for missing rounds")
+	}
+	out := &ScrollResult{Rounds: rounds}
+	if out.TotalItems() != 24 {
+		t.Fatalf("TotalItems = %d", out.TotalItems())
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestCrawlInfinite_ReportsRounds(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "https://feed.com", "success": true,
+			"html": `<html><head><meta name="crawl4ai-scroll-rounds" content="[{&quot;round&quot;:0,&quot;items&quot;:10,&quot;new&quot;:10},{&quot;round&quot;:1,&quot;items&quot;:10,&quot;new&quot;:0}]"></head></html>`,
+		})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, OmitFields: HeavyResultFields})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := c.CrawlInfinite("https://feed.com", ScrollSpec{ItemSelector: ".post", MaxScrolls: 40, StopWhenNoNew: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Rounds) != 2 || out.TotalItems() != 10 || out.Rounds[1].New != 0 {
+		t.Fatalf("rounds = %+v", out.Rounds)
+	}
+	cc := body["crawler_config"].(map[string]interface{})
+	if cc["scan_full_page"] != nil || !strings.Contains(cc["js_code"].(string), `".post"`) {
+		t.Fatalf("unexpected config: %v", cc)
+	}
+	if cc["page_timeout"] != 70000.0 {
+		t.Fatalf("page_timeout = %v, want 40 rounds + 30s", cc["page_timeout"])
+	}
+}
+
+func TestCrawlInfinite_ScanFullPageFallback(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://feed.com", "success": true})
+	}))
+	defer srv.Close()
+	c, _ := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+
+	out, err := c.CrawlInfinite("https://feed.com", ScrollSpec{ScrollDelay: 500 * time.Millisecond},
+		&RunOptions{Config: &CrawlerRunConfig{PageTimeout: 120000}})
+	if err != nil || out.Rounds != nil {
+		t.Fatalf("out %+v, err %v", out, err)
+	}
+	cc := body["crawler_config"].(map[string]interface{})
+	if cc["scan_full_page"] != true || cc["scroll_delay"] != 0.5 || cc["js_code"] != nil || cc["page_timeout"] != 120000.0 {
+		t.Fatalf("unexpected config: %v", cc)
+	}
+
+	if _, err := c.CrawlInfinite("https://feed.com", ScrollSpec{Virtual: true}, nil); err == nil {
+		t.Fatal("expected error for Virtual without ItemSelector")
+	}
+}
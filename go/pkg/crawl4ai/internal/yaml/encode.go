@@ -0,0 +1,226 @@
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FromJSON converts a JSON document to block-style YAML, keeping key order
+// so a struct's fields come out in declaration order. Strings that would
+// read back as another type are quoted; multi-line strings become literal
+// blocks.
+func FromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	n, err := readNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	switch {
+	case n.kind == '{' && len(n.keys) > 0:
+		writeMap(&b, n, 0)
+	case n.kind == '[' && len(n.items) > 0:
+		writeSeq(&b, n, 0)
+	default:
+		b.WriteString(n.inline())
+		b.WriteByte('\n')
+	}
+	return []byte(b.String()), nil
+}
+
+// node is an ordered JSON value.
+type node struct {
+	kind   byte // '{', '[', or 0 for a scalar
+	keys   []string
+	vals   []*node
+	items  []*node
+	scalar string // rendered YAML scalar
+	str    *string
+}
+
+func readNode(dec *json.Decoder) (*node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		n := &node{kind: byte(t)}
+		for dec.More() {
+			if n.kind == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, k.(string))
+			}
+			v, err := readNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			if n.kind == '{' {
+				n.vals = append(n.vals, v)
+			} else {
+				n.items = append(n.items, v)
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+	case string:
+		return &node{scalar: quoteString(t), str: &t}, nil
+	case json.Number:
+		return &node{scalar: t.String()}, nil
+	case bool:
+		return &node{scalar: strconv.FormatBool(t)}, nil
+	case nil:
+		return &node{scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("yaml: unexpected JSON token %v", tok)
+}
+
+// inline renders a scalar or an empty collection.
+func (n *node) inline() string {
+	switch {
+	case n.kind == '{':
+		return "{}"
+	case n.kind == '[':
+		return "[]"
+	}
+	return n.scalar
+}
+
+func (n *node) isBlock() bool {
+	return n.kind == '{' && len(n.keys) > 0 || n.kind == '[' && len(n.items) > 0
+}
+
+// literal reports whether a string is best written as a | block.
+func (n *node) literal() bool {
+	if n.str == nil || !strings.Contains(strings.TrimRight(*n.str, "\n"), "\n") {
+		return false
+	}
+	s := *n.str
+	if strings.HasPrefix(s, " ") || strings.ContainsAny(s, "\r\t") {
+		return false
+	}
+	for _, l := range strings.Split(s, "\n") {
+		if strings.HasSuffix(l, " ") {
+			return false
+		}
+		for _, r := range l {
+			if !strconv.IsPrint(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func writeLiteral(b *strings.Builder, s string, indent int) {
+	body := strings.TrimRight(s, "\n")
+	switch trailing := len(s) - len(body); {
+	case trailing == 0:
+		b.WriteString("|-\n")
+	case trailing == 1:
+		b.WriteString("|\n")
+	default:
+		b.WriteString("|+\n")
+		body = s[:len(s)-1]
+	}
+	pad := strings.Repeat(" ", indent)
+	for _, l := range strings.Split(body, "\n") {
+		if l != "" {
+			b.WriteString(pad)
+			b.WriteString(l)
+		}
+		b.WriteByte('\n')
+	}
+}
+
+func writeMap(b *strings.Builder, n *node, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for i, k := range n.keys {
+		v := n.vals[i]
+		b.WriteString(pad)
+		b.WriteString(quoteString(k))
+		b.WriteByte(':')
+		writeValue(b, v, indent)
+	}
+}
+
+func writeSeq(b *strings.Builder, n *node, indent int) {
+	pad := strings.Repeat(" ", indent)
+	for _, v := range n.items {
+		if !v.isBlock() {
+			b.WriteString(pad)
+			b.WriteByte('-')
+			writeValue(b, v, indent)
+			continue
+		}
+		// Write the item two columns in, then put the dash over its first
+		// line's indentation.
+		var item strings.Builder
+		if v.kind == '{' {
+			writeMap(&item, v, indent+2)
+		} else {
+			writeSeq(&item, v, indent+2)
+		}
+		b.WriteString(pad)
+		b.WriteString("- ")
+		b.WriteString(item.String()[indent+2:])
+	}
+}
+
+// writeValue writes what follows "key:" or "-".
+func writeValue(b *strings.Builder, v *node, indent int) {
+	switch {
+	case v.kind == '{' && v.isBlock():
+		b.WriteByte('\n')
+		writeMap(b, v, indent+2)
+	case v.kind == '[' && v.isBlock():
+		b.WriteByte('\n')
+		writeSeq(b, v, indent+2)
+	case v.literal():
+		b.WriteByte(' ')
+		writeLiteral(b, *v.str, indent+2)
+	default:
+		b.WriteByte(' ')
+		b.WriteString(v.inline())
+		b.WriteByte('\n')
+	}
+}
+
+// quoteString renders s as a plain scalar when Unmarshal would read it
+// back unchanged, and double-quoted otherwise.
+func quoteString(s string) string {
+	if needsQuotes(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsQuotes(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+	if v, err := plain(s); err != nil || v != s {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if !strconv.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,604 @@
+// Package yaml reads the subset of YAML that configuration files use, so
+// the SDK can load YAML without a third-party dependency: block mappings
+// and sequences, flow [lists] and {maps}, plain, quoted and block (| and >)
+// scalars, and comments. Anchors, aliases, tags, complex keys and multiple
+// documents are rejected rather than misread.
+//
+// Unmarshal produces the same shapes encoding/json does for interface{}:
+// map[string]interface{}, []interface{}, string, bool, nil, and int or
+// float64 for numbers. Decode into structs by re-encoding as JSON.
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Unmarshal parses a YAML document.
+func Unmarshal(data []byte) (interface{}, error) {
+	p := &parser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		if i == 0 {
+			raw = strings.TrimPrefix(raw, "\ufeff")
+		}
+		if lead := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]; strings.Contains(lead, "\t") && strings.TrimSpace(raw) != "" {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed for indentation", i+1)
+		}
+		text, err := stripComment(raw)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %w", i+1, err)
+		}
+		trimmed := strings.TrimLeft(text, " ")
+		p.lines = append(p.lines, line{
+			num: i + 1, raw: raw, indent: len(text) - len(trimmed),
+			text: strings.TrimRight(trimmed, " "), blank: strings.TrimSpace(text) == "",
+		})
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.lines[p.pos].text == "---" {
+		p.pos++
+	}
+	v, err := p.block(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if l.text == "---" || l.text == "..." {
+			if l.text == "---" {
+				return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", l.num)
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+	}
+	return v, nil
+}
+
+type line struct {
+	num    int
+	raw    string
+	indent int
+	text   string
+	blank  bool
+}
+
+type parser struct {
+	lines []line
+	pos   int
+}
+
+func (p *parser) skipBlank() {
+	for p.pos < len(p.lines) && p.lines[p.pos].blank {
+		p.pos++
+	}
+}
+
+// peek returns the next non-blank line.
+func (p *parser) peek() (*line, bool) {
+	p.skipBlank()
+	if p.pos >= len(p.lines) {
+		return nil, false
+	}
+	return &p.lines[p.pos], true
+}
+
+// block parses the node starting on the next line if it is indented at
+// least minIndent, or returns nil.
+func (p *parser) block(minIndent int) (interface{}, error) {
+	l, ok := p.peek()
+	if !ok || l.indent < minIndent || l.text == "---" || l.text == "..." {
+		return nil, nil
+	}
+	if isSeqItem(l.text) {
+		return p.sequence(l.indent)
+	}
+	if _, _, ok, err := splitKey(l.text); err != nil {
+		return nil, fmt.Errorf("yaml: line %d: %w", l.num, err)
+	} else if ok {
+		return p.mapping(l.indent)
+	}
+	p.pos++
+	return p.inline(l, l.text, l.indent-1)
+}
+
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *parser) mapping(indent int) (interface{}, error) {
+	out := map[string]interface{}{}
+	for {
+		l, ok := p.peek()
+		if !ok || l.indent < indent || l.text == "---" || l.text == "..." {
+			return out, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+		}
+		key, rest, ok, err := splitKey(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %w", l.num, err)
+		}
+		if !ok {
+			if isSeqItem(l.text) {
+				return nil, fmt.Errorf("yaml: line %d: sequence item in a mapping", l.num)
+			}
+			return nil, fmt.Errorf("yaml: line %d: expected \"key: value\"", l.num)
+		}
+		if _, dup := out[key]; dup {
+			return nil, fmt.Errorf("yaml: line %d: duplicate key %q", l.num, key)
+		}
+		p.pos++
+		var v interface{}
+		if rest == "" {
+			next, ok := p.peek()
+			switch {
+			case ok && next.indent > indent:
+				v, err = p.block(indent + 1)
+			case ok && next.indent == indent && isSeqItem(next.text):
+				// A sequence may sit at its key's indentation.
+				v, err = p.sequence(indent)
+			}
+		} else {
+			v, err = p.inline(l, rest, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out[key] = v
+	}
+}
+
+func (p *parser) sequence(indent int) (interface{}, error) {
+	out := []interface{}{}
+	for {
+		l, ok := p.peek()
+		if !ok || l.indent < indent || l.text == "---" || l.text == "..." {
+			return out, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+		}
+		if !isSeqItem(l.text) {
+			return out, nil
+		}
+		content := strings.TrimLeft(l.text[1:], " ")
+		if content == "" {
+			p.pos++
+			v, err := p.block(indent + 1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+			continue
+		}
+		_, _, isKey, err := splitKey(content)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %w", l.num, err)
+		}
+		if isKey || isSeqItem(content) {
+			// "- key: v" opens a node indented where its content starts:
+			// re-read this line as that node's first line.
+			l.indent += len(l.text) - len(content)
+			l.text = content
+			v, err := p.block(l.indent)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+			continue
+		}
+		p.pos++
+		v, err := p.inline(l, content, indent)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+}
+
+// inline parses a value written after "key:" or "- " on line l. parent is
+// the indentation of the line's node, which block scalar content must
+// exceed.
+func (p *parser) inline(l *line, s string, parent int) (interface{}, error) {
+	switch {
+	case s[0] == '|' || s[0] == '>':
+		return p.blockScalar(l, s, parent)
+	case s[0] == '[' || s[0] == '{':
+		f := &flow{s: s}
+		v, err := f.value()
+		if err == nil {
+			f.space()
+			if f.i < len(f.s) {
+				err = fmt.Errorf("unexpected %q after flow collection", f.s[f.i:])
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %w", l.num, err)
+		}
+		return v, nil
+	case s[0] == '"' || s[0] == '\'':
+		v, n, err := quoted(s)
+		if err == nil && strings.TrimSpace(s[n:]) != "" {
+			err = fmt.Errorf("unexpected %q after quoted string", s[n:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: %w", l.num, err)
+		}
+		return v, nil
+	}
+	v, err := plain(s)
+	if err != nil {
+		return nil, fmt.Errorf("yaml: line %d: %w", l.num, err)
+	}
+	// A plain scalar may continue on more-indented lines.
+	for {
+		next, ok := p.peek()
+		if !ok || next.indent <= parent || isSeqItem(next.text) {
+			break
+		}
+		if _, _, isKey, _ := splitKey(next.text); isKey {
+			break
+		}
+		str, isStr := v.(string)
+		if !isStr {
+			str = s
+		}
+		v = str + " " + next.text
+		p.pos++
+	}
+	return v, nil
+}
+
+func (p *parser) blockScalar(l *line, header string, parent int) (interface{}, error) {
+	folded := header[0] == '>'
+	chomp := byte(0)
+	for _, c := range header[1:] {
+		switch {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = byte(c)
+		default:
+			return nil, fmt.Errorf("yaml: line %d: unsupported block scalar header %q", l.num, header)
+		}
+	}
+	var body []string
+	indent := -1
+	for p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		rawTrim := strings.TrimLeft(next.raw, " ")
+		ind := len(next.raw) - len(rawTrim)
+		if strings.TrimSpace(next.raw) == "" {
+			body = append(body, "")
+			p.pos++
+			continue
+		}
+		if indent < 0 {
+			if ind <= parent {
+				break
+			}
+			indent = ind
+		}
+		if ind < indent {
+			break
+		}
+		body = append(body, next.raw[indent:])
+		p.pos++
+	}
+	// Trailing blank lines belong to the scalar only for chomping.
+	end := len(body)
+	for end > 0 && body[end-1] == "" {
+		end--
+	}
+	trailing := len(body) - end
+	body = body[:end]
+	if len(body) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	for i, s := range body {
+		if i > 0 {
+			prev := body[i-1]
+			switch {
+			case !folded:
+				b.WriteByte('\n')
+			case s == "":
+				// Each blank line folds to one newline.
+				b.WriteByte('\n')
+				continue
+			case prev == "":
+			case strings.HasPrefix(s, " ") || strings.HasPrefix(prev, " "):
+				// More-indented lines are not folded.
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(s)
+	}
+	switch chomp {
+	case '-':
+	case '+':
+		b.WriteString(strings.Repeat("\n", trailing+1))
+	default:
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// splitKey splits "key: rest" and reports whether text is a mapping entry.
+func splitKey(text string) (key, rest string, ok bool, err error) {
+	if strings.HasPrefix(text, "? ") || text == "?" {
+		return "", "", false, fmt.Errorf("complex mapping keys are not supported")
+	}
+	if text[0] == '"' || text[0] == '\'' {
+		k, n, err := quoted(text)
+		if err != nil {
+			return "", "", false, err
+		}
+		after := strings.TrimLeft(text[n:], " ")
+		if after == ":" || strings.HasPrefix(after, ": ") {
+			return k.(string), strings.TrimSpace(after[1:]), true, nil
+		}
+		return "", "", false, nil
+	}
+	if text[0] == '[' || text[0] == '{' || isSeqItem(text) {
+		return "", "", false, nil
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			k := strings.TrimSpace(text[:i])
+			if k == "" {
+				return "", "", false, nil
+			}
+			return k, strings.TrimSpace(text[i+1:]), true, nil
+		}
+	}
+	return "", "", false, nil
+}
+
+// stripComment removes a trailing "# comment" outside quotes.
+func stripComment(s string) (string, error) {
+	var q byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case q == '"' && c == '\\':
+			i++
+		case q != 0 && c == q:
+			if q == '\'' && i+1 < len(s) && s[i+1] == '\'' {
+				i++
+				continue
+			}
+			q = 0
+		case q != 0:
+		case (c == '"' || c == '\'') && (i == 0 || strings.ContainsRune(" :-[{,", rune(s[i-1]))):
+			q = c
+		case c == '#' && (i == 0 || s[i-1] == ' '):
+			return s[:i], nil
+		}
+	}
+	return s, nil
+}
+
+// plain resolves an unquoted scalar.
+func plain(s string) (interface{}, error) {
+	switch s[0] {
+	case '&', '*', '!':
+		return nil, fmt.Errorf("anchors, aliases and tags are not supported")
+	case '@', '`':
+		return nil, fmt.Errorf("plain scalars cannot start with %q", s[0])
+	}
+	switch s {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", "+.inf":
+		return nil, fmt.Errorf("infinite numbers are not supported")
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return int(n), nil
+	}
+	if strings.HasPrefix(s, "0x") {
+		if n, err := strconv.ParseInt(s[2:], 16, 64); err == nil {
+			return int(n), nil
+		}
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && numeric(s) {
+		return f, nil
+	}
+	return s, nil
+}
+
+// numeric guards strconv against words it would accept ("Inf", "NaN").
+func numeric(s string) bool {
+	s = strings.TrimLeft(s, "+-")
+	return s != "" && (s[0] >= '0' && s[0] <= '9' || s[0] == '.' && len(s) > 1 && s[1] >= '0' && s[1] <= '9')
+}
+
+// quoted reads a quoted scalar at the start of s and returns it and the
+// bytes consumed.
+func quoted(s string) (interface{}, int, error) {
+	q := s[0]
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case q == '\'' && c == '\'':
+			if i+1 < len(s) && s[i+1] == '\'' {
+				b.WriteByte('\'')
+				i++
+				continue
+			}
+			return b.String(), i + 1, nil
+		case q == '"' && c == '"':
+			return b.String(), i + 1, nil
+		case q == '"' && c == '\\':
+			if i+1 >= len(s) {
+				return nil, 0, fmt.Errorf("unterminated escape")
+			}
+			i++
+			switch e := s[i]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '0':
+				b.WriteByte(0)
+			case 'a':
+				b.WriteByte('\a')
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'v':
+				b.WriteByte('\v')
+			case 'e':
+				b.WriteByte(0x1b)
+			case '"', '\\', '/', ' ':
+				b.WriteByte(e)
+			case 'x', 'u', 'U':
+				n := map[byte]int{'x': 2, 'u': 4, 'U': 8}[e]
+				if i+n >= len(s) {
+					return nil, 0, fmt.Errorf("short \\%c escape", e)
+				}
+				r, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32)
+				if err != nil {
+					return nil, 0, fmt.Errorf("invalid \\%c escape", e)
+				}
+				b.WriteRune(rune(r))
+				i += n
+			default:
+				return nil, 0, fmt.Errorf("unsupported escape \\%c", e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return nil, 0, fmt.Errorf("unterminated quoted string")
+}
+
+// flow parses a single-line flow collection.
+type flow struct {
+	s string
+	i int
+}
+
+func (f *flow) space() {
+	for f.i < len(f.s) && f.s[f.i] == ' ' {
+		f.i++
+	}
+}
+
+func (f *flow) value() (interface{}, error) {
+	f.space()
+	if f.i >= len(f.s) {
+		return nil, fmt.Errorf("unterminated flow collection")
+	}
+	switch f.s[f.i] {
+	case '[':
+		f.i++
+		out := []interface{}{}
+		for {
+			f.space()
+			if f.i < len(f.s) && f.s[f.i] == ']' {
+				f.i++
+				return out, nil
+			}
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+			if err := f.sep(']'); err != nil {
+				return nil, err
+			}
+		}
+	case '{':
+		f.i++
+		out := map[string]interface{}{}
+		for {
+			f.space()
+			if f.i < len(f.s) && f.s[f.i] == '}' {
+				f.i++
+				return out, nil
+			}
+			k, err := f.scalar(true)
+			if err != nil {
+				return nil, err
+			}
+			key := fmt.Sprint(k)
+			if k == nil {
+				key = ""
+			}
+			f.space()
+			if f.i >= len(f.s) || f.s[f.i] != ':' {
+				return nil, fmt.Errorf("expected ':' after key %q", key)
+			}
+			f.i++
+			v, err := f.value()
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := out[key]; dup {
+				return nil, fmt.Errorf("duplicate key %q", key)
+			}
+			out[key] = v
+			if err := f.sep('}'); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return f.scalar(false)
+}
+
+// sep consumes the "," between items, leaving the closing bracket.
+func (f *flow) sep(end byte) error {
+	f.space()
+	if f.i >= len(f.s) {
+		return fmt.Errorf("unterminated flow collection")
+	}
+	switch f.s[f.i] {
+	case ',':
+		f.i++
+		return nil
+	case end:
+		return nil
+	}
+	return fmt.Errorf("expected ',' or %q, got %q", end, f.s[f.i:])
+}
+
+func (f *flow) scalar(key bool) (interface{}, error) {
+	if c := f.s[f.i]; c == '"' || c == '\'' {
+		v, n, err := quoted(f.s[f.i:])
+		if err != nil {
+			return nil, err
+		}
+		f.i += n
+		return v, nil
+	}
+	start := f.i
+	for f.i < len(f.s) {
+		c := f.s[f.i]
+		if c == ',' || c == ']' || c == '}' || c == '[' || c == '{' {
+			break
+		}
+		if c == ':' && (key || f.i+1 == len(f.s) || strings.ContainsRune(" ,]}", rune(f.s[f.i+1]))) {
+			break
+		}
+		f.i++
+	}
+	s := strings.TrimSpace(f.s[start:f.i])
+	if s == "" {
+		return nil, nil
+	}
+	return plain(s)
+}
@@ -0,0 +1,150 @@
+package yaml
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshal(t *testing.T) {
+	doc := `
+# crawl spec
+version: 1
+name: "docs: main"   # quoted key-like value
+empty:
+crawls:
+  - name: docs
+    urls:
+      - https://example.com/a
+      - 'https://example.com/it''s'
+    config: {word_count_threshold: 10, css_selector: "main, article", tags: [a, b]}
+    ratio: 0.5
+    enabled: true
+  - name: blog
+    urls: [https://example.com/blog]
+list:
+- 1
+- -2
+- null
+script: |
+  line one
+  # not a comment
+
+  line three
+folded: >-
+  one
+  two
+
+  three
+long: a plain
+  scalar continued
+`
+	got, err := Unmarshal([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"version": 1,
+		"name":    "docs: main",
+		"empty":   nil,
+		"crawls": []interface{}{
+			map[string]interface{}{
+				"name": "docs",
+				"urls": []interface{}{"https://example.com/a", "https://example.com/it's"},
+				"config": map[string]interface{}{
+					"word_count_threshold": 10, "css_selector": "main, article", "tags": []interface{}{"a", "b"},
+				},
+				"ratio":   0.5,
+				"enabled": true,
+			},
+			map[string]interface{}{"name": "blog", "urls": []interface{}{"https://example.com/blog"}},
+		},
+		"list":   []interface{}{1, -2, nil},
+		"script": "line one\n# not a comment\n\nline three\n",
+		"folded": "one two\nthree",
+		"long":   "a plain scalar continued",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %#v\nwant %#v", got, want)
+	}
+}
+
+func TestUnmarshal_Scalars(t *testing.T) {
+	cases := map[string]interface{}{
+		`v: "tab\there \u00e9"`: "tab\there é",
+		`v: 1e3`:                1000.0,
+		`v: 0x1F`:               31,
+		`v: 010`:                10,
+		`v: ~`:                  nil,
+		`v: NaN`:                "NaN",
+		`v: 1.10`:               1.1,
+		`v: a#b`:                "a#b",
+		`v: []`:                 []interface{}{},
+		`v: {}`:                 map[string]interface{}{},
+	}
+	for doc, want := range cases {
+		got, err := Unmarshal([]byte(doc))
+		if err != nil {
+			t.Errorf("%s: %v", doc, err)
+			continue
+		}
+		if v := got.(map[string]interface{})["v"]; !reflect.DeepEqual(v, want) {
+			t.Errorf("%s = %#v, want %#v", doc, v, want)
+		}
+	}
+}
+
+func TestUnmarshal_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"a: 1\na: 2":            "duplicate key",
+		"a: &x 1":               "anchors",
+		"a: *x":                 "anchors",
+		"a: !!str 1":            "tags",
+		"a: 1\n---\nb: 2":       "multiple documents",
+		"a:\n\tb: 1":            "tabs",
+		"a: [1, 2":              "unterminated",
+		"a: 'x":                 "unterminated",
+		"a: 1\n  b: 2":          "indentation",
+		"a: 1\n- b":             "sequence item",
+		"? complex\n: value":    "complex",
+		"a: {b: 1, b: 2}":       "duplicate key",
+		"a: \"bad \\q escape\"": "escape",
+	}
+	for doc, want := range cases {
+		_, err := Unmarshal([]byte(doc))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want %q", doc, err, want)
+		}
+	}
+}
+
+func TestFromJSON_RoundTrip(t *testing.T) {
+	in := `{"name":"docs","empty":"","num_like":"42","bool_like":"true","colon":"a: b","n":3,"f":0.25,"ok":false,"none":null,` +
+		`"script":"let a = 1;\n  indented();\n\nend();\n","no_nl":"x\ny","keep":"x\n\n","tab":"a\tb",` +
+		`"list":[1,"two",{"k":"v","k2":[true]},[],{}],"nested":{"deep":{"x":"-lead"}},"es":[]}`
+	out, err := FromJSON([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Unmarshal(out)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, out)
+	}
+	var want interface{}
+	if err := json.Unmarshal([]byte(in), &want); err != nil {
+		t.Fatal(err)
+	}
+	// JSON numbers decode as float64; YAML integers as int.
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("round trip changed the value:\n got %s\nwant %s\nyaml:\n%s", gotJSON, wantJSON, out)
+	}
+	if !strings.HasPrefix(string(out), "name: docs\nempty: \"\"\n") {
+		t.Errorf("key order or quoting lost:\n%s", out)
+	}
+	if !strings.Contains(string(out), "script: |\n  let a = 1;\n    indented();\n\n  end();\n") {
+		t.Errorf("multi-line string not written as a literal block:\n%s", out)
+	}
+}
@@ -0,0 +1,102 @@
+package crawl4ai
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JobHandle is everything needed to resume waiting for a job from another
+// process: a cron run can submit jobs, save their handles and exit, and a
+// later run picks them up with ResumeWait. It marshals to JSON, or to a
+// single-line token with Encode. Complements webhooks where callbacks
+// can't be received.
+type JobHandle struct {
+	JobID string `json:"job_id"`
+	// URLs are the submitted inputs, so the resumed result can report
+	// MissingURLs and FailedURLs.
+	URLs []string `json:"urls,omitempty"`
+	// PollInterval between status checks. Default: 2s.
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
+	// Deadline is when waiting gives up, across every resume. Zero waits
+	// indefinitely.
+	Deadline    time.Time `json:"deadline"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// Handle returns a JobHandle for r's job that waits until timeout from
+// now (0 = no deadline).
+//
+//	result, _ := crawler.RunMany(urls, nil)
+//	token, _ := result.Handle(5*time.Second, time.Hour).Encode()
+//	os.WriteFile("pending.job", []byte(token), 0o600)
+func (r *RunManyResult) Handle(pollInterval, timeout time.Duration) JobHandle {
+	h := JobHandle{PollInterval: pollInterval, SubmittedAt: time.Now().UTC(), URLs: r.inputs}
+	if r.Job != nil {
+		h.JobID = r.Job.JobID
+		if !r.Job.CreatedAt.IsZero() {
+			h.SubmittedAt = r.Job.CreatedAt
+		}
+		if len(h.URLs) == 0 {
+			h.URLs = r.Job.URLs
+		}
+	}
+	if timeout > 0 {
+		h.Deadline = time.Now().UTC().Add(timeout)
+	}
+	return h
+}
+
+// Encode returns h as a URL-safe token for a file, environment variable or
+// command-line argument.
+func (h JobHandle) Encode() (string, error) {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// ParseJobHandle decodes a token from JobHandle.Encode.
+func ParseJobHandle(token string) (JobHandle, error) {
+	var h JobHandle
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return h, fmt.Errorf("invalid job handle: %w", err)
+	}
+	if err := json.Unmarshal(data, &h); err != nil {
+		return h, fmt.Errorf("invalid job handle: %w", err)
+	}
+	if h.JobID == "" {
+		return h, fmt.Errorf("invalid job handle: no job ID")
+	}
+	return h, nil
+}
+
+// ResumeWait waits for a job from a handle saved by another process, until
+// it finishes or the handle's deadline passes. Past the deadline the job
+// is checked once. On timeout the result holds the last snapshot along
+// with the *TimeoutError, so the caller can extend the deadline and save
+// the handle again.
+//
+//	token, _ := os.ReadFile("pending.job")
+//	h, err := crawl4ai.ParseJobHandle(string(token))
+//	result, err := crawler.ResumeWait(h)
+//	if err == nil {
+//	    err = result.Err()
+//	}
+func (c *AsyncWebCrawler) ResumeWait(h JobHandle) (*RunManyResult, error) {
+	wait := time.Until(h.Deadline)
+	if h.Deadline.IsZero() {
+		wait = 0
+	} else if wait <= 0 {
+		// WaitJob treats 0 as no deadline; poll once instead.
+		wait = time.Nanosecond
+	}
+	job, err := c.WaitJob(h.JobID, h.PollInterval, wait)
+	if job == nil {
+		return nil, err
+	}
+	return &RunManyResult{Job: job, Results: job.Results, inputs: h.URLs}, err
+}
@@ -0,0 +1,68 @@
+package crawl4ai
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestJobHandle_EncodeRoundTrip(t *testing.T) {
+	r := &RunManyResult{Job: &CrawlJob{JobID: "job_1"}, inputs: []string{"https://a.com", "https://b.com"}}
+	h := r.Handle(time.Second, time.Hour)
+	if h.JobID != "job_1" || h.Deadline.IsZero() || len(h.URLs) != 2 {
+		t.Fatalf("handle = %+v", h)
+	}
+	token, err := h.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ParseJobHandle(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.JobID != h.JobID || got.PollInterval != h.PollInterval || !got.Deadline.Equal(h.Deadline) || !reflect.DeepEqual(got.URLs, h.URLs) {
+		t.Errorf("round trip: %+v != %+v", got, h)
+	}
+
+	if h := r.Handle(0, 0); !h.Deadline.IsZero() {
+		t.Errorf("no timeout should mean no deadline: %v", h.Deadline)
+	}
+	for _, bad := range []string{"%%%", "bm90IGpzb24", "e30"} {
+		if _, err := ParseJobHandle(bad); err == nil {
+			t.Errorf("ParseJobHandle(%q) should fail", bad)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestResumeWait(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/done": map[string]interface{}{"job_id": "done", "status": "partial", "results": []interface{}{
+			map[string]interface{}{"url": "https://a.com", "success": true},
+		}},
+		"GET /v1/crawl/jobs/slow": map[string]interface{}{"job_id": "slow", "status": "running"},
+	})
+
+	result, err := c.ResumeWait(JobHandle{JobID: "done", URLs: []string{"https://a.com", "https://b.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if missing := result.MissingURLs(); len(missing) != 1 || missing[0] != "https://b.com" {
+		t.Errorf("MissingURLs = %v", missing)
+	}
+
+	// Past its deadline the job is checked once, and the snapshot kept.
+	started := time.Now()
+	result, err = c.ResumeWait(JobHandle{JobID: "slow", PollInterval: time.Hour, Deadline: time.Now().Add(-time.Minute)})
+	var te *TimeoutError
+	if !errors.As(err, &te) || result == nil || result.Job.Status != JobStatusRunning {
+		t.Fatalf("result = %+v, err = %v", result, err)
+	}
+	if time.Since(started) > time.Second {
+		t.Error("an expired handle should not wait")
+	}
+}
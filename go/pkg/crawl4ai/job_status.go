@@ -0,0 +1,71 @@
+package crawl4ai
+
+import "fmt"
+
+// JobStatus is the lifecycle state of an async crawl job.
+type JobStatus string
+
+// Job statuses reported by the crawl jobs endpoints.
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	JobStatusPartial   JobStatus = "partial"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// jobStatusAliases maps spellings some endpoints use onto the canonical
+// constants.
+var jobStatusAliases = map[string]JobStatus{
+	"queued":     JobStatusPending,
+	"processing": JobStatusRunning,
+	"canceled":   JobStatusCancelled,
+}
+
+// ParseJobStatus converts a wire status to a JobStatus. Unrecognised
+// values are returned unchanged alongside an error, so callers can decide
+// whether a state added by a newer API is fatal; decoding never fails on
+// them.
+func ParseJobStatus(s string) (JobStatus, error) {
+	if alias, ok := jobStatusAliases[s]; ok {
+		return alias, nil
+	}
+	st := JobStatus(s)
+	if !st.Known() {
+		return st, fmt.Errorf("unknown job status %q", s)
+	}
+	return st, nil
+}
+
+// Known reports whether s is one of the JobStatus constants.
+func (s JobStatus) Known() bool {
+	switch s {
+	case JobStatusPending, JobStatusRunning, JobStatusCompleted,
+		JobStatusPartial, JobStatusFailed, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the job has stopped advancing. Unknown
+// statuses are treated as still in flight, so polling loops keep waiting
+// rather than returning early.
+func (s JobStatus) IsTerminal() bool {
+	switch s {
+	case JobStatusCompleted, JobStatusPartial, JobStatusFailed, JobStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// CanCancel reports whether CancelJob can still stop the job: any status
+// that is not terminal, including ones this SDK doesn't know yet.
+func (s JobStatus) CanCancel() bool {
+	return s != "" && !s.IsTerminal()
+}
+
+// String implements fmt.Stringer.
+func (s JobStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,95 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestJobStatus_Helpers(t *testing.T) {
+	cases := []struct {
+		wire      string
+		want      JobStatus
+		known     bool
+		terminal  bool
+		canCancel bool
+	}{
+		{"pending", JobStatusPending, true, false, true},
+		{"queued", JobStatusPending, true, false, true},
+		{"running", JobStatusRunning, true, false, true},
+		{"partial", JobStatusPartial, true, true, false},
+		{"canceled", JobStatusCancelled, true, true, false},
+		{"paused", JobStatus("paused"), false, false, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseJobStatus(tc.wire)
+		if got != tc.want || (err == nil) != tc.known {
+			t.Errorf("ParseJobStatus(%q) = %q, %v", tc.wire, got, err)
+		}
+		if got.Known() != tc.known || got.IsTerminal() != tc.terminal || got.CanCancel() != tc.canCancel {
+			t.Errorf("%q: known %v terminal %v canCancel %v", tc.wire, got.Known(), got.IsTerminal(), got.CanCancel())
+		}
+	}
+}
+
+func TestJobStatus_DecodesUnknownStates(t *testing.T) {
+	job := CrawlJobFromMap(map[string]interface{}{"job_id": "job_1", "status": "throttled"})
+	if job.Status != "throttled" || job.IsComplete() {
+		t.Fatalf("unknown status should decode as in-flight, got %+v", job)
+	}
+	job = CrawlJobFromMap(map[string]interface{}{"job_id": "job_1", "status": "completed"})
+	if job.Status != JobStatusCompleted || !job.IsSuccessful() {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+}
+
+func TestCrawlJob_Timestamps(t *testing.T) {
+	job := CrawlJobFromMap(map[string]interface{}{
+		"job_id":       "job_1",
+		"status":       "completed",
+		"created_at":   "2026-05-03 12:00:00.250000",
+		"started_at":   "2026-05-03T12:01:30+00:00",
+		"completed_at": 1777809750.0, // 2026-05-03T12:02:30Z
+	})
+	if job.CreatedAt.IsZero() || job.StartedAt.IsZero() || job.CompletedAt.IsZero() {
+		t.Fatalf("expected all timestamps parsed: %+v", job)
+	}
+	if q := job.QueueTime(); q != 89750*time.Millisecond {
+		t.Fatalf("QueueTime = %s", q)
+	}
+	if d := job.Duration(); d != time.Minute {
+		t.Fatalf("Duration = %s", d)
+	}
+
+	pending := CrawlJobFromMap(map[string]interface{}{"status": "pending", "created_at": time.Now().Add(-time.Minute).Format(time.RFC3339)})
+	if pending.Duration() != 0 || pending.QueueTime() < time.Minute-time.Second {
+		t.Fatalf("pending job: queue %s duration %s", pending.QueueTime(), pending.Duration())
+	}
+}
+
+func TestCrawlJob_MarshalOmitsZeroTimes(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	data, err := json.Marshal(&CrawlJob{JobID: "job_1", Status: JobStatusPending, CreatedAt: created})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "0001-01-01") || strings.Contains(string(data), "started_at") {
+		t.Fatalf("expected unset timestamps omitted, got %s", data)
+	}
+	if !strings.Contains(string(data), `"created_at":"2026-01-02T03:04:05Z"`) {
+		t.Fatalf("expected created_at kept, got %s", data)
+	}
+
+	job := CrawlJob{JobID: "job_1", StartedAt: created, CompletedAt: created.Add(time.Minute)}
+	data, _ = json.Marshal(job)
+	var back CrawlJob
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !back.StartedAt.Equal(job.StartedAt) || !back.CompletedAt.Equal(job.CompletedAt) {
+		t.Fatalf("expected timestamps to round-trip, got %s", data)
+	}
+}
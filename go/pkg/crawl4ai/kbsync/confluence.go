@@ -0,0 +1,161 @@
+package kbsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ConfluenceConfig configures the Confluence connector.
+type ConfluenceConfig struct {
+	// BaseURL is the site, e.g. "https://acme.atlassian.net/wiki".
+	BaseURL string
+	// Email and APIToken authenticate (Confluence Cloud basic auth).
+	Email    string
+	APIToken string
+	SpaceKey string
+	// ParentID, when set, nests new pages under this page.
+	ParentID   string
+	HTTPClient *http.Client
+}
+
+// Confluence returns a Connector that adds each page to a Confluence space
+// in storage format, with the source link at the top. Confluence requires
+// unique titles within a space; pages sharing a title fail to create.
+func Confluence(cfg ConfluenceConfig) Connector {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &confluence{cfg: cfg}
+}
+
+type confluence struct {
+	cfg ConfluenceConfig
+}
+
+func (c *confluence) Create(ctx context.Context, p Page) (string, error) {
+	body := map[string]interface{}{
+		"type":  "page",
+		"title": p.Title,
+		"space": map[string]string{"key": c.cfg.SpaceKey},
+		"body":  confluenceBody(p),
+	}
+	if c.cfg.ParentID != "" {
+		body["ancestors"] = []map[string]string{{"id": c.cfg.ParentID}}
+	}
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/content", body, &out); err != nil {
+		return "", err
+	}
+	return out.ID, nil
+}
+
+func (c *confluence) Update(ctx context.Context, id string, p Page) error {
+	// Updates must carry the next version number.
+	var cur struct {
+		Version struct {
+			Number int `json:"number"`
+		} `json:"version"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/content/"+id+"?expand=version", nil, &cur); err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPut, "/rest/api/content/"+id, map[string]interface{}{
+		"type":    "page",
+		"title":   p.Title,
+		"version": map[string]int{"number": cur.Version.Number + 1},
+		"body":    confluenceBody(p),
+	}, nil)
+}
+
+func (c *confluence) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.BaseURL+path, r)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.cfg.Email, c.cfg.APIToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("confluence: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("confluence: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(raw, &e) == nil && e.Message != "" {
+			return fmt.Errorf("confluence: %s %s: HTTP %d: %s", method, path, resp.StatusCode, e.Message)
+		}
+		return fmt.Errorf("confluence: %s %s: HTTP %d", method, path, resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return fmt.Errorf("confluence: decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+func confluenceBody(p Page) map[string]interface{} {
+	return map[string]interface{}{
+		"storage": map[string]string{"value": storageFormat(p), "representation": "storage"},
+	}
+}
+
+// storageFormat renders a page as Confluence storage-format XHTML.
+func storageFormat(p Page) string {
+	var b strings.Builder
+	u := html.EscapeString(p.URL)
+	fmt.Fprintf(&b, `<p>Source: <a href="%s">%s</a></p>`, u, u)
+	var list string // the open list element, if any
+	for _, blk := range parseBlocks(p.Markdown) {
+		want := map[string]string{"bullet": "ul", "numbered": "ol"}[blk.kind]
+		if list != want {
+			if list != "" {
+				fmt.Fprintf(&b, "</%s>", list)
+			}
+			if want != "" {
+				fmt.Fprintf(&b, "<%s>", want)
+			}
+			list = want
+		}
+		text := html.EscapeString(blk.text)
+		switch blk.kind {
+		case "h1", "h2", "h3":
+			fmt.Fprintf(&b, "<%s>%s</%s>", blk.kind, text, blk.kind)
+		case "bullet", "numbered":
+			fmt.Fprintf(&b, "<li>%s</li>", text)
+		case "code":
+			fmt.Fprintf(&b, "<pre>%s</pre>", text)
+		default:
+			fmt.Fprintf(&b, "<p>%s</p>", text)
+		}
+	}
+	if list != "" {
+		fmt.Fprintf(&b, "</%s>", list)
+	}
+	return b.String()
+}
@@ -0,0 +1,64 @@
+package kbsync
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+func TestConfluence_CreateAndUpdate(t *testing.T) {
+	var created, updated map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != "me@acme.com" || p != "tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/wiki/rest/api/content":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"id":"123"}`))
+		case r.Method == "GET" && r.URL.Path == "/wiki/rest/api/content/123" && r.URL.Query().Get("expand") == "version":
+			_, _ = w.Write([]byte(`{"id":"123","version":{"number":4}}`))
+		case r.Method == "PUT" && r.URL.Path == "/wiki/rest/api/content/123":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"No content found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := Confluence(ConfluenceConfig{BaseURL: srv.URL + "/wiki/", Email: "me@acme.com", APIToken: "tok", SpaceKey: "DOCS", ParentID: "9"})
+	id, err := c.Create(context.Background(), Page{URL: "https://a.com", Title: "A", Markdown: "Hi"})
+	if err != nil || id != "123" {
+		t.Fatalf("id = %q err = %v", id, err)
+	}
+	if created["space"].(map[string]interface{})["key"] != "DOCS" || created["ancestors"].([]interface{})[0].(map[string]interface{})["id"] != "9" {
+		t.Errorf("created = %v", created)
+	}
+	if err := c.Update(context.Background(), "123", Page{URL: "https://a.com", Title: "A2", Markdown: "Bye"}); err != nil {
+		t.Fatal(err)
+	}
+	if updated["title"] != "A2" || updated["version"].(map[string]interface{})["number"] != float64(5) {
+		t.Errorf("updated = %v", updated)
+	}
+	if err := c.Update(context.Background(), "404", Page{}); err == nil || err.Error() != "confluence: GET /rest/api/content/404?expand=version: HTTP 404: No content found" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func TestStorageFormat(t *testing.T) {
+	got := storageFormat(Page{URL: "https://a.com/?a=1&b=2", Markdown: "# T\n\n- x\n- y\n\n1. z\n\na < b\n\n```\ncode & more\n```"})
+	want := `<p>Source: <a href="https://a.com/?a=1&amp;b=2">https://a.com/?a=1&amp;b=2</a></p>` +
+		`<h1>T</h1><ul><li>x</li><li>y</li></ul><ol><li>z</li></ol><p>a &lt; b</p><pre>code &amp; more</pre>`
+	if got != want {
+		t.Errorf("storage =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package kbsync
+
+import "strings"
+
+// block is one markdown block, the unit both Notion and Confluence pages
+// are built from.
+type block struct {
+	kind string // "h1", "h2", "h3", "bullet", "numbered", "code", "para"
+	text string
+	lang string // code blocks only
+}
+
+// parseBlocks splits markdown into headings, list items, fenced code and
+// paragraphs. Inline markup is kept as text; both targets render it
+// verbatim rather than guess at it.
+func parseBlocks(md string) []block {
+	var out []block
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			out = append(out, block{kind: "para", text: strings.Join(para, " ")})
+			para = nil
+		}
+	}
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out = append(out, block{kind: "code", text: strings.Join(code, "\n"), lang: lang})
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "### "), strings.HasPrefix(trimmed, "#### "),
+			strings.HasPrefix(trimmed, "##### "), strings.HasPrefix(trimmed, "###### "):
+			flush()
+			out = append(out, block{kind: "h3", text: strings.TrimSpace(strings.TrimLeft(trimmed, "#"))})
+		case strings.HasPrefix(trimmed, "## "):
+			flush()
+			out = append(out, block{kind: "h2", text: strings.TrimSpace(trimmed[3:])})
+		case strings.HasPrefix(trimmed, "# "):
+			flush()
+			out = append(out, block{kind: "h1", text: strings.TrimSpace(trimmed[2:])})
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			flush()
+			out = append(out, block{kind: "bullet", text: strings.TrimSpace(trimmed[2:])})
+		case numberedItem(trimmed) > 0:
+			flush()
+			out = append(out, block{kind: "numbered", text: strings.TrimSpace(trimmed[numberedItem(trimmed):])})
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+	return out
+}
+
+// numberedItem returns the length of a "12. " list marker, or 0.
+func numberedItem(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || i+1 >= len(s) || s[i] != '.' || s[i+1] != ' ' {
+		return 0
+	}
+	return i + 2
+}
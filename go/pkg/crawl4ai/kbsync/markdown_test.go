@@ -0,0 +1,37 @@
+package kbsync
+
+import "testing"
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func TestParseBlocks(t *testing.T) {
+	md := "# Title\n\nFirst line\nsecond line\n\n- one\n* two\n1. first\n10. tenth\n\n```go\nfmt.Println(1)\n\nx := 2\n```\n#### Deep\nlast"
+	want := []block{
+		{kind: "h1", text: "Title"},
+		{kind: "para", text: "First line second line"},
+		{kind: "bullet", text: "one"},
+		{kind: "bullet", text: "two"},
+		{kind: "numbered", text: "first"},
+		{kind: "numbered", text: "tenth"},
+		{kind: "code", text: "fmt.Println(1)\n\nx := 2", lang: "go"},
+		{kind: "h3", text: "Deep"},
+		{kind: "para", text: "last"},
+	}
+	got := parseBlocks(md)
+	if len(got) != len(want) {
+		t.Fatalf("got %d blocks: %+v", len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("block %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNumberedItem(t *testing.T) {
+	for s, want := range map[string]int{"1. a": 3, "12. b": 4, "1.5 c": 0, "a. d": 0, "3.": 0} {
+		if got := numberedItem(s); got != want {
+			t.Errorf("numberedItem(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package kbsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultNotionEndpoint is the Notion API base URL.
+const DefaultNotionEndpoint = "https://api.notion.com"
+
+// notionVersion is the API version the request bodies follow.
+const notionVersion = "2022-06-28"
+
+// NotionConfig configures the Notion connector.
+type NotionConfig struct {
+	// Token is an internal integration token with access to the database.
+	Token      string
+	DatabaseID string
+	// TitleProperty is the database's title column. Default "Name".
+	TitleProperty string
+	// URLProperty, when set, is a URL column filled with the source link.
+	URLProperty string
+	// Endpoint overrides DefaultNotionEndpoint.
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// Notion returns a Connector that adds each page as a row of a Notion
+// database, with the markdown as the row's page content and the source
+// link as its first paragraph. Updates replace the content in place.
+func Notion(cfg NotionConfig) Connector {
+	if cfg.TitleProperty == "" {
+		cfg.TitleProperty = "Name"
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultNotionEndpoint
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &notion{cfg: cfg}
+}
+
+type notion struct {
+	cfg NotionConfig
+}
+
+// notionBlockLimit is the most children one request may carry.
+const notionBlockLimit = 100
+
+func (n *notion) Create(ctx context.Context, p Page) (string, error) {
+	blocks := notionBlocks(p)
+	first := blocks[:min(len(blocks), notionBlockLimit)]
+	var out struct {
+		ID string `json:"id"`
+	}
+	err := n.do(ctx, http.MethodPost, "/v1/pages", map[string]interface{}{
+		"parent":     map[string]string{"database_id": n.cfg.DatabaseID},
+		"properties": n.properties(p),
+		"children":   first,
+	}, &out)
+	if err != nil {
+		return "", err
+	}
+	if err := n.append(ctx, out.ID, blocks[len(first):]); err != nil {
+		return out.ID, err
+	}
+	return out.ID, nil
+}
+
+func (n *notion) Update(ctx context.Context, id string, p Page) error {
+	if err := n.do(ctx, http.MethodPatch, "/v1/pages/"+id, map[string]interface{}{"properties": n.properties(p)}, nil); err != nil {
+		return err
+	}
+	// Notion has no "replace content": delete the old blocks, append anew.
+	var children []string
+	cursor := ""
+	for {
+		path := "/v1/blocks/" + id + "/children?page_size=100"
+		if cursor != "" {
+			path += "&start_cursor=" + url.QueryEscape(cursor)
+		}
+		var page struct {
+			Results []struct {
+				ID string `json:"id"`
+			} `json:"results"`
+			HasMore    bool   `json:"has_more"`
+			NextCursor string `json:"next_cursor"`
+		}
+		if err := n.do(ctx, http.MethodGet, path, nil, &page); err != nil {
+			return err
+		}
+		for _, b := range page.Results {
+			children = append(children, b.ID)
+		}
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	for _, c := range children {
+		if err := n.do(ctx, http.MethodDelete, "/v1/blocks/"+c, nil, nil); err != nil {
+			return err
+		}
+	}
+	return n.append(ctx, id, notionBlocks(p))
+}
+
+func (n *notion) append(ctx context.Context, id string, blocks []map[string]interface{}) error {
+	for len(blocks) > 0 {
+		batch := blocks[:min(len(blocks), notionBlockLimit)]
+		if err := n.do(ctx, http.MethodPatch, "/v1/blocks/"+id+"/children", map[string]interface{}{"children": batch}, nil); err != nil {
+			return err
+		}
+		blocks = blocks[len(batch):]
+	}
+	return nil
+}
+
+func (n *notion) properties(p Page) map[string]interface{} {
+	props := map[string]interface{}{
+		n.cfg.TitleProperty: map[string]interface{}{"title": notionText(p.Title, "")},
+	}
+	if n.cfg.URLProperty != "" {
+		props[n.cfg.URLProperty] = map[string]interface{}{"url": p.URL}
+	}
+	return props
+}
+
+func (n *notion) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		r = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, n.cfg.Endpoint+path, r)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+n.cfg.Token)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("notion: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var e struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(raw, &e) == nil && e.Message != "" {
+			return fmt.Errorf("notion: %s %s: HTTP %d: %s", method, path, resp.StatusCode, e.Message)
+		}
+		return fmt.Errorf("notion: %s %s: HTTP %d", method, path, resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.Unmarshal(raw, out); err != nil {
+			return fmt.Errorf("notion: decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// notionBlocks renders a page: a "Source" paragraph linking the URL, then
+// one block per markdown block.
+func notionBlocks(p Page) []map[string]interface{} {
+	out := []map[string]interface{}{
+		notionBlock("paragraph", append(notionText("Source: ", ""), notionText(p.URL, p.URL)...)),
+	}
+	kinds := map[string]string{
+		"h1": "heading_1", "h2": "heading_2", "h3": "heading_3",
+		"bullet": "bulleted_list_item", "numbered": "numbered_list_item", "para": "paragraph",
+	}
+	for _, b := range parseBlocks(p.Markdown) {
+		if b.kind == "code" {
+			blk := notionBlock("code", notionText(b.text, ""))
+			blk["code"].(map[string]interface{})["language"] = notionLanguage(b.lang)
+			out = append(out, blk)
+			continue
+		}
+		out = append(out, notionBlock(kinds[b.kind], notionText(b.text, "")))
+	}
+	return out
+}
+
+// notionLanguages are the code-block languages Notion accepts; it rejects
+// the whole request for any other value.
+var notionLanguages = map[string]bool{
+	"abap": true, "arduino": true, "bash": true, "basic": true, "c": true, "clojure": true,
+	"coffeescript": true, "c++": true, "c#": true, "css": true, "dart": true, "diff": true,
+	"docker": true, "elixir": true, "elm": true, "erlang": true, "flow": true, "fortran": true,
+	"f#": true, "gherkin": true, "glsl": true, "go": true, "graphql": true, "groovy": true,
+	"haskell": true, "html": true, "java": true, "javascript": true, "json": true, "julia": true,
+	"kotlin": true, "latex": true, "less": true, "lisp": true, "livescript": true, "lua": true,
+	"makefile": true, "markdown": true, "markup": true, "matlab": true, "mermaid": true,
+	"nix": true, "objective-c": true, "ocaml": true, "pascal": true, "perl": true, "php": true,
+	"plain text": true, "powershell": true, "prolog": true, "protobuf": true, "python": true,
+	"r": true, "reason": true, "ruby": true, "rust": true, "sass": true, "scala": true,
+	"scheme": true, "scss": true, "shell": true, "sql": true, "swift": true, "typescript": true,
+	"vb.net": true, "verilog": true, "vhdl": true, "visual basic": true, "webassembly": true,
+	"xml": true, "yaml": true, "java/c/c++/c#": true,
+}
+
+// notionLanguageAliases maps common markdown fence names to Notion's.
+var notionLanguageAliases = map[string]string{
+	"js": "javascript", "jsx": "javascript", "mjs": "javascript", "cjs": "javascript", "node": "javascript",
+	"ts": "typescript", "tsx": "typescript",
+	"sh": "shell", "zsh": "shell", "console": "shell", "shell-session": "shell",
+	"ps1": "powershell", "pwsh": "powershell",
+	"py": "python", "python3": "python", "rb": "ruby", "rs": "rust", "golang": "go",
+	"kt": "kotlin", "kts": "kotlin", "hs": "haskell", "ex": "elixir", "exs": "elixir",
+	"erl": "erlang", "clj": "clojure", "pl": "perl", "objc": "objective-c",
+	"cpp": "c++", "cc": "c++", "cxx": "c++", "hpp": "c++", "h": "c",
+	"cs": "c#", "csharp": "c#", "fs": "f#", "fsharp": "f#", "vb": "visual basic",
+	"yml": "yaml", "md": "markdown", "htm": "html", "xhtml": "html", "svg": "xml",
+	"jsonc": "json", "json5": "json", "dockerfile": "docker", "make": "makefile",
+	"proto": "protobuf", "tex": "latex", "gql": "graphql", "patch": "diff", "wasm": "webassembly",
+	"postgres": "sql", "postgresql": "sql", "mysql": "sql", "sqlite": "sql",
+	"text": "plain text", "txt": "plain text", "plaintext": "plain text",
+}
+
+// notionLanguage maps a fence's info string to a Notion code language,
+// falling back to "plain text".
+func notionLanguage(fence string) string {
+	fields := strings.Fields(fence)
+	if len(fields) == 0 {
+		return "plain text"
+	}
+	lang := strings.ToLower(fields[0])
+	if notionLanguages[lang] {
+		return lang
+	}
+	if alias, ok := notionLanguageAliases[lang]; ok {
+		return alias
+	}
+	return "plain text"
+}
+
+func notionBlock(kind string, text []map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"object": "block",
+		"type":   kind,
+		kind:     map[string]interface{}{"rich_text": text},
+	}
+}
+
+// notionTextLimit is the most characters one rich-text object may hold.
+const notionTextLimit = 2000
+
+// notionText builds rich text for s, split into objects within Notion's
+// length limit, optionally linked.
+func notionText(s, link string) []map[string]interface{} {
+	runes := []rune(s)
+	var out []map[string]interface{}
+	for len(runes) > 0 || out == nil {
+		part := runes[:min(len(runes), notionTextLimit)]
+		text := map[string]interface{}{"content": string(part)}
+		if link != "" {
+			text["link"] = map[string]string{"url": link}
+		}
+		out = append(out, map[string]interface{}{"type": "text", "text": text})
+		runes = runes[len(part):]
+	}
+	return out
+}
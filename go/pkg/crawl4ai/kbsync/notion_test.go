@@ -0,0 +1,144 @@
+package kbsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+type notionCall struct {
+	method, path string
+	body         map[string]interface{}
+}
+
+func notionServer(t *testing.T) (*httptest.Server, func() []notionCall) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []notionCall
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") != notionVersion {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"API token is invalid."}`))
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		calls = append(calls, notionCall{r.Method, r.URL.RequestURI(), body})
+		mu.Unlock()
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v1/pages":
+			_, _ = w.Write([]byte(`{"id":"pg_1"}`))
+		case r.Method == "GET" && r.URL.Query().Get("start_cursor") == "":
+			_, _ = w.Write([]byte(`{"results":[{"id":"b1"},{"id":"b2"}],"has_more":true,"next_cursor":"c/2"}`))
+		case r.Method == "GET":
+			_, _ = w.Write([]byte(`{"results":[{"id":"b3"}],"has_more":false}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []notionCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]notionCall{}, calls...)
+	}
+}
+
+func TestNotion_CreateBatchesChildren(t *testing.T) {
+	srv, calls := notionServer(t)
+	n := Notion(NotionConfig{Token: "secret", DatabaseID: "db_1", URLProperty: "Source", Endpoint: srv.URL})
+
+	var md []string
+	for i := 0; i < 150; i++ {
+		md = append(md, fmt.Sprintf("Paragraph %d", i))
+	}
+	id, err := n.Create(context.Background(), Page{URL: "https://a.com", Title: "A", Markdown: strings.Join(md, "\n\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "pg_1" {
+		t.Errorf("id = %q", id)
+	}
+	got := calls()
+	if len(got) != 2 || got[1].method != "PATCH" || got[1].path != "/v1/blocks/pg_1/children" {
+		t.Fatalf("calls = %+v", got)
+	}
+	create := got[0].body
+	if len(create["children"].([]interface{})) != 100 || len(got[1].body["children"].([]interface{})) != 51 {
+		t.Errorf("children split %d + %d", len(create["children"].([]interface{})), len(got[1].body["children"].([]interface{})))
+	}
+	props := create["properties"].(map[string]interface{})
+	if props["Source"].(map[string]interface{})["url"] != "https://a.com" || props["Name"] == nil {
+		t.Errorf("properties = %v", props)
+	}
+	first := create["children"].([]interface{})[0].(map[string]interface{})
+	link := first["paragraph"].(map[string]interface{})["rich_text"].([]interface{})[1].(map[string]interface{})["text"].(map[string]interface{})["link"]
+	if link.(map[string]interface{})["url"] != "https://a.com" {
+		t.Errorf("source link = %v", first)
+	}
+}
+
+func TestNotion_UpdateReplacesContent(t *testing.T) {
+	srv, calls := notionServer(t)
+	n := Notion(NotionConfig{Token: "secret", DatabaseID: "db_1", Endpoint: srv.URL})
+	if err := n.Update(context.Background(), "pg_1", Page{URL: "https://a.com", Title: "A", Markdown: "# New"}); err != nil {
+		t.Fatal(err)
+	}
+	var seq []string
+	for _, c := range calls() {
+		seq = append(seq, c.method+" "+c.path)
+	}
+	want := []string{
+		"PATCH /v1/pages/pg_1",
+		"GET /v1/blocks/pg_1/children?page_size=100",
+		"GET /v1/blocks/pg_1/children?page_size=100&start_cursor=c%2F2",
+		"DELETE /v1/blocks/b1", "DELETE /v1/blocks/b2", "DELETE /v1/blocks/b3",
+		"PATCH /v1/blocks/pg_1/children",
+	}
+	if strings.Join(seq, "\n") != strings.Join(want, "\n") {
+		t.Errorf("calls =\n%s\nwant\n%s", strings.Join(seq, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNotion_ErrorMessage(t *testing.T) {
+	srv, _ := notionServer(t)
+	_, err := Notion(NotionConfig{Token: "wrong", Endpoint: srv.URL}).Create(context.Background(), Page{Markdown: "x"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 401: API token is invalid.") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func TestNotionText_SplitsLongText(t *testing.T) {
+	parts := notionText(strings.Repeat("a", 4500), "")
+	if len(parts) != 3 {
+		t.Errorf("parts = %d", len(parts))
+	}
+	if len(notionText("", "")) != 1 {
+		t.Error("empty text should still give one object")
+	}
+}
+
+func TestNotionLanguage_MapsFenceAliases(t *testing.T) {
+	for fence, want := range map[string]string{
+		"js": "javascript", "sh": "shell", "yml": "yaml", "Go": "go", "c++": "c++",
+		"python title=app.py": "python", "": "plain text", "brainfuck": "plain text",
+	} {
+		if got := notionLanguage(fence); got != want {
+			t.Errorf("notionLanguage(%q) = %q, want %q", fence, got, want)
+		}
+	}
+	blocks := notionBlocks(Page{URL: "https://a.com", Markdown: "```yml\na: 1\n```"})
+	if lang := blocks[1]["code"].(map[string]interface{})["language"]; lang != "yaml" {
+		t.Fatalf("code block language = %v", lang)
+	}
+}
@@ -0,0 +1,220 @@
+// Package kbsync pushes crawled pages into a knowledge base — a Notion
+// database or a Confluence space — and keeps them current: each sync
+// creates pages for new URLs, updates only pages whose content changed and
+// leaves the rest alone.
+//
+//	s := kbsync.New(kbsync.Notion(kbsync.NotionConfig{Token: token, DatabaseID: db}))
+//	s.Restore(loadState())
+//	err := s.Run(ctx, 24*time.Hour, func(ctx context.Context) ([]*crawl4ai.CrawlResult, error) {
+//	    res, err := crawler.WithContext(ctx).RunMany(docsURLs, &crawl4ai.RunManyOptions{Wait: true})
+//	    if err != nil {
+//	        return nil, err
+//	    }
+//	    return res.Results, nil
+//	}, func(r *kbsync.Report, err error) { saveState(s.Snapshot()) })
+package kbsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// Page is a crawled page as it is written to the knowledge base.
+type Page struct {
+	URL      string
+	Title    string
+	Markdown string
+}
+
+// Connector writes pages to one knowledge base.
+type Connector interface {
+	// Create adds a page and returns its ID in the knowledge base.
+	Create(ctx context.Context, p Page) (remoteID string, err error)
+	// Update replaces the title and content of an existing page.
+	Update(ctx context.Context, remoteID string, p Page) error
+}
+
+// ResultSource loads the results of a crawl job. *crawl4ai.AsyncWebCrawler
+// implements it.
+type ResultSource interface {
+	JobResults(jobID string) ([]*crawl4ai.CrawlResult, error)
+}
+
+// Entry is what the Syncer remembers about a synced URL.
+type Entry struct {
+	RemoteID string `json:"remote_id"`
+	Hash     string `json:"hash"`
+}
+
+// Report is the outcome of one sync. URL lists are in result order.
+type Report struct {
+	Created   []string
+	Updated   []string
+	Unchanged []string
+	// Skipped lists failed crawls and pages without markdown.
+	Skipped []string
+	Failed  map[string]error
+}
+
+// Syncer tracks which URLs are in the knowledge base and at which content
+// version. Persist its state with Snapshot and Restore so a restart does
+// not re-upload everything. It is safe for concurrent use.
+type Syncer struct {
+	conn Connector
+
+	mu    sync.Mutex
+	state map[string]Entry
+}
+
+// New returns a Syncer writing through conn.
+func New(conn Connector) *Syncer {
+	return &Syncer{conn: conn, state: map[string]Entry{}}
+}
+
+// Snapshot returns the synced pages by URL, for persisting.
+func (s *Syncer) Snapshot() map[string]Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]Entry, len(s.state))
+	for k, v := range s.state {
+		out[k] = v
+	}
+	return out
+}
+
+// Restore loads state saved by Snapshot.
+func (s *Syncer) Restore(state map[string]Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range state {
+		s.state[k] = v
+	}
+}
+
+// Sync writes results to the knowledge base: new URLs are created, changed
+// ones updated, unchanged ones skipped. A page that fails is recorded in
+// Report.Failed and retried on the next sync; the error is only set when
+// ctx ends.
+func (s *Syncer) Sync(ctx context.Context, results []*crawl4ai.CrawlResult) (*Report, error) {
+	rep := &Report{Failed: map[string]error{}}
+	for _, r := range results {
+		if err := ctx.Err(); err != nil {
+			return rep, err
+		}
+		p, ok := pageOf(r)
+		if !ok {
+			if r != nil {
+				rep.Skipped = append(rep.Skipped, r.URL)
+			}
+			continue
+		}
+		hash := pageHash(p)
+		s.mu.Lock()
+		prev, known := s.state[p.URL]
+		s.mu.Unlock()
+
+		switch {
+		case known && prev.Hash == hash:
+			rep.Unchanged = append(rep.Unchanged, p.URL)
+		case known:
+			if err := s.conn.Update(ctx, prev.RemoteID, p); err != nil {
+				rep.Failed[p.URL] = err
+				continue
+			}
+			s.record(p.URL, Entry{RemoteID: prev.RemoteID, Hash: hash})
+			rep.Updated = append(rep.Updated, p.URL)
+		default:
+			id, err := s.conn.Create(ctx, p)
+			if err != nil {
+				rep.Failed[p.URL] = err
+				continue
+			}
+			s.record(p.URL, Entry{RemoteID: id, Hash: hash})
+			rep.Created = append(rep.Created, p.URL)
+		}
+	}
+	return rep, nil
+}
+
+func (s *Syncer) record(url string, e Entry) {
+	s.mu.Lock()
+	s.state[url] = e
+	s.mu.Unlock()
+}
+
+// SyncJob syncs the results of a crawl job.
+func (s *Syncer) SyncJob(ctx context.Context, src ResultSource, jobID string) (*Report, error) {
+	results, err := src.JobResults(jobID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Sync(ctx, results)
+}
+
+// Run syncs now and then every interval until ctx ends, returning
+// ctx.Err(). crawl produces each round's results; onReport, when set, sees
+// every round's report or crawl error.
+func (s *Syncer) Run(ctx context.Context, interval time.Duration, crawl func(ctx context.Context) ([]*crawl4ai.CrawlResult, error), onReport func(*Report, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("kbsync: interval must be positive")
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		results, err := crawl(ctx)
+		var rep *Report
+		if err == nil {
+			rep, err = s.Sync(ctx, results)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if onReport != nil {
+			onReport(rep, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// pageOf turns a successful result into a Page. The title is the page's
+// metadata title, then its first heading, then its URL.
+func pageOf(r *crawl4ai.CrawlResult) (Page, bool) {
+	if r == nil || !r.Success || r.Markdown == nil {
+		return Page{}, false
+	}
+	md := strings.TrimSpace(r.Markdown.FitMarkdown)
+	if md == "" {
+		md = strings.TrimSpace(r.Markdown.RawMarkdown)
+	}
+	if md == "" {
+		return Page{}, false
+	}
+	title, _ := r.Metadata["title"].(string)
+	title = strings.TrimSpace(title)
+	if title == "" {
+		for _, b := range parseBlocks(md) {
+			if b.kind == "h1" || b.kind == "h2" {
+				title = b.text
+				break
+			}
+		}
+	}
+	if title == "" {
+		title = r.URL
+	}
+	return Page{URL: r.URL, Title: title, Markdown: md}, true
+}
+
+func pageHash(p Page) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(p.Title+"\n"+p.Markdown)))
+}
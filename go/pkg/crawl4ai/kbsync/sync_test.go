@@ -0,0 +1,162 @@
+package kbsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+// fakeKB is an in-memory Connector.
+type fakeKB struct {
+	mu      sync.Mutex
+	pages   map[string]Page
+	creates int
+	updates int
+	fail    map[string]bool
+}
+
+func newFakeKB() *fakeKB { return &fakeKB{pages: map[string]Page{}, fail: map[string]bool{}} }
+
+func (k *fakeKB) Create(_ context.Context, p Page) (string, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.fail[p.URL] {
+		return "", errors.New("kb down")
+	}
+	k.creates++
+	id := fmt.Sprintf("page_%d", len(k.pages)+1)
+	k.pages[id] = p
+	return id, nil
+}
+
+func (k *fakeKB) Update(_ context.Context, id string, p Page) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.fail[p.URL] {
+		return errors.New("kb down")
+	}
+	k.updates++
+	k.pages[id] = p
+	return nil
+}
+
+func result(url, md string) *crawl4ai.CrawlResult {
+	return &crawl4ai.CrawlResult{URL: url, Success: true, Markdown: &crawl4ai.MarkdownResult{RawMarkdown: md}}
+}
+
+func TestSync_CreatesUpdatesAndSkipsUnchanged(t *testing.T) {
+	kb := newFakeKB()
+	s := New(kb)
+	ctx := context.Background()
+
+	rep, err := s.Sync(ctx, []*crawl4ai.CrawlResult{
+		result("https://a.com/1", "# Intro\n\nHello"),
+		result("https://a.com/2", "Second"),
+		{URL: "https://a.com/3", ErrorMessage: "timeout"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rep.Created) != 2 || len(rep.Skipped) != 1 || kb.creates != 2 {
+		t.Fatalf("first sync = %+v", rep)
+	}
+	if p := kb.pages["page_1"]; p.Title != "Intro" || p.URL != "https://a.com/1" {
+		t.Errorf("page = %+v", p)
+	}
+
+	rep, _ = s.Sync(ctx, []*crawl4ai.CrawlResult{
+		result("https://a.com/1", "# Intro\n\nHello again"),
+		result("https://a.com/2", "Second"),
+	})
+	if len(rep.Updated) != 1 || rep.Updated[0] != "https://a.com/1" || len(rep.Unchanged) != 1 || len(rep.Created) != 0 {
+		t.Errorf("second sync = %+v", rep)
+	}
+	if kb.updates != 1 || kb.pages["page_1"].Markdown != "# Intro\n\nHello again" {
+		t.Errorf("updates = %d, page = %+v", kb.updates, kb.pages["page_1"])
+	}
+}
+
+func TestSync_FailedPageRetriedNextTime(t *testing.T) {
+	kb := newFakeKB()
+	kb.fail["https://a.com"] = true
+	s := New(kb)
+	rep, err := s.Sync(context.Background(), []*crawl4ai.CrawlResult{result("https://a.com", "x")})
+	if err != nil || rep.Failed["https://a.com"] == nil {
+		t.Fatalf("rep = %+v err = %v", rep, err)
+	}
+	kb.fail["https://a.com"] = false
+	rep, _ = s.Sync(context.Background(), []*crawl4ai.CrawlResult{result("https://a.com", "x")})
+	if len(rep.Created) != 1 {
+		t.Errorf("retry = %+v", rep)
+	}
+}
+
+func TestSnapshotRestore_AvoidsReupload(t *testing.T) {
+	kb := newFakeKB()
+	first := New(kb)
+	_, _ = first.Sync(context.Background(), []*crawl4ai.CrawlResult{result("https://a.com", "x")})
+
+	second := New(kb)
+	second.Restore(first.Snapshot())
+	rep, _ := second.Sync(context.Background(), []*crawl4ai.CrawlResult{result("https://a.com", "x")})
+	if len(rep.Unchanged) != 1 || kb.creates != 1 {
+		t.Errorf("rep = %+v, creates = %d", rep, kb.creates)
+	}
+}
+
+func TestPageOf_Title(t *testing.T) {
+	r := result("https://a.com", "## Heading\n\nbody")
+	if p, _ := pageOf(r); p.Title != "Heading" {
+		t.Errorf("title = %q", p.Title)
+	}
+	r.Metadata = map[string]interface{}{"title": " Meta Title "}
+	if p, _ := pageOf(r); p.Title != "Meta Title" {
+		t.Errorf("title = %q", p.Title)
+	}
+	if p, _ := pageOf(result("https://a.com/x", "no heading")); p.Title != "https://a.com/x" {
+		t.Errorf("title = %q", p.Title)
+	}
+	if _, ok := pageOf(result("u", "  ")); ok {
+		t.Error("empty page accepted")
+	}
+}
+
+func TestRun_SyncsEachInterval(t *testing.T) {
+	kb := newFakeKB()
+	s := New(kb)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	rounds := 0
+	err := s.Run(ctx, 10*time.Millisecond, func(context.Context) ([]*crawl4ai.CrawlResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		rounds++
+		return []*crawl4ai.CrawlResult{result("https://a.com", fmt.Sprintf("v%d", rounds))}, nil
+	}, func(r *Report, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			t.Errorf("round error: %v", err)
+		}
+		if rounds == 3 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v", err)
+	}
+	if kb.creates != 1 || kb.updates != 2 {
+		t.Errorf("creates = %d updates = %d", kb.creates, kb.updates)
+	}
+	if s.Run(ctx, 0, nil, nil) == nil {
+		t.Error("zero interval accepted")
+	}
+}
@@ -0,0 +1,296 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// keywordStopWords are words too common, or too navigational, to steer a
+// crawl.
+var keywordStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "you": true, "your": true, "our": true,
+	"are": true, "was": true, "this": true, "that": true, "from": true, "how": true, "what": true,
+	"all": true, "more": true, "new": true, "can": true, "not": true, "use": true, "using": true,
+	"into": true, "about": true, "home": true, "contact": true, "login": true, "log": true,
+	"sign": true, "signup": true, "menu": true, "search": true, "next": true, "previous": true,
+	"prev": true, "page": true, "read": true, "here": true, "click": true, "skip": true,
+	"content": true, "main": true, "privacy": true, "terms": true, "cookie": true, "cookies": true,
+	"policy": true, "copyright": true, "rights": true, "reserved": true, "http": true, "https": true,
+	"www": true, "com": true, "html": true, "index": true,
+}
+
+// KeywordSuggestOptions are options for SuggestKeywords.
+type KeywordSuggestOptions struct {
+	// Topic keeps only terms that share an anchor or heading with one of
+	// these words, plus the words themselves, focusing the suggestions on
+	// what the crawl is after. Empty = every term.
+	Topic []string
+	// MaxKeywords caps the suggestions. Default 10.
+	MaxKeywords int
+	// MaxPages bounds the shallow scan SuggestKeywords runs. Default 10.
+	MaxPages int
+	// StopWords are ignored on top of the built-in list.
+	StopWords []string
+}
+
+// KeywordCount is one suggested term with the evidence for it.
+type KeywordCount struct {
+	Term string
+	// Anchors and Headings count the link texts and headings using it.
+	Anchors  int
+	Headings int
+	// InURLs counts the internal link URLs containing it, which is what a
+	// KeywordScorer matches on.
+	InURLs int
+	Score  float64
+}
+
+// KeywordSuggestion is SuggestKeywords' result.
+type KeywordSuggestion struct {
+	Keywords []KeywordCount
+	// Scorer uses the suggested terms; pass it in a CompositeScorer.
+	Scorer *KeywordScorer
+	// Pages is how many pages the terms came from.
+	Pages int
+}
+
+// SuggestKeywords tunes a best-first crawl automatically: it runs a
+// shallow crawl of url, collects the site's anchor texts and headings and
+// proposes the terms that best separate its sections as a KeywordScorer.
+//
+//	suggestion, err := crawler.SuggestKeywords("https://docs.example.com", &crawl4ai.KeywordSuggestOptions{
+//	    Topic: []string{"api"},
+//	})
+//	scorers, _ := (&crawl4ai.CompositeScorer{Keywords: suggestion.Scorer}).ToMap()
+//	deep, err := crawler.DeepCrawl("https://docs.example.com", &crawl4ai.DeepCrawlOptions{
+//	    Strategy: "best_first", Scorers: scorers, Wait: true,
+//	})
+func (c *AsyncWebCrawler) SuggestKeywords(url string, opts *KeywordSuggestOptions) (*KeywordSuggestion, error) {
+	if opts == nil {
+		opts = &KeywordSuggestOptions{}
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 10
+	}
+	scan, err := c.DeepCrawl(url, &DeepCrawlOptions{
+		Strategy:      "bfs",
+		MaxDepth:      1,
+		MaxURLs:       maxPages,
+		CrawlStrategy: "http",
+		Wait:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("suggest keywords: %w", err)
+	}
+	if scan.CrawlJob == nil || len(scan.CrawlJob.Results) == 0 {
+		return nil, fmt.Errorf("suggest keywords: the scan of %s returned no pages", url)
+	}
+	return SuggestKeywordsFrom(scan.CrawlJob.Results, opts), nil
+}
+
+// SuggestKeywordsFrom is SuggestKeywords over pages already crawled.
+// Anchor texts repeated on most pages (navigation, footers) are ignored;
+// terms that also appear in internal link URLs rank higher, since that is
+// where a KeywordScorer looks for them.
+func SuggestKeywordsFrom(results []*CrawlResult, opts *KeywordSuggestOptions) *KeywordSuggestion {
+	if opts == nil {
+		opts = &KeywordSuggestOptions{}
+	}
+	maxKeywords := opts.MaxKeywords
+	if maxKeywords <= 0 {
+		maxKeywords = 10
+	}
+	stop := map[string]bool{}
+	for _, w := range opts.StopWords {
+		stop[strings.ToLower(w)] = true
+	}
+	useful := func(t string) bool {
+		return len(t) >= 3 && !keywordStopWords[t] && !stop[t] && strings.Trim(t, "0123456789") != ""
+	}
+
+	var pages []*CrawlResult
+	for _, r := range results {
+		if r != nil {
+			pages = append(pages, r)
+		}
+	}
+	// Anchor texts on most pages are site chrome.
+	anchorPages := map[string]int{}
+	for _, r := range pages {
+		seen := map[string]bool{}
+		for _, a := range resultAnchors(r) {
+			key := strings.ToLower(a.text)
+			if !seen[key] {
+				seen[key] = true
+				anchorPages[key]++
+			}
+		}
+	}
+	chrome := func(text string) bool {
+		return len(pages) >= 3 && anchorPages[strings.ToLower(text)]*5 >= len(pages)*4
+	}
+
+	counts := map[string]*KeywordCount{}
+	var phrases [][]string
+	get := func(t string) *KeywordCount {
+		if counts[t] == nil {
+			counts[t] = &KeywordCount{Term: t}
+		}
+		return counts[t]
+	}
+	urlTerms := map[string]map[string]bool{}
+	for _, r := range pages {
+		for _, a := range resultAnchors(r) {
+			if chrome(a.text) {
+				continue
+			}
+			terms := usefulTerms(a.text, useful)
+			for _, t := range terms {
+				get(t).Anchors++
+			}
+			phrases = append(phrases, terms)
+			if a.internal {
+				if link, ok := absoluteLink(r.URL, a.href); ok && urlTerms[link] == nil {
+					set := map[string]bool{}
+					if u, err := url.Parse(link); err == nil {
+						for _, t := range usefulTerms(u.Path+" "+u.RawQuery, useful) {
+							set[t] = true
+						}
+					}
+					urlTerms[link] = set
+				}
+			}
+		}
+		for _, h := range resultHeadings(r) {
+			terms := usefulTerms(h, useful)
+			for _, t := range terms {
+				get(t).Headings++
+			}
+			phrases = append(phrases, terms)
+		}
+	}
+	for _, set := range urlTerms {
+		for t := range set {
+			if c := counts[t]; c != nil {
+				c.InURLs++
+			}
+		}
+	}
+
+	if len(opts.Topic) > 0 {
+		keep := map[string]bool{}
+		topic := map[string]bool{}
+		for _, w := range opts.Topic {
+			for _, t := range tokenize(w) {
+				topic[t], keep[t] = true, true
+			}
+		}
+		for _, p := range phrases {
+			for _, t := range p {
+				if topic[t] {
+					for _, o := range p {
+						keep[o] = true
+					}
+					break
+				}
+			}
+		}
+		for t := range counts {
+			if !keep[t] {
+				delete(counts, t)
+			}
+		}
+	}
+
+	s := &KeywordSuggestion{Pages: len(pages)}
+	for _, c := range counts {
+		c.Score = float64(c.Anchors) + 2*float64(c.Headings)
+		if c.InURLs > 0 {
+			c.Score *= 2
+		}
+		s.Keywords = append(s.Keywords, *c)
+	}
+	sort.Slice(s.Keywords, func(i, k int) bool {
+		if s.Keywords[i].Score != s.Keywords[k].Score {
+			return s.Keywords[i].Score > s.Keywords[k].Score
+		}
+		return s.Keywords[i].Term < s.Keywords[k].Term
+	})
+	if len(s.Keywords) > maxKeywords {
+		s.Keywords = s.Keywords[:maxKeywords]
+	}
+	if len(s.Keywords) > 0 {
+		s.Scorer = &KeywordScorer{}
+		for _, k := range s.Keywords {
+			s.Scorer.Keywords = append(s.Scorer.Keywords, k.Term)
+		}
+	}
+	return s
+}
+
+// usefulTerms tokenizes text, keeping each useful term once.
+func usefulTerms(text string, useful func(string) bool) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range tokenize(text) {
+		if useful(t) && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+type resultAnchor struct {
+	href, text string
+	internal   bool
+}
+
+// resultAnchors lists r's links that have anchor text.
+func resultAnchors(r *CrawlResult) []resultAnchor {
+	var out []resultAnchor
+	for _, kind := range []string{"internal", "external"} {
+		items, _ := r.Links[kind].([]interface{})
+		for _, item := range items {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			href, _ := m["href"].(string)
+			text, _ := m["text"].(string)
+			if text = strings.TrimSpace(text); text != "" {
+				out = append(out, resultAnchor{href: href, text: text, internal: kind == "internal"})
+			}
+		}
+	}
+	return out
+}
+
+// resultHeadings returns r's headings from its markdown, or from its HTML
+// when the markdown was omitted.
+func resultHeadings(r *CrawlResult) []string {
+	var out []string
+	if r.Markdown != nil && r.Markdown.RawMarkdown != "" {
+		for _, line := range strings.Split(r.Markdown.RawMarkdown, "\n") {
+			line = strings.TrimSpace(line)
+			if h := strings.TrimLeft(line, "#"); h != line && strings.HasPrefix(h, " ") {
+				out = append(out, strings.TrimSpace(h))
+			}
+		}
+		return out
+	}
+	if r.HTML != "" {
+		parseHTML(r.HTML).walk(func(n *htmlNode) {
+			switch n.Tag {
+			case "h1", "h2", "h3", "h4":
+				if t := n.fullText(); t != "" {
+					out = append(out, t)
+				}
+			}
+		})
+	}
+	return out
+}
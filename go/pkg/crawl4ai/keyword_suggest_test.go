@@ -0,0 +1,57 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestSuggestKeywordsFrom(t *testing.T) {
+	nav := []interface{}{
+		map[string]interface{}{"href": "/", "text": "Docs Home"},
+		map[string]interface{}{"href": "/pricing", "text": "Pricing"},
+	}
+	page := func(path string, links []interface{}, markdown string) *CrawlResult {
+		return &CrawlResult{
+			URL:      "https://docs.example.com" + path,
+			Links:    map[string]interface{}{"internal": append(append([]interface{}{}, nav...), links...)},
+			Markdown: &MarkdownResult{RawMarkdown: markdown},
+		}
+	}
+	results := []*CrawlResult{
+		page("/", []interface{}{
+			map[string]interface{}{"href": "/api/auth", "text": "Authentication API"},
+			map[string]interface{}{"href": "/api/webhooks", "text": "Webhooks reference"},
+			map[string]interface{}{"href": "/blog/launch", "text": "Launch party photos"},
+		}, "# Welcome\n\n## API reference\n\nText with #hashtag"),
+		page("/api/auth", []interface{}{
+			map[string]interface{}{"href": "/api/tokens", "text": "Token API"},
+		}, "# Authentication\n## Tokens"),
+		page("/blog/launch", nil, "# Launch party"),
+	}
+
+	s := SuggestKeywordsFrom(results, nil)
+	if s.Pages != 3 || s.Scorer == nil {
+		t.Fatalf("suggestion = %+v", s)
+	}
+	byTerm := map[string]KeywordCount{}
+	for _, k := range s.Keywords {
+		byTerm[k.Term] = k
+	}
+	if _, ok := byTerm["pricing"]; ok {
+		t.Error("navigation repeated on every page should be ignored")
+	}
+	if api := byTerm["api"]; api.Anchors != 2 || api.Headings != 1 || api.InURLs != 3 || s.Keywords[0].Term != "api" {
+		t.Errorf("api = %+v, top = %+v", api, s.Keywords[0])
+	}
+	if _, ok := byTerm["hashtag"]; ok {
+		t.Error("#hashtag is not a heading")
+	}
+
+	focused := SuggestKeywordsFrom(results, &KeywordSuggestOptions{Topic: []string{"API"}, MaxKeywords: 3})
+	got := fmt.Sprint(focused.Scorer.Keywords)
+	if got != "[api authentication reference]" {
+		t.Errorf("focused keywords = %s", got)
+	}
+}
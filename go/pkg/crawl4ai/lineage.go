@@ -0,0 +1,84 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"time"
+)
+
+// Lineage relations — how a job derives from the next node up the chain.
+const (
+	LineageRelationParent     = "parent"      // retry / re-run of the parent job
+	LineageRelationSourceScan = "source_scan" // crawl of URLs a scan discovered
+)
+
+// maxLineageDepth bounds GetJobLineage so a server-side cycle can't loop forever.
+const maxLineageDepth = 20
+
+// JobLineageNode is one job in a lineage chain.
+type JobLineageNode struct {
+	JobID     string
+	Kind      string // "crawl" | "scan"
+	Status    JobStatus
+	CreatedAt time.Time
+	// Relation is how this node derives from the next one in the chain;
+	// empty on the root.
+	Relation string
+}
+
+// GetJobLineage reconstructs the ancestry of a crawl job by following
+// ParentJobID (retries) and SourceScanID (two-phase scan → crawl) links.
+//
+// The returned chain starts at jobID and ends at the root — the original
+// crawl or the scan that seeded it:
+//
+//	chain, _ := crawler.GetJobLineage("job_retry_2")
+//	// [job_retry_2 (parent)] → [job_retry_1 (source_scan)] → [scan_abc]
+func (c *AsyncWebCrawler) GetJobLineage(jobID string) ([]JobLineageNode, error) {
+	chain := make([]JobLineageNode, 0, 2)
+	seen := map[string]bool{}
+	next := jobID
+	for depth := 0; next != ""; depth++ {
+		if depth >= maxLineageDepth {
+			return chain, fmt.Errorf("job lineage for %s exceeds %d hops", jobID, maxLineageDepth)
+		}
+		if seen[next] {
+			return chain, fmt.Errorf("job lineage for %s loops back to %s", jobID, next)
+		}
+		seen[next] = true
+
+		job, err := c.GetJob(next)
+		if err != nil {
+			return chain, err
+		}
+		node := JobLineageNode{
+			JobID:     job.JobID,
+			Kind:      "crawl",
+			Status:    job.Status,
+			CreatedAt: job.CreatedAt,
+		}
+		switch {
+		case job.ParentJobID != "":
+			node.Relation = LineageRelationParent
+			next = job.ParentJobID
+		case job.SourceScanID != "":
+			node.Relation = LineageRelationSourceScan
+			chain = append(chain, node)
+			scan, err := c.GetDeepCrawlStatus(job.SourceScanID)
+			if err != nil {
+				return chain, err
+			}
+			scanCreated, _ := parseAPITime(scan.CreatedAt)
+			chain = append(chain, JobLineageNode{
+				JobID:     job.SourceScanID,
+				Kind:      "scan",
+				Status:    JobStatus(scan.Status),
+				CreatedAt: scanCreated,
+			})
+			return chain, nil
+		default:
+			next = ""
+		}
+		chain = append(chain, node)
+	}
+	return chain, nil
+}
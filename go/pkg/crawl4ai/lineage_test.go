@@ -0,0 +1,70 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newMockCrawler points a crawler at an in-process server that answers each
+// "METHOD /path" key with its JSON value; unknown routes 404.
+func newMockCrawler(t *testing.T, routes map[string]interface{}) *AsyncWebCrawler {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"detail": "not found"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	return c
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestGetJobLineage_RetryThenScan(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_r2":      map[string]interface{}{"job_id": "job_r2", "status": "completed", "parent_job_id": "job_r1"},
+		"GET /v1/crawl/jobs/job_r1":      map[string]interface{}{"job_id": "job_r1", "status": "failed", "source_job_id": "scan_1"},
+		"GET /v1/crawl/deep/jobs/scan_1": map[string]interface{}{"job_id": "scan_1", "status": "completed"},
+	})
+	chain, err := c.GetJobLineage("job_r2")
+	if err != nil {
+		t.Fatalf("GetJobLineage: %v", err)
+	}
+	want := []JobLineageNode{
+		{JobID: "job_r2", Kind: "crawl", Status: "completed", Relation: LineageRelationParent},
+		{JobID: "job_r1", Kind: "crawl", Status: "failed", Relation: LineageRelationSourceScan},
+		{JobID: "scan_1", Kind: "scan", Status: "completed"},
+	}
+	if len(chain) != len(want) {
+		t.Fatalf("expected %d nodes, got %+v", len(want), chain)
+	}
+	for i := range want {
+		if chain[i] != want[i] {
+			t.Errorf("node %d = %+v, want %+v", i, chain[i], want[i])
+		}
+	}
+}
+
+func TestGetJobLineage_DetectsCycle(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/a": map[string]interface{}{"job_id": "a", "parent_job_id": "b"},
+		"GET /v1/crawl/jobs/b": map[string]interface{}{"job_id": "b", "parent_job_id": "a"},
+	})
+	chain, err := c.GetJobLineage("a")
+	if err == nil {
+		t.Fatal("expected cycle error")
+	}
+	if len(chain) != 2 {
+		t.Fatalf("expected partial chain of 2, got %d", len(chain))
+	}
+}
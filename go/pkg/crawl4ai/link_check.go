@@ -0,0 +1,258 @@
+package crawl4ai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LinkCheckOptions configure CheckLinks.
+type LinkCheckOptions struct {
+	// DeepCrawl configures the crawl CheckLinks runs when given a start URL
+	// (Wait is forced on). Ignored when given a job ID.
+	DeepCrawl *DeepCrawlOptions
+	// SkipExternal checks only links that stay on the crawled site.
+	SkipExternal bool
+	// Concurrency is how many links are checked at once. Default 8.
+	Concurrency int
+	// Timeout bounds each link check. Default 10s.
+	Timeout time.Duration
+	// HTTPClient sends the checks. Default http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// LinkReport is the result of CheckLinks.
+type LinkReport struct {
+	JobID string
+	// Pages is how many crawled pages were scanned for links.
+	Pages int
+	// Checked is how many distinct links were checked.
+	Checked int
+	// Broken are the failing links, sorted by URL.
+	Broken []BrokenLinkInfo
+}
+
+// BrokenLinkInfo is a link that failed its check, with every crawled page
+// that links to it.
+type BrokenLinkInfo struct {
+	URL string
+	// StatusCode is the response status, or 0 when the request failed
+	// (DNS, TLS, timeout); Error says why.
+	StatusCode     int
+	Error          string
+	Internal       bool
+	ReferringPages []string
+}
+
+// CheckLinks finds broken links on a site. Given a start URL it deep
+// crawls the site first; given a job ID it reuses that crawl's results.
+// Every distinct link found on the crawled pages is then checked with a
+// HEAD request (falling back to GET for servers that reject HEAD). Links to
+// pages the crawl itself fetched reuse the crawl's status instead of being
+// requested again.
+//
+//	report, err := crawler.CheckLinks(ctx, "https://docs.example.com", &crawl4ai.LinkCheckOptions{
+//	    DeepCrawl: &crawl4ai.DeepCrawlOptions{MaxURLs: 500, CrawlStrategy: "http"},
+//	})
+//	for _, b := range report.Broken {
+//	    fmt.Println(b.StatusCode, b.URL, "linked from", b.ReferringPages)
+//	}
+func (c *AsyncWebCrawler) CheckLinks(ctx context.Context, target string, opts *LinkCheckOptions) (*LinkReport, error) {
+	o := LinkCheckOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 8
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Second
+	}
+	if o.HTTPClient == nil {
+		o.HTTPClient = http.DefaultClient
+	}
+
+	jobID := target
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		dc := DeepCrawlOptions{}
+		if o.DeepCrawl != nil {
+			dc = *o.DeepCrawl
+		}
+		dc.Wait = true
+		deep, err := c.DeepCrawl(target, &dc)
+		if err != nil {
+			return nil, fmt.Errorf("check links: %w", err)
+		}
+		if deep.DeepResult == nil || deep.DeepResult.CrawlJobID == "" {
+			return nil, fmt.Errorf("check links: deep crawl of %s produced no crawl job", target)
+		}
+		jobID = deep.DeepResult.CrawlJobID
+	}
+	results, err := c.JobResults(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("check links: %w", err)
+	}
+	return checkResultLinks(ctx, jobID, results, &o), nil
+}
+
+type linkTarget struct {
+	internal  bool
+	referrers map[string]bool
+}
+
+func checkResultLinks(ctx context.Context, jobID string, results []*CrawlResult, o *LinkCheckOptions) *LinkReport {
+	crawled := map[string]*CrawlResult{}
+	for _, r := range results {
+		crawled[linkKey(r.URL)] = r
+	}
+
+	targets := map[string]*linkTarget{}
+	for _, r := range results {
+		if !r.Success {
+			continue
+		}
+		kinds := []string{"internal", "external"}
+		if o.SkipExternal {
+			kinds = kinds[:1]
+		}
+		for _, kind := range kinds {
+			for _, href := range resultLinks(r, kind) {
+				abs, ok := absoluteLink(r.URL, href)
+				if !ok {
+					continue
+				}
+				t := targets[abs]
+				if t == nil {
+					t = &linkTarget{internal: kind == "internal", referrers: map[string]bool{}}
+					targets[abs] = t
+				}
+				t.referrers[r.URL] = true
+			}
+		}
+	}
+
+	report := &LinkReport{JobID: jobID, Pages: len(results), Checked: len(targets)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, o.Concurrency)
+	for link, t := range targets {
+		var status int
+		var errMsg string
+		if r, ok := crawled[linkKey(link)]; ok {
+			status, errMsg = r.StatusCode, r.ErrorMessage
+			if r.Success && status == 0 {
+				status = http.StatusOK
+			}
+		} else {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(link string, t *linkTarget) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				status, errMsg := headCheck(ctx, o.HTTPClient, link, o.Timeout)
+				mu.Lock()
+				report.addIfBroken(link, t, status, errMsg)
+				mu.Unlock()
+			}(link, t)
+			continue
+		}
+		mu.Lock()
+		report.addIfBroken(link, t, status, errMsg)
+		mu.Unlock()
+	}
+	wg.Wait()
+
+	sort.Slice(report.Broken, func(i, j int) bool { return report.Broken[i].URL < report.Broken[j].URL })
+	return report
+}
+
+func (r *LinkReport) addIfBroken(link string, t *linkTarget, status int, errMsg string) {
+	if status > 0 && status < 400 {
+		return
+	}
+	refs := make([]string, 0, len(t.referrers))
+	for ref := range t.referrers {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	r.Broken = append(r.Broken, BrokenLinkInfo{
+		URL: link, StatusCode: status, Error: errMsg, Internal: t.internal, ReferringPages: refs,
+	})
+}
+
+// headCheck requests link with HEAD, retrying with GET when the server
+// doesn't allow HEAD.
+func headCheck(ctx context.Context, client *http.Client, link string, timeout time.Duration) (int, string) {
+	status, err := probeLink(ctx, client, http.MethodHead, link, timeout)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = probeLink(ctx, client, http.MethodGet, link, timeout)
+	}
+	if err != nil {
+		return 0, err.Error()
+	}
+	return status, ""
+}
+
+func probeLink(ctx context.Context, client *http.Client, method, link string, timeout time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// resultLinks returns the hrefs in links[kind] ("internal" or "external").
+func resultLinks(r *CrawlResult, kind string) []string {
+	items, _ := r.Links[kind].([]interface{})
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case string:
+			out = append(out, v)
+		case map[string]interface{}:
+			if href, ok := v["href"].(string); ok {
+				out = append(out, href)
+			}
+		}
+	}
+	return out
+}
+
+// absoluteLink resolves href against page and drops its fragment. Only
+// http(s) links are kept; mailto:, tel:, javascript: and the like are not.
+func absoluteLink(page, href string) (string, bool) {
+	base, err := url.Parse(page)
+	if err != nil {
+		return "", false
+	}
+	u, err := base.Parse(strings.TrimSpace(href))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", false
+	}
+	u.Fragment = ""
+	return u.String(), true
+}
+
+// linkKey normalizes a URL for matching links to crawled pages.
+func linkKey(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Fragment = ""
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
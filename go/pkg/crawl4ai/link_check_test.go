@@ -0,0 +1,75 @@
+package crawl4ai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestCheckLinks_JobID(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer site.Close()
+
+	links := func(internal, external []string) map[string]interface{} {
+		toItems := func(hrefs []string) []interface{} {
+			out := []interface{}{}
+			for _, h := range hrefs {
+				out = append(out, map[string]interface{}{"href": h})
+			}
+			return out
+		}
+		return map[string]interface{}{"internal": toItems(internal), "external": toItems(external)}
+	}
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{
+			"job_id": "job_1", "status": "completed", "urls_count": 3,
+			"results": []interface{}{
+				map[string]interface{}{"url": "https://a.com/", "success": true, "status_code": 200,
+					"links": links([]string{"/docs#intro", "/old", "mailto:x@a.com"}, []string{site.URL + "/ok", site.URL + "/dead"})},
+				map[string]interface{}{"url": "https://a.com/docs", "success": true, "status_code": 200,
+					"links": links([]string{"/old"}, []string{site.URL + "/no-head", site.URL + "/dead"})},
+				map[string]interface{}{"url": "https://a.com/old", "success": false, "status_code": 410, "error_message": "Gone"},
+			},
+		},
+	})
+
+	report, err := c.CheckLinks(context.Background(), "job_1", nil)
+	if err != nil {
+		t.Fatalf("CheckLinks: %v", err)
+	}
+	if report.Pages != 3 || report.Checked != 5 {
+		t.Fatalf("expected 3 pages and 5 links, got %+v", report)
+	}
+	want := []BrokenLinkInfo{
+		{URL: "https://a.com/old", StatusCode: 410, Error: "Gone", Internal: true, ReferringPages: []string{"https://a.com/", "https://a.com/docs"}},
+		{URL: site.URL + "/dead", StatusCode: 404, ReferringPages: []string{"https://a.com/", "https://a.com/docs"}},
+	}
+	if want[1].URL < want[0].URL {
+		want[0], want[1] = want[1], want[0]
+	}
+	if !reflect.DeepEqual(report.Broken, want) {
+		t.Fatalf("unexpected broken links:\n%+v\nwant:\n%+v", report.Broken, want)
+	}
+
+	report, err = c.CheckLinks(context.Background(), "job_1", &LinkCheckOptions{SkipExternal: true})
+	if err != nil {
+		t.Fatalf("CheckLinks: %v", err)
+	}
+	if report.Checked != 2 || len(report.Broken) != 1 {
+		t.Fatalf("expected only internal links checked, got %+v", report)
+	}
+}
@@ -0,0 +1,102 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestParseAPITime_Layouts(t *testing.T) {
+	for _, s := range []string{
+		"2026-05-03T10:00:00Z",
+		"2026-05-03T10:00:00.123456+00:00",
+		"2026-05-03T10:00:00.123456",
+		"2026-05-03 10:00:00",
+	} {
+		got, err := parseAPITime(s)
+		if err != nil {
+			t.Fatalf("parseAPITime(%q): %v", s, err)
+		}
+		if got.UTC().Format("2006-01-02T15") != "2026-05-03T10" {
+			t.Fatalf("parseAPITime(%q) = %v", s, got)
+		}
+	}
+	if _, err := parseAPITime("yesterday"); err == nil {
+		t.Fatal("expected error for garbage timestamp")
+	}
+}
+
+func TestListJobsOptions_Matches(t *testing.T) {
+	day := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)
+	opts := &ListJobsOptions{
+		CreatedAfter:  day,
+		CreatedBefore: day.Add(24 * time.Hour),
+		URLContains:   "Amazon.com",
+	}
+	cases := []struct {
+		name string
+		job  *CrawlJob
+		want bool
+	}{
+		{"in range, url match", &CrawlJob{CreatedAt: day.Add(12 * time.Hour), URLs: []string{"https://www.amazon.com/dp/1"}}, true},
+		{"too early", &CrawlJob{CreatedAt: day.Add(-time.Second), URLs: []string{"https://amazon.com"}}, false},
+		{"upper bound exclusive", &CrawlJob{CreatedAt: day.Add(24 * time.Hour), URLs: []string{"https://amazon.com"}}, false},
+		{"url mismatch", &CrawlJob{CreatedAt: day.Add(12 * time.Hour), URLs: []string{"https://ebay.com"}}, false},
+		{"url from results", &CrawlJob{CreatedAt: day.Add(12 * time.Hour), Results: []*CrawlResult{{URL: "https://amazon.com/x"}}}, true},
+		{"no urls to check", &CrawlJob{CreatedAt: day.Add(12 * time.Hour)}, true},
+	}
+	for _, tc := range cases {
+		if got := opts.matches(tc.job); got != tc.want {
+			t.Errorf("%s: matches = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+// newCappedJobsCrawler serves n finished jobs from /v1/crawl/jobs, at most
+// pageCap per page whatever limit is asked for.
+func newCappedJobsCrawler(t *testing.T, n, pageCap int) *AsyncWebCrawler {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		jobs := []interface{}{}
+		for i := offset; i < n && i < offset+min(limit, pageCap); i++ {
+			jobs = append(jobs, map[string]interface{}{"job_id": fmt.Sprintf("job_%d", i), "status": "completed", "urls_count": 1})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jobs": jobs})
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestListJobs_PagesPastServerPageCap(t *testing.T) {
+	c := newCappedJobsCrawler(t, 5, 2)
+	jobs, err := c.ListJobs(&ListJobsOptions{Limit: 4})
+	if err != nil || len(jobs) != 4 || jobs[3].JobID != "job_3" {
+		t.Fatalf("Limit 4 = %d jobs, %v", len(jobs), err)
+	}
+	jobs, err = c.ListJobs(&ListJobsOptions{Limit: 10, CreatedAfter: time.Unix(0, 0)})
+	if err != nil || len(jobs) != 5 {
+		t.Fatalf("filtered Limit 10 = %d jobs, %v", len(jobs), err)
+	}
+}
+
+func TestFinishedJobs_PagesPastServerPageCap(t *testing.T) {
+	c := newCappedJobsCrawler(t, 250, 30)
+	jobs, err := c.finishedJobs()
+	if err != nil || len(jobs) != 250 {
+		t.Fatalf("finishedJobs = %d jobs, %v", len(jobs), err)
+	}
+}
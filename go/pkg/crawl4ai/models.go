@@ -1,6 +1,10 @@
 package crawl4ai
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // ProxyConfig represents proxy configuration for crawl requests.
 type ProxyConfig struct {
@@ -16,6 +20,9 @@ type JobProgress struct {
 	Total     int `json:"total"`
 	Completed int `json:"completed"`
 	Failed    int `json:"failed"`
+
+	eta      time.Duration
+	etaKnown bool
 }
 
 // Pending returns the number of pending items.
@@ -31,21 +38,81 @@ func (p *JobProgress) Percent() float64 {
 	return float64(p.Completed+p.Failed) / float64(p.Total) * 100
 }
 
+// apiTimeLayouts are the timestamp shapes the API emits: RFC 3339 with or
+// without fractional seconds, space-separated variants, and naive ISO-8601
+// (treated as UTC).
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// parseAPITime parses an API timestamp string.
+func parseAPITime(s string) (time.Time, error) {
+	for _, layout := range apiTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised timestamp %q", s)
+}
+
+// apiTimeValue reads a timestamp field that may be a string or Unix
+// seconds. ok is false when the field is missing or unparseable.
+func apiTimeValue(v interface{}) (t time.Time, ok bool) {
+	switch x := v.(type) {
+	case string:
+		t, err := parseAPITime(x)
+		return t, err == nil
+	case float64:
+		sec := int64(x)
+		return time.Unix(sec, int64((x-float64(sec))*1e9)).UTC(), true
+	}
+	return time.Time{}, false
+}
+
 // CrawlJob represents an async crawl job.
 type CrawlJob struct {
 	JobID           string         `json:"job_id"`
-	Status          string         `json:"status"`
+	Status          JobStatus      `json:"status"`
 	Progress        JobProgress    `json:"progress"`
 	URLsCount       int            `json:"urls_count"`
-	CreatedAt       string         `json:"created_at"`
-	StartedAt       string         `json:"started_at,omitempty"`
-	CompletedAt     string         `json:"completed_at,omitempty"`
+	URLs            []string       `json:"urls,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	StartedAt       time.Time      `json:"started_at,omitempty"`   // zero until a worker picks the job up; see MarshalJSON
+	CompletedAt     time.Time      `json:"completed_at,omitempty"` // zero until the job is terminal; see MarshalJSON
 	Results         []*CrawlResult `json:"results,omitempty"`
 	Error           string         `json:"error,omitempty"`
 	ResultSizeBytes int            `json:"result_size_bytes,omitempty"`
 	DownloadURL     string         `json:"download_url,omitempty"`
 	// Usage contains resource usage metrics (completed jobs only)
 	Usage *Usage `json:"usage,omitempty"`
+	// ParentJobID is the job this one was retried or re-run from.
+	ParentJobID string `json:"parent_job_id,omitempty"`
+	// SourceScanID is the deep-crawl scan job whose discovered URLs fed
+	// this crawl (two-phase workflows via DeepCrawlOptions.SourceJob).
+	SourceScanID string `json:"source_scan_id,omitempty"`
+}
+
+// MarshalJSON leaves out StartedAt and CompletedAt while they are zero;
+// omitempty has no effect on time.Time, so they would otherwise encode as
+// "0001-01-01T00:00:00Z".
+func (j CrawlJob) MarshalJSON() ([]byte, error) {
+	type plain CrawlJob
+	out := struct {
+		plain
+		StartedAt   *time.Time `json:"started_at,omitempty"`
+		CompletedAt *time.Time `json:"completed_at,omitempty"`
+	}{plain: plain(j)}
+	if !j.StartedAt.IsZero() {
+		out.StartedAt = &j.StartedAt
+	}
+	if !j.CompletedAt.IsZero() {
+		out.CompletedAt = &j.CompletedAt
+	}
+	return json.Marshal(out)
 }
 
 // ID returns the job ID (backward compatibility alias for JobID).
@@ -54,18 +121,44 @@ func (j *CrawlJob) ID() string {
 	return j.JobID
 }
 
+// QueueTime is how long the job waited before a worker started it. For a
+// job that hasn't started yet it is the time queued so far.
+func (j *CrawlJob) QueueTime() time.Duration {
+	if j.CreatedAt.IsZero() {
+		return 0
+	}
+	if j.StartedAt.IsZero() {
+		if j.IsComplete() {
+			return 0
+		}
+		return time.Since(j.CreatedAt)
+	}
+	return j.StartedAt.Sub(j.CreatedAt)
+}
+
+// Duration is how long the job ran, from start to completion. For a
+// running job it is the time elapsed so far; 0 before it starts.
+func (j *CrawlJob) Duration() time.Duration {
+	if j.StartedAt.IsZero() {
+		return 0
+	}
+	if j.CompletedAt.IsZero() {
+		if j.IsComplete() {
+			return 0
+		}
+		return time.Since(j.StartedAt)
+	}
+	return j.CompletedAt.Sub(j.StartedAt)
+}
+
 // IsComplete checks if job is in a terminal state.
 func (j *CrawlJob) IsComplete() bool {
-	switch j.Status {
-	case "completed", "partial", "failed", "cancelled":
-		return true
-	}
-	return false
+	return j.Status.IsTerminal()
 }
 
 // IsSuccessful checks if job completed successfully.
 func (j *CrawlJob) IsSuccessful() bool {
-	return j.Status == "completed"
+	return j.Status == JobStatusCompleted
 }
 
 // CrawlJobFromMap creates a CrawlJob from API response map.
@@ -76,28 +169,39 @@ func CrawlJobFromMap(data map[string]interface{}) *CrawlJob {
 		job.JobID = v
 	}
 	if v, ok := data["status"].(string); ok {
-		job.Status = v
+		job.Status, _ = ParseJobStatus(v)
 	}
 	if v, ok := data["urls_count"].(float64); ok {
 		job.URLsCount = int(v)
 	} else if v, ok := data["url_count"].(float64); ok {
 		job.URLsCount = int(v)
 	}
-	if v, ok := data["created_at"].(string); ok {
-		job.CreatedAt = v
-	}
-	if v, ok := data["started_at"].(string); ok {
-		job.StartedAt = v
-	}
-	if v, ok := data["completed_at"].(string); ok {
-		job.CompletedAt = v
+	if urls, ok := data["urls"].([]interface{}); ok {
+		for _, u := range urls {
+			if s, ok := u.(string); ok {
+				job.URLs = append(job.URLs, s)
+			}
+		}
 	}
+	job.CreatedAt, _ = apiTimeValue(data["created_at"])
+	job.StartedAt, _ = apiTimeValue(data["started_at"])
+	job.CompletedAt, _ = apiTimeValue(data["completed_at"])
 	if v, ok := data["error"].(string); ok {
 		job.Error = v
 	}
 	if v, ok := data["result_size_bytes"].(float64); ok {
 		job.ResultSizeBytes = int(v)
 	}
+	if v, ok := data["parent_job_id"].(string); ok {
+		job.ParentJobID = v
+	} else if v, ok := data["retry_of"].(string); ok {
+		job.ParentJobID = v
+	}
+	if v, ok := data["source_scan_id"].(string); ok {
+		job.SourceScanID = v
+	} else if v, ok := data["source_job_id"].(string); ok {
+		job.SourceScanID = v
+	}
 
 	if progress, ok := data["progress"].(map[string]interface{}); ok {
 		if v, ok := progress["total"].(float64); ok {
@@ -141,6 +245,11 @@ type MarkdownResult struct {
 }
 
 // CrawlResult represents a single URL crawl result.
+//
+// Its maps and slices are references, so a struct copy (r2 := *r) still
+// shares them; use Clone for a copy you can mutate. A result is its
+// caller's alone, even when CrawlerOptions.CoalesceRuns shares one
+// response between concurrent callers.
 type CrawlResult struct {
 	URL              string                 `json:"url"`
 	Success          bool                   `json:"success"`
@@ -159,17 +268,60 @@ type CrawlResult struct {
 	DurationMs       int                    `json:"duration_ms,omitempty"`
 	Tables           []interface{}          `json:"tables,omitempty"`
 	RedirectedURL    string                 `json:"redirected_url,omitempty"`
-	CrawlStrategy    string                 `json:"crawl_strategy,omitempty"`
+	// RedirectChain lists every hop in order, ending with the final URL
+	// (RedirectedURL) and its non-3xx status. Empty when the server
+	// doesn't report hops.
+	RedirectChain []RedirectHop `json:"redirect_chain,omitempty"`
+	CrawlStrategy string        `json:"crawl_strategy,omitempty"`
 	// DownloadedFiles contains presigned S3 URLs for file downloads (CSV, PDF, XLSX, etc.)
 	DownloadedFiles []string `json:"downloaded_files,omitempty"`
 	// ID is the job ID for async results (use with DownloadURL())
 	ID string `json:"id,omitempty"`
 	// Usage contains resource usage metrics
 	Usage *Usage `json:"usage,omitempty"`
+	// BlockInfo is set when the page looks like a captcha, block page or
+	// login wall instead of the requested content.
+	BlockInfo *BlockInfo `json:"block_info,omitempty"`
+	// RequestID is the server's ID for the call that produced this result;
+	// quote it when contacting support.
+	RequestID string `json:"request_id,omitempty"`
+	// Chunks holds the embedded markdown chunks after Embed or
+	// CrawlAndEmbed.
+	Chunks []Chunk `json:"chunks,omitempty"`
+	// Warnings lists the options Run dropped from the request. It is set
+	// by the SDK, not the API. See SanitizeWarnings.
+	Warnings []Warning `json:"-"`
+
+	lazy *lazyResult
+	// inJob marks a result inlined in a CrawlJob; its usage is billed as
+	// part of the job's.
+	inJob bool
+}
+
+// RedirectHop is one response in a redirect chain: the URL requested and
+// the status it answered with.
+type RedirectHop struct {
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code"`
+}
+
+// Redirects reports whether the crawl followed at least one redirect.
+//
+//	for _, hop := range result.RedirectChain {
+//	    fmt.Println(hop.StatusCode, hop.URL) // 301 http://example.com, ..., 200 https://www.example.com/
+//	}
+func (r *CrawlResult) Redirects() bool {
+	return len(r.RedirectChain) > 1
 }
 
 // CrawlResultFromMap creates a CrawlResult from API response map.
 func CrawlResultFromMap(data map[string]interface{}) *CrawlResult {
+	return crawlResultFromMap(data, detectBlock(data))
+}
+
+// crawlResultFromMap decodes data with an already classified block, for
+// callers that ran detectBlock before dropping fields.
+func crawlResultFromMap(data map[string]interface{}, block *BlockInfo) *CrawlResult {
 	result := &CrawlResult{}
 
 	if v, ok := data["url"].(string); ok {
@@ -208,6 +360,19 @@ func CrawlResultFromMap(data map[string]interface{}) *CrawlResult {
 	if v, ok := data["redirected_url"].(string); ok {
 		result.RedirectedURL = v
 	}
+	if hops, ok := data["redirect_chain"].([]interface{}); ok {
+		result.RedirectChain = make([]RedirectHop, 0, len(hops))
+		for _, h := range hops {
+			if hm, ok := h.(map[string]interface{}); ok {
+				hop := RedirectHop{}
+				hop.URL, _ = hm["url"].(string)
+				if v, ok := hm["status_code"].(float64); ok {
+					hop.StatusCode = int(v)
+				}
+				result.RedirectChain = append(result.RedirectChain, hop)
+			}
+		}
+	}
 	if v, ok := data["crawl_strategy"].(string); ok {
 		result.CrawlStrategy = v
 	}
@@ -256,6 +421,10 @@ func CrawlResultFromMap(data map[string]interface{}) *CrawlResult {
 	if usage, ok := data["usage"].(map[string]interface{}); ok {
 		result.Usage = UsageFromMap(usage)
 	}
+	if v, ok := data["request_id"].(string); ok {
+		result.RequestID = v
+	}
+	result.BlockInfo = block
 
 	return result
 }
@@ -1036,7 +1205,7 @@ type EnrichOptions struct {
 	LLMConfig     map[string]interface{} `json:"-"`
 	Proxy         map[string]interface{} `json:"-"`
 	WebhookURL    string                 `json:"-"`
-	Priority      int                    `json:"-"`
+	Priority      Priority               `json:"-"`
 
 	// Polling
 	Wait         bool          `json:"-"`
@@ -1308,7 +1477,7 @@ type ExtractOptions struct {
 type ScrapeAsyncOptions struct {
 	MarkdownOptions
 	WebhookURL   string        `json:"webhook_url,omitempty"`
-	Priority     int           `json:"priority,omitempty"`
+	Priority     Priority      `json:"priority,omitempty"`
 	Wait         bool          `json:"-"`
 	PollInterval time.Duration `json:"-"`
 	Timeout      time.Duration `json:"-"`
@@ -1318,7 +1487,7 @@ type ScrapeAsyncOptions struct {
 type ScreenshotAsyncOptions struct {
 	ScreenshotOptions
 	WebhookURL   string        `json:"webhook_url,omitempty"`
-	Priority     int           `json:"priority,omitempty"`
+	Priority     Priority      `json:"priority,omitempty"`
 	Wait         bool          `json:"-"`
 	PollInterval time.Duration `json:"-"`
 	Timeout      time.Duration `json:"-"`
@@ -1336,7 +1505,7 @@ type ExtractAsyncOptions struct {
 	// Up to 99 (total ≤100 with the base url).
 	ExtraURLs    []string      `json:"extra_urls,omitempty"`
 	WebhookURL   string        `json:"webhook_url,omitempty"`
-	Priority     int           `json:"priority,omitempty"`
+	Priority     Priority      `json:"priority,omitempty"`
 	Wait         bool          `json:"-"`
 	PollInterval time.Duration `json:"-"`
 	Timeout      time.Duration `json:"-"`
@@ -1373,7 +1542,7 @@ type SiteCrawlOptions struct {
 	BrowserConfig map[string]interface{} `json:"browser_config,omitempty"`
 	Proxy         map[string]interface{} `json:"proxy,omitempty"`
 	WebhookURL    string                 `json:"webhook_url,omitempty"`
-	Priority      int                    `json:"priority,omitempty"`
+	Priority      Priority               `json:"priority,omitempty"`
 
 	// AI-assisted fields (new in 0.4.0)
 	Criteria        string             `json:"-"` // plain-English — triggers LLM config gen
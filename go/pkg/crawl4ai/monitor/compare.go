@@ -0,0 +1,98 @@
+package monitor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// Comparator reduces a crawl result to the value a watch tracks; a change
+// is any check whose value differs from the previous one.
+type Comparator struct {
+	// Name describes the comparator in ChangeEvents, e.g. "content_hash".
+	Name string
+	// Configure, when set, adjusts the crawl config before each check — to
+	// add the extraction strategy Value reads, for instance.
+	Configure func(*crawl4ai.CrawlerRunConfig)
+	// Value extracts the tracked value from a successful result.
+	Value func(*crawl4ai.CrawlResult) (string, error)
+}
+
+// ContentHash tracks the SHA-256 of the page's markdown, so any visible
+// text change fires.
+func ContentHash() Comparator {
+	return Comparator{
+		Name: "content_hash",
+		Value: func(r *crawl4ai.CrawlResult) (string, error) {
+			text := r.HTML
+			if r.Markdown != nil && r.Markdown.RawMarkdown != "" {
+				text = r.Markdown.RawMarkdown
+			}
+			sum := sha256.Sum256([]byte(text))
+			return hex.EncodeToString(sum[:]), nil
+		},
+	}
+}
+
+// SelectorValue tracks the text of the first element matching a CSS
+// selector — a stock badge, a version number, a headline. It is extracted
+// server-side with a json_css strategy.
+func SelectorValue(selector string) Comparator {
+	schema := &crawl4ai.Schema{
+		Name:         "monitor",
+		BaseSelector: "html",
+		Fields:       []crawl4ai.Field{crawl4ai.NewTextField("value", selector)},
+	}
+	return Comparator{
+		Name: "selector:" + selector,
+		Configure: func(cfg *crawl4ai.CrawlerRunConfig) {
+			strategy, _ := crawl4ai.JSONCSSExtraction(schema)
+			cfg.ExtractionStrategy = strategy
+		},
+		Value: func(r *crawl4ai.CrawlResult) (string, error) {
+			var rows []map[string]interface{}
+			if err := json.Unmarshal([]byte(r.ExtractedContent), &rows); err != nil {
+				return "", fmt.Errorf("selector %s: decode extracted content: %w", selector, err)
+			}
+			if len(rows) == 0 {
+				return "", nil
+			}
+			v, _ := rows[0]["value"].(string)
+			return strings.TrimSpace(v), nil
+		},
+	}
+}
+
+// PriceRegex tracks the first match of pattern in the page's markdown —
+// its first capture group when it has one. A page where the pattern stops
+// matching reports an empty value, which counts as a change.
+//
+//	monitor.PriceRegex(`\$\s*([0-9][0-9,]*\.[0-9]{2})`)
+func PriceRegex(pattern string) (Comparator, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Comparator{}, fmt.Errorf("price regex: %w", err)
+	}
+	return Comparator{
+		Name: "regex:" + pattern,
+		Value: func(r *crawl4ai.CrawlResult) (string, error) {
+			text := r.HTML
+			if r.Markdown != nil && r.Markdown.RawMarkdown != "" {
+				text = r.Markdown.RawMarkdown
+			}
+			m := re.FindStringSubmatch(text)
+			switch {
+			case m == nil:
+				return "", nil
+			case len(m) > 1:
+				return m[1], nil
+			}
+			return m[0], nil
+		},
+	}, nil
+}
@@ -0,0 +1,60 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+func md(text string) *crawl4ai.CrawlResult {
+	return &crawl4ai.CrawlResult{Success: true, Markdown: &crawl4ai.MarkdownResult{RawMarkdown: text}}
+}
+
+func TestContentHash(t *testing.T) {
+	c := ContentHash()
+	a, _ := c.Value(md("hello"))
+	b, _ := c.Value(md("hello"))
+	d, _ := c.Value(md("hello!"))
+	if a != b || a == d || len(a) != 64 {
+		t.Fatalf("hashes %q %q %q", a, b, d)
+	}
+}
+
+func TestSelectorValue(t *testing.T) {
+	c := SelectorValue(".stock")
+	cfg := &crawl4ai.CrawlerRunConfig{}
+	c.Configure(cfg)
+	if cfg.ExtractionStrategy["type"] != crawl4ai.ExtractionTypeJSONCSS {
+		t.Fatalf("strategy = %v", cfg.ExtractionStrategy)
+	}
+	v, err := c.Value(&crawl4ai.CrawlResult{ExtractedContent: `[{"value": "  In stock "}]`})
+	if err != nil || v != "In stock" {
+		t.Fatalf("value %q, %v", v, err)
+	}
+	if v, err := c.Value(&crawl4ai.CrawlResult{ExtractedContent: `[]`}); err != nil || v != "" {
+		t.Fatalf("empty extraction: %q, %v", v, err)
+	}
+	if _, err := c.Value(&crawl4ai.CrawlResult{ExtractedContent: "oops"}); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
+
+func TestPriceRegex(t *testing.T) {
+	c, err := PriceRegex(`\$([0-9,]+\.[0-9]{2})`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := c.Value(md("Now only $1,299.00!")); v != "1,299.00" {
+		t.Fatalf("value %q", v)
+	}
+	if v, _ := c.Value(md("Sold out")); v != "" {
+		t.Fatalf("no match should be empty, got %q", v)
+	}
+	whole, _ := PriceRegex(`[0-9]+ left`)
+	if v, _ := whole.Value(md("only 3 left")); v != "3 left" {
+		t.Fatalf("whole match %q", v)
+	}
+	if _, err := PriceRegex("("); err == nil {
+		t.Fatal("expected compile error")
+	}
+}
@@ -0,0 +1,291 @@
+// Package monitor watches URLs for changes: each Watch is re-crawled on its
+// interval, reduced to a value by a Comparator, and every time the value
+// moves a ChangeEvent goes to the configured Sinks.
+//
+//	price, _ := monitor.PriceRegex(`\$([0-9.]+)`)
+//	m := monitor.New(crawler, monitor.Options{
+//	    Sinks: []monitor.Sink{monitor.Webhook("https://hooks.example.com/prices")},
+//	})
+//	m.Add(monitor.Watch{ID: "gpu", URL: "https://shop.example.com/rtx", Interval: time.Hour, Compare: price})
+//	err := m.Run(ctx) // blocks until ctx ends
+//
+// The scheduler runs in-process; the monitor does nothing while the program
+// isn't running. Use Snapshot and Restore to carry baselines across
+// restarts.
+package monitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// Fetcher crawls one URL. *crawl4ai.AsyncWebCrawler implements it.
+type Fetcher interface {
+	Run(url string, opts *crawl4ai.RunOptions) (*crawl4ai.CrawlResult, error)
+}
+
+// Watch is one monitored URL.
+type Watch struct {
+	// ID names the watch; it defaults to URL.
+	ID       string
+	URL      string
+	Interval time.Duration
+	// Compare defaults to ContentHash.
+	Compare Comparator
+	// Options are used for every check. Checks always bypass the cache.
+	Options *crawl4ai.RunOptions
+}
+
+// ChangeEvent reports a tracked value that moved between two checks.
+type ChangeEvent struct {
+	WatchID    string    `json:"watch_id"`
+	URL        string    `json:"url"`
+	Comparator string    `json:"comparator"`
+	Old        string    `json:"old"`
+	New        string    `json:"new"`
+	DetectedAt time.Time `json:"detected_at"`
+	// Result is the crawl that observed the new value.
+	Result *crawl4ai.CrawlResult `json:"-"`
+}
+
+// Options configures a Monitor.
+type Options struct {
+	Sinks []Sink
+	// OnError sees failed checks and sink errors; the monitor keeps going.
+	OnError func(watchID string, err error)
+	// DefaultInterval applies to watches without one. Default 1h.
+	DefaultInterval time.Duration
+}
+
+// Monitor schedules and runs watches. It is safe for concurrent use.
+type Monitor struct {
+	fetcher Fetcher
+	opts    Options
+
+	mu        sync.Mutex
+	watches   map[string]*watchState
+	baselines map[string]string
+	wake      chan struct{}
+}
+
+type watchState struct {
+	Watch
+	next time.Time
+}
+
+// New returns a Monitor that crawls through fetcher.
+func New(fetcher Fetcher, opts Options) *Monitor {
+	if opts.DefaultInterval <= 0 {
+		opts.DefaultInterval = time.Hour
+	}
+	return &Monitor{
+		fetcher:   fetcher,
+		opts:      opts,
+		watches:   map[string]*watchState{},
+		baselines: map[string]string{},
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Add registers w, replacing any watch with the same ID. Its first check
+// is due immediately and records the baseline without emitting.
+func (m *Monitor) Add(w Watch) error {
+	if w.URL == "" {
+		return errors.New("monitor: watch URL is required")
+	}
+	if w.ID == "" {
+		w.ID = w.URL
+	}
+	if w.Interval <= 0 {
+		w.Interval = m.opts.DefaultInterval
+	}
+	if w.Compare.Value == nil {
+		w.Compare = ContentHash()
+	}
+	m.mu.Lock()
+	m.watches[w.ID] = &watchState{Watch: w}
+	m.mu.Unlock()
+	m.poke()
+	return nil
+}
+
+// Remove unregisters a watch and forgets its baseline.
+func (m *Monitor) Remove(id string) {
+	m.mu.Lock()
+	delete(m.watches, id)
+	delete(m.baselines, id)
+	m.mu.Unlock()
+}
+
+// Snapshot returns every watch's last value by ID, for persisting.
+func (m *Monitor) Snapshot() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]string, len(m.baselines))
+	for k, v := range m.baselines {
+		out[k] = v
+	}
+	return out
+}
+
+// Restore loads baselines saved by Snapshot, so the first check after a
+// restart can already report a change.
+func (m *Monitor) Restore(baselines map[string]string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, v := range baselines {
+		m.baselines[k] = v
+	}
+}
+
+// Check runs one watch now. It returns the ChangeEvent it emitted, or nil
+// when the value is unchanged or this was the baseline check. When a sink
+// fails the baseline is kept, so the next check emits the change again —
+// to every sink, including those that already received it.
+func (m *Monitor) Check(ctx context.Context, id string) (*ChangeEvent, error) {
+	m.mu.Lock()
+	ws, ok := m.watches[id]
+	var w Watch
+	if ok {
+		w = ws.Watch
+		ws.next = time.Now().Add(w.Interval)
+	}
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("monitor: no watch %q", id)
+	}
+
+	value, result, err := m.observe(ctx, w)
+	if err != nil {
+		return nil, fmt.Errorf("monitor: watch %s: %w", id, err)
+	}
+
+	m.mu.Lock()
+	old, seen := m.baselines[id]
+	if !seen {
+		m.baselines[id] = value
+	}
+	m.mu.Unlock()
+	if !seen || old == value {
+		return nil, nil
+	}
+
+	ev := ChangeEvent{
+		WatchID: id, URL: w.URL, Comparator: w.Compare.Name,
+		Old: old, New: value, DetectedAt: time.Now().UTC(), Result: result,
+	}
+	var errs []error
+	for _, s := range m.opts.Sinks {
+		if err := s.Emit(ctx, ev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return &ev, fmt.Errorf("monitor: watch %s: emit: %w", id, err)
+	}
+	// Only now does the new value become the baseline, so a failed emit is
+	// detected, and emitted, again by the next check.
+	m.mu.Lock()
+	if m.baselines[id] == old {
+		m.baselines[id] = value
+	}
+	m.mu.Unlock()
+	return &ev, nil
+}
+
+// observe crawls w and extracts its tracked value.
+func (m *Monitor) observe(ctx context.Context, w Watch) (string, *crawl4ai.CrawlResult, error) {
+	opts := crawl4ai.RunOptions{}
+	if w.Options != nil {
+		opts = *w.Options
+	}
+	opts.BypassCache = true
+	if w.Compare.Configure != nil {
+		cfg := crawl4ai.CrawlerRunConfig{}
+		if opts.Config != nil {
+			cfg = *opts.Config
+		}
+		w.Compare.Configure(&cfg)
+		opts.Config = &cfg
+	}
+
+	fetcher := m.fetcher
+	if c, ok := fetcher.(*crawl4ai.AsyncWebCrawler); ok {
+		fetcher = c.WithContext(ctx)
+	}
+	result, err := fetcher.Run(w.URL, &opts)
+	if err != nil {
+		return "", nil, err
+	}
+	if !result.Success {
+		return "", result, fmt.Errorf("crawl failed: %s", result.ErrorMessage)
+	}
+	value, err := w.Compare.Value(result)
+	return value, result, err
+}
+
+// Run checks every watch when it falls due until ctx ends, then returns
+// ctx.Err(). Check errors go to Options.OnError.
+func (m *Monitor) Run(ctx context.Context) error {
+	for {
+		for _, id := range m.due(time.Now()) {
+			if _, err := m.Check(ctx, id); err != nil && m.opts.OnError != nil {
+				m.opts.OnError(id, err)
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+		}
+		timer := time.NewTimer(m.untilNext(time.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-m.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// due lists the watches whose next check is at or before now.
+func (m *Monitor) due(now time.Time) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var ids []string
+	for id, ws := range m.watches {
+		if !ws.next.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// untilNext is how long Run sleeps before the earliest pending check.
+func (m *Monitor) untilNext(now time.Time) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wait := m.opts.DefaultInterval
+	for _, ws := range m.watches {
+		if d := ws.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// poke wakes Run so a newly added watch is checked without waiting.
+func (m *Monitor) poke() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,184 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// pages is a Fetcher serving mutable markdown per URL.
+type pages struct {
+	mu    sync.Mutex
+	md    map[string]string
+	calls int
+	opts  []*crawl4ai.RunOptions
+}
+
+func (p *pages) set(url, md string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.md[url] = md
+}
+
+func (p *pages) Run(url string, opts *crawl4ai.RunOptions) (*crawl4ai.CrawlResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	p.opts = append(p.opts, opts)
+	md, ok := p.md[url]
+	if !ok {
+		return &crawl4ai.CrawlResult{URL: url, ErrorMessage: "not found"}, nil
+	}
+	return &crawl4ai.CrawlResult{URL: url, Success: true, Markdown: &crawl4ai.MarkdownResult{RawMarkdown: md}}, nil
+}
+
+func TestCheck_BaselineThenChange(t *testing.T) {
+	src := &pages{md: map[string]string{"https://a.com/p": "Price: $10.00"}}
+	price, err := PriceRegex(`\$([0-9.]+)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []ChangeEvent
+	m := New(src, Options{Sinks: []Sink{Callback(func(ev ChangeEvent) { got = append(got, ev) })}})
+	if err := m.Add(Watch{ID: "p", URL: "https://a.com/p", Compare: price}); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if ev, err := m.Check(ctx, "p"); ev != nil || err != nil {
+		t.Fatalf("baseline check should not emit: %+v, %v", ev, err)
+	}
+	if ev, _ := m.Check(ctx, "p"); ev != nil {
+		t.Fatalf("unchanged value emitted: %+v", ev)
+	}
+	src.set("https://a.com/p", "Price: $8.50")
+	ev, err := m.Check(ctx, "p")
+	if err != nil || ev == nil || ev.Old != "10.00" || ev.New != "8.50" || ev.Comparator != `regex:\$([0-9.]+)` {
+		t.Fatalf("change event: %+v, %v", ev, err)
+	}
+	if len(got) != 1 || got[0].WatchID != "p" {
+		t.Fatalf("sink saw %+v", got)
+	}
+	if !src.opts[0].BypassCache {
+		t.Fatal("checks must bypass the cache")
+	}
+	if m.Snapshot()["p"] != "8.50" {
+		t.Fatalf("snapshot = %v", m.Snapshot())
+	}
+}
+
+func TestCheck_RestoreAndErrors(t *testing.T) {
+	src := &pages{md: map[string]string{"https://a.com": "hello"}}
+	m := New(src, Options{})
+	_ = m.Add(Watch{URL: "https://a.com"})
+	m.Restore(map[string]string{"https://a.com": "stale-hash"})
+
+	ev, err := m.Check(context.Background(), "https://a.com")
+	if err != nil || ev == nil || ev.Comparator != "content_hash" {
+		t.Fatalf("restored baseline should produce a change: %+v, %v", ev, err)
+	}
+
+	_ = m.Add(Watch{ID: "gone", URL: "https://a.com/missing"})
+	if _, err := m.Check(context.Background(), "gone"); err == nil {
+		t.Fatal("failed crawl should be an error")
+	}
+	if _, err := m.Check(context.Background(), "nope"); err == nil {
+		t.Fatal("unknown watch should be an error")
+	}
+	if err := m.Add(Watch{}); err == nil {
+		t.Fatal("watch without URL should be rejected")
+	}
+}
+
+func TestCheck_FailedEmitKeepsBaseline(t *testing.T) {
+	src := &pages{md: map[string]string{"https://a.com": "v1"}}
+	fail := true
+	var delivered []ChangeEvent
+	sink := SinkFunc(func(_ context.Context, ev ChangeEvent) error {
+		if fail {
+			return errors.New("sink down")
+		}
+		delivered = append(delivered, ev)
+		return nil
+	})
+	m := New(src, Options{Sinks: []Sink{sink}})
+	_ = m.Add(Watch{URL: "https://a.com"})
+	ctx := context.Background()
+	if _, err := m.Check(ctx, "https://a.com"); err != nil {
+		t.Fatal(err)
+	}
+	base := m.Snapshot()["https://a.com"]
+
+	src.set("https://a.com", "v2")
+	if _, err := m.Check(ctx, "https://a.com"); err == nil {
+		t.Fatal("expected the emit error")
+	}
+	if m.Snapshot()["https://a.com"] != base {
+		t.Fatal("baseline must not advance past an undelivered change")
+	}
+
+	fail = false
+	ev, err := m.Check(ctx, "https://a.com")
+	if err != nil || ev == nil || ev.Old != base || len(delivered) != 1 {
+		t.Fatalf("expected the change re-emitted once the sink recovers: %+v, %v, %d", ev, err, len(delivered))
+	}
+	if m.Snapshot()["https://a.com"] == base {
+		t.Fatal("baseline should advance after a successful emit")
+	}
+}
+
+func TestRun_ChecksDueWatchesAndEmitsToChannel(t *testing.T) {
+	src := &pages{md: map[string]string{"https://a.com": "v1"}}
+	events := make(chan ChangeEvent, 1)
+	m := New(src, Options{Sinks: []Sink{Channel(events)}})
+	_ = m.Add(Watch{URL: "https://a.com", Interval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+
+	time.Sleep(30 * time.Millisecond)
+	src.set("https://a.com", "v2")
+	select {
+	case ev := <-events:
+		if ev.URL != "https://a.com" || ev.Old == ev.New {
+			t.Fatalf("unexpected event %+v", ev)
+		}
+	case <-ctx.Done():
+		t.Fatal("no change event before timeout")
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v", err)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got ChangeEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.WatchID == "fail" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	sink := Webhook(srv.URL)
+	if err := sink.Emit(context.Background(), ChangeEvent{WatchID: "w", Old: "a", New: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.WatchID != "w" || got.New != "b" {
+		t.Fatalf("webhook got %+v", got)
+	}
+	if err := sink.Emit(context.Background(), ChangeEvent{WatchID: "fail"}); err == nil {
+		t.Fatal("expected error for 502")
+	}
+}
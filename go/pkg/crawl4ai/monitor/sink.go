@@ -0,0 +1,68 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sink receives the ChangeEvents a Monitor emits.
+type Sink interface {
+	Emit(ctx context.Context, ev ChangeEvent) error
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(ctx context.Context, ev ChangeEvent) error
+
+// Emit implements Sink.
+func (f SinkFunc) Emit(ctx context.Context, ev ChangeEvent) error { return f(ctx, ev) }
+
+// Callback returns a Sink that calls fn for every change.
+func Callback(fn func(ChangeEvent)) Sink {
+	return SinkFunc(func(_ context.Context, ev ChangeEvent) error {
+		fn(ev)
+		return nil
+	})
+}
+
+// Channel returns a Sink that sends every change on ch, blocking until the
+// receiver takes it or the check's context ends.
+func Channel(ch chan<- ChangeEvent) Sink {
+	return SinkFunc(func(ctx context.Context, ev ChangeEvent) error {
+		select {
+		case ch <- ev:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Webhook returns a Sink that POSTs every change as JSON to url. Non-2xx
+// answers are errors.
+func Webhook(url string) Sink {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return SinkFunc(func(ctx context.Context, ev ChangeEvent) error {
+		body, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("webhook %s: HTTP %d", url, resp.StatusCode)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,103 @@
+package crawl4ai
+
+import "fmt"
+
+// Cache modes, as in the open-source library's CacheMode enum, for
+// CrawlerRunConfig.CacheMode. The cloud manages its own cache, so Run
+// strips cache_mode; ArunWithConfig and TranslateOSSConfig turn it into
+// the nearest cloud option instead.
+const (
+	CacheModeEnabled   = "enabled"
+	CacheModeDisabled  = "disabled"
+	CacheModeReadOnly  = "read_only"
+	CacheModeWriteOnly = "write_only"
+	CacheModeBypass    = "bypass"
+)
+
+// Warning reports an option the SDK changed or dropped because the cloud
+// API has no exact equivalent.
+type Warning struct {
+	// Field is the option's wire name, e.g. "cache_mode".
+	Field   string
+	Message string
+}
+
+func (w Warning) String() string {
+	return w.Field + ": " + w.Message
+}
+
+// warn hands w to CrawlerOptions.OnWarning, if set. The SDK never logs on
+// its own.
+func (c *AsyncWebCrawler) warn(w Warning) {
+	if c.onWarning != nil {
+		c.onWarning(w)
+	}
+}
+
+// TranslateOSSConfig maps the open-source-only options in config onto the
+// cloud's: cache modes and cache flags become RunOptions.BypassCache, and
+// options with no cloud equivalent are cleared. Each change is reported as
+// a Warning. config itself is not modified.
+func TranslateOSSConfig(config *CrawlerRunConfig) (*RunOptions, []Warning) {
+	if config == nil {
+		return &RunOptions{}, nil
+	}
+	cfg := *config
+	opts := &RunOptions{Config: &cfg}
+	var warnings []Warning
+	add := func(field, format string, args ...interface{}) {
+		warnings = append(warnings, Warning{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch cfg.CacheMode {
+	case "", CacheModeEnabled:
+	case CacheModeBypass, CacheModeWriteOnly:
+		opts.BypassCache = true
+	case CacheModeDisabled:
+		opts.BypassCache = true
+		add("cache_mode", "the cloud cannot skip writing its cache; %q sent as bypass_cache", cfg.CacheMode)
+	case CacheModeReadOnly:
+		add("cache_mode", "the cloud always writes its cache; %q dropped", cfg.CacheMode)
+	default:
+		add("cache_mode", "unknown cache mode %q dropped", cfg.CacheMode)
+	}
+	if cfg.BypassCache || cfg.NoCacheRead {
+		opts.BypassCache = true
+	}
+	if cfg.DisableCache {
+		opts.BypassCache = true
+		add("disable_cache", "the cloud cannot skip writing its cache; sent as bypass_cache")
+	}
+	if cfg.NoCacheWrite {
+		add("no_cache_write", "the cloud always writes its cache; dropped")
+	}
+	if cfg.SessionID != "" {
+		add("session_id", "open-source session IDs name local browser tabs; dropped (drive a SessionPool session over CDP instead)")
+	}
+	cfg.CacheMode, cfg.SessionID = "", ""
+	cfg.BypassCache, cfg.NoCacheRead, cfg.NoCacheWrite, cfg.DisableCache = false, false, false, false
+	return opts, warnings
+}
+
+// ArunWithConfig is the open-source arun(url, config=config): it
+// translates open-source options with TranslateOSSConfig, reports what
+// changed through CrawlerOptions.OnWarning (under StrictOptions, fails
+// instead), and crawls with the crawler's
+// BrowserConfig.
+func (c *AsyncWebCrawler) ArunWithConfig(url string, config *CrawlerRunConfig) (*CrawlResult, error) {
+	opts, warnings := TranslateOSSConfig(config)
+	if err := c.reportWarnings(warnings); err != nil {
+		return nil, err
+	}
+	return c.Run(url, opts)
+}
+
+// ArunManyWithConfig is the open-source arun_many(urls, config=config).
+// Like the open-source call it returns once every URL is crawled.
+func (c *AsyncWebCrawler) ArunManyWithConfig(urls []string, config *CrawlerRunConfig) (*RunManyResult, error) {
+	opts, warnings := TranslateOSSConfig(config)
+	if err := c.reportWarnings(warnings); err != nil {
+		return nil, err
+	}
+	return c.RunMany(urls, &RunManyOptions{Config: opts.Config, BypassCache: opts.BypassCache, Wait: true})
+}
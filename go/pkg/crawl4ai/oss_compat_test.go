@@ -0,0 +1,116 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestTranslateOSSConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		cfg      CrawlerRunConfig
+		bypass   bool
+		warnings []string
+	}{
+		{"enabled", CrawlerRunConfig{CacheMode: CacheModeEnabled}, false, nil},
+		{"bypass", CrawlerRunConfig{CacheMode: CacheModeBypass}, true, nil},
+		{"write only", CrawlerRunConfig{CacheMode: CacheModeWriteOnly}, true, nil},
+		{"disabled", CrawlerRunConfig{CacheMode: CacheModeDisabled}, true, []string{"cache_mode"}},
+		{"read only", CrawlerRunConfig{CacheMode: CacheModeReadOnly}, false, []string{"cache_mode"}},
+		{"unknown", CrawlerRunConfig{CacheMode: "smart"}, false, []string{"cache_mode"}},
+		{"flags", CrawlerRunConfig{NoCacheRead: true, NoCacheWrite: true}, true, []string{"no_cache_write"}},
+		{"session", CrawlerRunConfig{SessionID: "tab1", DisableCache: true}, true, []string{"disable_cache", "session_id"}},
+	}
+	for _, tc := range cases {
+		cfg := tc.cfg
+		opts, warnings := TranslateOSSConfig(&cfg)
+		if opts.BypassCache != tc.bypass {
+			t.Errorf("%s: BypassCache = %v, want %v", tc.name, opts.BypassCache, tc.bypass)
+		}
+		var fields []string
+		for _, w := range warnings {
+			fields = append(fields, w.Field)
+		}
+		if strings.Join(fields, ",") != strings.Join(tc.warnings, ",") {
+			t.Errorf("%s: warnings = %v, want fields %v", tc.name, warnings, tc.warnings)
+		}
+		c := opts.Config
+		if c.CacheMode != "" || c.SessionID != "" || c.BypassCache || c.NoCacheRead || c.NoCacheWrite || c.DisableCache {
+			t.Errorf("%s: translated config still has OSS options: %+v", tc.name, c)
+		}
+		if !reflect.DeepEqual(cfg, tc.cfg) {
+			t.Errorf("%s: input config modified", tc.name)
+		}
+	}
+}
+
+func TestWarn_SilentWithoutOnWarning(t *testing.T) {
+	var buf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&buf)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.warn(Warning{Field: "cache_mode", Message: "dropped"})
+	if buf.Len() != 0 {
+		t.Errorf("warning logged without OnWarning: %q", buf.String())
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestArunWithConfig(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": body["url"], "success": true})
+	}))
+	defer srv.Close()
+
+	var warnings []Warning
+	c, err := NewAsyncWebCrawler(CrawlerOptions{
+		APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1,
+		BrowserConfig: &BrowserConfig{ViewportWidth: 1440},
+		OnWarning:     func(w Warning) { warnings = append(warnings, w) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.ArunWithConfig("https://example.com", &CrawlerRunConfig{CacheMode: CacheModeDisabled, Screenshot: true}); err != nil {
+		t.Fatal(err)
+	}
+	body := bodies[0]
+	if body["bypass_cache"] != true {
+		t.Errorf("bypass_cache not sent: %v", body)
+	}
+	if bc, _ := body["browser_config"].(map[string]interface{}); bc["viewport_width"] != 1440.0 {
+		t.Errorf("default browser config not applied: %v", body["browser_config"])
+	}
+	if len(warnings) != 1 || warnings[0].Field != "cache_mode" {
+		t.Errorf("warnings = %v", warnings)
+	}
+
+	// A per-call BrowserConfig replaces the default.
+	if _, err := c.Run("https://example.com", &RunOptions{BrowserConfig: &BrowserConfig{ViewportWidth: 800}}); err != nil {
+		t.Fatal(err)
+	}
+	if bc, _ := bodies[1]["browser_config"].(map[string]interface{}); bc["viewport_width"] != 800.0 {
+		t.Errorf("per-call browser config = %v", bodies[1]["browser_config"])
+	}
+}
@@ -0,0 +1,167 @@
+package crawl4ai
+
+import (
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+)
+
+// LinkClass classifies an outlink.
+type LinkClass string
+
+// Link classes, checked in this order: a link to a file is an asset
+// wherever it is hosted, and a social profile is social even when the
+// crawled site is itself a social network.
+const (
+	LinkAsset    LinkClass = "asset"
+	LinkSocial   LinkClass = "social"
+	LinkInternal LinkClass = "internal"
+	LinkExternal LinkClass = "external"
+)
+
+// socialDomains are the networks LinkSocial recognises, subdomains
+// included.
+var socialDomains = []string{
+	"facebook.com", "fb.com", "twitter.com", "x.com", "instagram.com", "linkedin.com",
+	"youtube.com", "youtu.be", "tiktok.com", "pinterest.com", "reddit.com", "threads.net",
+	"mastodon.social", "bsky.app", "t.me", "wa.me", "discord.gg", "discord.com", "github.com",
+}
+
+// assetExtensions mark links to downloadable files rather than pages.
+var assetExtensions = map[string]bool{
+	".pdf": true, ".zip": true, ".gz": true, ".tar": true, ".rar": true, ".7z": true,
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".svg": true, ".webp": true, ".ico": true,
+	".mp3": true, ".mp4": true, ".mov": true, ".webm": true, ".wav": true,
+	".css": true, ".js": true, ".woff": true, ".woff2": true, ".ttf": true,
+	".doc": true, ".docx": true, ".xls": true, ".xlsx": true, ".ppt": true, ".pptx": true, ".csv": true,
+	".exe": true, ".dmg": true, ".apk": true,
+}
+
+// Outlink is one classified link from a crawled page.
+type Outlink struct {
+	URL    string
+	Text   string
+	Domain string
+	Class  LinkClass
+	// Internal is whether the link stays on the crawled site, whatever
+	// its Class.
+	Internal bool
+}
+
+// LinkSummary is the classified outlinks of one or more pages, each
+// distinct URL once.
+type LinkSummary struct {
+	Links  []Outlink
+	Counts map[LinkClass]int
+}
+
+// Outlinks classifies the result's links, resolved against its URL; see
+// SummarizeOutlinks.
+func (r *CrawlResult) Outlinks() *LinkSummary {
+	return SummarizeOutlinks([]*CrawlResult{r})
+}
+
+// SummarizeOutlinks classifies the links of every result as internal,
+// external, social or asset, for a quick "who does this site link to":
+//
+//	summary := crawl4ai.SummarizeOutlinks(deep.CrawlJob.Results)
+//	fmt.Println(summary.Counts[crawl4ai.LinkExternal], "external links to", summary.ExternalDomains())
+//
+// Internal and external follow the API's split of the links; only http(s)
+// links are kept, without their fragment.
+func SummarizeOutlinks(results []*CrawlResult) *LinkSummary {
+	s := &LinkSummary{Counts: map[LinkClass]int{}}
+	seen := map[string]bool{}
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		for _, kind := range []string{"internal", "external"} {
+			items, _ := r.Links[kind].([]interface{})
+			for _, item := range items {
+				href, text := "", ""
+				switch v := item.(type) {
+				case string:
+					href = v
+				case map[string]interface{}:
+					href, _ = v["href"].(string)
+					text, _ = v["text"].(string)
+				}
+				link, ok := absoluteLink(r.URL, href)
+				if !ok || seen[link] {
+					continue
+				}
+				seen[link] = true
+				out := classifyLink(link, kind == "internal")
+				out.Text = strings.TrimSpace(text)
+				s.Links = append(s.Links, out)
+				s.Counts[out.Class]++
+			}
+		}
+	}
+	return s
+}
+
+// ClassifyLink classifies an absolute link; internal says whether it
+// stays on the crawled site.
+func ClassifyLink(link string, internal bool) LinkClass {
+	return classifyLink(link, internal).Class
+}
+
+func classifyLink(link string, internal bool) Outlink {
+	out := Outlink{URL: link, Internal: internal, Class: LinkExternal}
+	u, err := url.Parse(link)
+	if err != nil {
+		return out
+	}
+	out.Domain = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	switch {
+	case assetExtensions[strings.ToLower(path.Ext(u.Path))]:
+		out.Class = LinkAsset
+	case domainAllowed(out.Domain, socialDomains):
+		out.Class = LinkSocial
+	case internal:
+		out.Class = LinkInternal
+	}
+	return out
+}
+
+// ByClass returns the links of class c, in order.
+func (s *LinkSummary) ByClass(c LinkClass) []Outlink {
+	var out []Outlink
+	for _, l := range s.Links {
+		if l.Class == c {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// ExternalDomains returns the distinct domains of links leaving the site,
+// social and asset hosts included, most linked first.
+func (s *LinkSummary) ExternalDomains() []string {
+	counts := s.ExternalDomainCounts()
+	domains := make([]string, 0, len(counts))
+	for d := range counts {
+		domains = append(domains, d)
+	}
+	sort.Slice(domains, func(i, k int) bool {
+		if counts[domains[i]] != counts[domains[k]] {
+			return counts[domains[i]] > counts[domains[k]]
+		}
+		return domains[i] < domains[k]
+	})
+	return domains
+}
+
+// ExternalDomainCounts counts the distinct links to each external domain.
+func (s *LinkSummary) ExternalDomainCounts() map[string]int {
+	counts := map[string]int{}
+	for _, l := range s.Links {
+		if !l.Internal && l.Domain != "" {
+			counts[l.Domain]++
+		}
+	}
+	return counts
+}
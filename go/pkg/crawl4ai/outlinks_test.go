@@ -0,0 +1,48 @@
+package crawl4ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestSummarizeOutlinks(t *testing.T) {
+	page := func(u string, internal, external []interface{}) *CrawlResult {
+		return &CrawlResult{URL: u, Links: map[string]interface{}{"internal": internal, "external": external}}
+	}
+	results := []*CrawlResult{
+		page("https://site.com/", []interface{}{
+			map[string]interface{}{"href": "/about", "text": " About us "},
+			"/files/report.PDF",
+			"mailto:hi@site.com",
+		}, []interface{}{
+			map[string]interface{}{"href": "https://www.twitter.com/site", "text": "Twitter"},
+			"https://partner.com/a",
+			"https://partner.com/b#top",
+			"https://cdn.other.com/logo.png",
+		}),
+		page("https://site.com/about", []interface{}{"https://site.com/about"}, []interface{}{
+			"https://partner.com/a", "https://news.org/story",
+		}),
+		nil,
+	}
+	s := SummarizeOutlinks(results)
+	want := map[LinkClass]int{LinkInternal: 1, LinkAsset: 2, LinkSocial: 1, LinkExternal: 3}
+	if !reflect.DeepEqual(s.Counts, want) {
+		t.Errorf("Counts = %v, want %v", s.Counts, want)
+	}
+	if about := s.ByClass(LinkInternal); len(about) != 1 || about[0].URL != "https://site.com/about" || about[0].Text != "About us" {
+		t.Errorf("internal = %+v", about)
+	}
+	if got, want := s.ExternalDomains(), []string{"partner.com", "cdn.other.com", "news.org", "twitter.com"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ExternalDomains = %v, want %v", got, want)
+	}
+
+	if got := results[0].Outlinks().Counts[LinkExternal]; got != 2 {
+		t.Errorf("one page: %d external", got)
+	}
+	if ClassifyLink("https://github.com/org/repo", false) != LinkSocial || ClassifyLink("https://site.com/app.js", true) != LinkAsset {
+		t.Error("ClassifyLink")
+	}
+}
@@ -0,0 +1,180 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PagePlaceholder is replaced by the page number in
+// PaginationSpec.URLTemplate.
+const PagePlaceholder = "{page}"
+
+// PaginationSpec says how CrawlPaginated finds the next page. Set exactly
+// one of NextSelector and URLTemplate.
+type PaginationSpec struct {
+	// NextSelector matches the "next page" link. The browser resolves the
+	// link's href (the matched element, its enclosing <a>, or the first
+	// link inside it), so relative and JS-built URLs work.
+	NextSelector string
+	// URLTemplate builds later pages by number, e.g.
+	// "https://shop.example.com/list?page={page}".
+	URLTemplate string
+	// StartPage is the number of the first page (the URL passed to
+	// CrawlPaginated) when using URLTemplate. Default 1.
+	StartPage int
+	// MaxPages caps how many pages are crawled, the first included.
+	// Default 10.
+	MaxPages int
+}
+
+// nextPageMeta is the <meta> the next-link script writes into the page so
+// the resolved URL comes back in the result's HTML.
+const nextPageMeta = "crawl4ai-next-page"
+
+// CrawlPaginated crawls a listing page and the pages after it, returning
+// results in page order — a lighter alternative to a deep crawl when only
+// the "next" chain matters.
+//
+// It stops after MaxPages, when no next link is found, when a next URL was
+// already visited, or — with URLTemplate — at the first page that fails,
+// answers 404 or repeats the previous page's content (sites that clamp
+// out-of-range page numbers). A failing page in selector mode ends the walk
+// with an error; the pages crawled so far are returned with it.
+//
+//	pages, err := crawler.CrawlPaginated("https://shop.example.com/list", crawl4ai.PaginationSpec{
+//	    NextSelector: "a[rel=next]",
+//	    MaxPages:     5,
+//	}, nil)
+func (c *AsyncWebCrawler) CrawlPaginated(url string, spec PaginationSpec, opts *RunOptions) ([]*CrawlResult, error) {
+	if (spec.NextSelector == "") == (spec.URLTemplate == "") {
+		return nil, fmt.Errorf("pagination: set exactly one of NextSelector and URLTemplate")
+	}
+	if spec.URLTemplate != "" && !strings.Contains(spec.URLTemplate, PagePlaceholder) {
+		return nil, fmt.Errorf("pagination: URLTemplate %q has no %s placeholder", spec.URLTemplate, PagePlaceholder)
+	}
+	maxPages := spec.MaxPages
+	if maxPages <= 0 {
+		maxPages = 10
+	}
+	if spec.NextSelector != "" {
+		return c.paginateBySelector(url, spec.NextSelector, maxPages, opts)
+	}
+	return c.paginateByTemplate(url, spec, maxPages, opts)
+}
+
+func (c *AsyncWebCrawler) paginateBySelector(url, selector string, maxPages int, opts *RunOptions) ([]*CrawlResult, error) {
+	o := RunOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Strategy == "http" {
+		return nil, fmt.Errorf("pagination: NextSelector needs the browser strategy; use URLTemplate with http")
+	}
+	cfg := CrawlerRunConfig{}
+	if o.Config != nil {
+		cfg = *o.Config
+	}
+	if cfg.JsCode != "" {
+		cfg.JsCode += "\n"
+	}
+	cfg.JsCode += nextLinkScript(selector)
+	o.Config = &cfg
+	// The next link is read back from the HTML, so it must not be omitted.
+	if o.OmitFields == nil {
+		o.OmitFields = []string{}
+	}
+
+	var pages []*CrawlResult
+	seen := map[string]bool{}
+	for next := url; next != "" && len(pages) < maxPages; {
+		seen[next] = true
+		result, err := c.Run(next, &o)
+		if err != nil {
+			return pages, fmt.Errorf("pagination: page %d (%s): %w", len(pages)+1, next, err)
+		}
+		if !result.Success {
+			return pages, fmt.Errorf("pagination: page %d (%s) failed: %s", len(pages)+1, next, result.ErrorMessage)
+		}
+		pages = append(pages, result)
+		next = nextPageURL(result.HTML)
+		if seen[next] {
+			break
+		}
+	}
+	return pages, nil
+}
+
+func (c *AsyncWebCrawler) paginateByTemplate(url string, spec PaginationSpec, maxPages int, opts *RunOptions) ([]*CrawlResult, error) {
+	page := spec.StartPage
+	if page <= 0 {
+		page = 1
+	}
+	var pages []*CrawlResult
+	var prev string
+	seen := map[string]bool{}
+	for next := url; len(pages) < maxPages; {
+		if seen[next] {
+			break
+		}
+		seen[next] = true
+		result, err := c.Run(next, opts)
+		if err != nil {
+			return pages, fmt.Errorf("pagination: page %d (%s): %w", page, next, err)
+		}
+		if !result.Success || result.StatusCode == 404 {
+			break
+		}
+		content := pageContent(result)
+		if len(pages) > 0 && content == prev {
+			break
+		}
+		pages = append(pages, result)
+		prev = content
+		page++
+		next = strings.ReplaceAll(spec.URLTemplate, PagePlaceholder, strconv.Itoa(page))
+	}
+	return pages, nil
+}
+
+// nextLinkScript resolves the next link's absolute URL in the page and
+// records it in a <meta> tag; empty when there is no next page.
+func nextLinkScript(selector string) string {
+	sel, _ := json.Marshal(selector)
+	return fmt.Sprintf(`(() => {
+  const el = document.querySelector(%s);
+  const a = el && (el.closest("a[href]") || el.querySelector("a[href]"));
+  const m = document.createElement("meta");
+  m.name = %q;
+  m.content = a ? a.href : "";
+  document.head.appendChild(m);
+})();`, sel, nextPageMeta)
+}
+
+// nextPageURL reads the URL nextLinkScript recorded in html.
+func nextPageURL(pageHTML string) string {
+	return readPageMeta(pageHTML, nextPageMeta)
+}
+
+// readPageMeta returns the content of the <meta name=...> a page script
+// appended, or "" when it is absent. Scripts report back this way because
+// the crawl response carries the page's HTML but not script return values.
+func readPageMeta(pageHTML, name string) string {
+	re := regexp.MustCompile(`<meta name="` + regexp.QuoteMeta(name) + `" content="([^"]*)"`)
+	m := re.FindStringSubmatch(pageHTML)
+	if m == nil {
+		return ""
+	}
+	return html.UnescapeString(m[1])
+}
+
+// pageContent is what two pages are compared on to spot a repeat.
+func pageContent(r *CrawlResult) string {
+	if r.Markdown != nil && r.Markdown.RawMarkdown != "" {
+		return r.Markdown.RawMarkdown
+	}
+	return r.HTML
+}
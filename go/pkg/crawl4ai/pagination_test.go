@@ -0,0 +1,116 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestNextPageURL(t *testing.T) {
+	page := `<html><head><meta name="crawl4ai-next-page" content="https://a.com/list?page=2&amp;sort=new"></head></html>`
+	if got := nextPageURL(page); got != "https://a.com/list?page=2&sort=new" {
+		t.Fatalf("nextPageURL = %q", got)
+	}
+	if got := nextPageURL("<html></html>"); got != "" {
+		t.Fatalf("nextPageURL without meta = %q", got)
+	}
+	if script := nextLinkScript(`a[rel="next"]`); !strings.Contains(script, `document.querySelector("a[rel=\"next\"]")`) {
+		t.Fatalf("selector not quoted: %s", script)
+	}
+}
+
+func TestCrawlPaginated_Validation(t *testing.T) {
+	c, _ := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: "http://127.0.0.1:1", MaxRetries: 1})
+	for _, spec := range []PaginationSpec{
+		{},
+		{NextSelector: "a.next", URLTemplate: "https://a.com/{page}"},
+		{URLTemplate: "https://a.com/list"},
+	} {
+		if _, err := c.CrawlPaginated("https://a.com", spec, nil); err == nil {
+			t.Errorf("expected error for %+v", spec)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+// paginatedServer serves /v1/crawl for a listing with `last` pages. Page n
+// links to n+1 through the next-page meta when the request carries the
+// next-link script.
+func paginatedServer(t *testing.T, last int, requested *[]string) *AsyncWebCrawler {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		url := body["url"].(string)
+		*requested = append(*requested, url)
+		n := 1
+		fmt.Sscanf(url[strings.LastIndex(url, "/")+1:], "%d", &n)
+		if n > last {
+			n = last // clamp like many real sites do
+		}
+		head := ""
+		cc, _ := body["crawler_config"].(map[string]interface{})
+		if js, _ := cc["js_code"].(string); strings.Contains(js, nextPageMeta) && n < last {
+			head = fmt.Sprintf(`<meta name="%s" content="https://a.com/list/%d">`, nextPageMeta, n+1)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": url, "success": true, "status_code": 200,
+			"html":     "<html><head>" + head + "</head></html>",
+			"markdown": map[string]interface{}{"raw_markdown": fmt.Sprintf("items of page %d", n)},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, OmitFields: HeavyResultFields})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCrawlPaginated_NextSelector(t *testing.T) {
+	var requested []string
+	c := paginatedServer(t, 3, &requested)
+
+	pages, err := c.CrawlPaginated("https://a.com/list/1", PaginationSpec{NextSelector: "a.next"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 3 || pages[2].Markdown.RawMarkdown != "items of page 3" {
+		t.Fatalf("unexpected pages: %d", len(pages))
+	}
+	if strings.Join(requested, " ") != "https://a.com/list/1 https://a.com/list/2 https://a.com/list/3" {
+		t.Fatalf("requested %v", requested)
+	}
+}
+
+func TestCrawlPaginated_MaxPages(t *testing.T) {
+	var requested []string
+	c := paginatedServer(t, 10, &requested)
+
+	pages, err := c.CrawlPaginated("https://a.com/list/1", PaginationSpec{NextSelector: "a.next", MaxPages: 2}, nil)
+	if err != nil || len(pages) != 2 || len(requested) != 2 {
+		t.Fatalf("pages %d requested %d err %v", len(pages), len(requested), err)
+	}
+}
+
+func TestCrawlPaginated_URLTemplateStopsOnRepeat(t *testing.T) {
+	var requested []string
+	c := paginatedServer(t, 3, &requested)
+
+	pages, err := c.CrawlPaginated("https://a.com/list/1", PaginationSpec{URLTemplate: "https://a.com/list/{page}"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 3 || len(requested) != 4 {
+		t.Fatalf("pages %d requested %v", len(pages), requested)
+	}
+	if pages[1].URL != "https://a.com/list/2" {
+		t.Fatalf("pages out of order: %s", pages[1].URL)
+	}
+}
@@ -0,0 +1,328 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Parquet export writes job results as a single-row-group Parquet file —
+// readable by DuckDB, Spark, pandas/pyarrow, Polars and the Arrow family —
+// without pulling a Parquet or Arrow dependency into the SDK. The writer is
+// deliberately small: PLAIN encoding, no compression, REQUIRED columns
+// (missing values are empty strings / zero), one data page per column.
+// Land the file as-is, or rewrite it compressed downstream.
+
+// Parquet physical types, converted types and enums used by the writer.
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetByteArray = 6
+
+	parquetUTF8 = 0
+	parquetJSON = 19
+
+	parquetRequired  = 0
+	parquetEncPlain  = 0
+	parquetDataPage  = 0
+	parquetRLE       = 3
+	parquetNoConvert = -1
+)
+
+type parquetColumn struct {
+	name      string
+	physical  int32
+	converted int32
+	value     func(*CrawlResult) interface{}
+}
+
+var parquetSchema = []parquetColumn{
+	{"url", parquetByteArray, parquetUTF8, func(r *CrawlResult) interface{} { return r.URL }},
+	{"success", parquetBoolean, parquetNoConvert, func(r *CrawlResult) interface{} { return r.Success }},
+	{"status_code", parquetInt32, parquetNoConvert, func(r *CrawlResult) interface{} { return int32(r.StatusCode) }},
+	{"duration_ms", parquetInt32, parquetNoConvert, func(r *CrawlResult) interface{} { return int32(r.DurationMs) }},
+	{"markdown", parquetByteArray, parquetUTF8, func(r *CrawlResult) interface{} {
+		if r.Markdown == nil {
+			return ""
+		}
+		return r.Markdown.RawMarkdown
+	}},
+	{"extracted_content", parquetByteArray, parquetJSON, func(r *CrawlResult) interface{} {
+		if r.ExtractedContent == "" {
+			return "null"
+		}
+		return r.ExtractedContent
+	}},
+	{"error_message", parquetByteArray, parquetUTF8, func(r *CrawlResult) interface{} { return r.ErrorMessage }},
+}
+
+// WriteParquet writes results as a Parquet file, one row per result, with
+// columns url, success, status_code, duration_ms, markdown (raw markdown),
+// extracted_content and error_message. extracted_content is tagged as JSON
+// so engines that understand the logical type can query into it directly.
+func WriteParquet(w io.Writer, results []*CrawlResult) error {
+	cw := &countingWriter{w: w}
+	if _, err := cw.Write([]byte("PAR1")); err != nil {
+		return err
+	}
+
+	type chunkMeta struct {
+		offset int64
+		size   int64
+	}
+	var chunks []chunkMeta
+	if len(results) > 0 {
+		for _, col := range parquetSchema {
+			data, err := parquetPlain(col, results)
+			if err != nil {
+				return err
+			}
+			if len(data) > math.MaxInt32 {
+				return fmt.Errorf("parquet: column %s exceeds one page (%d bytes)", col.name, len(data))
+			}
+			header := parquetPageHeader(len(results), len(data))
+			offset := cw.n
+			if _, err := cw.Write(header); err != nil {
+				return err
+			}
+			if _, err := cw.Write(data); err != nil {
+				return err
+			}
+			chunks = append(chunks, chunkMeta{offset: offset, size: int64(len(header) + len(data))})
+		}
+	}
+
+	// FileMetaData.
+	t := newThriftWriter()
+	t.i32(1, 1) // version
+	t.listBegin(2, thriftStruct, len(parquetSchema)+1)
+	t.elemStructBegin()
+	t.binary(4, "schema")
+	t.i32(5, int32(len(parquetSchema)))
+	t.elemStructEnd()
+	for _, col := range parquetSchema {
+		t.elemStructBegin()
+		t.i32(1, col.physical)
+		t.i32(3, parquetRequired)
+		t.binary(4, col.name)
+		if col.converted != parquetNoConvert {
+			t.i32(6, col.converted)
+		}
+		t.elemStructEnd()
+	}
+	t.i64(3, int64(len(results)))
+	if len(chunks) == 0 {
+		t.listBegin(4, thriftStruct, 0)
+	} else {
+		var total int64
+		for _, ch := range chunks {
+			total += ch.size
+		}
+		t.listBegin(4, thriftStruct, 1)
+		t.elemStructBegin() // RowGroup
+		t.listBegin(1, thriftStruct, len(parquetSchema))
+		for i, col := range parquetSchema {
+			t.elemStructBegin() // ColumnChunk
+			t.i64(2, chunks[i].offset)
+			t.structBegin(3) // ColumnMetaData
+			t.i32(1, col.physical)
+			t.listBegin(2, thriftI32, 1)
+			t.elemI32(parquetEncPlain)
+			t.listBegin(3, thriftBinary, 1)
+			t.elemBinary(col.name)
+			t.i32(4, 0) // UNCOMPRESSED
+			t.i64(5, int64(len(results)))
+			t.i64(6, chunks[i].size)
+			t.i64(7, chunks[i].size)
+			t.i64(9, chunks[i].offset)
+			t.structEnd()
+			t.elemStructEnd()
+		}
+		t.i64(2, total)
+		t.i64(3, int64(len(results)))
+		t.elemStructEnd()
+	}
+	t.binary(6, "crawl4ai-cloud-go version "+Version)
+	footer := t.finish()
+
+	if _, err := cw.Write(footer); err != nil {
+		return err
+	}
+	var tail [8]byte
+	binary.LittleEndian.PutUint32(tail[:4], uint32(len(footer)))
+	copy(tail[4:], "PAR1")
+	_, err := cw.Write(tail[:])
+	return err
+}
+
+// ExportJobParquet writes a job's results (see JobResults) as Parquet.
+//
+//	f, _ := os.Create("crawl.parquet")
+//	defer f.Close()
+//	err := crawler.ExportJobParquet(jobID, f)
+//	// duckdb> SELECT url, extracted_content->>'$.title' FROM 'crawl.parquet';
+func (c *AsyncWebCrawler) ExportJobParquet(jobID string, w io.Writer) error {
+	results, err := c.JobResults(jobID)
+	if err != nil {
+		return err
+	}
+	return WriteParquet(w, results)
+}
+
+// parquetPlain PLAIN-encodes one column.
+func parquetPlain(col parquetColumn, results []*CrawlResult) ([]byte, error) {
+	var buf bytes.Buffer
+	switch col.physical {
+	case parquetBoolean:
+		packed := make([]byte, (len(results)+7)/8)
+		for i, r := range results {
+			if col.value(r).(bool) {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		buf.Write(packed)
+	case parquetInt32:
+		var b [4]byte
+		for _, r := range results {
+			binary.LittleEndian.PutUint32(b[:], uint32(col.value(r).(int32)))
+			buf.Write(b[:])
+		}
+	case parquetByteArray:
+		var b [4]byte
+		for _, r := range results {
+			s := col.value(r).(string)
+			binary.LittleEndian.PutUint32(b[:], uint32(len(s)))
+			buf.Write(b[:])
+			buf.WriteString(s)
+		}
+	default:
+		return nil, fmt.Errorf("parquet: unsupported type %d", col.physical)
+	}
+	return buf.Bytes(), nil
+}
+
+func parquetPageHeader(numValues, size int) []byte {
+	t := newThriftWriter()
+	t.i32(1, parquetDataPage)
+	t.i32(2, int32(size))
+	t.i32(3, int32(size))
+	t.structBegin(5) // DataPageHeader
+	t.i32(1, int32(numValues))
+	t.i32(2, parquetEncPlain)
+	t.i32(3, parquetRLE)
+	t.i32(4, parquetRLE)
+	t.structEnd()
+	return t.finish()
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ─── Thrift compact protocol (write side) ───────────────────────────────
+
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the handful of Thrift compact-protocol shapes the
+// Parquet footer and page headers need.
+type thriftWriter struct {
+	buf  bytes.Buffer
+	last []int16 // last field id per open struct
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{last: []int16{0}}
+}
+
+func (t *thriftWriter) finish() []byte {
+	t.buf.WriteByte(0) // stop
+	return t.buf.Bytes()
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	top := len(t.last) - 1
+	if delta := id - t.last[top]; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(uint64(zigzag64(int64(id))))
+	}
+	t.last[top] = id
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	t.buf.Write(b[:n])
+}
+
+func zigzag64(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(zigzag64(int64(v)))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(zigzag64(v))
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.elemBinary(s)
+}
+
+func (t *thriftWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.last = append(t.last, 0)
+}
+
+func (t *thriftWriter) structEnd() {
+	t.buf.WriteByte(0)
+	t.last = t.last[:len(t.last)-1]
+}
+
+func (t *thriftWriter) listBegin(id int16, elemType byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf.WriteByte(byte(n)<<4 | elemType)
+		return
+	}
+	t.buf.WriteByte(0xF0 | elemType)
+	t.varint(uint64(n))
+}
+
+func (t *thriftWriter) elemI32(v int32) {
+	t.varint(zigzag64(int64(v)))
+}
+
+func (t *thriftWriter) elemBinary(s string) {
+	t.varint(uint64(len(s)))
+	t.buf.WriteString(s)
+}
+
+func (t *thriftWriter) elemStructBegin() {
+	t.last = append(t.last, 0)
+}
+
+func (t *thriftWriter) elemStructEnd() {
+	t.structEnd()
+}
@@ -0,0 +1,138 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// thriftReader decodes compact-protocol structs into field-id maps so the
+// tests can check what WriteParquet put in the footer and page headers.
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.b[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.varint())
+		s := string(r.b[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftList:
+		h := r.b[r.pos]
+		r.pos++
+		n, elem := int(h>>4), h&0x0F
+		if n == 15 {
+			n = int(r.varint())
+		}
+		out := make([]interface{}, n)
+		for i := range out {
+			out[i] = r.value(elem)
+		}
+		return out
+	case thriftStruct:
+		return r.readStruct()
+	}
+	panic("unexpected thrift type")
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	out := map[int16]interface{}{}
+	var last int16
+	for {
+		h := r.b[r.pos]
+		r.pos++
+		if h == 0 {
+			return out
+		}
+		typ := h & 0x0F
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		last = id
+		out[id] = r.value(typ)
+	}
+}
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestWriteParquet_Layout(t *testing.T) {
+	results := []*CrawlResult{
+		{URL: "https://a.com", Success: true, StatusCode: 200, Markdown: &MarkdownResult{RawMarkdown: "# A"}, ExtractedContent: `{"x":1}`},
+		{URL: "https://b.com", ErrorMessage: "timeout"},
+	}
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, results); err != nil {
+		t.Fatalf("WriteParquet: %v", err)
+	}
+	b := buf.Bytes()
+	if string(b[:4]) != "PAR1" || string(b[len(b)-4:]) != "PAR1" {
+		t.Fatal("missing PAR1 magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(b[len(b)-8 : len(b)-4]))
+	footer := (&thriftReader{b: b[len(b)-8-footerLen : len(b)-8]}).readStruct()
+
+	if footer[3].(int64) != 2 {
+		t.Fatalf("expected num_rows 2, got %v", footer[3])
+	}
+	schema := footer[2].([]interface{})
+	if len(schema) != len(parquetSchema)+1 {
+		t.Fatalf("expected root + %d columns, got %d", len(parquetSchema), len(schema))
+	}
+	if name := schema[1].(map[int16]interface{})[4]; name != "url" {
+		t.Fatalf("expected first column url, got %v", name)
+	}
+
+	rowGroup := footer[4].([]interface{})[0].(map[int16]interface{})
+	columns := rowGroup[1].([]interface{})
+	// Walk to the url column's page and check the PLAIN-encoded values.
+	meta := columns[0].(map[int16]interface{})[3].(map[int16]interface{})
+	offset := int(meta[9].(int64))
+	pr := &thriftReader{b: b, pos: offset}
+	page := pr.readStruct()
+	if page[5].(map[int16]interface{})[1].(int64) != 2 {
+		t.Fatalf("expected 2 values in url page, got %v", page)
+	}
+	data := b[pr.pos : pr.pos+int(page[3].(int64))]
+	first := int(binary.LittleEndian.Uint32(data[:4]))
+	if string(data[4:4+first]) != "https://a.com" {
+		t.Fatalf("unexpected first url value %q", data[4:4+first])
+	}
+
+	// success is bit-packed: row 0 true, row 1 false.
+	succMeta := columns[1].(map[int16]interface{})[3].(map[int16]interface{})
+	sr := &thriftReader{b: b, pos: int(succMeta[9].(int64))}
+	sr.readStruct()
+	if b[sr.pos] != 0x01 {
+		t.Fatalf("expected success bits 0b01, got %08b", b[sr.pos])
+	}
+}
+
+func TestWriteParquet_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, nil); err != nil {
+		t.Fatalf("WriteParquet: %v", err)
+	}
+	b := buf.Bytes()
+	footerLen := int(binary.LittleEndian.Uint32(b[len(b)-8 : len(b)-4]))
+	footer := (&thriftReader{b: b[len(b)-8-footerLen : len(b)-8]}).readStruct()
+	if footer[3].(int64) != 0 || len(footer[4].([]interface{})) != 0 {
+		t.Fatalf("expected no rows and no row groups, got %v", footer)
+	}
+}
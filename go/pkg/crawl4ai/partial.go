@@ -0,0 +1,94 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoFailedURLs is returned by RetryFailed when every URL succeeded.
+var ErrNoFailedURLs = errors.New("crawl4ai: no failed URLs to retry")
+
+// PartialError reports a job that finished "partial": some URLs crawled,
+// others failed or returned no result. It carries both sides so the
+// successes can be kept and the failures resubmitted with RetryFailed.
+type PartialError struct {
+	JobID     string
+	Succeeded []*CrawlResult
+	// Failed are the results that came back unsuccessful.
+	Failed []*CrawlResult
+	// FailedURLs are the input URLs to resubmit: those of Failed plus the
+	// ones the job returned no result for.
+	FailedURLs []string
+}
+
+// Error implements error.
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("job %s finished partially: %d succeeded, %d failed",
+		e.JobID, len(e.Succeeded), len(e.FailedURLs))
+}
+
+// Err returns a *PartialError when the job finished "partial", and nil
+// otherwise, so a partial job can be handled like any other error:
+//
+//	result, err := crawler.RunMany(urls, &crawl4ai.RunManyOptions{Wait: true})
+//	if err == nil {
+//	    err = result.Err()
+//	}
+//	var partial *crawl4ai.PartialError
+//	if errors.As(err, &partial) {
+//	    save(partial.Succeeded)
+//	    retry, err := crawler.RetryFailed(result, &crawl4ai.RunManyOptions{Wait: true})
+//	}
+func (r *RunManyResult) Err() error {
+	if r.Job == nil || r.Job.Status != JobStatusPartial {
+		return nil
+	}
+	return &PartialError{
+		JobID:      r.Job.JobID,
+		Succeeded:  r.Successful(),
+		Failed:     r.Failed(),
+		FailedURLs: r.FailedURLs(),
+	}
+}
+
+// FailedURLs returns the input URLs to resubmit, in input order: those
+// whose result was unsuccessful and those the job returned no result for.
+// Without results (before Wait completes) it is empty.
+func (r *RunManyResult) FailedURLs() []string {
+	results := r.results()
+	if len(results) == 0 {
+		return nil
+	}
+	inputs := r.inputs
+	if len(inputs) == 0 && r.Job != nil {
+		inputs = r.Job.URLs
+	}
+	if len(inputs) == 0 {
+		var urls []string
+		for _, res := range r.Failed() {
+			urls = append(urls, res.URL)
+		}
+		return urls
+	}
+	var urls []string
+	for i, res := range alignResults(inputs, results) {
+		if res == nil || !res.Success {
+			urls = append(urls, inputs[i])
+		}
+	}
+	return urls
+}
+
+// RetryFailed submits prev's failed URLs (see FailedURLs) as a new job
+// with opts, returning ErrNoFailedURLs when there is nothing to retry.
+// A job fetched with GetJob or WaitJob can be retried by wrapping it:
+//
+//	job, _ := crawler.WaitJob(jobID, 0, 10*time.Minute)
+//	retry, err := crawler.RetryFailed(&crawl4ai.RunManyResult{Job: job}, nil)
+func (c *AsyncWebCrawler) RetryFailed(prev *RunManyResult, opts *RunManyOptions) (*RunManyResult, error) {
+	urls := prev.FailedURLs()
+	if len(urls) == 0 {
+		return nil, ErrNoFailedURLs
+	}
+	return c.RunMany(urls, opts)
+}
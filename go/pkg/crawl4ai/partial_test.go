@@ -0,0 +1,85 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestRunManyResult_Err(t *testing.T) {
+	results := []*CrawlResult{
+		{URL: "https://a.com/", Success: true},
+		{URL: "https://b.com/", Success: false, ErrorMessage: "timeout"},
+	}
+	r := &RunManyResult{
+		Job:    &CrawlJob{JobID: "job_1", Status: JobStatusPartial, Results: results},
+		inputs: []string{"https://a.com", "https://b.com", "https://c.com"},
+	}
+	var partial *PartialError
+	if !errors.As(r.Err(), &partial) {
+		t.Fatalf("Err = %v", r.Err())
+	}
+	if len(partial.Succeeded) != 1 || len(partial.Failed) != 1 || partial.JobID != "job_1" {
+		t.Errorf("partial = %+v", partial)
+	}
+	// c.com returned nothing, so it is retried too.
+	if want := []string{"https://b.com", "https://c.com"}; !reflect.DeepEqual(partial.FailedURLs, want) {
+		t.Errorf("FailedURLs = %v, want %v", partial.FailedURLs, want)
+	}
+
+	r.Job.Status = JobStatusCompleted
+	if r.Err() != nil {
+		t.Error("a completed job is not an error")
+	}
+
+	// Without the submitted inputs, only failed results are known.
+	bare := &RunManyResult{Job: &CrawlJob{Results: results}}
+	if got := bare.FailedURLs(); len(got) != 1 || got[0] != "https://b.com/" {
+		t.Errorf("FailedURLs = %v", got)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRetryFailed(t *testing.T) {
+	var submitted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			URLs []string `json:"urls"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		submitted = body.URLs
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_2", "status": "pending"})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev := &RunManyResult{Job: &CrawlJob{
+		JobID: "job_1", Status: JobStatusPartial,
+		URLs: []string{"https://a.com/", "https://b.com/"},
+		Results: []*CrawlResult{
+			{URL: "https://a.com/", Success: true},
+			{URL: "https://b.com/", Success: false},
+		},
+	}}
+	retry, err := c.RetryFailed(prev, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retry.Job.JobID != "job_2" || !reflect.DeepEqual(submitted, []string{"https://b.com/"}) {
+		t.Errorf("job %s submitted %v", retry.Job.JobID, submitted)
+	}
+
+	prev.Job.Results[1].Success = true
+	if _, err := c.RetryFailed(prev, nil); !errors.Is(err, ErrNoFailedURLs) {
+		t.Errorf("err = %v", err)
+	}
+}
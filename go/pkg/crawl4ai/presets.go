@@ -0,0 +1,141 @@
+package crawl4ai
+
+import (
+	"sort"
+	"time"
+)
+
+// Preset is a named starting point for a kind of site. Every Preset*
+// function returns fresh structs, so tweak them freely:
+//
+//	p := crawl4ai.PresetNewsArticle()
+//	p.Config.PageTimeout = 90000
+//	result, err := crawler.Run(url, p.RunOptions())
+type Preset struct {
+	Name          string
+	Config        *CrawlerRunConfig
+	BrowserConfig *BrowserConfig
+	// Strategy is "browser" or "http"; "" leaves the choice to the crawler.
+	Strategy string
+}
+
+// RunOptions returns RunOptions carrying the preset.
+func (p Preset) RunOptions() *RunOptions {
+	return &RunOptions{Config: p.Config, BrowserConfig: p.BrowserConfig, Strategy: p.Strategy}
+}
+
+// RunManyOptions returns RunManyOptions carrying the preset.
+func (p Preset) RunManyOptions() *RunManyOptions {
+	return &RunManyOptions{Config: p.Config, BrowserConfig: p.BrowserConfig, Strategy: p.Strategy}
+}
+
+// PresetDocsSite targets documentation sites: markdown-focused, with short
+// text blocks such as nav menus, breadcrumbs and footers pruned by a word
+// threshold, and forms dropped.
+func PresetDocsSite() Preset {
+	return Preset{
+		Name: "docs_site",
+		Config: &CrawlerRunConfig{
+			WordCountThreshold:    10,
+			RemoveForms:           true,
+			ExcludeExternalImages: true,
+		},
+		Strategy: "browser",
+	}
+}
+
+// ProductSchema extracts schema.org Product microdata — the markup most
+// storefronts emit for search engines. PresetEcommerce uses it.
+var ProductSchema = Schema{
+	Name:         "products",
+	BaseSelector: `[itemtype*="schema.org/Product"]`,
+	Fields: []Field{
+		NewTextField("name", `[itemprop="name"]`),
+		NewAttributeField("price", `[itemprop="price"]`, "content"),
+		NewAttributeField("currency", `[itemprop="priceCurrency"]`, "content"),
+		NewAttributeField("availability", `[itemprop="availability"]`, "href"),
+		NewAttributeField("image", `[itemprop="image"]`, "src"),
+		NewTextField("sku", `[itemprop="sku"]`),
+		NewTextField("brand", `[itemprop="brand"]`),
+	},
+}
+
+// PresetEcommerce targets product and listing pages: structured product
+// data via ProductSchema, a screenshot once images have loaded, and data-*
+// attributes kept for prices and variants rendered into them.
+func PresetEcommerce() Preset {
+	schema := ProductSchema
+	schema.Fields = append([]Field(nil), ProductSchema.Fields...)
+	strategy, _ := JSONCSSExtraction(&schema)
+	return Preset{
+		Name: "ecommerce",
+		Config: &CrawlerRunConfig{
+			Screenshot:         true,
+			WaitForImages:      true,
+			KeepDataAttributes: true,
+			ExtractionStrategy: strategy,
+		},
+		Strategy: "browser",
+	}
+}
+
+// PresetNewsArticle targets articles: a higher word threshold keeps the
+// body text readable and drops teasers and bylines, and social links, share
+// widgets and forms are excluded.
+func PresetNewsArticle() Preset {
+	return Preset{
+		Name: "news_article",
+		Config: &CrawlerRunConfig{
+			WordCountThreshold:      20,
+			ExcludeSocialMediaLinks: true,
+			ExcludeExternalImages:   true,
+			RemoveForms:             true,
+		},
+		Strategy: "browser",
+	}
+}
+
+// PresetSPA targets client-rendered single-page apps: wait for the network
+// to go idle, scroll the full page so lazy content renders, and allow a
+// longer page timeout.
+func PresetSPA() Preset {
+	cfg := &CrawlerRunConfig{
+		ScanFullPage: true,
+		ScrollDelay:  0.5,
+		PageTimeout:  60000,
+	}
+	_ = cfg.SetWaitFor(WaitForNetworkIdle(500 * time.Millisecond))
+	return Preset{
+		Name:          "spa",
+		Config:        cfg,
+		BrowserConfig: &BrowserConfig{Headless: true, JavaScriptEnabled: true},
+		Strategy:      "browser",
+	}
+}
+
+var presets = map[string]func() Preset{
+	"docs_site":    PresetDocsSite,
+	"ecommerce":    PresetEcommerce,
+	"news_article": PresetNewsArticle,
+	"spa":          PresetSPA,
+}
+
+// PresetByName returns the preset with the given Name, for picking one from
+// a flag or config file.
+func PresetByName(name string) (Preset, bool) {
+	fn, ok := presets[name]
+	if !ok {
+		return Preset{}, false
+	}
+	return fn(), true
+}
+
+// PresetNames lists the built-in preset names in sorted order.
+func PresetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
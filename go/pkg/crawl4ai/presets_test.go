@@ -0,0 +1,66 @@
+package crawl4ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestPresets_Populated(t *testing.T) {
+	docs := PresetDocsSite()
+	if docs.Config.WordCountThreshold == 0 || !docs.Config.RemoveForms {
+		t.Fatalf("docs preset: %+v", docs.Config)
+	}
+	shop := PresetEcommerce()
+	if !shop.Config.Screenshot || shop.Config.ExtractionStrategy["type"] != ExtractionTypeJSONCSS {
+		t.Fatalf("ecommerce preset: %+v", shop.Config)
+	}
+	news := PresetNewsArticle()
+	if !news.Config.ExcludeSocialMediaLinks {
+		t.Fatalf("news preset: %+v", news.Config)
+	}
+	spa := PresetSPA()
+	if !spa.Config.ScanFullPage || !strings.HasPrefix(spa.Config.WaitFor, "js:") {
+		t.Fatalf("spa preset: %+v", spa.Config)
+	}
+}
+
+func TestPresets_FreshCopies(t *testing.T) {
+	a := PresetEcommerce()
+	a.Config.Screenshot = false
+	a.Config.ExtractionStrategy["schema"].(map[string]interface{})["name"] = "changed"
+
+	b := PresetEcommerce()
+	if !b.Config.Screenshot || b.Config.ExtractionStrategy["schema"].(map[string]interface{})["name"] != "products" {
+		t.Fatal("tweaking one preset must not affect the next")
+	}
+}
+
+func TestPresets_ByName(t *testing.T) {
+	want := []string{"docs_site", "ecommerce", "news_article", "spa"}
+	if got := PresetNames(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("PresetNames() = %v", got)
+	}
+	for _, name := range want {
+		p, ok := PresetByName(name)
+		if !ok || p.Name != name {
+			t.Fatalf("PresetByName(%q) = %+v, %v", name, p, ok)
+		}
+	}
+	if _, ok := PresetByName("nope"); ok {
+		t.Fatal("unknown preset should not resolve")
+	}
+}
+
+func TestPresets_RunOptions(t *testing.T) {
+	p := PresetSPA()
+	opts := p.RunOptions()
+	if opts.Config != p.Config || opts.BrowserConfig != p.BrowserConfig || opts.Strategy != "browser" {
+		t.Fatalf("RunOptions() = %+v", opts)
+	}
+	if many := p.RunManyOptions(); many.Config != p.Config {
+		t.Fatalf("RunManyOptions() = %+v", many)
+	}
+}
@@ -0,0 +1,36 @@
+package crawl4ai
+
+import "fmt"
+
+// Priority orders async jobs in the cloud queue, from 1 (lowest) to 10
+// (highest); 0 means the default, PriorityNormal. Higher-priority jobs are
+// dequeued first when workers are busy — they don't run faster once
+// started. Priority above PriorityNormal may be billed at a premium
+// depending on plan, and Urgent is intended for small interactive jobs:
+// flooding the queue with it only makes every job Urgent.
+type Priority int
+
+// Priority classes. Any value from 1 to 10 is accepted; these name the
+// common tiers.
+const (
+	PriorityLow    Priority = 1
+	PriorityNormal Priority = 5
+	PriorityHigh   Priority = 8
+	PriorityUrgent Priority = 10
+)
+
+// Validate reports a priority outside 0–10.
+func (p Priority) Validate() error {
+	if p < 0 || p > 10 {
+		return fmt.Errorf("priority %d out of range: use 1 (PriorityLow) to 10 (PriorityUrgent), or 0 for the default", int(p))
+	}
+	return nil
+}
+
+// orDefault returns the wire value, PriorityNormal for 0.
+func (p Priority) orDefault() int {
+	if p == 0 {
+		return int(PriorityNormal)
+	}
+	return int(p)
+}
@@ -0,0 +1,41 @@
+package crawl4ai
+
+import (
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestPriority_ValidateAndDefault(t *testing.T) {
+	for _, p := range []Priority{0, PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent, 7} {
+		if err := p.Validate(); err != nil {
+			t.Errorf("Priority(%d): %v", p, err)
+		}
+	}
+	for _, p := range []Priority{-1, 11} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Priority(%d) should be rejected", p)
+		}
+	}
+	if Priority(0).orDefault() != 5 || PriorityHigh.orDefault() != 8 {
+		t.Fatal("unexpected wire values")
+	}
+	body := buildRunManyBody([]string{"https://a.com"}, &RunManyOptions{Priority: PriorityUrgent})
+	if body["priority"] != 10 {
+		t.Fatalf("unexpected body priority: %v", body["priority"])
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestPriority_RejectedBeforeSubmission(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{})
+	_, err := c.RunMany([]string{"https://a.com"}, &RunManyOptions{Priority: 42})
+	if err == nil || !strings.Contains(err.Error(), "priority 42 out of range") {
+		t.Fatalf("expected range error, got %v", err)
+	}
+	if _, err := c.DeepCrawl("https://a.com", &DeepCrawlOptions{Priority: -3}); err == nil {
+		t.Fatal("expected DeepCrawl to validate priority")
+	}
+}
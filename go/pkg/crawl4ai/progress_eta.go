@@ -0,0 +1,50 @@
+package crawl4ai
+
+import "time"
+
+// etaWindow is how many recent polls the completion rate is measured over,
+// so the estimate follows throughput changes instead of the job's whole
+// history.
+const etaWindow = 10
+
+// ETA estimates the time until every URL is processed, from the completion
+// rate observed across recent polls. ok is false until WaitJobWithProgress
+// (or RunMany with OnProgress) has seen the job advance between two polls.
+//
+//	if eta, ok := job.Progress.ETA(); ok {
+//	    fmt.Printf("about %d minutes remaining\n", int(eta.Round(time.Minute).Minutes()))
+//	}
+func (p *JobProgress) ETA() (eta time.Duration, ok bool) {
+	return p.eta, p.etaKnown
+}
+
+type progressSample struct {
+	at   time.Time
+	done int
+}
+
+// progressTracker keeps the poll history one wait loop uses for ETA.
+type progressTracker struct {
+	samples []progressSample
+}
+
+// observe records p at now and stamps the current estimate onto it.
+func (t *progressTracker) observe(p *JobProgress, now time.Time) {
+	done := p.Completed + p.Failed
+	t.samples = append(t.samples, progressSample{at: now, done: done})
+	if len(t.samples) > etaWindow {
+		t.samples = t.samples[len(t.samples)-etaWindow:]
+	}
+	first := t.samples[0]
+	elapsed := now.Sub(first.at)
+	advanced := done - first.done
+	if advanced <= 0 || elapsed <= 0 {
+		return
+	}
+	remaining := p.Total - done
+	if remaining < 0 {
+		remaining = 0
+	}
+	p.eta = time.Duration(float64(elapsed) / float64(advanced) * float64(remaining))
+	p.etaKnown = true
+}
@@ -0,0 +1,74 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestProgressTracker_ETA(t *testing.T) {
+	var tr progressTracker
+	start := time.Date(2026, 5, 3, 12, 0, 0, 0, time.UTC)
+
+	p := JobProgress{Total: 100}
+	tr.observe(&p, start)
+	if _, ok := p.ETA(); ok {
+		t.Fatal("no ETA from a single sample")
+	}
+	p = JobProgress{Total: 100, Completed: 8, Failed: 2}
+	tr.observe(&p, start.Add(time.Minute))
+	if eta, ok := p.ETA(); !ok || eta != 9*time.Minute {
+		t.Fatalf("expected 9m remaining at 10 URLs/min, got %s %v", eta, ok)
+	}
+
+	// Only the last etaWindow polls count: a fast start followed by a
+	// stall raises the estimate.
+	for i := 2; i <= etaWindow+1; i++ {
+		p = JobProgress{Total: 100, Completed: 10 + i - 1}
+		tr.observe(&p, start.Add(time.Duration(i)*time.Minute))
+	}
+	if eta, _ := p.ETA(); eta != 80*time.Minute {
+		t.Fatalf("expected 80m at 1 URL/min, got %s", eta)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestWaitJobWithProgress_ReportsETA(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "running"
+		if polls == 3 {
+			status = "completed"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"job_id": "job_1", "status": status,
+			"progress": map[string]interface{}{"total": 6.0, "completed": float64(polls * 2), "failed": 0.0},
+		})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var etas []bool
+	job, err := c.WaitJobWithProgress("job_1", 5*time.Millisecond, time.Second, func(j *CrawlJob) {
+		_, ok := j.Progress.ETA()
+		etas = append(etas, ok)
+	})
+	if err != nil || !job.IsSuccessful() {
+		t.Fatalf("WaitJobWithProgress: %v %+v", err, job)
+	}
+	if len(etas) != 3 || etas[0] || !etas[1] {
+		t.Fatalf("expected ETA from the second poll on, got %v", etas)
+	}
+	if eta, ok := job.Progress.ETA(); !ok || eta != 0 {
+		t.Fatalf("finished job should have zero ETA, got %s %v", eta, ok)
+	}
+}
@@ -0,0 +1,145 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FromPythonConfig builds a CrawlerRunConfig from a Python config dump,
+// for teams moving crawls between the Python and Go SDKs. It accepts
+// either SDK's CrawlerRunConfig.dump(): the cloud SDK's flat dict, or the
+// open-source library's {"type": "CrawlerRunConfig", "params": {...}}
+// form, in which dicts are wrapped as {"type": "dict", "value": ...} and
+// enums as {"type": "CacheMode", "params": "bypass"}. Nested strategies
+// such as extraction_strategy keep their {"type", "params"} shape, which
+// the API accepts as is.
+//
+// Keys without a Go field are returned, sorted, rather than failing the
+// import: the open-source dump includes every parameter, defaults too.
+// Check them to see what the Go config leaves out.
+//
+//	var dump map[string]interface{}
+//	json.Unmarshal(pythonJSON, &dump) // json.dumps(config.dump())
+//	cfg, ignored, err := crawl4ai.FromPythonConfig(dump)
+func FromPythonConfig(dump map[string]interface{}) (*CrawlerRunConfig, []string, error) {
+	var cfg CrawlerRunConfig
+	ignored, err := fromPythonDump(dump, "CrawlerRunConfig", &cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cfg, ignored, nil
+}
+
+// FromPythonBrowserConfig is FromPythonConfig for BrowserConfig.dump().
+func FromPythonBrowserConfig(dump map[string]interface{}) (*BrowserConfig, []string, error) {
+	var cfg BrowserConfig
+	ignored, err := fromPythonDump(dump, "BrowserConfig", &cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &cfg, ignored, nil
+}
+
+func fromPythonDump(dump map[string]interface{}, typeName string, v interface{}) ([]string, error) {
+	params := dump
+	if t, ok := dump["type"].(string); ok {
+		if t != typeName {
+			return nil, fmt.Errorf("python config: got a %s dump, want %s", t, typeName)
+		}
+		p, ok := dump["params"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("python config: %s dump has no params", typeName)
+		}
+		params = p
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+	plain := map[string]interface{}{}
+	var ignored []string
+	for k, val := range params {
+		val = unwrapPythonValue(val)
+		switch {
+		case val == nil:
+			// None: the Go zero value already means "unset".
+		case !known[k]:
+			ignored = append(ignored, k)
+		case k == "js_code":
+			// Python takes one script or a list; Go runs one script.
+			if list, ok := val.([]interface{}); ok {
+				parts := make([]string, 0, len(list))
+				for _, s := range list {
+					parts = append(parts, fmt.Sprint(s))
+				}
+				val = strings.Join(parts, "\n")
+			}
+			plain[k] = val
+		default:
+			plain[k] = val
+		}
+	}
+	sort.Strings(ignored)
+
+	raw, err := json.Marshal(plain)
+	if err != nil {
+		return nil, fmt.Errorf("python config: %w", err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return nil, fmt.Errorf("python config: %w", err)
+	}
+	return ignored, nil
+}
+
+// unwrapPythonValue undoes the open-source serializer's wrappers: dicts
+// become plain maps and enums their values. Other {"type", "params"}
+// objects (strategies) keep their shape with their params unwrapped.
+func unwrapPythonValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		typ, typed := t["type"].(string)
+		if typed && len(t) == 2 {
+			if value, ok := t["value"].(map[string]interface{}); ok && typ == "dict" {
+				return unwrapPythonValue(value)
+			}
+			if p, ok := t["params"]; ok {
+				if _, isMap := p.(map[string]interface{}); !isMap {
+					return unwrapPythonValue(p)
+				}
+			}
+		}
+		out := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			out[k] = unwrapPythonValue(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i, val := range t {
+			out[i] = unwrapPythonValue(val)
+		}
+		return out
+	}
+	return v
+}
+
+// jsonFieldNames lists the JSON keys of a struct type's fields.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	out := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[name] = true
+	}
+	return out
+}
@@ -0,0 +1,102 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+// ossDump is json.dumps(CrawlerRunConfig(...).dump()) from the open-source
+// library, trimmed.
+const ossDump = `{
+  "type": "CrawlerRunConfig",
+  "params": {
+    "word_count_threshold": 15,
+    "cache_mode": {"type": "CacheMode", "params": "bypass"},
+    "js_code": ["window.scrollTo(0, 9999);", "await new Promise(r => setTimeout(r, 500));"],
+    "wait_for": "css:.loaded",
+    "page_timeout": 45000,
+    "screenshot": true,
+    "exclude_domains": ["ads.example.com"],
+    "session_id": null,
+    "extraction_strategy": {
+      "type": "JsonCssExtractionStrategy",
+      "params": {"schema": {"type": "dict", "value": {"baseSelector": "article", "fields": [
+        {"type": "dict", "value": {"name": "title", "selector": "h2", "type": "text"}}
+      ]}}}
+    },
+    "markdown_generator": {"type": "DefaultMarkdownGenerator", "params": {}},
+    "stream": false,
+    "verbose": true
+  }
+}`
+
+func TestFromPythonConfig_OSSDump(t *testing.T) {
+	var dump map[string]interface{}
+	if err := json.Unmarshal([]byte(ossDump), &dump); err != nil {
+		t.Fatal(err)
+	}
+	cfg, ignored, err := FromPythonConfig(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.WordCountThreshold != 15 || cfg.CacheMode != "bypass" || cfg.WaitFor != "css:.loaded" ||
+		cfg.PageTimeout != 45000 || !cfg.Screenshot || !reflect.DeepEqual(cfg.ExcludeDomains, []string{"ads.example.com"}) {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if !strings.Contains(cfg.JsCode, "scrollTo") || !strings.Contains(cfg.JsCode, "\nawait") {
+		t.Errorf("js_code = %q", cfg.JsCode)
+	}
+	wantStrategy := map[string]interface{}{
+		"type": "JsonCssExtractionStrategy",
+		"params": map[string]interface{}{"schema": map[string]interface{}{
+			"baseSelector": "article",
+			"fields":       []interface{}{map[string]interface{}{"name": "title", "selector": "h2", "type": "text"}},
+		}},
+	}
+	if !reflect.DeepEqual(cfg.ExtractionStrategy, wantStrategy) {
+		t.Errorf("extraction_strategy = %#v", cfg.ExtractionStrategy)
+	}
+	if want := []string{"markdown_generator", "stream", "verbose"}; !reflect.DeepEqual(ignored, want) {
+		t.Errorf("ignored = %v, want %v", ignored, want)
+	}
+}
+
+func TestFromPythonConfig_FlatDump(t *testing.T) {
+	cfg, ignored, err := FromPythonConfig(map[string]interface{}{"js_code": "go()", "magic": true, "scroll_delay": 0.5})
+	if err != nil || cfg.JsCode != "go()" || !cfg.Magic || cfg.ScrollDelay != 0.5 || len(ignored) != 0 {
+		t.Errorf("cfg = %+v, ignored %v, err %v", cfg, ignored, err)
+	}
+}
+
+func TestFromPythonConfig_Errors(t *testing.T) {
+	if _, _, err := FromPythonConfig(map[string]interface{}{"type": "BrowserConfig", "params": map[string]interface{}{}}); err == nil || !strings.Contains(err.Error(), "want CrawlerRunConfig") {
+		t.Errorf("wrong type: err = %v", err)
+	}
+	if _, _, err := FromPythonConfig(map[string]interface{}{"word_count_threshold": "many"}); err == nil {
+		t.Error("wrong value type: want error")
+	}
+}
+
+func TestFromPythonBrowserConfig(t *testing.T) {
+	dump := map[string]interface{}{"type": "BrowserConfig", "params": map[string]interface{}{
+		"headless":        true,
+		"viewport_width":  1440.0,
+		"headers":         map[string]interface{}{"type": "dict", "value": map[string]interface{}{"Accept-Language": "de"}},
+		"user_agent_mode": "random",
+		"extra_args":      []interface{}{"--no-sandbox"},
+	}}
+	cfg, ignored, err := FromPythonBrowserConfig(dump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Headless || cfg.ViewportWidth != 1440 || cfg.Headers["Accept-Language"] != "de" || cfg.UserAgentMode != "random" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if !reflect.DeepEqual(ignored, []string{"extra_args"}) {
+		t.Errorf("ignored = %v", ignored)
+	}
+}
@@ -0,0 +1,99 @@
+package crawl4ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// maxKeyedCrawlers bounds keyedCrawlers; multi-tenant services should
+// hold their own crawler per tenant rather than rely on this cache.
+const maxKeyedCrawlers = 16
+
+// keyedCrawlers caches a crawler per API key for the one-liners below, so
+// a script calling them in a loop reuses connections and rate-limit state.
+// keyedOrder lists the keys from least to most recently used; the least
+// recent is evicted past maxKeyedCrawlers.
+var (
+	keyedMu       sync.Mutex
+	keyedCrawlers = map[string]*AsyncWebCrawler{}
+	keyedOrder    []string
+)
+
+// sharedCrawler returns the cached crawler for apiKey, or the default
+// crawler (see SetDefault) when apiKey is empty.
+func sharedCrawler(apiKey string) (*AsyncWebCrawler, error) {
+	if apiKey == "" {
+		return Default()
+	}
+	return keyedCrawler(apiKey)
+}
+
+// keyedCrawler returns the cached crawler for apiKey, creating it on first
+// use.
+func keyedCrawler(apiKey string) (*AsyncWebCrawler, error) {
+	keyedMu.Lock()
+	defer keyedMu.Unlock()
+	if c, ok := keyedCrawlers[apiKey]; ok {
+		touchKey(apiKey)
+		return c, nil
+	}
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: apiKey})
+	if err != nil {
+		return nil, err
+	}
+	keyedCrawlers[apiKey] = c
+	keyedOrder = append(keyedOrder, apiKey)
+	if len(keyedOrder) > maxKeyedCrawlers {
+		oldest := keyedOrder[0]
+		keyedOrder = keyedOrder[1:]
+		// In-flight calls keep their crawler; only its idle sockets go.
+		keyedCrawlers[oldest].http.client.CloseIdleConnections()
+		delete(keyedCrawlers, oldest)
+	}
+	return c, nil
+}
+
+// touchKey moves apiKey to the most recently used end of keyedOrder.
+func touchKey(apiKey string) {
+	for i, k := range keyedOrder {
+		if k == apiKey {
+			keyedOrder = append(append(keyedOrder[:i:i], keyedOrder[i+1:]...), apiKey)
+			return
+		}
+	}
+}
+
+// CrawlToMarkdown crawls url and returns its markdown — the zero-setup path
+// for scripts. An empty apiKey uses the default crawler (see SetDefault);
+// ctx cancels the request.
+//
+//	md, err := crawl4ai.CrawlToMarkdown(ctx, "", "https://example.com")
+func CrawlToMarkdown(ctx context.Context, apiKey, url string) (string, error) {
+	c, err := sharedCrawler(apiKey)
+	if err != nil {
+		return "", err
+	}
+	result, err := c.WithContext(ctx).Run(url, nil)
+	if err != nil {
+		return "", err
+	}
+	if !result.Success {
+		return "", fmt.Errorf("crawl of %s failed: %s", url, result.ErrorMessage)
+	}
+	if result.Markdown == nil {
+		return "", nil
+	}
+	return result.Markdown.RawMarkdown, nil
+}
+
+// CrawlToFile crawls url and writes its markdown to path, replacing any
+// existing file.
+func CrawlToFile(ctx context.Context, apiKey, url, path string) error {
+	md, err := CrawlToMarkdown(ctx, apiKey, url)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(md), 0o644)
+}
@@ -0,0 +1,96 @@
+package crawl4ai
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process sandbox) ─────────────────────────────────────
+
+func TestCrawlToMarkdown(t *testing.T) {
+	t.Setenv("CRAWL4AI_SANDBOX", "1")
+	ctx := context.Background()
+
+	md, err := CrawlToMarkdown(ctx, "sk_test_quickstart_md", "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(md, "# Example Domain") {
+		t.Fatalf("unexpected markdown: %q", md)
+	}
+	first, _ := sharedCrawler("sk_test_quickstart_md")
+	second, _ := sharedCrawler("sk_test_quickstart_md")
+	if first != second {
+		t.Fatal("crawler should be shared per key")
+	}
+
+	if _, err := CrawlToMarkdown(ctx, "sk_test_quickstart_md", "https://real.io"); err == nil ||
+		!strings.Contains(err.Error(), "crawl of https://real.io failed") {
+		t.Fatalf("expected failed crawl error, got %v", err)
+	}
+}
+
+func TestCrawlToMarkdown_CancelledContext(t *testing.T) {
+	t.Setenv("CRAWL4AI_SANDBOX", "1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := CrawlToMarkdown(ctx, "sk_test_quickstart_ctx", "https://example.com"); err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+}
+
+func TestCrawlToFile(t *testing.T) {
+	t.Setenv("CRAWL4AI_SANDBOX", "1")
+	path := filepath.Join(t.TempDir(), "page.md")
+
+	if err := CrawlToFile(context.Background(), "sk_test_quickstart_file", "https://example.org/about", path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "sandbox fixture for https://example.org/about") {
+		t.Fatalf("unexpected file contents: %q", data)
+	}
+}
+
+func TestKeyedCrawler_EvictsLeastRecentlyUsed(t *testing.T) {
+	keyedMu.Lock()
+	keyedCrawlers, keyedOrder = map[string]*AsyncWebCrawler{}, nil
+	keyedMu.Unlock()
+
+	first, err := keyedCrawler("sk_test_quickstart_lru_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= maxKeyedCrawlers; i++ {
+		if i == maxKeyedCrawlers/2 {
+			keyedCrawler("sk_test_quickstart_lru_0") // keep the first key warm
+		}
+		if _, err := keyedCrawler(fmt.Sprintf("sk_test_quickstart_lru_%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keyedMu.Lock()
+	n := len(keyedCrawlers)
+	keyedMu.Unlock()
+	if n > maxKeyedCrawlers {
+		t.Fatalf("expected at most %d cached crawlers, got %d", maxKeyedCrawlers, n)
+	}
+	if again, _ := keyedCrawler("sk_test_quickstart_lru_0"); again != first {
+		t.Fatal("recently used key should not be evicted")
+	}
+	keyedMu.Lock()
+	_, kept := keyedCrawlers["sk_test_quickstart_lru_1"]
+	keyedMu.Unlock()
+	if kept {
+		t.Fatal("least recently used key should be evicted")
+	}
+}
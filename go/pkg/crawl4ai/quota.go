@@ -0,0 +1,91 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DefaultStorageMBPerURL is the stored size assumed per result when
+// QuotaCheck.StorageMBPerURL is unset — a rough average for a page with
+// HTML and markdown, no screenshot.
+const DefaultStorageMBPerURL = 0.5
+
+// QuotaCheck configures the pre-submission quota check for async jobs.
+type QuotaCheck struct {
+	// StorageMBPerURL is the expected stored size of one result. Raise it
+	// when capturing screenshots or PDFs. Default DefaultStorageMBPerURL.
+	StorageMBPerURL float64
+	// SkipStorage / SkipCredits disable either half of the check.
+	SkipStorage bool
+	SkipCredits bool
+}
+
+// InsufficientQuotaError is returned by CheckQuota (and by RunMany with
+// QuotaCheck set) when a job would exhaust storage or credits.
+type InsufficientQuotaError struct {
+	Resource  string  // "storage" or "credits"
+	Needed    float64 // MB for storage, credits for credits
+	Available float64
+}
+
+// Error implements error.
+func (e *InsufficientQuotaError) Error() string {
+	unit := "MB"
+	if e.Resource == "credits" {
+		unit = "credits"
+	}
+	return fmt.Sprintf(
+		"insufficient %s for this job: needs %.2f %s, only %.2f %s available (short by %.2f)",
+		e.Resource, e.Needed, unit, e.Available, unit, e.Needed-e.Available,
+	)
+}
+
+// CheckQuota verifies there is enough storage and credit for a RunMany
+// call with these arguments, without creating a job. Storage is checked
+// against /v1/crawl/storage using QuotaCheck.StorageMBPerURL per URL;
+// credits use a dry-run Estimate of the same request.
+//
+//	err := crawler.CheckQuota(urls, &RunManyOptions{Strategy: "http"}, nil)
+//	var quota *InsufficientQuotaError
+//	if errors.As(err, &quota) {
+//	    log.Printf("need %.0f more %s", quota.Needed-quota.Available, quota.Resource)
+//	}
+func (c *AsyncWebCrawler) CheckQuota(urls []string, opts *RunManyOptions, check *QuotaCheck) error {
+	if opts == nil {
+		opts = &RunManyOptions{}
+	}
+	if check == nil {
+		check = &QuotaCheck{}
+	}
+	return c.checkQuota(len(urls), buildRunManyBody(urls, opts), check)
+}
+
+func (c *AsyncWebCrawler) checkQuota(urlCount int, body map[string]interface{}, check *QuotaCheck) error {
+	if !check.SkipStorage {
+		perURL := check.StorageMBPerURL
+		if perURL <= 0 {
+			perURL = DefaultStorageMBPerURL
+		}
+		storage, err := c.Storage()
+		if err != nil {
+			return fmt.Errorf("quota check: storage: %w", err)
+		}
+		needed := float64(urlCount) * perURL
+		if needed > storage.RemainingMB {
+			return &InsufficientQuotaError{Resource: "storage", Needed: needed, Available: storage.RemainingMB}
+		}
+	}
+
+	if !check.SkipCredits {
+		est, err := c.Estimate("crawl", body)
+		if err != nil {
+			return fmt.Errorf("quota check: credits: %w", err)
+		}
+		if !est.CoveredByBalance {
+			needed, _ := strconv.ParseFloat(est.Credits, 64)
+			available, _ := strconv.ParseFloat(est.SpendableCredit, 64)
+			return &InsufficientQuotaError{Resource: "credits", Needed: needed, Available: available}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package crawl4ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func quotaRoutes(remainingMB float64, covered bool) map[string]interface{} {
+	return map[string]interface{}{
+		"GET /v1/crawl/storage": map[string]interface{}{
+			"used_mb": 1000 - remainingMB, "max_mb": 1000.0, "remaining_mb": remainingMB,
+		},
+		"POST /v1/crawl": map[string]interface{}{
+			"service": "crawl", "credits": "40", "covered_by_balance": covered,
+			"spendable_credit": "12.5", "dry_run": true,
+		},
+		"POST /v1/crawl/async": map[string]interface{}{"job_id": "job_1", "status": "pending"},
+	}
+}
+
+func TestCheckQuota_Storage(t *testing.T) {
+	c := newMockCrawler(t, quotaRoutes(3, true))
+	urls := []string{"https://a.com", "https://b.com", "https://c.com", "https://d.com"}
+
+	err := c.CheckQuota(urls, nil, &QuotaCheck{StorageMBPerURL: 1})
+	var quota *InsufficientQuotaError
+	if !errors.As(err, &quota) || quota.Resource != "storage" || quota.Needed != 4 || quota.Available != 3 {
+		t.Fatalf("expected storage shortfall 4 > 3, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "short by 1.00") {
+		t.Fatalf("expected shortfall in message, got %q", err.Error())
+	}
+
+	if err := c.CheckQuota(urls, nil, &QuotaCheck{StorageMBPerURL: 0.5}); err != nil {
+		t.Fatalf("expected 2 MB to fit, got %v", err)
+	}
+}
+
+func TestCheckQuota_Credits(t *testing.T) {
+	c := newMockCrawler(t, quotaRoutes(1000, false))
+	err := c.CheckQuota([]string{"https://a.com"}, nil, nil)
+	var quota *InsufficientQuotaError
+	if !errors.As(err, &quota) || quota.Resource != "credits" || quota.Needed != 40 || quota.Available != 12.5 {
+		t.Fatalf("expected credit shortfall, got %v", err)
+	}
+	if err := c.CheckQuota([]string{"https://a.com"}, nil, &QuotaCheck{SkipCredits: true}); err != nil {
+		t.Fatalf("expected SkipCredits to pass, got %v", err)
+	}
+}
+
+func TestCheckQuota_RunManyFailsFast(t *testing.T) {
+	c := newMockCrawler(t, quotaRoutes(0, true))
+	_, err := c.RunMany([]string{"https://a.com"}, &RunManyOptions{QuotaCheck: &QuotaCheck{}})
+	var quota *InsufficientQuotaError
+	if !errors.As(err, &quota) {
+		t.Fatalf("expected RunMany to fail before submitting, got %v", err)
+	}
+
+	c = newMockCrawler(t, quotaRoutes(1000, true))
+	res, err := c.RunMany([]string{"https://a.com"}, &RunManyOptions{QuotaCheck: &QuotaCheck{}})
+	if err != nil || res.Job.JobID != "job_1" {
+		t.Fatalf("expected submission after passing check, got %v", err)
+	}
+}
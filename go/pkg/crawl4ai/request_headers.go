@@ -0,0 +1,119 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// withRequestHeaders returns bc, or a copy of it carrying headers and
+// cookies when either is set. Headers override same-named ones in bc;
+// cookies replace any with the same name and domain. bc is never modified.
+func withRequestHeaders(bc *BrowserConfig, headers map[string]string, cookies []map[string]interface{}) *BrowserConfig {
+	if len(headers) == 0 && len(cookies) == 0 {
+		return bc
+	}
+	out := &BrowserConfig{}
+	if bc != nil {
+		*out = *bc
+	}
+	if len(headers) > 0 {
+		merged := make(map[string]string, len(out.Headers)+len(headers))
+		for k, v := range out.Headers {
+			merged[k] = v
+		}
+		for k, v := range headers {
+			merged[k] = v
+		}
+		out.Headers = merged
+	}
+	out.Cookies = mergeCookies(out.Cookies, cookies)
+	return out
+}
+
+// mergeCookies returns base with every cookie in fresh added, dropping any
+// base cookie that has the same name and domain as a fresh one.
+func mergeCookies(base, fresh []map[string]interface{}) []map[string]interface{} {
+	if len(fresh) == 0 {
+		return base
+	}
+	key := func(c map[string]interface{}) string {
+		return fmt.Sprint(c["name"], "\x00", c["domain"])
+	}
+	replaced := make(map[string]bool, len(fresh))
+	for _, c := range fresh {
+		replaced[key(c)] = true
+	}
+	out := make([]map[string]interface{}, 0, len(base)+len(fresh))
+	for _, c := range base {
+		if !replaced[key(c)] {
+			out = append(out, c)
+		}
+	}
+	return append(out, fresh...)
+}
+
+// HTTPRequest customizes the request the http strategy sends, so API
+// endpoints and form posts can be fetched, not just plain GETs.
+//
+//	result, err := crawler.Run("https://example.com/search", &crawl4ai.RunOptions{
+//	    Strategy: "http",
+//	    Headers:  map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+//	    HTTPRequest: &crawl4ai.HTTPRequest{Method: "POST", Data: "q=crawl4ai"},
+//	})
+type HTTPRequest struct {
+	// Method is "GET" (default) or "POST".
+	Method string
+	// Data is sent verbatim as the request body; JSON is marshalled and
+	// sent as application/json. Set at most one, and only with POST.
+	Data string
+	JSON interface{}
+	// FollowRedirects controls whether 3xx responses are followed.
+	// Default (nil) follows them.
+	FollowRedirects *bool
+	// MaxRedirects caps how many redirects are followed. 0 uses the
+	// server default.
+	MaxRedirects int
+}
+
+// httpRequestConfig builds the http_config sent with http-strategy crawls,
+// which run without a browser and so ignore BrowserConfig.
+func httpRequestConfig(opts *RunOptions) (map[string]interface{}, error) {
+	hc := map[string]interface{}{}
+	if len(opts.Headers) > 0 {
+		hc["headers"] = opts.Headers
+	}
+	if len(opts.Cookies) > 0 {
+		hc["cookies"] = opts.Cookies
+	}
+	req := opts.HTTPRequest
+	if req == nil {
+		return hc, nil
+	}
+	method := strings.ToUpper(req.Method)
+	switch method {
+	case "", "GET":
+		if req.Data != "" || req.JSON != nil {
+			return nil, fmt.Errorf("http request: a body needs Method POST")
+		}
+	case "POST":
+		hc["method"] = method
+	default:
+		return nil, fmt.Errorf("http request: unsupported method %q (use GET or POST)", req.Method)
+	}
+	if req.Data != "" && req.JSON != nil {
+		return nil, fmt.Errorf("http request: set Data or JSON, not both")
+	}
+	if req.Data != "" {
+		hc["data"] = req.Data
+	}
+	if req.JSON != nil {
+		hc["json"] = req.JSON
+	}
+	if req.FollowRedirects != nil {
+		hc["follow_redirects"] = *req.FollowRedirects
+	}
+	if req.MaxRedirects > 0 {
+		hc["max_redirects"] = req.MaxRedirects
+	}
+	return hc, nil
+}
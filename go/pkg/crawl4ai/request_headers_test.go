@@ -0,0 +1,97 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestWithRequestHeaders(t *testing.T) {
+	base := &BrowserConfig{
+		Headless: true,
+		Headers:  map[string]string{"Accept-Language": "en", "X-Keep": "1"},
+		Cookies:  []map[string]interface{}{{"name": "sid", "value": "old", "domain": "a.com"}},
+	}
+	if got := withRequestHeaders(base, nil, nil); got != base {
+		t.Fatal("expected config returned unchanged when nothing to merge")
+	}
+	got := withRequestHeaders(base,
+		map[string]string{"Accept-Language": "de"},
+		[]map[string]interface{}{{"name": "sid", "value": "new", "domain": "a.com"}})
+	if !reflect.DeepEqual(got.Headers, map[string]string{"Accept-Language": "de", "X-Keep": "1"}) {
+		t.Fatalf("unexpected headers: %v", got.Headers)
+	}
+	if len(got.Cookies) != 1 || got.Cookies[0]["value"] != "new" || !got.Headless {
+		t.Fatalf("unexpected config: %+v", got)
+	}
+	if base.Headers["Accept-Language"] != "en" || base.Cookies[0]["value"] != "old" {
+		t.Fatal("base config was modified")
+	}
+}
+
+func TestHTTPRequestConfig(t *testing.T) {
+	noFollow := false
+	hc, err := httpRequestConfig(&RunOptions{HTTPRequest: &HTTPRequest{
+		Method: "post", JSON: map[string]int{"page": 2}, FollowRedirects: &noFollow, MaxRedirects: 3,
+	}})
+	if err != nil {
+		t.Fatalf("httpRequestConfig: %v", err)
+	}
+	want := map[string]interface{}{
+		"method": "POST", "json": map[string]int{"page": 2}, "follow_redirects": false, "max_redirects": 3,
+	}
+	if !reflect.DeepEqual(hc, want) {
+		t.Fatalf("got %v, want %v", hc, want)
+	}
+
+	for _, bad := range []*HTTPRequest{
+		{Data: "a=1"},
+		{Method: "PUT"},
+		{Method: "POST", Data: "a=1", JSON: map[string]int{}},
+	} {
+		if _, err := httpRequestConfig(&RunOptions{HTTPRequest: bad}); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRun_RequestHeaders(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://a.com", "success": true})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headers := map[string]string{"X-Token": "t"}
+	if _, err := c.Run("https://a.com", &RunOptions{Headers: headers}); err != nil {
+		t.Fatalf("browser run: %v", err)
+	}
+	if _, err := c.Run("https://a.com", &RunOptions{Strategy: "http", Headers: headers}); err != nil {
+		t.Fatalf("http run: %v", err)
+	}
+
+	bc, _ := bodies[0]["browser_config"].(map[string]interface{})
+	if h, _ := bc["headers"].(map[string]interface{}); h["X-Token"] != "t" {
+		t.Fatalf("browser run: headers not in browser_config: %v", bodies[0])
+	}
+	if _, ok := bodies[0]["http_config"]; ok {
+		t.Fatal("browser run should not send http_config")
+	}
+	hc, _ := bodies[1]["http_config"].(map[string]interface{})
+	if h, _ := hc["headers"].(map[string]interface{}); h["X-Token"] != "t" {
+		t.Fatalf("http run: headers not in http_config: %v", bodies[1])
+	}
+}
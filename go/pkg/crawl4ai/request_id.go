@@ -0,0 +1,72 @@
+package crawl4ai
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// RequestIDHeader carries the per-call correlation ID. The SDK sends one
+// on every request and prefers the server's echo when reporting it.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose calls are sent with id as their
+// X-Request-ID instead of a generated one. Use it with
+// AsyncWebCrawler.WithContext to tie SDK calls to your own trace IDs.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the ID set by WithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// WithContext returns a crawler whose API calls use ctx: they are
+// cancelled with it and carry its request ID, if any. The copy shares
+// configuration, rate-limit and retention state with c.
+//
+//	ctx = crawl4ai.WithRequestID(ctx, traceID)
+//	result, err := crawler.WithContext(ctx).Run(url, nil)
+//	// err.Error() ends with "(request_id: <traceID>)" unless the server
+//	// assigned its own.
+func (c *AsyncWebCrawler) WithContext(ctx context.Context) *AsyncWebCrawler {
+	out := *c
+	out.http = c.http.withContext(ctx)
+	return &out
+}
+
+func (c *HTTPClient) withContext(ctx context.Context) *HTTPClient {
+	root := c
+	if c.root != nil {
+		root = c.root
+	}
+	return &HTTPClient{
+		apiKey:     c.apiKey,
+		baseURL:    c.baseURL,
+		timeout:    c.timeout,
+		maxRetries: c.maxRetries,
+		client:     c.client,
+		gate:       c.gate,
+		scope:      c.scope,
+		ctx:        ctx,
+		root:       root,
+	}
+}
+
+// withRequestID stamps id on err's embedded CloudError.
+func withRequestID(err error, id string) error {
+	if ce, ok := err.(interface{ cloudError() *CloudError }); ok {
+		ce.cloudError().RequestID = id
+	}
+	return err
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,76 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRequestID_SentAndEchoed(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(RequestIDHeader))
+		switch r.URL.Path {
+		case "/v1/crawl":
+			w.Header().Set(RequestIDHeader, "srv-123")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://a.com", "success": true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"detail": "job not found"})
+		}
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Run("https://a.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RequestID != "srv-123" {
+		t.Fatalf("expected server request ID on result, got %q", result.RequestID)
+	}
+	if len(seen) != 1 || len(seen[0]) != 32 {
+		t.Fatalf("expected a generated request ID, got %q", seen)
+	}
+
+	ctx := WithRequestID(context.Background(), "trace-42")
+	_, err = c.WithContext(ctx).GetJob("job_1")
+	var nf *NotFoundError
+	if !errors.As(err, &nf) {
+		t.Fatalf("expected *NotFoundError, got %v", err)
+	}
+	if seen[len(seen)-1] != "trace-42" || nf.RequestID != "trace-42" {
+		t.Fatalf("expected context request ID to be sent and reported, sent %q, error %q", seen[len(seen)-1], nf.RequestID)
+	}
+	if !strings.HasSuffix(err.Error(), "(request_id: trace-42)") {
+		t.Fatalf("error string should carry the request ID: %v", err)
+	}
+}
+
+func TestRequestID_WithContextCancels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://a.com", "success": true})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.WithContext(ctx).Run("https://a.com", nil); err == nil {
+		t.Fatal("expected a cancelled context to fail the call")
+	}
+	if _, err := c.Run("https://a.com", nil); err != nil {
+		t.Fatalf("original crawler should be unaffected: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// ResponseCache keeps GET responses that carry an ETag or Last-Modified
+// header and revalidates them with If-None-Match / If-Modified-Since, so
+// polling a job or refreshing a dashboard downloads an unchanged payload
+// once; repeats are answered 304 Not Modified and served from memory.
+// Every request still reaches the API, so answers are never stale. Set it
+// as CrawlerOptions.ResponseCache; one cache may be shared by several
+// crawlers. Safe for concurrent use.
+type ResponseCache struct {
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front is most recently used
+	bytes   int64
+	stats   ResponseCacheStats
+}
+
+// ResponseCacheStats counts a ResponseCache's traffic.
+type ResponseCacheStats struct {
+	// Hits are responses served from the cache after a 304.
+	Hits int64
+	// Misses are GETs answered with a full response.
+	Misses  int64
+	Entries int
+	Bytes   int64
+}
+
+type cachedResponse struct {
+	key    string
+	header http.Header
+	body   []byte
+}
+
+// NewResponseCache creates a cache holding up to maxBytes of response
+// bodies, evicting the least recently used. maxBytes <= 0 means 64 MiB.
+func NewResponseCache(maxBytes int64) *ResponseCache {
+	if maxBytes <= 0 {
+		maxBytes = 64 << 20
+	}
+	return &ResponseCache{maxBytes: maxBytes, entries: map[string]*list.Element{}, lru: list.New()}
+}
+
+// Stats returns the cache's counters.
+func (rc *ResponseCache) Stats() ResponseCacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	s := rc.stats
+	s.Entries, s.Bytes = rc.lru.Len(), rc.bytes
+	return s
+}
+
+func (rc *ResponseCache) get(key string) *cachedResponse {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	el, ok := rc.entries[key]
+	if !ok {
+		return nil
+	}
+	rc.lru.MoveToFront(el)
+	return el.Value.(*cachedResponse)
+}
+
+func (rc *ResponseCache) put(entry *cachedResponse) {
+	size := int64(len(entry.body))
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.removeLocked(entry.key)
+	if size > rc.maxBytes {
+		return
+	}
+	rc.entries[entry.key] = rc.lru.PushFront(entry)
+	rc.bytes += size
+	for rc.bytes > rc.maxBytes {
+		rc.removeLocked(rc.lru.Back().Value.(*cachedResponse).key)
+	}
+}
+
+func (rc *ResponseCache) removeLocked(key string) {
+	if el, ok := rc.entries[key]; ok {
+		rc.lru.Remove(el)
+		delete(rc.entries, key)
+		rc.bytes -= int64(len(el.Value.(*cachedResponse).body))
+	}
+}
+
+func (rc *ResponseCache) count(hit bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if hit {
+		rc.stats.Hits++
+	} else {
+		rc.stats.Misses++
+	}
+}
+
+// install routes client's requests through the cache.
+func (rc *ResponseCache) install(client *http.Client) {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &cachingTransport{base: base, cache: rc}
+}
+
+// cachingTransport adds conditional requests to GETs made through base.
+type cachingTransport struct {
+	base  http.RoundTripper
+	cache *ResponseCache
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Leave non-GETs and callers doing their own revalidation alone.
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return t.base.RoundTrip(req)
+	}
+	// Responses differ per account, so the key includes the API key.
+	key := req.Header.Get("X-API-Key") + " " + req.URL.String()
+	cached := t.cache.get(key)
+	if cached != nil {
+		req = req.Clone(req.Context())
+		if etag := cached.header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lm := cached.header.Get("Last-Modified"); lm != "" {
+			req.Header.Set("If-Modified-Since", lm)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		resp.Body.Close()
+		// A 304 carries fresh headers (rate limits, request ID) for the
+		// stored response.
+		header := cached.header.Clone()
+		for k, v := range resp.Header {
+			header[k] = v
+		}
+		header.Set("Content-Length", strconv.Itoa(len(cached.body)))
+		t.cache.put(&cachedResponse{key: key, header: header, body: cached.body})
+		t.cache.count(true)
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.body)),
+			ContentLength: int64(len(cached.body)),
+			Request:       req,
+		}, nil
+	case resp.StatusCode == http.StatusOK && (resp.Header.Get("ETag") != "" || resp.Header.Get("Last-Modified") != ""):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		t.cache.put(&cachedResponse{key: key, header: resp.Header.Clone(), body: body})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if resp.StatusCode == http.StatusOK {
+		t.cache.count(false)
+	}
+	return resp, nil
+}
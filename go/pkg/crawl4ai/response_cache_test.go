@@ -0,0 +1,106 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestResponseCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	rc := NewResponseCache(10)
+	rc.put(&cachedResponse{key: "a", header: http.Header{}, body: []byte("aaaa")})
+	rc.put(&cachedResponse{key: "b", header: http.Header{}, body: []byte("bbbb")})
+	rc.get("a")
+	rc.put(&cachedResponse{key: "c", header: http.Header{}, body: []byte("cccc")})
+	if rc.get("b") != nil || rc.get("a") == nil || rc.get("c") == nil {
+		t.Error("expected b, the least recently used, to be evicted")
+	}
+	rc.put(&cachedResponse{key: "huge", header: http.Header{}, body: make([]byte, 11)})
+	if s := rc.Stats(); s.Entries != 2 || s.Bytes != 8 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestResponseCache_RevalidatesJobPolls(t *testing.T) {
+	var full, notModified int32
+	status := "running"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := fmt.Sprintf(`"%s"`, status)
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&full, 1)
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"job_id": "job_1", "status": %q, "urls_count": 3}`, status)
+	}))
+	defer srv.Close()
+	cache := NewResponseCache(0)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, ResponseCache: cache})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		job, err := c.GetJob("job_1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status != "running" || job.URLsCount != 3 {
+			t.Fatalf("poll %d: job = %+v", i, job)
+		}
+	}
+	if full != 1 || notModified != 2 {
+		t.Errorf("full = %d, 304s = %d; want 1 and 2", full, notModified)
+	}
+	if rl, ok := c.http.RateLimit(); !ok || rl.Remaining != 42 {
+		t.Errorf("rate limit from 304 not observed: %+v %v", rl, ok)
+	}
+
+	status = "completed"
+	if job, _ := c.GetJob("job_1"); job.Status != "completed" {
+		t.Errorf("changed job served stale: %+v", job)
+	}
+	if s := cache.Stats(); s.Hits != 2 || s.Misses != 2 || s.Entries != 1 {
+		t.Errorf("stats = %+v", s)
+	}
+}
+
+func TestResponseCache_SkipsPostsAndUntaggedResponses(t *testing.T) {
+	var conditional int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			atomic.AddInt32(&conditional, 1)
+		}
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/v1/crawl/jobs/") {
+			w.Header().Set("ETag", `"x"`)
+		}
+		fmt.Fprint(w, `{"status": "ok", "success": true, "url": "https://example.com"}`)
+	}))
+	defer srv.Close()
+	cache := NewResponseCache(0)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, ResponseCache: cache})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Run("https://example.com", nil); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.Health(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if conditional != 0 || cache.Stats().Entries != 0 {
+		t.Errorf("conditional = %d, stats = %+v", conditional, cache.Stats())
+	}
+}
@@ -0,0 +1,211 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Wire names of the CrawlResult fields that dominate payload size. A single
+// full-page HTML dump or base64 screenshot can be megabytes; pass any of these
+// as OmitFields when you only read markdown or metadata.
+const (
+	ResultFieldHTML        = "html"
+	ResultFieldCleanedHTML = "cleaned_html"
+	ResultFieldFitHTML     = "fit_html"
+	ResultFieldScreenshot  = "screenshot"
+	ResultFieldPDF         = "pdf"
+)
+
+// HeavyResultFields lists every heavy field. Use it as OmitFields to keep
+// only the lightweight parts (markdown, metadata, links, ...) in memory.
+var HeavyResultFields = []string{
+	ResultFieldHTML,
+	ResultFieldCleanedHTML,
+	ResultFieldFitHTML,
+	ResultFieldScreenshot,
+	ResultFieldPDF,
+}
+
+// lazyResult remembers which fields were dropped at decode time and how to
+// fetch them again.
+type lazyResult struct {
+	omitted []string
+	fetch   func() (map[string]interface{}, error)
+}
+
+// omitResultFields deletes the named keys from a raw result map before it is
+// decoded and returns the ones that were actually present.
+func omitResultFields(data map[string]interface{}, omit []string) []string {
+	var dropped []string
+	for _, k := range omit {
+		if v, ok := data[k]; ok {
+			delete(data, k)
+			if v != nil {
+				dropped = append(dropped, k)
+			}
+		}
+	}
+	return dropped
+}
+
+// decodeCrawlResult parses a raw result, dropping omit and everything a
+// Fields projection leaves out first. When anything was dropped — or left
+// out by a server that honoured the projection — and fetch is non-nil, the
+// result can restore it on demand via LoadOmitted.
+func decodeCrawlResult(data map[string]interface{}, omit, fields []string, fetch func() (map[string]interface{}, error)) *CrawlResult {
+	omit = mergeOmit(data, omit, fields)
+	if len(omit) == 0 && len(fields) == 0 {
+		return CrawlResultFromMap(data)
+	}
+	// Classify blocks before the HTML they're detected from is dropped.
+	block := detectBlock(data)
+	dropped := omitResultFields(data, omit)
+	dropped = append(dropped, unrequestedFields(data, fields)...)
+	result := crawlResultFromMap(data, block)
+	if len(dropped) > 0 {
+		result.lazy = &lazyResult{omitted: dropped, fetch: fetch}
+	}
+	return result
+}
+
+// OmittedFields returns the fields dropped at decode time that have not been
+// loaded yet. Empty when the result is complete.
+func (r *CrawlResult) OmittedFields() []string {
+	if r.lazy == nil {
+		return nil
+	}
+	return r.lazy.omitted
+}
+
+// LoadOmitted fetches the fields dropped by OmitFields and fills them in.
+// A no-op when nothing was omitted; safe to call multiple times.
+//
+// It re-sends the original request (without bypass_cache). The cloud
+// answers from its cache while it still holds the page; otherwise the
+// page is crawled, and billed, again.
+func (r *CrawlResult) LoadOmitted() error {
+	if r.lazy == nil || len(r.lazy.omitted) == 0 {
+		return nil
+	}
+	if r.lazy.fetch == nil {
+		return fmt.Errorf(
+			"result for %s was decoded without a fetch source; "+
+				"omitted fields %v cannot be loaded", r.URL, r.lazy.omitted,
+		)
+	}
+	data, err := r.lazy.fetch()
+	if err != nil {
+		return err
+	}
+	full := CrawlResultFromMap(data)
+	for _, name := range r.lazy.omitted {
+		copyResultField(r, full, name)
+	}
+	r.lazy = nil
+	return nil
+}
+
+// resultFieldIndex maps each CrawlResult wire name to its struct field.
+var resultFieldIndex = func() map[string]int {
+	t := reflect.TypeOf(CrawlResult{})
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			index[name] = i
+		}
+	}
+	return index
+}()
+
+// copyResultField copies one wire-named field from src onto dst. Names
+// that aren't CrawlResult fields are ignored.
+func copyResultField(dst, src *CrawlResult, name string) {
+	if i, ok := resultFieldIndex[name]; ok {
+		reflect.ValueOf(dst).Elem().Field(i).Set(reflect.ValueOf(src).Elem().Field(i))
+	}
+}
+
+// sdkResultFields are CrawlResult fields the SDK fills in, never the API.
+var sdkResultFields = map[string]bool{"chunks": true}
+
+// unrequestedFields returns the wire fields a Fields projection leaves out
+// that data doesn't carry — the ones a server honouring the projection
+// never sent. Keys data does carry are dropped by projectionOmits.
+func unrequestedFields(data map[string]interface{}, fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+	var out []string
+	for name := range resultFieldIndex {
+		if _, sent := data[name]; !sent && !keep[name] && !alwaysKeptFields[name] && !sdkResultFields[name] {
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// alwaysKeptFields survive any Fields projection — without them a result
+// can't be matched to its URL or told apart from a failure.
+var alwaysKeptFields = map[string]bool{
+	"url":           true,
+	"success":       true,
+	"error_message": true,
+	"status_code":   true,
+	"request_id":    true,
+	"usage":         true,
+}
+
+// projectionOmits returns the keys of data a Fields projection drops. The
+// server honours "fields" where supported; this is the client-side fallback
+// so older deployments behave the same.
+func projectionOmits(data map[string]interface{}, fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	keep := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		keep[f] = true
+	}
+	var omit []string
+	for k := range data {
+		if !keep[k] && !alwaysKeptFields[k] {
+			omit = append(omit, k)
+		}
+	}
+	sort.Strings(omit)
+	return omit
+}
+
+// mergeOmit combines an explicit omit list with the fields a projection
+// drops from data, without duplicates.
+func mergeOmit(data map[string]interface{}, omit, fields []string) []string {
+	projected := projectionOmits(data, fields)
+	if len(projected) == 0 {
+		return omit
+	}
+	seen := make(map[string]bool, len(omit))
+	out := append([]string(nil), omit...)
+	for _, k := range omit {
+		seen[k] = true
+	}
+	for _, k := range projected {
+		if !seen[k] {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// fieldsParam renders a Fields projection as the comma-separated query value
+// the jobs endpoints accept.
+func fieldsParam(fields []string) string {
+	return strings.Join(fields, ",")
+}
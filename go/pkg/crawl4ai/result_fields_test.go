@@ -0,0 +1,182 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func rawHeavyResult() map[string]interface{} {
+	return map[string]interface{}{
+		"url":        "https://example.com",
+		"success":    true,
+		"html":       "<html>big</html>",
+		"screenshot": "iVBORw0KGgo",
+		"markdown":   "# Example",
+		"metadata":   map[string]interface{}{"title": "Example"},
+	}
+}
+
+func TestResultFields_OmitDropsHeavyFields(t *testing.T) {
+	r := decodeCrawlResult(rawHeavyResult(), HeavyResultFields, nil, nil)
+	if r.HTML != "" || r.Screenshot != "" {
+		t.Fatalf("expected heavy fields dropped, got html=%q screenshot=%q", r.HTML, r.Screenshot)
+	}
+	if r.Markdown == nil || r.Markdown.RawMarkdown != "# Example" {
+		t.Fatalf("expected markdown kept, got %+v", r.Markdown)
+	}
+	got := r.OmittedFields()
+	if len(got) != 2 || got[0] != ResultFieldHTML || got[1] != ResultFieldScreenshot {
+		t.Fatalf("expected [html screenshot] omitted, got %v", got)
+	}
+}
+
+func TestResultFields_LoadOmittedRestores(t *testing.T) {
+	calls := 0
+	fetch := func() (map[string]interface{}, error) {
+		calls++
+		return rawHeavyResult(), nil
+	}
+	r := decodeCrawlResult(rawHeavyResult(), []string{ResultFieldHTML}, nil, fetch)
+	if err := r.LoadOmitted(); err != nil {
+		t.Fatalf("LoadOmitted: %v", err)
+	}
+	if r.HTML != "<html>big</html>" {
+		t.Fatalf("expected html restored, got %q", r.HTML)
+	}
+	if len(r.OmittedFields()) != 0 {
+		t.Fatalf("expected nothing omitted after load, got %v", r.OmittedFields())
+	}
+	if err := r.LoadOmitted(); err != nil || calls != 1 {
+		t.Fatalf("second LoadOmitted should be a no-op, calls=%d err=%v", calls, err)
+	}
+}
+
+func TestResultFields_LoadOmittedErrors(t *testing.T) {
+	r := decodeCrawlResult(rawHeavyResult(), []string{ResultFieldHTML}, nil, nil)
+	if err := r.LoadOmitted(); err == nil {
+		t.Fatal("expected error without a fetch source")
+	}
+
+	boom := errors.New("boom")
+	r = decodeCrawlResult(rawHeavyResult(), []string{ResultFieldHTML}, nil, func() (map[string]interface{}, error) {
+		return nil, boom
+	})
+	if err := r.LoadOmitted(); !errors.Is(err, boom) {
+		t.Fatalf("expected fetch error, got %v", err)
+	}
+	if len(r.OmittedFields()) != 1 {
+		t.Fatal("failed load should keep the omitted list")
+	}
+}
+
+func TestResultFields_NoOmitIsPlainDecode(t *testing.T) {
+	r := decodeCrawlResult(rawHeavyResult(), nil, nil, nil)
+	if r.HTML == "" || r.OmittedFields() != nil {
+		t.Fatalf("expected full decode, got html=%q omitted=%v", r.HTML, r.OmittedFields())
+	}
+}
+
+func TestResultFields_ProjectionKeepsRequestedAndIdentity(t *testing.T) {
+	data := rawHeavyResult()
+	data["status_code"] = float64(200)
+	r := decodeCrawlResult(data, nil, []string{"markdown"}, nil)
+	if r.Markdown == nil || r.URL == "" || !r.Success || r.StatusCode != 200 {
+		t.Fatalf("expected markdown + identity fields kept, got %+v", r)
+	}
+	if r.HTML != "" || r.Screenshot != "" || r.Metadata != nil {
+		t.Fatalf("expected unrequested fields dropped, got %+v", r)
+	}
+}
+
+func TestResultFields_LoadOmittedRestoresAnyProjectedField(t *testing.T) {
+	full := rawHeavyResult()
+	full["redirected_url"] = "https://example.com/final"
+	full["crawl_strategy"] = "browser"
+	full["block_info"] = map[string]interface{}{"kind": "captcha", "reason": "datadome"}
+	fetch := func() (map[string]interface{}, error) {
+		m := map[string]interface{}{}
+		for k, v := range full {
+			m[k] = v
+		}
+		return m, nil
+	}
+	data, _ := fetch()
+	r := decodeCrawlResult(data, nil, []string{"markdown"}, fetch)
+	if r.RedirectedURL != "" || r.CrawlStrategy != "" {
+		t.Fatalf("expected unrequested fields dropped, got %+v", r)
+	}
+	if err := r.LoadOmitted(); err != nil {
+		t.Fatal(err)
+	}
+	if r.RedirectedURL != "https://example.com/final" || r.CrawlStrategy != "browser" || r.BlockInfo == nil || r.HTML == "" {
+		t.Errorf("projected fields not restored: %+v", r)
+	}
+}
+
+func TestResultFields_ServerHonouredProjectionIsRecorded(t *testing.T) {
+	// The server already applied the projection: only markdown and the
+	// identity fields came back.
+	data := map[string]interface{}{"url": "https://example.com", "success": true, "markdown": "# Example"}
+	fetch := func() (map[string]interface{}, error) { return rawHeavyResult(), nil }
+	r := decodeCrawlResult(data, nil, []string{"markdown"}, fetch)
+	omitted := map[string]bool{}
+	for _, name := range r.OmittedFields() {
+		omitted[name] = true
+	}
+	for _, name := range []string{"html", "metadata", "redirected_url"} {
+		if !omitted[name] {
+			t.Errorf("%s not recorded as omitted: %v", name, r.OmittedFields())
+		}
+	}
+	if omitted["markdown"] || omitted["chunks"] || omitted["url"] {
+		t.Errorf("requested or SDK-only field recorded as omitted: %v", r.OmittedFields())
+	}
+	if err := r.LoadOmitted(); err != nil || r.HTML == "" || r.Metadata["title"] != "Example" {
+		t.Errorf("LoadOmitted did not fill the projection: %v, %+v", err, r)
+	}
+}
+
+func TestResultFields_MergeOmitDedupes(t *testing.T) {
+	got := mergeOmit(rawHeavyResult(), []string{ResultFieldHTML}, []string{"markdown"})
+	want := []string{"html", "metadata", "screenshot"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestResultFields_RefetchDropsBypassCache(t *testing.T) {
+	var bypass []interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bypass = append(bypass, body["bypass_cache"])
+		_ = json.NewEncoder(w).Encode(rawHeavyResult())
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := c.Run("https://example.com", &RunOptions{BypassCache: true, OmitFields: []string{"html"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.LoadOmitted(); err != nil || r.HTML == "" {
+		t.Fatalf("LoadOmitted: %v (html %q)", err, r.HTML)
+	}
+	if len(bypass) != 2 || bypass[0] != true || bypass[1] != nil {
+		t.Errorf("bypass_cache per request = %v, want [true <nil>]", bypass)
+	}
+}
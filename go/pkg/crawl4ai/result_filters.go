@@ -0,0 +1,146 @@
+package crawl4ai
+
+import "net/url"
+
+// results returns Results, falling back to the job's inline results.
+func (r *RunManyResult) results() []*CrawlResult {
+	if len(r.Results) > 0 || r.Job == nil {
+		return r.Results
+	}
+	return r.Job.Results
+}
+
+// Successful returns the results that crawled successfully.
+func (r *RunManyResult) Successful() []*CrawlResult {
+	return r.Filter(func(res *CrawlResult) bool { return res.Success })
+}
+
+// Failed returns the results that did not crawl successfully.
+func (r *RunManyResult) Failed() []*CrawlResult {
+	return r.Filter(func(res *CrawlResult) bool { return !res.Success })
+}
+
+// ByStatusCode returns the results whose page answered with code.
+func (r *RunManyResult) ByStatusCode(code int) []*CrawlResult {
+	return r.Filter(func(res *CrawlResult) bool { return res.StatusCode == code })
+}
+
+// ByDomain returns the results on domain or one of its subdomains.
+func (r *RunManyResult) ByDomain(domain string) []*CrawlResult {
+	return r.Filter(func(res *CrawlResult) bool {
+		u, err := url.Parse(res.URL)
+		return err == nil && domainAllowed(u.Hostname(), []string{domain})
+	})
+}
+
+// Filter returns the results keep accepts, in order.
+func (r *RunManyResult) Filter(keep func(*CrawlResult) bool) []*CrawlResult {
+	var out []*CrawlResult
+	for _, res := range r.results() {
+		if res != nil && keep(res) {
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// URLs returns the URL of every result, in order, skipping the nil
+// entries of aligned results.
+func (r *RunManyResult) URLs() []string {
+	var urls []string
+	for _, res := range r.results() {
+		if res != nil {
+			urls = append(urls, res.URL)
+		}
+	}
+	return urls
+}
+
+// Find returns the result for rawURL, or nil. URLs match exactly first,
+// then ignoring host case, fragment and a trailing slash, and finally by
+// the result's RedirectedURL or redirect chain.
+func (r *RunManyResult) Find(rawURL string) *CrawlResult {
+	results := r.results()
+	if i := matchResult(rawURL, results, nil); i >= 0 {
+		return results[i]
+	}
+	return nil
+}
+
+// MissingURLs returns the input URLs the job returned no result for, in
+// input order. Results whose URL the server normalised (host case, a
+// trailing slash) or that redirected are matched to their input first, so
+// only genuinely dropped URLs are reported. Empty before results are
+// available.
+func (r *RunManyResult) MissingURLs() []string {
+	results := r.results()
+	if len(results) == 0 {
+		return nil
+	}
+	var missing []string
+	for i, res := range alignResults(r.inputs, results) {
+		if res == nil {
+			missing = append(missing, r.inputs[i])
+		}
+	}
+	return missing
+}
+
+// alignResults orders results to match inputs, each result used at most
+// once; inputs without a result get nil.
+func alignResults(inputs []string, results []*CrawlResult) []*CrawlResult {
+	used := make([]bool, len(results))
+	aligned := make([]*CrawlResult, len(inputs))
+	// Exact matches first, so a normalised match can't steal a result
+	// another input names exactly.
+	for i, u := range inputs {
+		for j, res := range results {
+			if !used[j] && res != nil && res.URL == u {
+				aligned[i], used[j] = res, true
+				break
+			}
+		}
+	}
+	for i, u := range inputs {
+		if aligned[i] != nil {
+			continue
+		}
+		if j := matchResult(u, results, used); j >= 0 {
+			aligned[i], used[j] = results[j], true
+		}
+	}
+	return aligned
+}
+
+// matchResult returns the index of the first unused result for rawURL:
+// matching exactly, then ignoring host case, fragment and a trailing
+// slash, then by RedirectedURL or any hop of RedirectChain. -1 when none
+// match.
+func matchResult(rawURL string, results []*CrawlResult, used []bool) int {
+	free := func(j int) bool { return results[j] != nil && (used == nil || !used[j]) }
+	for j, res := range results {
+		if free(j) && res.URL == rawURL {
+			return j
+		}
+	}
+	key := linkKey(rawURL)
+	for j, res := range results {
+		if free(j) && linkKey(res.URL) == key {
+			return j
+		}
+	}
+	for j, res := range results {
+		if !free(j) {
+			continue
+		}
+		if res.RedirectedURL != "" && linkKey(res.RedirectedURL) == key {
+			return j
+		}
+		for _, hop := range res.RedirectChain {
+			if linkKey(hop.URL) == key {
+				return j
+			}
+		}
+	}
+	return -1
+}
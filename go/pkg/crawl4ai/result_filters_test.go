@@ -0,0 +1,84 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestRunManyResult_Filters(t *testing.T) {
+	r := &RunManyResult{Job: &CrawlJob{Results: []*CrawlResult{
+		{URL: "https://a.com/", Success: true, StatusCode: 200},
+		{URL: "https://blog.a.com/post", Success: true, StatusCode: 200, RedirectedURL: "https://blog.a.com/post-2"},
+		{URL: "https://b.com/x", Success: false, StatusCode: 404},
+		{URL: "https://notb.com/", Success: false, StatusCode: 500},
+	}}}
+
+	if got := r.Successful(); len(got) != 2 {
+		t.Fatalf("Successful: %d", len(got))
+	}
+	if got := r.Failed(); len(got) != 2 || got[0].URL != "https://b.com/x" {
+		t.Fatalf("Failed: %+v", got)
+	}
+	if got := r.ByStatusCode(404); len(got) != 1 {
+		t.Fatalf("ByStatusCode: %+v", got)
+	}
+	if got := r.ByDomain("a.com"); len(got) != 2 {
+		t.Fatalf("ByDomain(a.com): %+v", got)
+	}
+	if got := r.ByDomain("b.com"); len(got) != 1 {
+		t.Fatalf("ByDomain(b.com) should not match notb.com: %+v", got)
+	}
+	if urls := r.URLs(); len(urls) != 4 || urls[3] != "https://notb.com/" {
+		t.Fatalf("URLs: %v", urls)
+	}
+	if res := r.Find("https://A.com"); res == nil || res.URL != "https://a.com/" {
+		t.Fatalf("Find normalized: %+v", res)
+	}
+	if res := r.Find("https://blog.a.com/post-2"); res == nil || res.URL != "https://blog.a.com/post" {
+		t.Fatalf("Find by redirect: %+v", res)
+	}
+	if r.Find("https://c.com") != nil {
+		t.Fatal("Find should return nil for unknown URLs")
+	}
+}
+
+func TestRunManyResult_AlignAndMissing(t *testing.T) {
+	inputs := []string{"https://a.com/x/", "https://b.com", "https://old.c.com/p", "https://d.com"}
+	results := []*CrawlResult{
+		{URL: "https://new.c.com/p", RedirectChain: []RedirectHop{{URL: "https://old.c.com/p", StatusCode: 301}, {URL: "https://new.c.com/p", StatusCode: 200}}},
+		{URL: "https://b.com"},
+		{URL: "https://a.com/x"},
+	}
+	aligned := alignResults(inputs, results)
+	if aligned[0] != results[2] || aligned[1] != results[1] || aligned[2] != results[0] || aligned[3] != nil {
+		t.Fatalf("unexpected alignment: %+v", aligned)
+	}
+
+	r := &RunManyResult{Job: &CrawlJob{Results: results}, inputs: inputs}
+	if missing := r.MissingURLs(); len(missing) != 1 || missing[0] != "https://d.com" {
+		t.Fatalf("MissingURLs: %v", missing)
+	}
+	r.Results = aligned
+	if len(r.URLs()) != 3 || len(r.Successful())+len(r.Failed()) != 3 {
+		t.Fatal("helpers should skip nil aligned entries")
+	}
+}
+
+func TestRunMany_AlignResults(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl/async": map[string]interface{}{"job_id": "job_1", "status": "pending"},
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{"job_id": "job_1", "status": "completed", "results": []interface{}{
+			map[string]interface{}{"url": "https://b.com/", "success": true},
+			map[string]interface{}{"url": "https://a.com", "success": true},
+		}},
+	})
+	res, err := c.RunMany([]string{"https://a.com", "https://b.com", "https://c.com"}, &RunManyOptions{Wait: true, AlignResults: true, PollInterval: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Results) != 3 || res.Results[0].URL != "https://a.com" || res.Results[1].URL != "https://b.com/" || res.Results[2] != nil {
+		t.Fatalf("unexpected results: %+v", res.Results)
+	}
+	if missing := res.MissingURLs(); len(missing) != 1 || missing[0] != "https://c.com" {
+		t.Fatalf("MissingURLs: %v", missing)
+	}
+}
@@ -0,0 +1,202 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RetentionPolicy bounds how long an async job's stored results live.
+// It is sent with the job; deployments that don't apply retention
+// server-side leave it to the client — see RunRetentionDaemon.
+type RetentionPolicy struct {
+	// TTL deletes results this long after the job completes. 0 = no TTL.
+	TTL time.Duration
+	// DeleteAfterDownload deletes results once a download URL issued by
+	// DownloadURL has expired, i.e. after the caller had its chance to
+	// fetch the ZIP.
+	DeleteAfterDownload bool
+}
+
+func (p *RetentionPolicy) toMap() map[string]interface{} {
+	m := map[string]interface{}{}
+	if p.TTL > 0 {
+		m["ttl_seconds"] = int(p.TTL.Seconds())
+	}
+	if p.DeleteAfterDownload {
+		m["delete_after_download"] = true
+	}
+	return m
+}
+
+// retentionHonoured reports whether a job-creation response shows the
+// server took over retention (it echoes the policy or an expiry).
+func retentionHonoured(data map[string]interface{}) bool {
+	if _, ok := data["retention"]; ok {
+		return true
+	}
+	_, ok := data["expires_at"]
+	return ok
+}
+
+// retentionTracker holds jobs whose retention the client must enforce.
+type retentionTracker struct {
+	mu   sync.Mutex
+	jobs map[string]*retainedJob
+}
+
+type retainedJob struct {
+	policy         RetentionPolicy
+	downloadExpiry time.Time
+}
+
+// TrackRetention registers jobID for client-side retention. RunMany does
+// this automatically when the server doesn't apply the policy itself; call
+// it directly to re-register jobs after a restart, since tracking is kept
+// in memory.
+func (c *AsyncWebCrawler) TrackRetention(jobID string, policy RetentionPolicy) {
+	c.retention.mu.Lock()
+	defer c.retention.mu.Unlock()
+	if c.retention.jobs == nil {
+		c.retention.jobs = make(map[string]*retainedJob)
+	}
+	c.retention.jobs[jobID] = &retainedJob{policy: policy}
+}
+
+// RetainedJobs lists the job IDs awaiting client-side retention, sorted.
+func (c *AsyncWebCrawler) RetainedJobs() []string {
+	c.retention.mu.Lock()
+	defer c.retention.mu.Unlock()
+	ids := make([]string, 0, len(c.retention.jobs))
+	for id := range c.retention.jobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// markDownloaded notes that a download URL valid until expiry was issued.
+func (c *AsyncWebCrawler) markDownloaded(jobID string, expiry time.Time) {
+	c.retention.mu.Lock()
+	defer c.retention.mu.Unlock()
+	if j, ok := c.retention.jobs[jobID]; ok && expiry.After(j.downloadExpiry) {
+		j.downloadExpiry = expiry
+	}
+}
+
+// DownloadURL returns a presigned URL for a job's result ZIP, valid for
+// expiresIn (default 1h).
+func (c *AsyncWebCrawler) DownloadURL(jobID string, expiresIn time.Duration) (string, error) {
+	if expiresIn <= 0 {
+		expiresIn = time.Hour
+	}
+	params := map[string]string{"expires_in": fmt.Sprintf("%d", int(expiresIn.Seconds()))}
+	data, err := c.http.Get(fmt.Sprintf("/v1/crawl/jobs/%s/download", jobID), params)
+	if err != nil {
+		return "", err
+	}
+	u, _ := data["download_url"].(string)
+	if u == "" {
+		return "", NewCloudError("download URL missing from response", 0, data, nil)
+	}
+	c.markDownloaded(jobID, time.Now().Add(expiresIn))
+	return u, nil
+}
+
+// SweepRetention applies client-side retention once: every tracked job
+// whose TTL has elapsed, or whose download URL has expired under
+// DeleteAfterDownload, has its results deleted. Returns the deleted job
+// IDs. Jobs that no longer exist are dropped from tracking.
+func (c *AsyncWebCrawler) SweepRetention() ([]string, error) {
+	c.retention.mu.Lock()
+	due := make(map[string]retainedJob, len(c.retention.jobs))
+	for id, j := range c.retention.jobs {
+		due[id] = *j
+	}
+	c.retention.mu.Unlock()
+
+	now := time.Now()
+	var deleted []string
+	var firstErr error
+	for id, j := range due {
+		expired, err := c.retentionExpired(id, j, now)
+		if err == nil && expired {
+			err = c.deleteJobResults(id)
+			if err == nil {
+				deleted = append(deleted, id)
+			}
+		}
+		var notFound *NotFoundError
+		switch {
+		case errors.As(err, &notFound) || (err == nil && expired):
+			c.retention.mu.Lock()
+			delete(c.retention.jobs, id)
+			c.retention.mu.Unlock()
+		case err != nil && firstErr == nil:
+			firstErr = fmt.Errorf("retention for job %s: %w", id, err)
+		}
+	}
+	sort.Strings(deleted)
+	return deleted, firstErr
+}
+
+func (c *AsyncWebCrawler) retentionExpired(jobID string, j retainedJob, now time.Time) (bool, error) {
+	if j.policy.DeleteAfterDownload && !j.downloadExpiry.IsZero() && now.After(j.downloadExpiry) {
+		return true, nil
+	}
+	if j.policy.TTL <= 0 {
+		return false, nil
+	}
+	job, err := c.GetJob(jobID)
+	if err != nil {
+		return false, err
+	}
+	if !job.IsComplete() {
+		return false, nil
+	}
+	if job.CompletedAt.IsZero() {
+		// No usable completion time — count the TTL from now on.
+		return false, nil
+	}
+	return now.Sub(job.CompletedAt) >= j.policy.TTL, nil
+}
+
+// deleteJobResults removes a finished job and its stored results. Without
+// delete_results=true the same DELETE only cancels the job and the results
+// stay in storage.
+func (c *AsyncWebCrawler) deleteJobResults(jobID string) error {
+	_, err := c.http.Request(RequestOptions{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("/v1/crawl/jobs/%s", jobID),
+		Params: map[string]string{"delete_results": "true"},
+	})
+	return err
+}
+
+// RunRetentionDaemon calls SweepRetention every interval (default 10m)
+// until ctx is done, then returns ctx.Err(). Sweep errors are passed to
+// onError when non-nil and otherwise ignored; the next sweep retries.
+//
+//	go crawler.RunRetentionDaemon(ctx, 15*time.Minute, func(err error) {
+//	    log.Printf("retention: %v", err)
+//	})
+func (c *AsyncWebCrawler) RunRetentionDaemon(ctx context.Context, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := c.SweepRetention(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package crawl4ai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRetention_TrackedWhenServerIgnoresPolicy(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl/async": map[string]interface{}{"job_id": "job_1", "status": "pending"},
+	})
+	policy := &RetentionPolicy{TTL: 24 * time.Hour}
+	if _, err := c.RunMany([]string{"https://a.com"}, &RunManyOptions{Retention: policy}); err != nil {
+		t.Fatalf("RunMany: %v", err)
+	}
+	if got := c.RetainedJobs(); len(got) != 1 || got[0] != "job_1" {
+		t.Fatalf("expected job_1 tracked, got %v", got)
+	}
+}
+
+func TestRetention_NotTrackedWhenServerHonoursPolicy(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl/async": map[string]interface{}{
+			"job_id": "job_1", "status": "pending", "retention": map[string]interface{}{"ttl_seconds": 86400},
+		},
+	})
+	if _, err := c.RunMany([]string{"https://a.com"}, &RunManyOptions{Retention: &RetentionPolicy{TTL: 24 * time.Hour}}); err != nil {
+		t.Fatalf("RunMany: %v", err)
+	}
+	if got := c.RetainedJobs(); len(got) != 0 {
+		t.Fatalf("expected nothing tracked, got %v", got)
+	}
+}
+
+func TestRetention_SweepDeletesExpired(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/old":    map[string]interface{}{"job_id": "old", "status": "completed", "completed_at": "2020-01-01T00:00:00Z"},
+		"GET /v1/crawl/jobs/fresh":  map[string]interface{}{"job_id": "fresh", "status": "completed", "completed_at": time.Now().UTC().Format(time.RFC3339)},
+		"GET /v1/crawl/jobs/run":    map[string]interface{}{"job_id": "run", "status": "running"},
+		"DELETE /v1/crawl/jobs/old": map[string]interface{}{},
+	})
+	ttl := RetentionPolicy{TTL: time.Hour}
+	for _, id := range []string{"old", "fresh", "run", "gone"} {
+		c.TrackRetention(id, ttl)
+	}
+
+	deleted, err := c.SweepRetention()
+	if err != nil {
+		t.Fatalf("SweepRetention: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "old" {
+		t.Fatalf("expected only old deleted, got %v", deleted)
+	}
+	got := c.RetainedJobs()
+	if len(got) != 2 || got[0] != "fresh" || got[1] != "run" {
+		t.Fatalf("expected fresh and run still tracked (gone dropped), got %v", got)
+	}
+}
+
+func TestRetention_DeleteAfterDownload(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1/download": map[string]interface{}{"download_url": "https://s3.example/job_1.zip"},
+		"DELETE /v1/crawl/jobs/job_1":       map[string]interface{}{},
+	})
+	c.TrackRetention("job_1", RetentionPolicy{DeleteAfterDownload: true})
+
+	if deleted, _ := c.SweepRetention(); len(deleted) != 0 {
+		t.Fatalf("expected nothing deleted before download, got %v", deleted)
+	}
+	u, err := c.DownloadURL("job_1", time.Nanosecond)
+	if err != nil || u != "https://s3.example/job_1.zip" {
+		t.Fatalf("DownloadURL: %q %v", u, err)
+	}
+	time.Sleep(time.Millisecond)
+	if deleted, err := c.SweepRetention(); err != nil || len(deleted) != 1 {
+		t.Fatalf("expected job deleted after URL expiry, got %v %v", deleted, err)
+	}
+}
+
+func TestRetention_SweepAsksServerToDeleteResults(t *testing.T) {
+	var deletes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"job_id": "old", "status": "completed", "completed_at": "2020-01-01T00:00:00Z"}`))
+		case http.MethodDelete:
+			deletes = append(deletes, r.URL.Path+"?"+r.URL.RawQuery)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.TrackRetention("old", RetentionPolicy{TTL: time.Hour})
+
+	if _, err := c.SweepRetention(); err != nil {
+		t.Fatalf("SweepRetention: %v", err)
+	}
+	// A bare DELETE only cancels; results are freed only with delete_results.
+	if len(deletes) != 1 || deletes[0] != "/v1/crawl/jobs/old?delete_results=true" {
+		t.Fatalf("expected DELETE with delete_results=true, got %v", deletes)
+	}
+}
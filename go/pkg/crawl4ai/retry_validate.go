@@ -0,0 +1,142 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResultValidator checks a crawl result's content and returns an error
+// describing what is wrong with it — a thin page, a captcha interstitial,
+// a consent wall. Set RunOptions.Validator to have Run retry failing
+// results with escalated profiles.
+//
+//	result, err := crawler.Run(url, &crawl4ai.RunOptions{
+//	    Validator: crawl4ai.AllValidators(
+//	        crawl4ai.MinWords(200),
+//	        crawl4ai.RejectPhrases("verify you are human", "access denied"),
+//	    ),
+//	})
+//	var rejected *crawl4ai.ResultRejectedError
+//	if errors.As(err, &rejected) {
+//	    log.Printf("%s still invalid after %d attempts: %v", url, rejected.Attempts, rejected.Err)
+//	}
+type ResultValidator func(*CrawlResult) error
+
+// RetryProfile is one escalation step tried after a result fails
+// validation. Zero fields keep the value from the original RunOptions.
+type RetryProfile struct {
+	Strategy      string
+	Proxy         interface{}
+	BrowserConfig *BrowserConfig
+}
+
+// DefaultEscalation is used when RunOptions.Escalation is nil: the browser
+// strategy through a datacenter proxy, then through a residential one.
+var DefaultEscalation = []RetryProfile{
+	{Strategy: "browser", Proxy: "datacenter"},
+	{Strategy: "browser", Proxy: "residential"},
+}
+
+// ResultRejectedError is returned by Run when every attempt failed
+// validation. Result is the last attempt's result.
+type ResultRejectedError struct {
+	Result   *CrawlResult
+	Attempts int
+	Err      error
+}
+
+func (e *ResultRejectedError) Error() string {
+	return fmt.Sprintf("result for %s failed validation after %d attempts: %v", e.Result.URL, e.Attempts, e.Err)
+}
+
+func (e *ResultRejectedError) Unwrap() error { return e.Err }
+
+// MinWords rejects results whose markdown has fewer than n words.
+func MinWords(n int) ResultValidator {
+	return func(r *CrawlResult) error {
+		words := markdownWords(r)
+		if words < n {
+			return fmt.Errorf("markdown has %d words, want at least %d", words, n)
+		}
+		return nil
+	}
+}
+
+// markdownWords counts the words in r's raw markdown.
+func markdownWords(r *CrawlResult) int {
+	if r.Markdown == nil {
+		return 0
+	}
+	return len(strings.Fields(r.Markdown.RawMarkdown))
+}
+
+// RejectPhrases rejects results whose markdown or HTML contains any of the
+// phrases, compared case-insensitively.
+func RejectPhrases(phrases ...string) ResultValidator {
+	return func(r *CrawlResult) error {
+		text := strings.ToLower(r.HTML)
+		if r.Markdown != nil {
+			text += "\n" + strings.ToLower(r.Markdown.RawMarkdown)
+		}
+		for _, p := range phrases {
+			if strings.Contains(text, strings.ToLower(p)) {
+				return fmt.Errorf("content contains %q", p)
+			}
+		}
+		return nil
+	}
+}
+
+// AllValidators combines validators, failing with the first error.
+func AllValidators(validators ...ResultValidator) ResultValidator {
+	return func(r *CrawlResult) error {
+		for _, v := range validators {
+			if err := v(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// runValidated runs url, then retries through the escalation profiles
+// until a result passes opts.Validator. Retries bypass the cache so a
+// stored bad page isn't served again.
+func (c *AsyncWebCrawler) runValidated(url string, opts *RunOptions) (*CrawlResult, error) {
+	profiles := opts.Escalation
+	if profiles == nil {
+		profiles = DefaultEscalation
+	}
+	result, err := c.runOnce(url, opts)
+	if err != nil {
+		return nil, err
+	}
+	verr := opts.Validator(result)
+	attempts := 1
+	for _, p := range profiles {
+		if verr == nil {
+			return result, nil
+		}
+		o := *opts
+		o.BypassCache = true
+		if p.Strategy != "" {
+			o.Strategy = p.Strategy
+		}
+		if p.Proxy != nil {
+			o.Proxy = p.Proxy
+		}
+		if p.BrowserConfig != nil {
+			o.BrowserConfig = p.BrowserConfig
+		}
+		result, err = c.runOnce(url, &o)
+		if err != nil {
+			return nil, err
+		}
+		attempts++
+		verr = opts.Validator(result)
+	}
+	if verr == nil {
+		return result, nil
+	}
+	return nil, &ResultRejectedError{Result: result, Attempts: attempts, Err: verr}
+}
@@ -0,0 +1,69 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestValidators(t *testing.T) {
+	r := &CrawlResult{HTML: "<p>Please VERIFY you are human</p>", Markdown: &MarkdownResult{RawMarkdown: "one two three"}}
+	if err := MinWords(3)(r); err != nil {
+		t.Fatalf("MinWords(3): %v", err)
+	}
+	if err := MinWords(4)(r); err == nil {
+		t.Fatal("expected MinWords(4) to fail")
+	}
+	if err := RejectPhrases("verify you are human")(r); err == nil {
+		t.Fatal("expected RejectPhrases to match case-insensitively")
+	}
+	if err := AllValidators(MinWords(1), RejectPhrases("access denied"))(r); err != nil {
+		t.Fatalf("AllValidators: %v", err)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRun_ValidatorEscalates(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		md := "Checking your browser"
+		if proxy, _ := body["proxy"].(map[string]interface{}); proxy["mode"] == "residential" {
+			md = "the real article text"
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://a.com", "success": true, "markdown": md})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := c.Run("https://a.com", &RunOptions{Strategy: "http", Validator: RejectPhrases("checking your browser")})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Markdown.RawMarkdown != "the real article text" || len(bodies) != 3 {
+		t.Fatalf("expected success on the third attempt, got %d attempts: %+v", len(bodies), result.Markdown)
+	}
+	if bodies[0]["strategy"] != "http" || bodies[1]["strategy"] != "browser" || bodies[1]["bypass_cache"] != true {
+		t.Fatalf("unexpected escalation requests: %v", bodies)
+	}
+
+	bodies = nil
+	_, err = c.Run("https://a.com", &RunOptions{
+		Validator:  MinWords(50),
+		Escalation: []RetryProfile{{Proxy: "datacenter"}},
+	})
+	var rejected *ResultRejectedError
+	if !errors.As(err, &rejected) || rejected.Attempts != 2 || len(bodies) != 2 {
+		t.Fatalf("expected ResultRejectedError after 2 attempts, got %v (%d requests)", err, len(bodies))
+	}
+}
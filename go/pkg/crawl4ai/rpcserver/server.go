@@ -0,0 +1,390 @@
+// Package rpcserver exposes the SDK as a Connect-protocol service, so
+// internal services in other languages can crawl through one Go process
+// that owns the API key, retries, rate limiting and quota handling instead
+// of each reimplementing them.
+//
+//	crawler, _ := crawl4ai.NewAsyncWebCrawler(crawl4ai.CrawlerOptions{})
+//	srv := rpcserver.New(crawler, rpcserver.Options{
+//	    Authorize: func(r *http.Request) error {
+//	        if r.Header.Get("Authorization") != "Bearer "+internalToken {
+//	            return errors.New("bad token")
+//	        }
+//	        return nil
+//	    },
+//	})
+//	http.Handle(rpcserver.ServicePath, srv)
+//
+// The service is described by crawler.proto in this directory; generate a
+// Connect client from it (connect-go, connect-es, connect-python, ...) or
+// call it with a plain JSON POST:
+//
+//	curl -H 'Content-Type: application/json' -d '{"url":"https://example.com"}' \
+//	    http://localhost:8080/crawl4ai.v1.CrawlerService/Run
+//
+// Only unary calls with the JSON codec are served. The binary protobuf
+// codec and native gRPC framing need generated code this dependency-free
+// module does not carry; clients that can only speak gRPC should go
+// through a Connect-aware proxy. Configs and results travel as
+// google.protobuf.Struct, in the same JSON shape the cloud API uses.
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ServicePath is the route prefix of every method; mount the Server on it.
+const ServicePath = "/crawl4ai.v1.CrawlerService/"
+
+// Options configures a Server.
+type Options struct {
+	// Authorize vets each call before it reaches the API, for example by
+	// checking a shared token or the client certificate. A non-nil error
+	// answers "unauthenticated". nil lets every call through, so only leave
+	// it unset behind a trusted network boundary.
+	Authorize func(r *http.Request) error
+	// MaxBodyBytes caps a request body. Default 8 MiB.
+	MaxBodyBytes int64
+}
+
+// Server is an http.Handler serving the CrawlerService methods.
+type Server struct {
+	crawler *crawl4ai.AsyncWebCrawler
+	opts    Options
+	methods map[string]method
+}
+
+type method func(c *crawl4ai.AsyncWebCrawler, req json.RawMessage) (interface{}, error)
+
+// New returns a Server that makes its calls through crawler.
+func New(crawler *crawl4ai.AsyncWebCrawler, opts Options) *Server {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 8 << 20
+	}
+	return &Server{crawler: crawler, opts: opts, methods: map[string]method{
+		"Run":        run,
+		"RunMany":    runMany,
+		"GetJob":     getJob,
+		"WaitJob":    waitJob,
+		"CancelJob":  cancelJob,
+		"JobResults": jobResults,
+		"Storage":    storage,
+		"Health":     health,
+	}}
+}
+
+// ServeHTTP implements the Connect unary protocol for JSON requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "unimplemented", "only POST is supported")
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, ServicePath)
+	m, ok := s.methods[name]
+	if !ok || name == r.URL.Path {
+		writeError(w, http.StatusNotFound, "unimplemented", fmt.Sprintf("unknown method %q", r.URL.Path))
+		return
+	}
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, "unimplemented", "only the JSON codec (application/json) is supported")
+		return
+	}
+	if s.opts.Authorize != nil {
+		if err := s.opts.Authorize(r); err != nil {
+			writeError(w, http.StatusUnauthorized, "unauthenticated", err.Error())
+			return
+		}
+	}
+
+	ctx := r.Context()
+	if ms := r.Header.Get("Connect-Timeout-Ms"); ms != "" {
+		n, err := strconv.ParseInt(ms, 10, 64)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid_argument", "invalid Connect-Timeout-Ms")
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(n)*time.Millisecond)
+		defer cancel()
+	}
+	if id := r.Header.Get("X-Request-ID"); id != "" {
+		ctx = crawl4ai.WithRequestID(ctx, id)
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.opts.MaxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "resource_exhausted", "request body too large or unreadable")
+		return
+	}
+	req, err := normalizeRequest(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_argument", err.Error())
+		return
+	}
+	out, err := m(s.crawler.WithContext(ctx), req)
+	if err != nil {
+		code, status := errorCode(err)
+		if ctx.Err() != nil {
+			// The deadline or the client's disconnect, not the API, ended
+			// the call.
+			code, status = errorCode(ctx.Err())
+		}
+		writeError(w, status, code, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// normalizeRequest rewrites the top-level field names of a request to
+// their proto (snake_case) spelling: Connect clients send the lowerCamelCase
+// JSON names by default. Nested configs are passed through as written.
+func normalizeRequest(body []byte) (json.RawMessage, error) {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return json.RawMessage("{}"), nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("invalid JSON request: %w", err)
+	}
+	out := make(map[string]json.RawMessage, len(fields))
+	for k, v := range fields {
+		out[snakeCase(k)] = v
+	}
+	return json.Marshal(out)
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func decode(req json.RawMessage, v interface{}) error {
+	dec := json.NewDecoder(strings.NewReader(string(req)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return &requestError{err}
+	}
+	return nil
+}
+
+// requestError marks a request the server could not decode.
+type requestError struct{ err error }
+
+func (e *requestError) Error() string { return "invalid request: " + e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+type crawlFields struct {
+	Config        *crawl4ai.CrawlerRunConfig `json:"config"`
+	BrowserConfig *crawl4ai.BrowserConfig    `json:"browser_config"`
+	Strategy      string                     `json:"strategy"`
+	BypassCache   bool                       `json:"bypass_cache"`
+}
+
+func run(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	var req struct {
+		URL string `json:"url"`
+		crawlFields
+	}
+	if err := decode(raw, &req); err != nil {
+		return nil, err
+	}
+	if req.URL == "" {
+		return nil, &requestError{errors.New("url is required")}
+	}
+	res, err := c.Run(req.URL, &crawl4ai.RunOptions{
+		Config: req.Config, BrowserConfig: req.BrowserConfig, Strategy: req.Strategy, BypassCache: req.BypassCache,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"result": res}, nil
+}
+
+func runMany(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	var req struct {
+		URLs []string `json:"urls"`
+		crawlFields
+		Wait           bool   `json:"wait"`
+		Priority       int    `json:"priority"`
+		WebhookURL     string `json:"webhook_url"`
+		TimeoutSeconds int    `json:"timeout_seconds"`
+	}
+	if err := decode(raw, &req); err != nil {
+		return nil, err
+	}
+	if len(req.URLs) == 0 {
+		return nil, &requestError{errors.New("urls is required")}
+	}
+	res, err := c.RunMany(req.URLs, &crawl4ai.RunManyOptions{
+		Config: req.Config, BrowserConfig: req.BrowserConfig, Strategy: req.Strategy, BypassCache: req.BypassCache,
+		Wait: req.Wait, Priority: crawl4ai.Priority(req.Priority), WebhookURL: req.WebhookURL,
+		Timeout: time.Duration(req.TimeoutSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"job": res.Job, "results": nonNil(res.Results)}, nil
+}
+
+type jobRequest struct {
+	JobID string `json:"job_id"`
+}
+
+func (r jobRequest) check() error {
+	if r.JobID == "" {
+		return &requestError{errors.New("job_id is required")}
+	}
+	return nil
+}
+
+func getJob(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	var req jobRequest
+	if err := decode(raw, &req); err != nil {
+		return nil, err
+	}
+	if err := req.check(); err != nil {
+		return nil, err
+	}
+	job, err := c.GetJob(req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"job": job}, nil
+}
+
+func waitJob(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	var req struct {
+		jobRequest
+		TimeoutSeconds      int `json:"timeout_seconds"`
+		PollIntervalSeconds int `json:"poll_interval_seconds"`
+	}
+	if err := decode(raw, &req); err != nil {
+		return nil, err
+	}
+	if err := req.check(); err != nil {
+		return nil, err
+	}
+	job, err := c.WaitJob(req.JobID, time.Duration(req.PollIntervalSeconds)*time.Second, time.Duration(req.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"job": job}, nil
+}
+
+func cancelJob(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	var req jobRequest
+	if err := decode(raw, &req); err != nil {
+		return nil, err
+	}
+	if err := req.check(); err != nil {
+		return nil, err
+	}
+	if err := c.CancelJob(req.JobID); err != nil {
+		return nil, err
+	}
+	return struct{}{}, nil
+}
+
+func jobResults(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	var req jobRequest
+	if err := decode(raw, &req); err != nil {
+		return nil, err
+	}
+	if err := req.check(); err != nil {
+		return nil, err
+	}
+	results, err := c.JobResults(req.JobID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"results": nonNil(results)}, nil
+}
+
+func storage(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	if err := decode(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	return c.Storage()
+}
+
+func health(c *crawl4ai.AsyncWebCrawler, raw json.RawMessage) (interface{}, error) {
+	if err := decode(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	h, err := c.Health()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"status": h}, nil
+}
+
+func nonNil(results []*crawl4ai.CrawlResult) []*crawl4ai.CrawlResult {
+	if results == nil {
+		return []*crawl4ai.CrawlResult{}
+	}
+	return results
+}
+
+// errorCode maps an SDK error to a Connect error code and HTTP status.
+func errorCode(err error) (code string, status int) {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr):
+		return "invalid_argument", http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded", http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		return "canceled", 499
+	}
+	ce, ok := crawl4ai.AsCloudError(err)
+	if !ok {
+		return "unknown", http.StatusInternalServerError
+	}
+	switch s := ce.StatusCode; {
+	case s == http.StatusBadRequest || s == http.StatusUnprocessableEntity:
+		return "invalid_argument", http.StatusBadRequest
+	case s == http.StatusUnauthorized:
+		return "unauthenticated", http.StatusUnauthorized
+	case s == http.StatusForbidden:
+		return "permission_denied", http.StatusForbidden
+	case s == http.StatusNotFound:
+		return "not_found", http.StatusNotFound
+	case s == http.StatusConflict:
+		return "already_exists", http.StatusConflict
+	case s == http.StatusPaymentRequired || s == http.StatusTooManyRequests:
+		return "resource_exhausted", http.StatusTooManyRequests
+	case s == http.StatusRequestTimeout || s == http.StatusGatewayTimeout:
+		return "deadline_exceeded", http.StatusGatewayTimeout
+	case s >= 500:
+		return "unavailable", http.StatusServiceUnavailable
+	}
+	return "unknown", http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, status int, code, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": code, "message": msg})
+}
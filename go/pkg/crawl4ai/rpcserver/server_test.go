@@ -0,0 +1,160 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ─── Unit tests (in-process sandbox) ─────────────────────────────────────
+
+func newServer(t *testing.T, opts Options) *httptest.Server {
+	t.Helper()
+	c, err := crawl4ai.NewAsyncWebCrawler(crawl4ai.CrawlerOptions{APIKey: "sk_test_sandbox", Sandbox: true, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(ServicePath, New(c, opts))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func call(t *testing.T, srv *httptest.Server, method, body string, header http.Header) (int, map[string]interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+ServicePath+method, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var out map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("%s: decode: %v", method, err)
+	}
+	return resp.StatusCode, out
+}
+
+func TestServer_Run(t *testing.T) {
+	srv := newServer(t, Options{})
+	status, out := call(t, srv, "Run", `{"url":"https://example.com/docs","config":{"word_count_threshold":5}}`, nil)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, body %v", status, out)
+	}
+	res, _ := out["result"].(map[string]interface{})
+	if res["url"] != "https://example.com/docs" || res["success"] != true {
+		t.Errorf("result = %v", res)
+	}
+}
+
+func TestServer_RunManyAndJobCalls(t *testing.T) {
+	srv := newServer(t, Options{})
+	// Connect clients send lowerCamelCase field names.
+	status, out := call(t, srv, "RunMany", `{"urls":["https://example.com/a","https://example.com/b"],"bypassCache":true}`, nil)
+	if status != http.StatusOK {
+		t.Fatalf("RunMany status = %d, body %v", status, out)
+	}
+	job, _ := out["job"].(map[string]interface{})
+	id, _ := job["job_id"].(string)
+	if id == "" {
+		t.Fatalf("no job id in %v", out)
+	}
+
+	status, out = call(t, srv, "WaitJob", `{"jobId":"`+id+`","timeoutSeconds":5}`, nil)
+	if status != http.StatusOK || out["job"].(map[string]interface{})["status"] != "completed" {
+		t.Fatalf("WaitJob = %d %v", status, out)
+	}
+	status, out = call(t, srv, "JobResults", `{"job_id":"`+id+`"}`, nil)
+	if results, _ := out["results"].([]interface{}); status != http.StatusOK || len(results) != 2 {
+		t.Fatalf("JobResults = %d %v", status, out)
+	}
+	if status, out = call(t, srv, "CancelJob", `{"job_id":"`+id+`"}`, nil); status != http.StatusOK {
+		t.Fatalf("CancelJob = %d %v", status, out)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	srv := newServer(t, Options{})
+	cases := []struct {
+		method, body string
+		status       int
+		code         string
+	}{
+		{"GetJob", `{"job_id":"missing"}`, http.StatusNotFound, "not_found"},
+		{"GetJob", `{}`, http.StatusBadRequest, "invalid_argument"},
+		{"Run", `{"url":"https://example.com","nope":1}`, http.StatusBadRequest, "invalid_argument"},
+		{"Run", `not json`, http.StatusBadRequest, "invalid_argument"},
+		{"Crawl", `{}`, http.StatusNotFound, "unimplemented"},
+	}
+	for _, tc := range cases {
+		status, out := call(t, srv, tc.method, tc.body, nil)
+		if status != tc.status || out["code"] != tc.code || out["message"] == "" {
+			t.Errorf("%s %s = %d %v, want %d %s", tc.method, tc.body, status, out, tc.status, tc.code)
+		}
+	}
+
+	resp, err := http.Post(srv.URL+ServicePath+"Health", "application/proto", strings.NewReader(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("proto codec status = %d, want 415", resp.StatusCode)
+	}
+}
+
+func TestServer_Authorize(t *testing.T) {
+	srv := newServer(t, Options{Authorize: func(r *http.Request) error {
+		if r.Header.Get("Authorization") != "Bearer internal" {
+			return errors.New("bad token")
+		}
+		return nil
+	}})
+	status, out := call(t, srv, "Health", `{}`, nil)
+	if status != http.StatusUnauthorized || out["code"] != "unauthenticated" {
+		t.Errorf("no token = %d %v", status, out)
+	}
+	status, out = call(t, srv, "Health", `{}`, http.Header{"Authorization": {"Bearer internal"}})
+	if status != http.StatusOK || out["status"] == nil {
+		t.Errorf("with token = %d %v", status, out)
+	}
+}
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestErrorCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code string
+	}{
+		{crawl4ai.NewCloudError("bad", 422, nil, nil), "invalid_argument"},
+		{crawl4ai.NewCloudError("key", 401, nil, nil), "unauthenticated"},
+		{crawl4ai.NewCloudError("slow down", 429, nil, nil), "resource_exhausted"},
+		{crawl4ai.NewTimeoutError("waited"), "deadline_exceeded"},
+		{crawl4ai.NewCloudError("down", 503, nil, nil), "unavailable"},
+		{errors.New("local"), "unknown"},
+	}
+	for _, tc := range cases {
+		if code, _ := errorCode(tc.err); code != tc.code {
+			t.Errorf("errorCode(%v) = %s, want %s", tc.err, code, tc.code)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{"jobId": "job_id", "browserConfig": "browser_config", "url": "url", "job_id": "job_id"} {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
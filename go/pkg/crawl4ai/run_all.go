@@ -0,0 +1,82 @@
+package crawl4ai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// RunFunc returns Run(url, opts) as a func() error that stores the result
+// in *dst and is cancelled with ctx, for use with errgroup.Group.Go or any
+// other task runner:
+//
+//	g, ctx := errgroup.WithContext(ctx)
+//	g.SetLimit(8)
+//	results := make([]*crawl4ai.CrawlResult, len(urls))
+//	for i, u := range urls {
+//	    g.Go(crawler.RunFunc(ctx, u, nil, &results[i]))
+//	}
+//	err := g.Wait()
+//
+// RunAll does the same without the dependency.
+func (c *AsyncWebCrawler) RunFunc(ctx context.Context, url string, opts *RunOptions, dst **CrawlResult) func() error {
+	return func() error {
+		result, err := c.WithContext(ctx).Run(url, opts)
+		if err != nil {
+			return err
+		}
+		*dst = result
+		return nil
+	}
+}
+
+// RunAll crawls urls with Run, up to concurrency at a time (default 8),
+// for callers who want synchronous results without a batch job. Results
+// are in the order of urls. Like errgroup, the first error cancels the
+// crawls still running and is returned, wrapped with its URL; results
+// that finished are kept, the rest are nil. A crawl that reached the page
+// but failed (Success false) is a result, not an error.
+//
+//	results, err := crawler.RunAll(ctx, urls, &crawl4ai.RunOptions{Strategy: "http"}, 16)
+func (c *AsyncWebCrawler) RunAll(ctx context.Context, urls []string, opts *RunOptions, concurrency int) ([]*CrawlResult, error) {
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*CrawlResult, len(urls))
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for i, url := range urls {
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(url string, run func() error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := run(); err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("run %s: %w", url, err)
+					cancel()
+				})
+			}
+		}(url, c.RunFunc(ctx, url, opts, &results[i]))
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return results, firstErr
+}
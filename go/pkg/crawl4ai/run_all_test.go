@@ -0,0 +1,88 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunAll(t *testing.T) {
+	var inFlight, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		url, _ := body["url"].(string)
+		if strings.HasSuffix(url, "/denied") {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail": "bad key"}`))
+			return
+		}
+		if strings.HasSuffix(url, "/slow") {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": url, "success": true})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var urls []string
+	for i := 0; i < 12; i++ {
+		urls = append(urls, "https://example.com/"+string(rune('a'+i)))
+	}
+	results, err := c.RunAll(context.Background(), urls, nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range results {
+		if r == nil || r.URL != urls[i] {
+			t.Errorf("results[%d] = %+v, want %s", i, r, urls[i])
+		}
+	}
+	if p := atomic.LoadInt32(&peak); p > 3 {
+		t.Errorf("%d crawls in flight, limit 3", p)
+	}
+
+	// The first error cancels the rest.
+	started := time.Now()
+	results, err = c.RunAll(context.Background(), []string{"https://example.com/slow", "https://example.com/denied", "https://example.com/a"}, nil, 2)
+	var authErr *AuthenticationError
+	if !errors.As(err, &authErr) || !strings.Contains(err.Error(), "https://example.com/denied") {
+		t.Fatalf("err = %v", err)
+	}
+	if time.Since(started) > 2*time.Second {
+		t.Error("the slow crawl was not cancelled")
+	}
+	if results[0] != nil || results[1] != nil {
+		t.Errorf("results = %v", results)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.RunAll(ctx, urls, nil, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled context: err = %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunAsync_SubmitsSingleURLJob(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method+" "+r.URL.Path != "POST /v1/crawl/async" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "pending", "urls_count": 1})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := c.RunAsync("https://a.com", &RunAsyncOptions{Strategy: "http", WebhookURL: "https://hooks.test/done"})
+	if err != nil {
+		t.Fatalf("RunAsync: %v", err)
+	}
+	if job.JobID != "job_1" || job.Status != "pending" {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+	urls, _ := body["urls"].([]interface{})
+	if len(urls) != 1 || urls[0] != "https://a.com" || body["webhook_url"] != "https://hooks.test/done" || body["strategy"] != "http" {
+		t.Fatalf("unexpected request body: %v", body)
+	}
+
+	c.allowedDomains = []string{"b.com"}
+	if _, err := c.RunAsync("https://a.com", nil); err == nil {
+		t.Fatal("expected AllowedDomains to apply")
+	}
+}
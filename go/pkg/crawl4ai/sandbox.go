@@ -0,0 +1,228 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SandboxDomains are the hosts the sandbox serves fixtures for: the
+// RFC 2606 reserved names and their subdomains. Other URLs come back as
+// failed results so scripts written against the sandbox fail loudly
+// instead of pretending to crawl real sites.
+var SandboxDomains = []string{"example.com", "example.org", "example.net", "test", "example"}
+
+// sandboxTransport is an in-process stand-in for the API, installed when
+// CrawlerOptions.Sandbox is set. It answers the crawl endpoints with
+// deterministic fixtures — the same URL always yields the same result — and
+// never touches the network, so onboarding scripts and CI cost no credits.
+//
+// Served: POST /v1/crawl, POST /v1/crawl/async, GET and DELETE
+// /v1/crawl/jobs/{id}, GET /v1/crawl/jobs and GET /health. Anything else
+// answers 404 with code "sandbox_unsupported".
+type sandboxTransport struct {
+	mu   sync.Mutex
+	jobs map[string]map[string]interface{}
+	seq  int
+}
+
+func newSandboxTransport() *sandboxTransport {
+	return &sandboxTransport{jobs: map[string]map[string]interface{}{}}
+}
+
+// sandboxEnabled resolves CrawlerOptions.Sandbox, falling back to the
+// CRAWL4AI_SANDBOX environment variable so CI can flip it without a code
+// change.
+func sandboxEnabled(opt bool) bool {
+	if opt {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("CRAWL4AI_SANDBOX")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+func (t *sandboxTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	var body map[string]interface{}
+	if req.Body != nil {
+		raw, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return sandboxResponse(req, http.StatusBadRequest, map[string]interface{}{"detail": "invalid JSON body"}), nil
+			}
+		}
+	}
+
+	p := req.URL.Path
+	switch {
+	case req.Method == http.MethodGet && p == "/health":
+		return sandboxResponse(req, http.StatusOK, map[string]interface{}{"status": "healthy", "sandbox": true}), nil
+	case req.Method == http.MethodPost && p == "/v1/crawl":
+		u, _ := body["url"].(string)
+		return sandboxResponse(req, http.StatusOK, sandboxResult(u, body)), nil
+	case req.Method == http.MethodPost && p == "/v1/crawl/async":
+		return sandboxResponse(req, http.StatusOK, t.submit(body)), nil
+	case req.Method == http.MethodGet && p == "/v1/crawl/jobs":
+		return sandboxResponse(req, http.StatusOK, t.list()), nil
+	case strings.HasPrefix(p, "/v1/crawl/jobs/") && !strings.Contains(strings.TrimPrefix(p, "/v1/crawl/jobs/"), "/"):
+		id := strings.TrimPrefix(p, "/v1/crawl/jobs/")
+		t.mu.Lock()
+		job, ok := t.jobs[id]
+		if ok && req.Method == http.MethodDelete {
+			delete(t.jobs, id)
+		}
+		t.mu.Unlock()
+		if !ok {
+			return sandboxResponse(req, http.StatusNotFound, map[string]interface{}{"detail": fmt.Sprintf("job %s not found", id)}), nil
+		}
+		switch req.Method {
+		case http.MethodGet:
+			return sandboxResponse(req, http.StatusOK, job), nil
+		case http.MethodDelete:
+			return sandboxResponse(req, http.StatusOK, map[string]interface{}{"job_id": id, "deleted": true}), nil
+		}
+	}
+	return sandboxResponse(req, http.StatusNotFound, map[string]interface{}{"detail": map[string]interface{}{
+		"code":    ErrCodeSandboxUnsupported,
+		"message": fmt.Sprintf("sandbox mode does not simulate %s %s", req.Method, p),
+	}}), nil
+}
+
+// submit records an async crawl job. Sandbox jobs complete instantly, so
+// the first poll sees the final results.
+func (t *sandboxTransport) submit(body map[string]interface{}) map[string]interface{} {
+	var urls []interface{}
+	var results []interface{}
+	raw, _ := body["urls"].([]interface{})
+	for _, u := range raw {
+		if s, ok := u.(string); ok {
+			urls = append(urls, s)
+			results = append(results, sandboxResult(s, body))
+		}
+	}
+	failed := 0
+	for _, r := range results {
+		if ok, _ := r.(map[string]interface{})["success"].(bool); !ok {
+			failed++
+		}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.seq++
+	id := fmt.Sprintf("sandbox_job_%04d", t.seq)
+	t.jobs[id] = map[string]interface{}{
+		"job_id":       id,
+		"status":       string(JobStatusCompleted),
+		"urls":         urls,
+		"urls_count":   len(urls),
+		"created_at":   now,
+		"started_at":   now,
+		"completed_at": now,
+		"progress":     map[string]interface{}{"total": len(urls), "completed": len(urls) - failed, "failed": failed},
+		"results":      results,
+	}
+	return map[string]interface{}{"job_id": id, "status": string(JobStatusPending), "urls_count": len(urls), "created_at": now}
+}
+
+func (t *sandboxTransport) list() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ids := make([]string, 0, len(t.jobs))
+	for id := range t.jobs {
+		ids = append(ids, id)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	jobs := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		summary := map[string]interface{}{}
+		for k, v := range t.jobs[id] {
+			if k != "results" {
+				summary[k] = v
+			}
+		}
+		jobs = append(jobs, summary)
+	}
+	return map[string]interface{}{"jobs": jobs, "total": len(jobs)}
+}
+
+// sandboxResult is the fixture for one URL: a small page whose title,
+// markdown and links derive only from the URL.
+func sandboxResult(rawURL string, body map[string]interface{}) map[string]interface{} {
+	strategy, _ := body["strategy"].(string)
+	if strategy == "" {
+		strategy = "browser"
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || !domainAllowed(u.Hostname(), SandboxDomains) {
+		return map[string]interface{}{
+			"url":     rawURL,
+			"success": false,
+			"error_message": fmt.Sprintf("sandbox mode only serves %s and their subdomains; got %q",
+				strings.Join(SandboxDomains, ", "), rawURL),
+			"crawl_strategy": strategy,
+		}
+	}
+
+	base := u.Scheme + "://" + u.Host
+	title := "Example Domain"
+	if name := path.Base(u.Path); name != "/" && name != "." {
+		title = "Example Domain - " + name
+	}
+	links := []interface{}{
+		map[string]interface{}{"href": base + "/", "text": "Home"},
+		map[string]interface{}{"href": base + "/about", "text": "About"},
+		map[string]interface{}{"href": base + "/docs/getting-started", "text": "Getting started"},
+	}
+	markdown := fmt.Sprintf("# %s\n\nThis is a sandbox fixture for %s. "+
+		"It is served locally and costs no credits.\n\n"+
+		"- [Home](%s/)\n- [About](%s/about)\n- [Getting started](%s/docs/getting-started)\n",
+		title, rawURL, base, base, base)
+	html := fmt.Sprintf("<html><head><title>%s</title></head><body><h1>%s</h1>"+
+		"<p>This is a sandbox fixture for %s.</p>"+
+		`<a href="/">Home</a> <a href="/about">About</a> <a href="/docs/getting-started">Getting started</a>`+
+		"</body></html>", title, title, rawURL)
+
+	return map[string]interface{}{
+		"url":            rawURL,
+		"success":        true,
+		"status_code":    200,
+		"html":           html,
+		"cleaned_html":   html,
+		"markdown":       map[string]interface{}{"raw_markdown": markdown, "fit_markdown": markdown},
+		"metadata":       map[string]interface{}{"title": title, "sandbox": true},
+		"links":          map[string]interface{}{"internal": links, "external": []interface{}{}},
+		"crawl_strategy": strategy,
+	}
+}
+
+func sandboxResponse(req *http.Request, status int, payload map[string]interface{}) *http.Response {
+	data, _ := json.Marshal(payload)
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,102 @@
+package crawl4ai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process sandbox) ─────────────────────────────────────
+
+func newSandboxCrawler(t *testing.T) *AsyncWebCrawler {
+	t.Helper()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_sandbox", Sandbox: true, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestSandbox_RunIsDeterministic(t *testing.T) {
+	c := newSandboxCrawler(t)
+
+	first, err := c.Run("https://example.com/docs/intro", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.Run("https://example.com/docs/intro", &RunOptions{Timeout: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Success || first.StatusCode != 200 || first.Markdown == nil {
+		t.Fatalf("unexpected fixture: %+v", first)
+	}
+	if first.Markdown.RawMarkdown != second.Markdown.RawMarkdown || first.HTML != second.HTML {
+		t.Fatal("fixture differs between calls")
+	}
+	if first.Metadata["title"] != "Example Domain - intro" {
+		t.Fatalf("title = %v", first.Metadata["title"])
+	}
+	if links := resultLinks(first, "internal"); len(links) != 3 || links[1] != "https://example.com/about" {
+		t.Fatalf("links = %v", links)
+	}
+}
+
+func TestSandbox_RejectsRealDomains(t *testing.T) {
+	c := newSandboxCrawler(t)
+
+	result, err := c.Run("https://news.ycombinator.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Success || !strings.Contains(result.ErrorMessage, "sandbox mode only serves") {
+		t.Fatalf("expected failed result, got %+v", result)
+	}
+	if ok, _ := c.Run("https://shop.example.org/cart", nil); !ok.Success {
+		t.Fatal("subdomains of reserved names should be served")
+	}
+}
+
+func TestSandbox_RunManyWaits(t *testing.T) {
+	c := newSandboxCrawler(t)
+
+	out, err := c.RunMany([]string{"https://example.com/a", "https://example.net/b", "https://real.io"},
+		&RunManyOptions{Wait: true, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Job == nil || !strings.HasPrefix(out.Job.JobID, "sandbox_job_") || out.Job.Status != JobStatusCompleted {
+		t.Fatalf("unexpected job: %+v", out.Job)
+	}
+	if len(out.Successful()) != 2 || len(out.Failed()) != 1 {
+		t.Fatalf("successful %d failed %d", len(out.Successful()), len(out.Failed()))
+	}
+	if out.Job.Progress.Failed != 1 {
+		t.Fatalf("progress = %+v", out.Job.Progress)
+	}
+	if err := c.CancelJob(out.Job.JobID); err != nil {
+		t.Fatal(err)
+	}
+	var nf *NotFoundError
+	if _, err := c.GetJob(out.Job.JobID); !errors.As(err, &nf) {
+		t.Fatalf("deleted job should be gone, got %v", err)
+	}
+}
+
+func TestSandbox_UnsupportedEndpoint(t *testing.T) {
+	c := newSandboxCrawler(t)
+
+	_, err := c.Screenshot("https://example.com", nil)
+	var ce interface{ cloudError() *CloudError }
+	if !errors.As(err, &ce) || ce.cloudError().Code != ErrCodeSandboxUnsupported {
+		t.Fatalf("expected sandbox_unsupported, got %v", err)
+	}
+}
+
+func TestSandbox_RequiresTestKey(t *testing.T) {
+	_, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_live_abc", Sandbox: true})
+	if err == nil || !strings.Contains(err.Error(), "sk_test_") {
+		t.Fatalf("expected key error, got %v", err)
+	}
+}
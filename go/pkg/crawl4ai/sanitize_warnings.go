@@ -0,0 +1,75 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SanitizeWarnings reports what SanitizeCrawlerConfig and
+// SanitizeBrowserConfig drop from these configs: each cloud-controlled
+// field the caller set, and the whole browser config when strategy is
+// "http". Run and RunMany attach the list to their results and pass each
+// warning to CrawlerOptions.OnWarning, or with CrawlerOptions.StrictOptions
+// fail with them instead.
+func SanitizeWarnings(config *CrawlerRunConfig, browserConfig *BrowserConfig, strategy string) []Warning {
+	var warnings []Warning
+	add := func(set bool, field, message string) {
+		if set {
+			warnings = append(warnings, Warning{Field: field, Message: message})
+		}
+	}
+
+	if config != nil {
+		add(config.CacheMode != "", "cache_mode", "the cloud manages its cache; dropped (use BypassCache)")
+		add(config.SessionID != "", "session_id", "open-source session IDs name local browser tabs; dropped (drive a SessionPool session over CDP instead)")
+		add(config.BypassCache, "bypass_cache", "dropped from the config; set BypassCache on the run options instead")
+		add(config.NoCacheRead, "no_cache_read", "dropped from the config; set BypassCache on the run options instead")
+		add(config.NoCacheWrite, "no_cache_write", "the cloud always writes its cache; dropped")
+		add(config.DisableCache, "disable_cache", "the cloud always writes its cache; dropped")
+	}
+
+	if browserConfig == nil {
+		return warnings
+	}
+	if strategy == "http" {
+		add(true, "browser_config", "ignored by the http strategy (use Headers and Cookies on the run options)")
+		return warnings
+	}
+	const managed = "the cloud manages its browsers; dropped"
+	add(browserConfig.CdpURL != "", "cdp_url", managed)
+	add(browserConfig.UseManagedBrowser, "use_managed_browser", managed)
+	add(browserConfig.BrowserMode != "", "browser_mode", managed)
+	add(browserConfig.UserDataDir != "", "user_data_dir", managed)
+	add(browserConfig.ChromeChannel != "", "chrome_channel", managed)
+	return warnings
+}
+
+// reportWarnings hands warnings to CrawlerOptions.OnWarning or, under
+// CrawlerOptions.StrictOptions, fails with them as a *ValidationError
+// before anything is submitted.
+func (c *AsyncWebCrawler) reportWarnings(warnings []Warning) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+	if c.strictOptions {
+		return newStrictOptionsError(warnings)
+	}
+	for _, w := range warnings {
+		c.warn(w)
+	}
+	return nil
+}
+
+func newStrictOptionsError(warnings []Warning) *ValidationError {
+	names := make([]string, len(warnings))
+	fields := make([]FieldError, len(warnings))
+	for i, w := range warnings {
+		names[i] = w.Field
+		fields[i] = FieldError{Field: w.Field, Message: w.Message, Type: "ignored_option"}
+	}
+	verr := NewValidationError(
+		fmt.Sprintf("strict options: %d option(s) would be ignored: %s", len(warnings), strings.Join(names, ", ")),
+		map[string]interface{}{"ignored_options": names}, nil)
+	verr.Fields = fields
+	return verr
+}
@@ -0,0 +1,121 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func warningFields(warnings []Warning) string {
+	fields := make([]string, len(warnings))
+	for i, w := range warnings {
+		fields[i] = w.Field
+	}
+	return strings.Join(fields, ",")
+}
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestSanitizeWarnings(t *testing.T) {
+	cfg := &CrawlerRunConfig{CacheMode: CacheModeBypass, NoCacheWrite: true, Screenshot: true}
+	browser := &BrowserConfig{CdpURL: "ws://localhost:9222", Headless: true, ChromeChannel: "beta"}
+
+	if got := warningFields(SanitizeWarnings(cfg, browser, "browser")); got != "cache_mode,no_cache_write,cdp_url,chrome_channel" {
+		t.Errorf("browser strategy: fields = %s", got)
+	}
+	// Under http the whole browser config is ignored: one warning, not one
+	// per field.
+	if got := warningFields(SanitizeWarnings(nil, browser, "http")); got != "browser_config" {
+		t.Errorf("http strategy: fields = %s", got)
+	}
+	if w := SanitizeWarnings(&CrawlerRunConfig{Screenshot: true}, &BrowserConfig{Headless: true}, "browser"); w != nil {
+		t.Errorf("clean configs: warnings = %v", w)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRun_SurfacesSanitizeWarnings(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/crawl":       map[string]interface{}{"url": "https://example.com", "success": true},
+		"POST /v1/crawl/async": map[string]interface{}{"job_id": "job_1", "status": "pending"},
+	})
+	var logged []Warning
+	c.onWarning = func(w Warning) { logged = append(logged, w) }
+
+	result, err := c.Run("https://example.com", &RunOptions{
+		Config:        &CrawlerRunConfig{SessionID: "tab1"},
+		BrowserConfig: &BrowserConfig{Headless: true},
+		Strategy:      "http",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := warningFields(result.Warnings); got != "session_id,browser_config" {
+		t.Errorf("result warnings = %s", got)
+	}
+	if warningFields(logged) != warningFields(result.Warnings) {
+		t.Errorf("OnWarning got %v", logged)
+	}
+
+	// A crawler-wide default BrowserConfig is not the caller's, so the http
+	// strategy ignoring it is not reported.
+	logged = nil
+	c.browserConfig = &BrowserConfig{Headless: true}
+	many, err := c.RunMany([]string{"https://example.com"}, &RunManyOptions{
+		Config:   &CrawlerRunConfig{BypassCache: true},
+		Strategy: "http",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := warningFields(many.Warnings); got != "bypass_cache" || warningFields(logged) != got {
+		t.Errorf("RunMany warnings = %s, logged %v", got, logged)
+	}
+}
+
+func TestStrictOptions_RejectsIgnoredOptions(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://example.com", "success": true, "job_id": "job_1"})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, StrictOptions: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Run("https://example.com", &RunOptions{
+		Config:        &CrawlerRunConfig{CacheMode: CacheModeBypass},
+		BrowserConfig: &BrowserConfig{Headless: true},
+		Strategy:      "http",
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want *ValidationError", err)
+	}
+	if len(verr.Fields) != 2 || verr.Fields[0].Field != "cache_mode" || verr.Fields[1].Field != "browser_config" {
+		t.Errorf("fields = %v", verr.Fields)
+	}
+	if _, err := c.RunMany([]string{"https://example.com"}, &RunManyOptions{BrowserConfig: &BrowserConfig{CdpURL: "ws://x"}}); !errors.As(err, &verr) {
+		t.Errorf("RunMany err = %v", err)
+	}
+	if _, err := c.ArunWithConfig("https://example.com", &CrawlerRunConfig{CacheMode: CacheModeReadOnly}); !errors.As(err, &verr) {
+		t.Errorf("ArunWithConfig err = %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("%d request(s) sent despite strict failures", calls)
+	}
+
+	// Options the cloud honours pass through.
+	if _, err := c.Run("https://example.com", &RunOptions{Config: &CrawlerRunConfig{Screenshot: true}, BypassCache: true}); err != nil {
+		t.Errorf("clean run: %v", err)
+	}
+	if _, err := c.ArunWithConfig("https://example.com", &CrawlerRunConfig{CacheMode: CacheModeBypass}); err != nil {
+		t.Errorf("exact translation: %v", err)
+	}
+}
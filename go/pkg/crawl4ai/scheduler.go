@@ -0,0 +1,319 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSchedulerClosed is returned for submissions still queued when a
+// Scheduler is closed, and by Submit after Close.
+var ErrSchedulerClosed = errors.New("scheduler closed")
+
+// SchedulerOptions configure a Scheduler. Zero values pick the defaults.
+type SchedulerOptions struct {
+	// MinInterval spaces consecutive submissions. Default 0 (no spacing
+	// beyond what the rate-limit headers require).
+	MinInterval time.Duration
+	// RetryDelay is the first back-off after a rate-limit or concurrent-job
+	// quota error; it doubles per attempt up to MaxRetryDelay. Default 5s.
+	RetryDelay time.Duration
+	// MaxRetryDelay caps the back-off. Default 2m.
+	MaxRetryDelay time.Duration
+	// MaxDefer fails a submission once it has been deferred this long,
+	// returning the last quota error. Default 0 = keep waiting.
+	MaxDefer time.Duration
+}
+
+// Scheduler queues RunMany submissions and paces them against the API's
+// limits. Before each submission it honours the last observed
+// x-ratelimit-remaining / x-ratelimit-reset headers; a 429 rate-limit or
+// concurrent-job quota error defers the submission and retries it later
+// instead of failing. Submissions go out one at a time, in order.
+//
+//	s := crawler.NewScheduler(nil)
+//	defer s.Close()
+//	for _, batch := range batches {
+//	    pending, _ := s.Submit(batch, &RunManyOptions{Strategy: "http"})
+//	    go func() {
+//	        res, err := pending.Wait(ctx)
+//	        ...
+//	    }()
+//	}
+//	fmt.Println(len(s.Pending()), "batches waiting")
+type Scheduler struct {
+	crawler *AsyncWebCrawler
+	opts    SchedulerOptions
+
+	mu         sync.Mutex
+	queue      []*ScheduledJob
+	nextID     int
+	lastSubmit time.Time
+	closed     bool
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+// ScheduledJob is a submission queued on a Scheduler.
+type ScheduledJob struct {
+	ID   int
+	URLs []string
+
+	opts        RunManyOptions
+	queuedAt    time.Time
+	attempts    int
+	nextAttempt time.Time
+	lastErr     error
+
+	done   chan struct{}
+	result *RunManyResult
+	err    error
+}
+
+// Done is closed once the job has been submitted or has failed.
+func (j *ScheduledJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait blocks until the job has been submitted (returning the RunMany
+// result) or has failed, or until ctx is done.
+func (j *ScheduledJob) Wait(ctx context.Context) (*RunManyResult, error) {
+	select {
+	case <-j.done:
+		return j.result, j.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// PendingSubmission is a snapshot of one queued submission.
+type PendingSubmission struct {
+	ID          int
+	URLs        []string
+	QueuedAt    time.Time
+	Attempts    int
+	NextAttempt time.Time // zero when it will go out as soon as allowed
+	LastError   error     // the quota error that deferred it, if any
+}
+
+// NewScheduler starts a scheduler bound to this crawler. Call Close to stop
+// it.
+func (c *AsyncWebCrawler) NewScheduler(opts *SchedulerOptions) *Scheduler {
+	o := SchedulerOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = 5 * time.Second
+	}
+	if o.MaxRetryDelay <= 0 {
+		o.MaxRetryDelay = 2 * time.Minute
+	}
+	s := &Scheduler{
+		crawler: c,
+		opts:    o,
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+// RateLimit returns the rate-limit window last reported by the API. ok is
+// false until a response carrying x-ratelimit headers has been seen.
+func (c *AsyncWebCrawler) RateLimit() (RateLimitStatus, bool) {
+	return c.http.RateLimit()
+}
+
+// Submit queues a RunMany call. The scheduler always submits without
+// waiting; opts.Wait is ignored — use WaitJob on the returned job instead.
+func (s *Scheduler) Submit(urls []string, opts *RunManyOptions) (*ScheduledJob, error) {
+	job := &ScheduledJob{
+		URLs:     urls,
+		queuedAt: time.Now(),
+		done:     make(chan struct{}),
+	}
+	if opts != nil {
+		job.opts = *opts
+	}
+	job.opts.Wait = false
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil, ErrSchedulerClosed
+	}
+	s.nextID++
+	job.ID = s.nextID
+	s.queue = append(s.queue, job)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Pending returns a snapshot of the queued submissions, head first.
+func (s *Scheduler) Pending() []PendingSubmission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]PendingSubmission, len(s.queue))
+	for i, j := range s.queue {
+		out[i] = PendingSubmission{
+			ID:          j.ID,
+			URLs:        j.URLs,
+			QueuedAt:    j.queuedAt,
+			Attempts:    j.attempts,
+			NextAttempt: j.nextAttempt,
+			LastError:   j.lastErr,
+		}
+	}
+	return out
+}
+
+// Close stops the scheduler. Submissions still queued fail with
+// ErrSchedulerClosed; one already in flight completes first.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	close(s.stop)
+	<-s.done
+
+	s.mu.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.mu.Unlock()
+	for _, j := range queue {
+		j.finish(nil, ErrSchedulerClosed)
+	}
+}
+
+func (s *Scheduler) loop() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.wake:
+				continue
+			case <-s.stop:
+				return
+			}
+		}
+		job := s.queue[0]
+		wait := s.delayLocked(job, time.Now())
+		s.mu.Unlock()
+
+		if wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-s.stop:
+				timer.Stop()
+				return
+			}
+			continue
+		}
+
+		result, err := s.crawler.RunMany(job.URLs, &job.opts)
+		now := time.Now()
+
+		s.mu.Lock()
+		s.lastSubmit = now
+		job.attempts++
+		if delay, ok := s.deferral(err, job.attempts); ok &&
+			(s.opts.MaxDefer == 0 || now.Sub(job.queuedAt) < s.opts.MaxDefer) {
+			job.lastErr = err
+			job.nextAttempt = now.Add(delay)
+			s.mu.Unlock()
+			continue
+		}
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		job.finish(result, err)
+	}
+}
+
+// delayLocked is how long the head job must wait before going out: its own
+// back-off, MinInterval since the last submission, and an exhausted
+// rate-limit window.
+func (s *Scheduler) delayLocked(job *ScheduledJob, now time.Time) time.Duration {
+	wait := job.nextAttempt.Sub(now)
+	if s.opts.MinInterval > 0 && !s.lastSubmit.IsZero() {
+		if d := s.lastSubmit.Add(s.opts.MinInterval).Sub(now); d > wait {
+			wait = d
+		}
+	}
+	if d := rateLimitDelay(s.crawler.http, now); d > wait {
+		wait = d
+	}
+	return wait
+}
+
+// rateLimitDelay is how long to hold off when the last observed window is
+// exhausted.
+func rateLimitDelay(c *HTTPClient, now time.Time) time.Duration {
+	status, ok := c.RateLimit()
+	if !ok || status.Remaining > 0 || status.ResetAt.IsZero() {
+		return 0
+	}
+	return status.ResetAt.Sub(now)
+}
+
+// deferral reports whether err is a limit the scheduler should wait out,
+// and for how long.
+func (s *Scheduler) deferral(err error, attempts int) (time.Duration, bool) {
+	var rl *RateLimitError
+	if errors.As(err, &rl) {
+		if secs := rl.RetryAfter(); secs > 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		return s.backoff(attempts), true
+	}
+	var q *QuotaExceededError
+	if errors.As(err, &q) && isConcurrencyQuota(q.Message) {
+		return s.backoff(attempts), true
+	}
+	return 0, false
+}
+
+func (s *Scheduler) backoff(attempts int) time.Duration {
+	d := s.opts.RetryDelay
+	for i := 1; i < attempts && d < s.opts.MaxRetryDelay; i++ {
+		d *= 2
+	}
+	if d > s.opts.MaxRetryDelay {
+		d = s.opts.MaxRetryDelay
+	}
+	return d
+}
+
+// isConcurrencyQuota tells a concurrent-jobs 429 (clears when a running
+// job finishes) from a credit/storage quota (won't clear by waiting).
+func isConcurrencyQuota(detail string) bool {
+	return strings.Contains(strings.ToLower(detail), "concurrent")
+}
+
+func (j *ScheduledJob) finish(result *RunManyResult, err error) {
+	j.result, j.err = result, err
+	close(j.done)
+}
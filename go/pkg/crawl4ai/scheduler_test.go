@@ -0,0 +1,150 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newAsyncSubmitCrawler serves POST /v1/crawl/async with respond(n), where n
+// counts calls from 1.
+func newAsyncSubmitCrawler(t *testing.T, respond func(n int32, w http.ResponseWriter)) *AsyncWebCrawler {
+	t.Helper()
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/crawl/async" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		respond(atomic.AddInt32(&calls, 1), w)
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	return c
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestScheduler_DefersConcurrencyQuota(t *testing.T) {
+	c := newAsyncSubmitCrawler(t, func(n int32, w http.ResponseWriter) {
+		if n == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"detail": "Concurrent job limit reached (2/2)"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"job_id": "job_1", "status": "pending"}`))
+	})
+	s := c.NewScheduler(&SchedulerOptions{RetryDelay: 10 * time.Millisecond})
+	defer s.Close()
+
+	job, err := s.Submit([]string{"https://example.com"}, &RunManyOptions{Wait: true})
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := job.Wait(ctx)
+	if err != nil {
+		t.Fatalf("expected deferred submission to succeed, got %v", err)
+	}
+	if res.Job == nil || res.Job.JobID != "job_1" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+}
+
+func TestScheduler_FailsOnOtherErrors(t *testing.T) {
+	c := newAsyncSubmitCrawler(t, func(n int32, w http.ResponseWriter) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"detail": "Daily credit quota exceeded"}`))
+	})
+	s := c.NewScheduler(&SchedulerOptions{RetryDelay: 10 * time.Millisecond})
+	defer s.Close()
+
+	job, _ := s.Submit([]string{"https://example.com"}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var quota *QuotaExceededError
+	if _, err := job.Wait(ctx); !errors.As(err, &quota) {
+		t.Fatalf("expected credit quota error to fail fast, got %v", err)
+	}
+}
+
+func TestScheduler_PendingAndClose(t *testing.T) {
+	c := newAsyncSubmitCrawler(t, func(n int32, w http.ResponseWriter) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"detail": "Too many concurrent jobs"}`))
+	})
+	s := c.NewScheduler(&SchedulerOptions{RetryDelay: time.Hour})
+
+	first, _ := s.Submit([]string{"https://a.com"}, nil)
+	second, _ := s.Submit([]string{"https://b.com"}, nil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		p := s.Pending()
+		if len(p) == 2 && p[0].Attempts == 1 {
+			if p[0].ID != first.ID || p[0].LastError == nil || p[0].NextAttempt.IsZero() {
+				t.Fatalf("unexpected head snapshot: %+v", p[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("head never deferred, pending=%+v", p)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	s.Close()
+	for _, j := range []*ScheduledJob{first, second} {
+		if _, err := j.Wait(context.Background()); !errors.Is(err, ErrSchedulerClosed) {
+			t.Fatalf("expected ErrSchedulerClosed, got %v", err)
+		}
+	}
+	if _, err := s.Submit([]string{"https://c.com"}, nil); !errors.Is(err, ErrSchedulerClosed) {
+		t.Fatalf("expected Submit after Close to fail, got %v", err)
+	}
+}
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestScheduler_RateLimitHeaders(t *testing.T) {
+	c := &HTTPClient{}
+	if _, ok := c.RateLimit(); ok {
+		t.Fatal("expected no status before any response")
+	}
+	h := http.Header{}
+	h.Set("X-RateLimit-Limit", "60")
+	h.Set("X-RateLimit-Remaining", "0")
+	h.Set("X-RateLimit-Reset", "30")
+	c.observeRateLimit(h)
+
+	status, ok := c.RateLimit()
+	if !ok || status.Limit != 60 || status.Remaining != 0 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if d := rateLimitDelay(c, time.Now()); d <= 25*time.Second || d > 30*time.Second {
+		t.Fatalf("expected ~30s hold-off, got %v", d)
+	}
+
+	h.Set("X-RateLimit-Remaining", "5")
+	c.observeRateLimit(h)
+	if d := rateLimitDelay(c, time.Now()); d != 0 {
+		t.Fatalf("expected no hold-off with budget left, got %v", d)
+	}
+}
+
+func TestScheduler_Backoff(t *testing.T) {
+	s := &Scheduler{opts: SchedulerOptions{RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}}
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 10: 5 * time.Second} {
+		if got := s.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Extraction strategy types accepted in CrawlerRunConfig.ExtractionStrategy.
+const (
+	ExtractionTypeJSONCSS   = "json_css"
+	ExtractionTypeJSONXPath = "json_xpath"
+)
+
+// FieldType is how a Field's matched element becomes a value.
+type FieldType string
+
+// Field types understood by json_css / json_xpath extraction.
+const (
+	FieldText       FieldType = "text"        // element text
+	FieldAttribute  FieldType = "attribute"   // value of Field.Attribute
+	FieldHTML       FieldType = "html"        // inner HTML
+	FieldList       FieldType = "list"        // every match, each decoded with flat Field.Fields
+	FieldNested     FieldType = "nested"      // first match, decoded with Field.Fields
+	FieldNestedList FieldType = "nested_list" // every match, each decoded with Field.Fields (may nest)
+)
+
+// Schema is a typed selector schema for json_css / json_xpath extraction —
+// the same shape GenerateSchema returns. BaseSelector picks each repeated
+// item; every Field is evaluated relative to it.
+type Schema struct {
+	Name         string  `json:"name"`
+	BaseSelector string  `json:"baseSelector"`
+	Fields       []Field `json:"fields"`
+}
+
+// Field is one extracted value inside a Schema. Selector may be empty for
+// scalar fields, meaning the enclosing element itself. List and nested
+// fields carry their children in Fields; their Selector acts as the base
+// selector for that group, and children are evaluated relative to each
+// matched element.
+type Field struct {
+	Name      string    `json:"name"`
+	Selector  string    `json:"selector,omitempty"`
+	Type      FieldType `json:"type"`
+	Attribute string    `json:"attribute,omitempty"`
+	Fields    []Field   `json:"fields,omitempty"`
+}
+
+// NewTextField returns a text field.
+func NewTextField(name, selector string) Field {
+	return Field{Name: name, Selector: selector, Type: FieldText}
+}
+
+// NewAttributeField returns a field reading one attribute (e.g. "href").
+func NewAttributeField(name, selector, attribute string) Field {
+	return Field{Name: name, Selector: selector, Type: FieldAttribute, Attribute: attribute}
+}
+
+// NewHTMLField returns a field capturing the matched element's inner HTML.
+func NewHTMLField(name, selector string) Field {
+	return Field{Name: name, Selector: selector, Type: FieldHTML}
+}
+
+// NewListField returns a field that yields one object per element matching
+// selector, each built from fields.
+//
+//	NewListField("links", "a[href]", NewAttributeField("href", "", "href"))
+func NewListField(name, selector string, fields ...Field) Field {
+	return Field{Name: name, Selector: selector, Type: FieldList, Fields: fields}
+}
+
+// NewNestedListField returns a repeated group: one object per element
+// matching baseSelector, built from fields, which may themselves be nested
+// or lists.
+//
+//	NewNestedListField("variants", ".variant",
+//	    NewTextField("sku", ".sku"),
+//	    NewListField("sizes", ".size", NewTextField("label", "")),
+//	)
+func NewNestedListField(name, baseSelector string, fields ...Field) Field {
+	return Field{Name: name, Selector: baseSelector, Type: FieldNestedList, Fields: fields}
+}
+
+// NewNestedField returns a field that yields a single object built from
+// fields, evaluated inside the first element matching selector.
+func NewNestedField(name, selector string, fields ...Field) Field {
+	return Field{Name: name, Selector: selector, Type: FieldNested, Fields: fields}
+}
+
+// Validate checks the schema is well-formed: a base selector, at least one
+// field, unique field names per level, known types, an Attribute on
+// attribute fields, and children on list/nested fields. Children of a plain
+// list must be scalar; use a nested list for deeper structure.
+func (s *Schema) Validate() error {
+	if s == nil {
+		return fmt.Errorf("schema is nil")
+	}
+	if strings.TrimSpace(s.BaseSelector) == "" {
+		return fmt.Errorf("schema %q: baseSelector is required", s.Name)
+	}
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("schema %q: at least one field is required", s.Name)
+	}
+	return validateFields(s.Fields, "")
+}
+
+func validateFields(fields []Field, path string) error {
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		name := path + f.Name
+		if f.Name == "" {
+			return fmt.Errorf("field at %q has no name", strings.TrimSuffix(path, "."))
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("field %q is declared twice", name)
+		}
+		seen[f.Name] = true
+
+		switch f.Type {
+		case FieldText, FieldHTML:
+		case FieldAttribute:
+			if f.Attribute == "" {
+				return fmt.Errorf("field %q: attribute fields need Attribute set", name)
+			}
+		case FieldList, FieldNested, FieldNestedList:
+			if f.Selector == "" {
+				return fmt.Errorf("field %q: %s fields need a selector", name, f.Type)
+			}
+			if len(f.Fields) == 0 {
+				return fmt.Errorf("field %q: %s fields need child fields", name, f.Type)
+			}
+			if f.Type == FieldList {
+				for _, c := range f.Fields {
+					if len(c.Fields) > 0 {
+						return fmt.Errorf("field %q: list children must be scalar; use a nested_list for %q", name, c.Name)
+					}
+				}
+			}
+			if err := validateFields(f.Fields, name+"."); err != nil {
+				return err
+			}
+		case "":
+			return fmt.Errorf("field %q has no type", name)
+		default:
+			return fmt.Errorf("field %q has unknown type %q", name, f.Type)
+		}
+	}
+	return nil
+}
+
+// ToMap converts the schema to the wire dict.
+func (s *Schema) ToMap() map[string]interface{} {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil
+	}
+	var out map[string]interface{}
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
+
+// SchemaFromMap decodes a wire-shape schema (e.g. GeneratedSchema.Schema).
+func SchemaFromMap(data map[string]interface{}) (*Schema, error) {
+	if data == nil {
+		return nil, fmt.Errorf("schema is empty")
+	}
+	return unmarshalWrapper[Schema](data)
+}
+
+// JSONCSSExtraction builds a json_css extraction strategy from a typed
+// schema, validating it first.
+//
+//	strategy, err := JSONCSSExtraction(&Schema{
+//	    Name:         "Stories",
+//	    BaseSelector: ".athing",
+//	    Fields: []Field{
+//	        NewTextField("title", ".titleline > a"),
+//	        NewAttributeField("url", ".titleline > a", "href"),
+//	    },
+//	})
+func JSONCSSExtraction(schema *Schema) (map[string]interface{}, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"type":   ExtractionTypeJSONCSS,
+		"schema": schema.ToMap(),
+	}, nil
+}
+
+// JSONXPathExtraction builds a json_xpath extraction strategy from a typed
+// schema. Every selector is syntax-checked client-side first, so a typo
+// fails here instead of silently extracting nothing on the server.
+//
+//	schema, _ := crawler.GenerateSchema(html, &GenerateSchemaOptions{SchemaType: "XPATH"})
+//	typed, _ := SchemaFromMap(schema.Schema)
+//	strategy, err := JSONXPathExtraction(typed)
+//	result, _ := crawler.Run(url, &RunOptions{Config: &CrawlerRunConfig{ExtractionStrategy: strategy}})
+func JSONXPathExtraction(schema *Schema) (map[string]interface{}, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ValidateXPath(schema.BaseSelector); err != nil {
+		return nil, fmt.Errorf("baseSelector: %w", err)
+	}
+	if err := validateXPathFields(schema.Fields, ""); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"type":   ExtractionTypeJSONXPath,
+		"schema": schema.ToMap(),
+	}, nil
+}
+
+func validateXPathFields(fields []Field, path string) error {
+	for _, f := range fields {
+		if f.Selector != "" {
+			if err := ValidateXPath(f.Selector); err != nil {
+				return fmt.Errorf("field %q: %w", path+f.Name, err)
+			}
+		}
+		if err := validateXPathFields(f.Fields, path+f.Name+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateXPath performs a lightweight syntax check of an XPath 1.0
+// expression: balanced brackets and parentheses, closed string literals,
+// no empty predicates or dangling separators. It also rejects selectors
+// that are plainly CSS (".price", "#main"), the most common mix-up.
+//
+// It is not a full parser — a passing expression can still fail to match.
+func ValidateXPath(expr string) error {
+	e := strings.TrimSpace(expr)
+	if e == "" {
+		return fmt.Errorf("xpath expression is empty")
+	}
+	if e[0] == '#' || (len(e) > 1 && e[0] == '.' && isXPathNameStart(e[1])) {
+		return fmt.Errorf("%q looks like a CSS selector, not XPath", expr)
+	}
+	if strings.Contains(e, "///") {
+		return fmt.Errorf("%q contains an empty location step", expr)
+	}
+
+	var stack []byte
+	var quote byte
+	prev := byte(0)
+	for i := 0; i < len(e); i++ {
+		ch := e[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			prev = ch
+			continue
+		}
+		switch ch {
+		case '\'', '"':
+			quote = ch
+		case '[', '(':
+			stack = append(stack, ch)
+		case ']', ')':
+			open := byte('[')
+			if ch == ')' {
+				open = '('
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != open {
+				return fmt.Errorf("%q has unbalanced %q at position %d", expr, ch, i)
+			}
+			if ch == ']' && prev == '[' {
+				return fmt.Errorf("%q has an empty predicate at position %d", expr, i)
+			}
+			stack = stack[:len(stack)-1]
+		}
+		if ch != ' ' {
+			prev = ch
+		}
+	}
+	if quote != 0 {
+		return fmt.Errorf("%q has an unterminated string literal", expr)
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("%q has unclosed %q", expr, stack[len(stack)-1])
+	}
+	last := e[len(e)-1]
+	if (last == '/' && e != "/") || last == '|' || last == '=' || last == '@' {
+		return fmt.Errorf("%q ends with a dangling %q", expr, last)
+	}
+	return nil
+}
+
+func isXPathNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
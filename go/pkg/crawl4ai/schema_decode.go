@@ -0,0 +1,260 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ExtractInto decodes a json_css / json_xpath ExtractedContent into v, which
+// must be a pointer. Struct fields are matched by their `crawl4ai` tag,
+// falling back to the `json` tag and then the field name (case-insensitive),
+// so one struct can mirror a nested schema directly:
+//
+//	type Variant struct {
+//	    SKU   string   `crawl4ai:"sku"`
+//	    Sizes []string `crawl4ai:"sizes"`
+//	}
+//	type Product struct {
+//	    Title    string    `crawl4ai:"title"`
+//	    Price    float64   `crawl4ai:"price"`
+//	    Variants []Variant `crawl4ai:"variants"`
+//	}
+//	var products []Product
+//	err := result.ExtractInto(&products)
+func (r *CrawlResult) ExtractInto(v interface{}) error {
+	if r.ExtractedContent == "" {
+		return fmt.Errorf("result for %s has no extracted content", r.URL)
+	}
+	return DecodeExtracted([]byte(r.ExtractedContent), v)
+}
+
+// DecodeExtracted decodes raw extraction JSON into v using the same rules as
+// ExtractInto. Extraction returns everything as text, so decoding is lenient
+// where a schema can't be precise:
+//
+//   - numeric targets accept strings and use the first number in them
+//     ("$1,299.50" → 1299.5, "42 points" → 42);
+//   - a struct target given an array takes its first element;
+//   - a slice target given a single value wraps it;
+//   - a scalar target given a one-key object (a list item such as
+//     {"text": "..."}) takes that key's value, so []string works for lists.
+func DecodeExtracted(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("decode extracted content: need a non-nil pointer, got %T", v)
+	}
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("decode extracted content: %w", err)
+	}
+	return decodeExtractedValue(raw, rv.Elem(), "$")
+}
+
+func decodeExtractedValue(src interface{}, dst reflect.Value, path string) error {
+	if src == nil {
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeExtractedValue(src, dst.Elem(), path)
+
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			return decodeMismatch(src, dst, path)
+		}
+		dst.Set(reflect.ValueOf(src))
+		return nil
+
+	case reflect.Struct:
+		switch s := src.(type) {
+		case map[string]interface{}:
+			return decodeExtractedStruct(s, dst, path)
+		case []interface{}:
+			if len(s) == 0 {
+				return nil
+			}
+			return decodeExtractedValue(s[0], dst, path+"[0]")
+		}
+		return decodeMismatch(src, dst, path)
+
+	case reflect.Slice:
+		items, ok := src.([]interface{})
+		if !ok {
+			items = []interface{}{src}
+		}
+		out := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeExtractedValue(item, out.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+		return nil
+
+	case reflect.Map:
+		m, ok := src.(map[string]interface{})
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return decodeMismatch(src, dst, path)
+		}
+		out := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for k, item := range m {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeExtractedValue(item, elem, path+"."+k); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(out)
+		return nil
+	}
+
+	// Scalar target: unwrap single-key list items first.
+	if m, ok := src.(map[string]interface{}); ok && len(m) == 1 {
+		for _, inner := range m {
+			return decodeExtractedValue(inner, dst, path)
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		switch s := src.(type) {
+		case string:
+			dst.SetString(s)
+		case float64, bool:
+			dst.SetString(fmt.Sprint(s))
+		default:
+			return decodeMismatch(src, dst, path)
+		}
+
+	case reflect.Bool:
+		switch s := src.(type) {
+		case bool:
+			dst.SetBool(s)
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(s))
+			if err != nil {
+				return fmt.Errorf("decode %s: %q is not a bool", path, s)
+			}
+			dst.SetBool(b)
+		default:
+			return decodeMismatch(src, dst, path)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		n, err := extractedNumber(src)
+		if err != nil {
+			return fmt.Errorf("decode %s: %w", path, err)
+		}
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(n)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if n < 0 {
+				return fmt.Errorf("decode %s: %v is negative", path, n)
+			}
+			dst.SetUint(uint64(n))
+		default:
+			dst.SetInt(int64(n))
+		}
+
+	default:
+		return decodeMismatch(src, dst, path)
+	}
+	return nil
+}
+
+func decodeExtractedStruct(src map[string]interface{}, dst reflect.Value, path string) error {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue // unexported
+		}
+		key, tagged := extractedFieldKey(sf)
+		if key == "-" {
+			continue
+		}
+		if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+			if err := decodeExtractedStruct(src, dst.Field(i), path); err != nil {
+				return err
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		val, ok := src[key]
+		if !ok {
+			for k, v := range src {
+				if strings.EqualFold(k, key) {
+					val, ok = v, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := decodeExtractedValue(val, dst.Field(i), path+"."+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// extractedFieldKey returns the schema field name a struct field maps to and
+// whether it came from a tag.
+func extractedFieldKey(sf reflect.StructField) (string, bool) {
+	for _, tag := range []string{"crawl4ai", "json"} {
+		if v, ok := sf.Tag.Lookup(tag); ok {
+			name := strings.Split(v, ",")[0]
+			if name != "" {
+				return name, true
+			}
+		}
+	}
+	return sf.Name, false
+}
+
+// extractedNumber reads a number from a JSON number or from the first number
+// in a string, ignoring thousands separators and surrounding text.
+func extractedNumber(src interface{}) (float64, error) {
+	switch s := src.(type) {
+	case float64:
+		return s, nil
+	case string:
+		text := strings.ReplaceAll(s, ",", "")
+		start := strings.IndexAny(text, "0123456789")
+		if start < 0 {
+			return 0, fmt.Errorf("%q is not a number", s)
+		}
+		if start > 0 && text[start-1] == '.' {
+			start--
+		}
+		if start > 0 && text[start-1] == '-' {
+			start--
+		}
+		end := start + 1
+		for end < len(text) && (text[end] == '.' || (text[end] >= '0' && text[end] <= '9')) {
+			end++
+		}
+		n, err := strconv.ParseFloat(strings.TrimRight(text[start:end], "."), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", s)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("cannot use %T as a number", src)
+}
+
+func decodeMismatch(src interface{}, dst reflect.Value, path string) error {
+	return fmt.Errorf("decode %s: cannot use %T as %s", path, src, dst.Type())
+}
@@ -0,0 +1,117 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+type testVariant struct {
+	SKU   string   `crawl4ai:"sku"`
+	Sizes []string `crawl4ai:"sizes"`
+}
+
+type testProduct struct {
+	Title    string        `crawl4ai:"title"`
+	Price    float64       `crawl4ai:"price"`
+	Reviews  int           `json:"reviews"`
+	InStock  bool          `crawl4ai:"in_stock"`
+	Variants []testVariant `crawl4ai:"variants"`
+	Seller   *struct {
+		Name string `crawl4ai:"name"`
+	} `crawl4ai:"seller"`
+	Ignored string `crawl4ai:"-"`
+}
+
+const nestedExtraction = `[
+  {
+    "title": "Widget",
+    "price": "$1,299.50",
+    "reviews": "42 reviews",
+    "in_stock": "true",
+    "variants": [
+      {"sku": "W-1", "sizes": [{"label": "S"}, {"label": "M"}]},
+      {"sku": "W-2", "sizes": [{"label": "L"}]}
+    ],
+    "seller": {"name": "Acme"},
+    "Ignored": "nope"
+  }
+]`
+
+func TestDecodeExtracted_NestedStructs(t *testing.T) {
+	var products []testProduct
+	if err := DecodeExtracted([]byte(nestedExtraction), &products); err != nil {
+		t.Fatalf("DecodeExtracted: %v", err)
+	}
+	if len(products) != 1 {
+		t.Fatalf("expected 1 product, got %d", len(products))
+	}
+	p := products[0]
+	if p.Title != "Widget" || p.Price != 1299.5 || p.Reviews != 42 || !p.InStock {
+		t.Fatalf("unexpected scalars: %+v", p)
+	}
+	if len(p.Variants) != 2 || p.Variants[0].SKU != "W-1" {
+		t.Fatalf("unexpected variants: %+v", p.Variants)
+	}
+	if got := p.Variants[0].Sizes; len(got) != 2 || got[0] != "S" || got[1] != "M" {
+		t.Fatalf("expected list items unwrapped to strings, got %v", got)
+	}
+	if p.Seller == nil || p.Seller.Name != "Acme" {
+		t.Fatalf("unexpected seller: %+v", p.Seller)
+	}
+	if p.Ignored != "" {
+		t.Fatalf("expected tagged-out field skipped, got %q", p.Ignored)
+	}
+}
+
+func TestDecodeExtracted_StructTakesFirstItem(t *testing.T) {
+	var p testProduct
+	if err := DecodeExtracted([]byte(nestedExtraction), &p); err != nil {
+		t.Fatalf("DecodeExtracted: %v", err)
+	}
+	if p.Title != "Widget" {
+		t.Fatalf("expected first item decoded, got %+v", p)
+	}
+}
+
+func TestDecodeExtracted_Errors(t *testing.T) {
+	var p testProduct
+	if err := DecodeExtracted([]byte(`{"price": "n/a"}`), &p); err == nil {
+		t.Fatal("expected non-numeric price to fail")
+	}
+	if err := DecodeExtracted([]byte(`{}`), p); err == nil {
+		t.Fatal("expected non-pointer target to fail")
+	}
+	if err := DecodeExtracted([]byte(`not json`), &p); err == nil {
+		t.Fatal("expected invalid JSON to fail")
+	}
+}
+
+func TestExtractInto_UsesExtractedContent(t *testing.T) {
+	r := &CrawlResult{URL: "https://example.com", ExtractedContent: nestedExtraction}
+	var products []testProduct
+	if err := r.ExtractInto(&products); err != nil || len(products) != 1 {
+		t.Fatalf("ExtractInto: %v (%d products)", err, len(products))
+	}
+	if err := (&CrawlResult{}).ExtractInto(&products); err == nil {
+		t.Fatal("expected error with no extracted content")
+	}
+}
+
+func TestSchemaValidate_NestedList(t *testing.T) {
+	schema := &Schema{
+		BaseSelector: ".product",
+		Fields: []Field{
+			NewNestedListField("variants", ".variant",
+				NewTextField("sku", ".sku"),
+				NewListField("sizes", ".size", NewTextField("label", "")),
+			),
+		},
+	}
+	if err := schema.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	schema.Fields[0].Type = FieldList
+	if err := schema.Validate(); err == nil {
+		t.Fatal("expected plain list with nested children to be rejected")
+	}
+}
@@ -0,0 +1,151 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestValidateXPath_Valid(t *testing.T) {
+	for _, expr := range []string{
+		"//div[@class='product']",
+		".//h2/text()",
+		"./a/@href",
+		"(//table)[1]//tr[position() > 1]",
+		"//span[contains(@class, \"price\") and not(@hidden)]",
+		"..",
+		"/",
+	} {
+		if err := ValidateXPath(expr); err != nil {
+			t.Errorf("ValidateXPath(%q) = %v, want nil", expr, err)
+		}
+	}
+}
+
+func TestValidateXPath_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		".price",
+		"#main",
+		"//div[@class='x'",
+		"//div[]",
+		"//div[@id=\"a]",
+		"//div)",
+		"//div/",
+		"//div///a",
+		"//a/@",
+	} {
+		if err := ValidateXPath(expr); err == nil {
+			t.Errorf("ValidateXPath(%q) = nil, want error", expr)
+		}
+	}
+}
+
+func TestJSONXPathExtraction_BuildsStrategy(t *testing.T) {
+	schema := &Schema{
+		Name:         "Products",
+		BaseSelector: "//div[@class='product']",
+		Fields: []Field{
+			NewTextField("title", ".//h2"),
+			NewAttributeField("link", ".//a", "href"),
+		},
+	}
+	strategy, err := JSONXPathExtraction(schema)
+	if err != nil {
+		t.Fatalf("JSONXPathExtraction: %v", err)
+	}
+	if strategy["type"] != ExtractionTypeJSONXPath {
+		t.Fatalf("expected json_xpath, got %v", strategy["type"])
+	}
+	wire := strategy["schema"].(map[string]interface{})
+	if wire["baseSelector"] != "//div[@class='product']" {
+		t.Fatalf("unexpected baseSelector: %v", wire["baseSelector"])
+	}
+
+	schema.Fields[0].Selector = ".title"
+	if _, err := JSONXPathExtraction(schema); err == nil {
+		t.Fatal("expected CSS selector in an XPath schema to be rejected")
+	}
+}
+
+func TestSchemaFromMap_RoundTrip(t *testing.T) {
+	wire := map[string]interface{}{
+		"name":         "Items",
+		"baseSelector": "//li",
+		"fields": []interface{}{
+			map[string]interface{}{"name": "text", "selector": ".", "type": "text"},
+		},
+	}
+	s, err := SchemaFromMap(wire)
+	if err != nil {
+		t.Fatalf("SchemaFromMap: %v", err)
+	}
+	if s.BaseSelector != "//li" || len(s.Fields) != 1 || s.Fields[0].Name != "text" {
+		t.Fatalf("unexpected schema: %+v", s)
+	}
+}
+
+func TestSchemaValidate_Valid(t *testing.T) {
+	schema := &Schema{
+		Name:         "Articles",
+		BaseSelector: "article",
+		Fields: []Field{
+			NewTextField("title", "h2"),
+			NewHTMLField("body", ".content"),
+			NewListField("links", "a[href]", NewAttributeField("href", "", "href")),
+			NewNestedField("author", ".byline", NewTextField("name", ".name")),
+		},
+	}
+	if err := schema.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestSchemaValidate_Invalid(t *testing.T) {
+	cases := map[string]*Schema{
+		"nil":            nil,
+		"no base":        {Fields: []Field{NewTextField("t", "h1")}},
+		"no fields":      {BaseSelector: "div"},
+		"no name":        {BaseSelector: "div", Fields: []Field{NewTextField("", "h1")}},
+		"duplicate":      {BaseSelector: "div", Fields: []Field{NewTextField("t", "h1"), NewTextField("t", "h2")}},
+		"no type":        {BaseSelector: "div", Fields: []Field{{Name: "t", Selector: "h1"}}},
+		"unknown type":   {BaseSelector: "div", Fields: []Field{{Name: "t", Selector: "h1", Type: "regex"}}},
+		"no attribute":   {BaseSelector: "div", Fields: []Field{NewAttributeField("href", "a", "")}},
+		"empty list":     {BaseSelector: "div", Fields: []Field{NewListField("items", "li")}},
+		"list selector":  {BaseSelector: "div", Fields: []Field{NewListField("items", "", NewTextField("t", ""))}},
+		"bad child type": {BaseSelector: "div", Fields: []Field{NewNestedField("n", "p", Field{Name: "x", Type: "?"})}},
+	}
+	for name, schema := range cases {
+		if err := schema.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", name)
+		}
+	}
+}
+
+func TestJSONCSSExtraction_MarshalsTypedSchema(t *testing.T) {
+	strategy, err := JSONCSSExtraction(&Schema{
+		Name:         "Stories",
+		BaseSelector: ".athing",
+		Fields: []Field{
+			NewTextField("title", ".titleline > a"),
+			NewListField("tags", ".tag", NewTextField("text", "")),
+		},
+	})
+	if err != nil {
+		t.Fatalf("JSONCSSExtraction: %v", err)
+	}
+	if strategy["type"] != ExtractionTypeJSONCSS {
+		t.Fatalf("expected json_css, got %v", strategy["type"])
+	}
+	fields := strategy["schema"].(map[string]interface{})["fields"].([]interface{})
+	tags := fields[1].(map[string]interface{})
+	if tags["type"] != "list" || len(tags["fields"].([]interface{})) != 1 {
+		t.Fatalf("unexpected list field on the wire: %v", tags)
+	}
+	child := tags["fields"].([]interface{})[0].(map[string]interface{})
+	if _, ok := child["selector"]; ok {
+		t.Fatalf("empty selector should be omitted, got %v", child)
+	}
+
+	if _, err := JSONCSSExtraction(&Schema{BaseSelector: ".athing"}); err == nil {
+		t.Fatal("expected invalid schema to be rejected")
+	}
+}
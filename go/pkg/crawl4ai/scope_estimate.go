@@ -0,0 +1,167 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Rough worker time per page, used for ScopeEstimate.EstimatedDuration.
+const (
+	scopeBrowserPageTime = 2 * time.Second
+	scopeHTTPPageTime    = 500 * time.Millisecond
+)
+
+// minScopeScanURLs is the smallest sitemap sample EstimateScope asks for.
+const minScopeScanURLs = 1000
+
+// ScopeEstimate is the approximate size and cost of a deep crawl, from
+// EstimateScope.
+type ScopeEstimate struct {
+	// DiscoveredURLs is how many URLs the sitemap scan found.
+	DiscoveredURLs int
+	// InScopeURLs passed the filters and MaxDepth.
+	InScopeURLs int
+	// PlannedURLs is what the crawl would actually fetch: InScopeURLs
+	// capped at MaxURLs.
+	PlannedURLs int
+	// DepthHistogram counts in-scope URLs by depth below the start URL,
+	// measured in path segments — a stand-in for link depth.
+	DepthHistogram map[int]int
+	// Truncated is set when the scan hit its discovery cap, so the real
+	// site is likely larger than DiscoveredURLs.
+	Truncated bool
+	// EstimatedCredits is the server's dry-run price for PlannedURLs;
+	// CreditsExact is false when it is a worst-case upper bound.
+	EstimatedCredits string
+	CreditsExact     bool
+	CoveredByBalance bool
+	// EstimatedDuration is serial worker time for PlannedURLs (about 2s a
+	// page with a browser, 0.5s over HTTP); wall time is lower when the
+	// crawl runs pages in parallel.
+	EstimatedDuration time.Duration
+	// Filtered shows which discovered URLs the filters drop and why.
+	Filtered *FilterPreviewResult
+}
+
+// EstimateScope dry-runs a deep crawl: it runs a cheap sitemap scan of url,
+// applies the same filters, depth and URL limits DeepCrawl would, and
+// prices the resulting URL list — without crawling anything. Pass the
+// DeepCrawlOptions you intend to use in production.
+//
+//	opts := &crawl4ai.DeepCrawlOptions{MaxDepth: 3, MaxURLs: 500, IncludePatterns: []string{"/docs/*"}}
+//	est, err := crawler.EstimateScope("https://docs.example.com", opts)
+//	fmt.Printf("%d pages, ~%s credits, ~%s\n", est.PlannedURLs, est.EstimatedCredits, est.EstimatedDuration)
+//
+// Sites whose pages are only reachable by links (no sitemap) will report
+// few URLs; treat the estimate as a lower bound there.
+func (c *AsyncWebCrawler) EstimateScope(url string, opts *DeepCrawlOptions) (*ScopeEstimate, error) {
+	if opts == nil {
+		opts = &DeepCrawlOptions{}
+	}
+	if err := c.CheckAllowedDomains(url); err != nil {
+		return nil, err
+	}
+	maxDepth := opts.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = 3
+	}
+	maxURLs := opts.MaxURLs
+	if maxURLs == 0 {
+		maxURLs = 100
+	}
+	scanLimit := maxURLs * 5
+	if scanLimit < minScopeScanURLs {
+		scanLimit = minScopeScanURLs
+	}
+
+	scan, err := c.Scan(url, &ScanOptions{MaxUrls: scanLimit})
+	if err != nil {
+		return nil, fmt.Errorf("estimate scope: scan: %w", err)
+	}
+	discovered := make([]string, 0, len(scan.Urls))
+	for _, u := range scan.Urls {
+		discovered = append(discovered, u.URL)
+	}
+
+	est := &ScopeEstimate{
+		DiscoveredURLs: len(discovered),
+		DepthHistogram: map[int]int{},
+		Truncated:      scan.TotalUrls >= scanLimit,
+	}
+	filters, err := c.deepCrawlFilters(opts)
+	if err != nil {
+		return nil, err
+	}
+	est.Filtered = FilterPreview(filters, discovered)
+	var planned []string
+	for _, u := range est.Filtered.Included {
+		depth := pathDepthBelow(url, u)
+		if depth > maxDepth {
+			est.Filtered.Excluded = append(est.Filtered.Excluded, FilterDecision{
+				URL: u, Reason: fmt.Sprintf("depth %d exceeds MaxDepth %d", depth, maxDepth),
+			})
+			continue
+		}
+		est.InScopeURLs++
+		est.DepthHistogram[depth]++
+		if len(planned) < maxURLs {
+			planned = append(planned, u)
+		}
+	}
+	est.PlannedURLs = len(planned)
+	if est.PlannedURLs == 0 {
+		return est, nil
+	}
+
+	strategy := opts.CrawlStrategy
+	pageTime := scopeBrowserPageTime
+	if strategy == "http" {
+		pageTime = scopeHTTPPageTime
+	} else {
+		// "auto" may escalate to a browser; price the upper bound.
+		strategy = "browser"
+	}
+	est.EstimatedDuration = time.Duration(est.PlannedURLs) * pageTime
+
+	price, err := c.Estimate("crawl", buildRunManyBody(planned, &RunManyOptions{
+		Config: opts.Config, BrowserConfig: opts.BrowserConfig, Strategy: strategy,
+		Proxy: opts.Proxy, BypassCache: opts.BypassCache,
+	}))
+	if err != nil {
+		return est, fmt.Errorf("estimate scope: credits: %w", err)
+	}
+	est.EstimatedCredits = price.Credits
+	est.CreditsExact = price.CreditsExact
+	est.CoveredByBalance = price.CoveredByBalance
+	return est, nil
+}
+
+// pathDepthBelow counts how many path segments target sits below start.
+// URLs outside start's path tree are measured from the site root.
+func pathDepthBelow(start, target string) int {
+	segments := func(raw string) []string {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil
+		}
+		var out []string
+		for _, s := range strings.Split(u.Path, "/") {
+			if s != "" {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	base, segs := segments(start), segments(target)
+	if len(segs) < len(base) {
+		return 0
+	}
+	for i := range base {
+		if segs[i] != base[i] {
+			return len(segs)
+		}
+	}
+	return len(segs) - len(base)
+}
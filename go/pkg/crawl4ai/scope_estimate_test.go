@@ -0,0 +1,58 @@
+package crawl4ai
+
+import (
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestEstimateScope_PathDepth(t *testing.T) {
+	cases := map[string]int{
+		"https://x.com/docs":           0,
+		"https://x.com/docs/a":         1,
+		"https://x.com/docs/a/b/":      2,
+		"https://x.com/blog/2024/post": 3,
+		"https://x.com/":               0,
+	}
+	for target, want := range cases {
+		if got := pathDepthBelow("https://x.com/docs", target); got != want {
+			t.Errorf("pathDepthBelow(%q) = %d, want %d", target, got, want)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestEstimateScope_FiltersDepthAndPrice(t *testing.T) {
+	urls := []interface{}{}
+	for _, u := range []string{
+		"https://x.com/docs/a", "https://x.com/docs/b", "https://x.com/docs/a/b/c/d",
+		"https://x.com/blog/post", "https://x.com/docs/c",
+	} {
+		urls = append(urls, map[string]interface{}{"url": u})
+	}
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/scan":  map[string]interface{}{"success": true, "total_urls": len(urls), "urls": urls},
+		"POST /v1/crawl": map[string]interface{}{"credits": "2", "credits_exact": true, "covered_by_balance": true},
+	})
+
+	est, err := c.EstimateScope("https://x.com", &DeepCrawlOptions{
+		MaxDepth: 2, MaxURLs: 2, IncludePatterns: []string{"/docs/*"}, CrawlStrategy: "http",
+	})
+	if err != nil {
+		t.Fatalf("EstimateScope: %v", err)
+	}
+	if est.DiscoveredURLs != 5 || est.InScopeURLs != 3 || est.PlannedURLs != 2 {
+		t.Fatalf("unexpected counts: %+v", est)
+	}
+	if est.DepthHistogram[2] != 3 || len(est.Filtered.Excluded) != 2 {
+		t.Fatalf("unexpected histogram/exclusions: %v %v", est.DepthHistogram, est.Filtered.Excluded)
+	}
+	if est.EstimatedCredits != "2" || !est.CoveredByBalance || est.EstimatedDuration != time.Second {
+		t.Fatalf("unexpected pricing: %+v", est)
+	}
+	if est.Truncated {
+		t.Fatal("a small scan should not be marked truncated")
+	}
+}
@@ -0,0 +1,166 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Typed scorer configuration for best-first deep crawls. Build a
+// CompositeScorer and pass its map form as DeepCrawlOptions.Scorers (or
+// SiteOptions.Scorers):
+//
+//	scorers, err := (&crawl4ai.CompositeScorer{
+//	    Keywords:     &crawl4ai.KeywordScorer{Keywords: []string{"api", "guide"}, Weight: 3},
+//	    Depth:        &crawl4ai.DepthScorer{OptimalDepth: 2, Weight: 1},
+//	    PathPatterns: &crawl4ai.PathPatternScorer{Patterns: []crawl4ai.PathPattern{
+//	        {Pattern: "/docs/*", Score: 1}, {Pattern: "/blog/*", Score: 0.2},
+//	    }, Weight: 2},
+//	    Freshness: &crawl4ai.FreshnessScorer{Weight: 1},
+//	}).ToMap()
+//	result, err := crawler.DeepCrawl(url, &crawl4ai.DeepCrawlOptions{
+//	    Strategy: "best_first", Scorers: scorers, Wait: true,
+//	})
+//
+// Each crawled result then reports the score it was queued with; see
+// CrawlResult.Score.
+
+// KeywordScorer ranks URLs by how many keywords appear in them.
+type KeywordScorer struct {
+	Keywords []string
+	Weight   float64 // 0 = 1
+}
+
+// DepthScorer prefers URLs near OptimalDepth links from the start URL.
+type DepthScorer struct {
+	OptimalDepth int
+	Weight       float64 // 0 = 1
+}
+
+// PathPattern assigns Score (0-1) to URLs whose path matches the glob
+// Pattern. The first matching pattern wins.
+type PathPattern struct {
+	Pattern string  `json:"pattern"`
+	Score   float64 `json:"score"`
+}
+
+// PathPatternScorer ranks URLs by the first PathPattern their path matches;
+// unmatched URLs score 0.
+type PathPatternScorer struct {
+	Patterns []PathPattern
+	Weight   float64 // 0 = 1
+}
+
+// FreshnessScorer prefers URLs with recent dates in them (/2024/05/...),
+// decaying with age from CurrentYear.
+type FreshnessScorer struct {
+	CurrentYear int     // 0 = the server's current year
+	Weight      float64 // 0 = 1
+}
+
+// CompositeScorer combines scorers into the weighted sum best-first
+// crawling orders its queue by. Nil scorers are left out.
+type CompositeScorer struct {
+	Keywords     *KeywordScorer
+	Depth        *DepthScorer
+	PathPatterns *PathPatternScorer
+	Freshness    *FreshnessScorer
+}
+
+// Validate reports configuration errors the server would otherwise reject.
+func (s *CompositeScorer) Validate() error {
+	if s.Keywords == nil && s.Depth == nil && s.PathPatterns == nil && s.Freshness == nil {
+		return fmt.Errorf("scorer: at least one scorer is required")
+	}
+	for name, w := range s.weights() {
+		if w < 0 {
+			return fmt.Errorf("scorer: %s weight must not be negative", name)
+		}
+	}
+	if s.Keywords != nil && len(s.Keywords.Keywords) == 0 {
+		return fmt.Errorf("scorer: keywords scorer needs at least one keyword")
+	}
+	if s.Depth != nil && s.Depth.OptimalDepth < 0 {
+		return fmt.Errorf("scorer: optimal depth must not be negative")
+	}
+	if s.PathPatterns != nil {
+		if len(s.PathPatterns.Patterns) == 0 {
+			return fmt.Errorf("scorer: path scorer needs at least one pattern")
+		}
+		for _, p := range s.PathPatterns.Patterns {
+			if p.Pattern == "" || p.Score < 0 || p.Score > 1 {
+				return fmt.Errorf("scorer: path pattern %q needs a score between 0 and 1", p.Pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// weights returns each configured scorer's weight, defaulting 0 to 1.
+func (s *CompositeScorer) weights() map[string]float64 {
+	w := map[string]float64{}
+	set := func(name string, v float64) {
+		if v == 0 {
+			v = 1
+		}
+		w[name] = v
+	}
+	if s.Keywords != nil {
+		set("keywords", s.Keywords.Weight)
+	}
+	if s.Depth != nil {
+		set("depth", s.Depth.Weight)
+	}
+	if s.PathPatterns != nil {
+		set("path", s.PathPatterns.Weight)
+	}
+	if s.Freshness != nil {
+		set("freshness", s.Freshness.Weight)
+	}
+	return w
+}
+
+// ToMap validates the scorer and returns the "scorers" request body.
+func (s *CompositeScorer) ToMap() (map[string]interface{}, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{"weights": s.weights()}
+	if s.Keywords != nil {
+		m["keywords"] = s.Keywords.Keywords
+	}
+	if s.Depth != nil {
+		m["optimal_depth"] = s.Depth.OptimalDepth
+	}
+	if s.PathPatterns != nil {
+		m["path_patterns"] = s.PathPatterns.Patterns
+	}
+	if s.Freshness != nil {
+		fresh := map[string]interface{}{}
+		if s.Freshness.CurrentYear > 0 {
+			fresh["current_year"] = s.Freshness.CurrentYear
+		}
+		m["freshness"] = fresh
+	}
+	return m, nil
+}
+
+// Score returns the best-first priority score the URL was crawled with,
+// from the result's metadata. ok is false for results of crawls that
+// weren't scored.
+func (r *CrawlResult) Score() (score float64, ok bool) {
+	score, ok = r.Metadata["score"].(float64)
+	return score, ok
+}
+
+// SortByScore orders results by descending Score, unscored results last,
+// keeping the crawl order among equals.
+func SortByScore(results []*CrawlResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		si, iok := results[i].Score()
+		sj, jok := results[j].Score()
+		if iok != jok {
+			return iok
+		}
+		return si > sj
+	})
+}
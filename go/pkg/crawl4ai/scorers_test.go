@@ -0,0 +1,55 @@
+package crawl4ai
+
+import (
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestScorers_ToMap(t *testing.T) {
+	m, err := (&CompositeScorer{
+		Keywords:     &KeywordScorer{Keywords: []string{"api"}, Weight: 3},
+		PathPatterns: &PathPatternScorer{Patterns: []PathPattern{{Pattern: "/docs/*", Score: 1}}},
+		Freshness:    &FreshnessScorer{CurrentYear: 2026},
+	}).ToMap()
+	if err != nil {
+		t.Fatalf("ToMap: %v", err)
+	}
+	w := m["weights"].(map[string]float64)
+	if w["keywords"] != 3 || w["path"] != 1 || w["freshness"] != 1 || len(w) != 3 {
+		t.Fatalf("unexpected weights: %v", w)
+	}
+	if _, ok := m["optimal_depth"]; ok {
+		t.Fatal("unset depth scorer should be omitted")
+	}
+	if m["freshness"].(map[string]interface{})["current_year"] != 2026 {
+		t.Fatalf("unexpected freshness: %v", m["freshness"])
+	}
+}
+
+func TestScorers_Validate(t *testing.T) {
+	cases := map[string]*CompositeScorer{
+		"at least one":    {},
+		"negative":        {Depth: &DepthScorer{OptimalDepth: 2, Weight: -1}},
+		"keyword":         {Keywords: &KeywordScorer{}},
+		"between 0 and 1": {PathPatterns: &PathPatternScorer{Patterns: []PathPattern{{Pattern: "/a", Score: 2}}}},
+	}
+	for want, s := range cases {
+		if err := s.Validate(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestScorers_SortByScore(t *testing.T) {
+	results := []*CrawlResult{
+		{URL: "unscored"},
+		{URL: "low", Metadata: map[string]interface{}{"score": 0.2}},
+		{URL: "high", Metadata: map[string]interface{}{"score": 0.9}},
+	}
+	SortByScore(results)
+	if results[0].URL != "high" || results[1].URL != "low" || results[2].URL != "unscored" {
+		t.Fatalf("unexpected order: %s %s %s", results[0].URL, results[1].URL, results[2].URL)
+	}
+}
@@ -0,0 +1,175 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ScreenshotSiteOptions configures ScreenshotSite.
+type ScreenshotSiteOptions struct {
+	// Map tunes page discovery (sources, subdomains, query filter).
+	Map *MapOptions
+	// MaxPages caps how many discovered pages are captured, the start URL
+	// included. Default 50.
+	MaxPages int
+	// Screenshot applies to every capture (full page, PDF, wait condition).
+	Screenshot ScreenshotOptions
+	// PollInterval and Timeout control the wait for the screenshot job.
+	PollInterval time.Duration
+	Timeout      time.Duration
+}
+
+// ScreenshotSiteResult holds the captures of a ScreenshotSite run.
+type ScreenshotSiteResult struct {
+	Job *WrapperJob
+	// Pages lists the captured URLs in discovery order, start URL first.
+	Pages []string
+	// Artifacts holds the screenshots (and PDFs, when requested) in page
+	// order. Write them with WriteGallery.
+	Artifacts []Artifact
+	// Failed lists pages that produced no capture, with the reason.
+	Failed map[string]string
+	// Gallery is an index.html showing every capture, referencing the file
+	// names WriteGallery gives the artifacts.
+	Gallery string
+
+	crawler *AsyncWebCrawler
+}
+
+// ScreenshotSite discovers a site's pages with Map, captures each one in a
+// single screenshot job and assembles a gallery — for design reviews and
+// compliance archives.
+//
+//	shots, err := crawler.ScreenshotSite("https://example.com", &crawl4ai.ScreenshotSiteOptions{MaxPages: 20})
+//	index, err := shots.WriteGallery("review/2026-10")
+//	fmt.Println("open", index)
+func (c *AsyncWebCrawler) ScreenshotSite(url string, opts *ScreenshotSiteOptions) (*ScreenshotSiteResult, error) {
+	if opts == nil {
+		opts = &ScreenshotSiteOptions{}
+	}
+	if err := c.CheckAllowedDomains(url); err != nil {
+		return nil, err
+	}
+	maxPages := opts.MaxPages
+	if maxPages <= 0 {
+		maxPages = 50
+	}
+
+	mapped, err := c.Map(url, opts.Map)
+	if err != nil {
+		return nil, fmt.Errorf("screenshot site %s: discovery: %w", url, err)
+	}
+	pages := []string{url}
+	seen := map[string]bool{dedupeKey(url): true}
+	for _, u := range mapped.URLs {
+		if len(pages) >= maxPages {
+			break
+		}
+		key := dedupeKey(u.URL)
+		if u.URL == "" || seen[key] || c.CheckAllowedDomains(u.URL) != nil {
+			continue
+		}
+		seen[key] = true
+		pages = append(pages, u.URL)
+	}
+
+	job, err := c.ScreenshotAsync(pages, &ScreenshotAsyncOptions{
+		ScreenshotOptions: opts.Screenshot,
+		Wait:              true,
+		PollInterval:      opts.PollInterval,
+		Timeout:           c.jobWaitTimeout(opts.Timeout),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("screenshot site %s: %w", url, err)
+	}
+
+	out := &ScreenshotSiteResult{Job: job, Pages: pages, Failed: map[string]string{}, crawler: c}
+	for i := range job.Results {
+		r := &job.Results[i]
+		if !r.Success {
+			out.Failed[r.URL] = r.ErrorMessage
+			continue
+		}
+		if err := r.LoadOmitted(); err != nil {
+			out.Failed[r.URL] = err.Error()
+			continue
+		}
+		n := len(out.Artifacts)
+		for _, a := range resultArtifacts(r) {
+			if a.Type == ArtifactScreenshot || a.Type == ArtifactPDF {
+				out.Artifacts = append(out.Artifacts, a)
+			}
+		}
+		if len(out.Artifacts) == n {
+			out.Failed[r.URL] = "no screenshot in result"
+		}
+	}
+	out.Gallery = galleryHTML(url, out.Artifacts, out.Failed)
+	return out, nil
+}
+
+// WriteGallery saves every artifact into dir (created if needed) next to
+// an index.html, and returns the index path.
+func (s *ScreenshotSiteResult) WriteGallery(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	for i, a := range s.Artifacts {
+		if err := s.crawler.saveArtifact(a, filepath.Join(dir, artifactFileName(i, a))); err != nil {
+			return "", fmt.Errorf("artifact %d (%s of %s): %w", i, a.Type, a.SourceURL, err)
+		}
+	}
+	index := filepath.Join(dir, "index.html")
+	if err := os.WriteFile(index, []byte(s.Gallery), 0o644); err != nil {
+		return "", err
+	}
+	return index, nil
+}
+
+// galleryHTML renders a self-contained index page: one figure per capture,
+// then the pages that failed.
+func galleryHTML(site string, artifacts []Artifact, failed map[string]string) string {
+	var b strings.Builder
+	title := html.EscapeString("Screenshots of " + site)
+	fmt.Fprintf(&b, `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>%s</title>
+<style>
+body{font-family:sans-serif;margin:2em}
+.grid{display:grid;grid-template-columns:repeat(auto-fill,minmax(320px,1fr));gap:1.5em}
+figure{margin:0}img{width:100%%;border:1px solid #ccc}figcaption{font-size:.85em;word-break:break-all}
+</style></head><body>
+<h1>%s</h1>
+<p>%d captures, generated %s</p>
+<div class="grid">
+`, title, title, len(artifacts), time.Now().UTC().Format(time.RFC3339))
+	for i, a := range artifacts {
+		file := html.EscapeString(artifactFileName(i, a))
+		src := html.EscapeString(a.SourceURL)
+		if a.Type == ArtifactPDF {
+			fmt.Fprintf(&b, `<figure><a href="%s">PDF</a><figcaption><a href="%s">%s</a></figcaption></figure>`+"\n", file, src, src)
+			continue
+		}
+		fmt.Fprintf(&b, `<figure><a href="%s"><img src="%s" loading="lazy" alt="%s"></a><figcaption><a href="%s">%s</a></figcaption></figure>`+"\n",
+			file, file, src, src, src)
+	}
+	b.WriteString("</div>\n")
+	if len(failed) > 0 {
+		b.WriteString("<h2>Failed</h2>\n<ul>\n")
+		urls := make([]string, 0, len(failed))
+		for u := range failed {
+			urls = append(urls, u)
+		}
+		sort.Strings(urls)
+		for _, u := range urls {
+			fmt.Fprintf(&b, "<li>%s: %s</li>\n", html.EscapeString(u), html.EscapeString(failed[u]))
+		}
+		b.WriteString("</ul>\n")
+	}
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
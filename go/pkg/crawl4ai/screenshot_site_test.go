@@ -0,0 +1,62 @@
+package crawl4ai
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestScreenshotSite_GalleryFromMappedPages(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG fake"))
+	c := newMockCrawler(t, map[string]interface{}{
+		"POST /v1/map": map[string]interface{}{"success": true, "urls": []interface{}{
+			map[string]interface{}{"url": "https://a.com/"},
+			map[string]interface{}{"url": "https://a.com/about"},
+			map[string]interface{}{"url": "https://a.com/about#team"},
+			map[string]interface{}{"url": "https://a.com/broken"},
+			map[string]interface{}{"url": "https://a.com/extra"},
+		}},
+		"POST /v1/screenshot/async": map[string]interface{}{"job_id": "shot_1", "status": "pending"},
+		"GET /v1/screenshot/jobs/shot_1": map[string]interface{}{"job_id": "shot_1", "status": "completed", "url_statuses": []interface{}{
+			map[string]interface{}{"index": 0, "url": "https://a.com", "status": "done"},
+			map[string]interface{}{"index": 1, "url": "https://a.com/about", "status": "done"},
+			map[string]interface{}{"index": 2, "url": "https://a.com/broken", "status": "failed", "error": "HTTP 500"},
+		}},
+		"GET /v1/crawl/jobs/shot_1/result/0": map[string]interface{}{"url": "https://a.com", "success": true, "screenshot": png},
+		"GET /v1/crawl/jobs/shot_1/result/1": map[string]interface{}{"url": "https://a.com/about", "success": true, "screenshot": png},
+	})
+
+	shots, err := c.ScreenshotSite("https://a.com", &ScreenshotSiteOptions{MaxPages: 3, PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(shots.Pages, " ") != "https://a.com https://a.com/about https://a.com/broken" {
+		t.Fatalf("pages = %v", shots.Pages)
+	}
+	if len(shots.Artifacts) != 2 || shots.Failed["https://a.com/broken"] != "HTTP 500" {
+		t.Fatalf("artifacts %d failed %v", len(shots.Artifacts), shots.Failed)
+	}
+	for _, want := range []string{`<img src="000-a.com-screenshot.png"`, `001-a.com_about-screenshot.png`, "<li>https://a.com/broken: HTTP 500</li>"} {
+		if !strings.Contains(shots.Gallery, want) {
+			t.Errorf("gallery missing %q", want)
+		}
+	}
+
+	dir := filepath.Join(t.TempDir(), "gallery")
+	index, err := shots.WriteGallery(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index != filepath.Join(dir, "index.html") {
+		t.Fatalf("index = %s", index)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "001-a.com_about-screenshot.png"))
+	if err != nil || string(data) != "\x89PNG fake" {
+		t.Fatalf("screenshot file: %q, %v", data, err)
+	}
+}
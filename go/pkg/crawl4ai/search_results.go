@@ -0,0 +1,174 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchOptions are options for SearchJobResults.
+type SearchOptions struct {
+	// CaseSensitive matches the query exactly; by default case is ignored.
+	CaseSensitive bool
+	// MaxMatches stops the search after this many matches. Default 100.
+	MaxMatches int
+	// SnippetChars is roughly how much text each snippet shows around the
+	// first hit. Default 120.
+	SnippetChars int
+}
+
+// SearchMatch is one result field containing the query.
+type SearchMatch struct {
+	URL string
+	// Field is "markdown" or "extracted_content".
+	Field string
+	// Count is how many times the query occurs in the field.
+	Count int
+	// Snippet is the text around the first occurrence.
+	Snippet string
+}
+
+// SearchJobResults finds the pages of a stored job whose markdown or
+// extracted content contains query, with a snippet of each, to triage a
+// large crawl without downloading it:
+//
+//	matches, err := crawler.SearchJobResults(jobID, "out of stock", nil)
+//	for _, m := range matches {
+//	    fmt.Printf("%s (%d×): %s\n", m.URL, m.Count, m.Snippet)
+//	}
+//
+// The API searches when it supports it. Otherwise the results are fetched
+// and searched one at a time, so memory stays flat however large the job.
+func (c *AsyncWebCrawler) SearchJobResults(jobID, query string, opts *SearchOptions) ([]SearchMatch, error) {
+	if query == "" {
+		return nil, fmt.Errorf("search query is required")
+	}
+	o := SearchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxMatches <= 0 {
+		o.MaxMatches = 100
+	}
+	if o.SnippetChars <= 0 {
+		o.SnippetChars = 120
+	}
+
+	params := map[string]string{"q": query, "limit": strconv.Itoa(o.MaxMatches)}
+	if o.CaseSensitive {
+		params["case_sensitive"] = "true"
+	}
+	data, err := c.http.Get(fmt.Sprintf("/v1/crawl/jobs/%s/search", jobID), params)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return c.searchJobLocally(jobID, query, o)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var matches []SearchMatch
+	raw, _ := data["matches"].([]interface{})
+	for _, r := range raw {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		match := SearchMatch{}
+		match.URL, _ = m["url"].(string)
+		match.Field, _ = m["field"].(string)
+		match.Snippet, _ = m["snippet"].(string)
+		if n, ok := m["count"].(float64); ok {
+			match.Count = int(n)
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// searchJobLocally is SearchJobResults over results fetched one by one.
+func (c *AsyncWebCrawler) searchJobLocally(jobID, query string, o SearchOptions) ([]SearchMatch, error) {
+	job, err := c.GetJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	var matches []SearchMatch
+	search := func(r *CrawlResult) bool {
+		markdown := ""
+		if r.Markdown != nil {
+			markdown = r.Markdown.RawMarkdown
+		}
+		for _, f := range []struct{ name, text string }{
+			{"markdown", markdown},
+			{"extracted_content", r.ExtractedContent},
+		} {
+			if m, ok := searchText(f.text, query, o); ok {
+				m.URL, m.Field = r.URL, f.name
+				matches = append(matches, m)
+				if len(matches) >= o.MaxMatches {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	if len(job.Results) > 0 {
+		for _, r := range job.Results {
+			if !search(r) {
+				break
+			}
+		}
+		return matches, nil
+	}
+	for i := 0; i < job.URLsCount; i++ {
+		r, err := c.GetPerUrlResult(jobID, i)
+		if errors.Is(err, ErrSkipResult) {
+			continue
+		}
+		if err != nil {
+			return matches, fmt.Errorf("job %s result %d: %w", jobID, i, err)
+		}
+		if !search(r) {
+			break
+		}
+	}
+	return matches, nil
+}
+
+// searchText counts query in text and cuts a snippet around the first
+// occurrence.
+func searchText(text, query string, o SearchOptions) (SearchMatch, bool) {
+	haystack, needle := text, query
+	if !o.CaseSensitive {
+		haystack, needle = strings.ToLower(text), strings.ToLower(query)
+	}
+	// Lowercasing can change byte lengths; fall back to the lowered text
+	// for the snippet when it did.
+	if len(haystack) != len(text) {
+		text = haystack
+	}
+	first := strings.Index(haystack, needle)
+	if first < 0 {
+		return SearchMatch{}, false
+	}
+	m := SearchMatch{Count: strings.Count(haystack, needle)}
+
+	pad := max(0, (o.SnippetChars-len(needle))/2)
+	start, end := max(0, first-pad), min(len(text), first+len(needle)+pad)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	m.Snippet = strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		m.Snippet = "…" + m.Snippet
+	}
+	if end < len(text) {
+		m.Snippet += "…"
+	}
+	return m, true
+}
@@ -0,0 +1,67 @@
+package crawl4ai
+
+import (
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestSearchText(t *testing.T) {
+	text := "Intro text. " + strings.Repeat("filler ", 20) + "This item is OUT OF STOCK today.\n\nLater: out of stock again."
+	m, ok := searchText(text, "out of stock", SearchOptions{SnippetChars: 40})
+	if !ok || m.Count != 2 {
+		t.Fatalf("match = %+v, %v", m, ok)
+	}
+	if !strings.HasPrefix(m.Snippet, "…") || !strings.Contains(m.Snippet, "OUT OF STOCK") || !strings.HasSuffix(m.Snippet, "…") {
+		t.Errorf("snippet = %q", m.Snippet)
+	}
+	if _, ok := searchText(text, "out of stock", SearchOptions{CaseSensitive: true, SnippetChars: 40}); !ok {
+		t.Error("the lowercase occurrence should match case-sensitively")
+	}
+	if _, ok := searchText(text, "in stock", SearchOptions{SnippetChars: 40}); ok {
+		t.Error("unexpected match")
+	}
+	if m, _ := searchText("prix: 10 €, épuisé", "épuisé", SearchOptions{SnippetChars: 4}); !strings.HasSuffix(m.Snippet, "épuisé") {
+		t.Errorf("snippet should not split runes: %q", m.Snippet)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestSearchJobResults(t *testing.T) {
+	server := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1/search": map[string]interface{}{"matches": []interface{}{
+			map[string]interface{}{"url": "https://a.com", "field": "markdown", "count": 3.0, "snippet": "…sold out…"},
+		}},
+	})
+	matches, err := server.SearchJobResults("job_1", "sold out", nil)
+	if err != nil || len(matches) != 1 || matches[0].Count != 3 {
+		t.Fatalf("server-side: %+v, %v", matches, err)
+	}
+
+	local := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{"job_id": "job_1", "status": "completed", "urls_count": 3},
+		"GET /v1/crawl/jobs/job_1/result/0": map[string]interface{}{"url": "https://a.com", "success": true,
+			"markdown": "Widget is sold out.", "extracted_content": `[{"status": "Sold Out"}]`},
+		"GET /v1/crawl/jobs/job_1/result/1": map[string]interface{}{"url": "https://b.com", "success": true, "markdown": "In stock."},
+		"GET /v1/crawl/jobs/job_1/result/2": map[string]interface{}{"url": "https://c.com", "success": true, "markdown": "sold out"},
+	})
+	matches, err = local.SearchJobResults("job_1", "sold out", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.URL+" "+m.Field)
+	}
+	if strings.Join(got, ", ") != "https://a.com markdown, https://a.com extracted_content, https://c.com markdown" {
+		t.Errorf("matches = %v", got)
+	}
+	if matches, _ := local.SearchJobResults("job_1", "sold out", &SearchOptions{MaxMatches: 1}); len(matches) != 1 {
+		t.Errorf("MaxMatches: %d", len(matches))
+	}
+	if _, err := local.SearchJobResults("job_x", "sold out", nil); err == nil {
+		t.Error("expected an error for a missing job")
+	}
+}
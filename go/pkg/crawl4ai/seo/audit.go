@@ -0,0 +1,226 @@
+// Package seo builds SEO audit reports from crawl4ai crawl results.
+//
+//	deep, _ := crawler.DeepCrawl("https://example.com", &crawl4ai.DeepCrawlOptions{MaxURLs: 500, Wait: true})
+//	report, err := seo.Audit(crawler, deep.DeepResult.CrawlJobID)
+//	f, _ := os.Create("audit.html")
+//	defer f.Close()
+//	report.WriteHTML(f)
+package seo
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ResultSource loads the results of a crawl job. *crawl4ai.AsyncWebCrawler
+// implements it.
+type ResultSource interface {
+	JobResults(jobID string) ([]*crawl4ai.CrawlResult, error)
+}
+
+// Report is an SEO audit of one crawl. URL lists are sorted.
+type Report struct {
+	JobID string
+	Pages int
+	// StatusCodes counts pages by HTTP status; 0 means the fetch failed
+	// before a response arrived.
+	StatusCodes map[int]int
+
+	MissingTitle       []string
+	MissingDescription []string
+	// DuplicateTitles maps each title shared by more than one page to
+	// those pages.
+	DuplicateTitles map[string][]string
+	// BrokenLinks are internal links to crawled pages that failed or
+	// answered 4xx/5xx. Links to pages outside the crawl aren't checked.
+	BrokenLinks         []BrokenLink
+	CanonicalMismatches []CanonicalMismatch
+}
+
+// BrokenLink is an internal link whose target failed.
+type BrokenLink struct {
+	Source     string
+	Target     string
+	StatusCode int
+	Error      string
+}
+
+// CanonicalMismatch is a page whose rel=canonical points somewhere other
+// than the URL it was served from.
+type CanonicalMismatch struct {
+	URL       string
+	Canonical string
+}
+
+// IssueCount is the total number of findings in the report.
+func (r *Report) IssueCount() int {
+	n := len(r.MissingTitle) + len(r.MissingDescription) + len(r.BrokenLinks) + len(r.CanonicalMismatches)
+	for _, urls := range r.DuplicateTitles {
+		n += len(urls)
+	}
+	return n
+}
+
+// Audit loads every result of jobID (a crawl job, e.g. a deep crawl's
+// CrawlJobID) and audits it.
+func Audit(src ResultSource, jobID string) (*Report, error) {
+	results, err := src.JobResults(jobID)
+	if err != nil {
+		return nil, err
+	}
+	report := AuditResults(results)
+	report.JobID = jobID
+	return report, nil
+}
+
+// AuditResults audits results already in hand.
+func AuditResults(results []*crawl4ai.CrawlResult) *Report {
+	r := &Report{
+		Pages:           len(results),
+		StatusCodes:     map[int]int{},
+		DuplicateTitles: map[string][]string{},
+	}
+
+	byURL := make(map[string]*crawl4ai.CrawlResult, len(results))
+	titles := map[string][]string{}
+	for _, res := range results {
+		r.StatusCodes[res.StatusCode]++
+		byURL[normalize(res.URL)] = res
+		if res.RedirectedURL != "" {
+			byURL[normalize(res.RedirectedURL)] = res
+		}
+		if !res.Success {
+			continue
+		}
+
+		title := strings.TrimSpace(metaString(res, "title"))
+		if title == "" {
+			r.MissingTitle = append(r.MissingTitle, res.URL)
+		} else {
+			titles[title] = append(titles[title], res.URL)
+		}
+		if strings.TrimSpace(metaString(res, "description")) == "" {
+			r.MissingDescription = append(r.MissingDescription, res.URL)
+		}
+
+		if canonical := canonicalURL(res); canonical != "" {
+			served := res.URL
+			if res.RedirectedURL != "" {
+				served = res.RedirectedURL
+			}
+			if resolved := resolve(served, canonical); normalize(resolved) != normalize(served) {
+				r.CanonicalMismatches = append(r.CanonicalMismatches, CanonicalMismatch{URL: res.URL, Canonical: resolved})
+			}
+		}
+	}
+	for title, urls := range titles {
+		if len(urls) > 1 {
+			sort.Strings(urls)
+			r.DuplicateTitles[title] = urls
+		}
+	}
+
+	for _, res := range results {
+		if !res.Success {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, href := range internalLinks(res) {
+			target := normalize(resolve(res.URL, href))
+			if seen[target] {
+				continue
+			}
+			seen[target] = true
+			dest, ok := byURL[target]
+			if !ok || (dest.Success && dest.StatusCode < 400) {
+				continue
+			}
+			r.BrokenLinks = append(r.BrokenLinks, BrokenLink{
+				Source: res.URL, Target: dest.URL, StatusCode: dest.StatusCode, Error: dest.ErrorMessage,
+			})
+		}
+	}
+
+	sort.Strings(r.MissingTitle)
+	sort.Strings(r.MissingDescription)
+	sort.Slice(r.BrokenLinks, func(i, j int) bool {
+		a, b := r.BrokenLinks[i], r.BrokenLinks[j]
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Source < b.Source
+	})
+	sort.Slice(r.CanonicalMismatches, func(i, j int) bool {
+		return r.CanonicalMismatches[i].URL < r.CanonicalMismatches[j].URL
+	})
+	return r
+}
+
+func metaString(res *crawl4ai.CrawlResult, key string) string {
+	s, _ := res.Metadata[key].(string)
+	return s
+}
+
+var canonicalTag = regexp.MustCompile(`(?is)<link\b[^>]*\brel\s*=\s*["']?canonical["']?[^>]*>`)
+var hrefAttr = regexp.MustCompile(`(?is)\bhref\s*=\s*["']([^"']+)["']`)
+
+// canonicalURL reads the page's canonical URL from metadata, falling back
+// to the <link rel="canonical"> tag in its HTML.
+func canonicalURL(res *crawl4ai.CrawlResult) string {
+	for _, key := range []string{"canonical", "canonical_url"} {
+		if s := metaString(res, key); s != "" {
+			return s
+		}
+	}
+	tag := canonicalTag.FindString(res.HTML)
+	if m := hrefAttr.FindStringSubmatch(tag); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
+
+// internalLinks returns the hrefs in the result's links["internal"].
+func internalLinks(res *crawl4ai.CrawlResult) []string {
+	items, _ := res.Links["internal"].([]interface{})
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case string:
+			out = append(out, v)
+		case map[string]interface{}:
+			if href, ok := v["href"].(string); ok {
+				out = append(out, href)
+			}
+		}
+	}
+	return out
+}
+
+func resolve(base, ref string) string {
+	b, err := url.Parse(base)
+	if err != nil {
+		return ref
+	}
+	u, err := b.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return u.String()
+}
+
+// normalize drops the fragment, a trailing slash and host case, so
+// equivalent URLs compare equal.
+func normalize(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Fragment = ""
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	return u.String()
+}
@@ -0,0 +1,81 @@
+package seo
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+type fakeSource []*crawl4ai.CrawlResult
+
+func (f fakeSource) JobResults(string) ([]*crawl4ai.CrawlResult, error) { return f, nil }
+
+func page(url, title, desc string, links ...string) *crawl4ai.CrawlResult {
+	internal := make([]interface{}, len(links))
+	for i, l := range links {
+		internal[i] = map[string]interface{}{"href": l}
+	}
+	return &crawl4ai.CrawlResult{
+		URL: url, Success: true, StatusCode: 200,
+		Metadata: map[string]interface{}{"title": title, "description": desc},
+		Links:    map[string]interface{}{"internal": internal},
+	}
+}
+
+func TestAudit(t *testing.T) {
+	home := page("https://a.com/", "Home", "Welcome", "/docs", "/gone#top", "/missing")
+	docs := page("https://a.com/docs", "Docs", "", "/")
+	docs.HTML = `<head><link href="https://a.com/docs/" rel="canonical"></head>`
+	dupe := page("https://a.com/docs/v1", "Docs", "Old docs")
+	dupe.Metadata["canonical"] = "/docs"
+	untitled := page("https://a.com/about", "", "About us")
+	gone := &crawl4ai.CrawlResult{URL: "https://a.com/gone", StatusCode: 404, ErrorMessage: "Not Found"}
+
+	report, err := Audit(fakeSource{home, docs, dupe, untitled, gone}, "job_1")
+	if err != nil {
+		t.Fatalf("Audit: %v", err)
+	}
+	if report.JobID != "job_1" || report.Pages != 5 {
+		t.Fatalf("unexpected header: %+v", report)
+	}
+	if !reflect.DeepEqual(report.StatusCodes, map[int]int{200: 4, 404: 1}) {
+		t.Fatalf("unexpected status codes: %v", report.StatusCodes)
+	}
+	if !reflect.DeepEqual(report.MissingTitle, []string{"https://a.com/about"}) {
+		t.Fatalf("unexpected missing titles: %v", report.MissingTitle)
+	}
+	if !reflect.DeepEqual(report.MissingDescription, []string{"https://a.com/docs"}) {
+		t.Fatalf("unexpected missing descriptions: %v", report.MissingDescription)
+	}
+	if !reflect.DeepEqual(report.DuplicateTitles, map[string][]string{"Docs": {"https://a.com/docs", "https://a.com/docs/v1"}}) {
+		t.Fatalf("unexpected duplicates: %v", report.DuplicateTitles)
+	}
+	wantBroken := []BrokenLink{{Source: "https://a.com/", Target: "https://a.com/gone", StatusCode: 404, Error: "Not Found"}}
+	if !reflect.DeepEqual(report.BrokenLinks, wantBroken) {
+		t.Fatalf("unexpected broken links: %+v", report.BrokenLinks)
+	}
+	wantCanon := []CanonicalMismatch{{URL: "https://a.com/docs/v1", Canonical: "https://a.com/docs"}}
+	if !reflect.DeepEqual(report.CanonicalMismatches, wantCanon) {
+		t.Fatalf("unexpected canonical mismatches: %+v", report.CanonicalMismatches)
+	}
+	if report.IssueCount() != 6 {
+		t.Fatalf("expected 6 issues, got %d", report.IssueCount())
+	}
+
+	var csvOut, htmlOut bytes.Buffer
+	if err := report.WriteCSV(&csvOut); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	if !strings.Contains(csvOut.String(), "broken_link,https://a.com/,https://a.com/gone (404)\n") {
+		t.Fatalf("unexpected CSV:\n%s", csvOut.String())
+	}
+	if err := report.WriteHTML(&htmlOut); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	if !strings.Contains(htmlOut.String(), "5 pages, 6 issues.") {
+		t.Fatalf("unexpected HTML:\n%s", htmlOut.String())
+	}
+}
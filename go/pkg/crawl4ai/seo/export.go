@@ -0,0 +1,124 @@
+package seo
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Issue types used in CSV rows.
+const (
+	IssueMissingTitle       = "missing_title"
+	IssueMissingDescription = "missing_description"
+	IssueDuplicateTitle     = "duplicate_title"
+	IssueBrokenLink         = "broken_link"
+	IssueCanonicalMismatch  = "canonical_mismatch"
+)
+
+// WriteCSV writes one row per finding with the columns issue, url and
+// detail (the duplicated title, the broken target and its status, or the
+// canonical URL).
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"issue", "url", "detail"}); err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, u := range r.MissingTitle {
+		rows = append(rows, []string{IssueMissingTitle, u, ""})
+	}
+	for _, u := range r.MissingDescription {
+		rows = append(rows, []string{IssueMissingDescription, u, ""})
+	}
+	for _, title := range r.duplicateTitleKeys() {
+		for _, u := range r.DuplicateTitles[title] {
+			rows = append(rows, []string{IssueDuplicateTitle, u, title})
+		}
+	}
+	for _, l := range r.BrokenLinks {
+		rows = append(rows, []string{IssueBrokenLink, l.Source, l.describe()})
+	}
+	for _, m := range r.CanonicalMismatches {
+		rows = append(rows, []string{IssueCanonicalMismatch, m.URL, m.Canonical})
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// WriteHTML writes the report as a self-contained HTML page.
+func (r *Report) WriteHTML(w io.Writer) error {
+	type statusRow struct{ Code, Pages int }
+	var statuses []statusRow
+	for code, n := range r.StatusCodes {
+		statuses = append(statuses, statusRow{code, n})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Code < statuses[j].Code })
+
+	type dupRow struct {
+		Title string
+		URLs  []string
+	}
+	var dups []dupRow
+	for _, title := range r.duplicateTitleKeys() {
+		dups = append(dups, dupRow{title, r.DuplicateTitles[title]})
+	}
+
+	return htmlReport.Execute(w, map[string]interface{}{
+		"R":          r,
+		"Statuses":   statuses,
+		"Duplicates": dups,
+	})
+}
+
+func (r *Report) duplicateTitleKeys() []string {
+	keys := make([]string, 0, len(r.DuplicateTitles))
+	for k := range r.DuplicateTitles {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (l BrokenLink) describe() string {
+	if l.StatusCode == 0 {
+		if l.Error != "" {
+			return fmt.Sprintf("%s (%s)", l.Target, l.Error)
+		}
+		return l.Target + " (failed)"
+	}
+	return l.Target + " (" + strconv.Itoa(l.StatusCode) + ")"
+}
+
+var htmlReport = template.Must(template.New("seo").Funcs(template.FuncMap{
+	"describe": BrokenLink.describe,
+}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>SEO audit{{with .R.JobID}} — {{.}}{{end}}</title>
+<style>body{font-family:sans-serif;margin:2em}table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:4px 8px;text-align:left}</style>
+</head><body>
+<h1>SEO audit{{with .R.JobID}} — {{.}}{{end}}</h1>
+<p>{{.R.Pages}} pages, {{.R.IssueCount}} issues.</p>
+<h2>Status codes</h2>
+<table><tr><th>Status</th><th>Pages</th></tr>
+{{range .Statuses}}<tr><td>{{if .Code}}{{.Code}}{{else}}failed{{end}}</td><td>{{.Pages}}</td></tr>
+{{end}}</table>
+<h2>Missing titles ({{len .R.MissingTitle}})</h2>
+<ul>{{range .R.MissingTitle}}<li>{{.}}</li>{{end}}</ul>
+<h2>Missing descriptions ({{len .R.MissingDescription}})</h2>
+<ul>{{range .R.MissingDescription}}<li>{{.}}</li>{{end}}</ul>
+<h2>Duplicate titles ({{len .Duplicates}})</h2>
+{{range .Duplicates}}<h3>{{.Title}}</h3><ul>{{range .URLs}}<li>{{.}}</li>{{end}}</ul>
+{{end}}<h2>Broken internal links ({{len .R.BrokenLinks}})</h2>
+<table><tr><th>Page</th><th>Link</th></tr>
+{{range .R.BrokenLinks}}<tr><td>{{.Source}}</td><td>{{describe .}}</td></tr>
+{{end}}</table>
+<h2>Canonical mismatches ({{len .R.CanonicalMismatches}})</h2>
+<table><tr><th>Page</th><th>Canonical</th></tr>
+{{range .R.CanonicalMismatches}}<tr><td>{{.URL}}</td><td>{{.Canonical}}</td></tr>
+{{end}}</table>
+</body></html>
+`))
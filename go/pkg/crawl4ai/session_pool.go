@@ -0,0 +1,285 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrSessionPoolClosed is returned by Acquire after Close.
+var ErrSessionPoolClosed = errors.New("crawl4ai: session pool closed")
+
+// BrowserSession is a warm cloud browser session. Connect to WSURL over the
+// Chrome DevTools Protocol — Playwright's connectOverCDP, Puppeteer's
+// browserWSEndpoint or a Go CDP client — to drive the browser directly; its
+// cookies, storage and open pages persist until the session is released or
+// times out.
+type BrowserSession struct {
+	ID    string
+	WSURL string
+	// ExpiresAt is when the server times the session out.
+	ExpiresAt time.Time
+}
+
+// SessionPoolOptions configure NewSessionPool.
+type SessionPoolOptions struct {
+	// Size is how many sessions to keep warm. Default 2.
+	Size int
+	// Timeout is the lifetime requested for each session. Default 10
+	// minutes.
+	Timeout time.Duration
+	// ReplaceBefore swaps a session for a fresh one this long before it
+	// times out. Default Timeout/5.
+	ReplaceBefore time.Duration
+}
+
+// SessionPool keeps Size cloud browser sessions warm and hands them out,
+// cutting browser cold-start latency for services that drive a browser
+// over CDP. Sessions can't be extended, so a background loop creates a
+// replacement for each one shortly before it times out and releases the
+// old one once it is idle.
+//
+//	pool, err := crawler.NewSessionPool(ctx, &crawl4ai.SessionPoolOptions{Size: 4})
+//	defer pool.Close()
+//	s, err := pool.Acquire(ctx)
+//	defer pool.Release(s)
+//	browser, err := pw.Chromium.ConnectOverCDP(s.WSURL)
+type SessionPool struct {
+	c    *AsyncWebCrawler
+	opts SessionPoolOptions
+
+	ready chan struct{} // one token per idle session
+
+	mu       sync.Mutex
+	idle     []*BrowserSession
+	all      map[*BrowserSession]bool
+	replaced map[*BrowserSession]*BrowserSession // in-use session -> its replacement
+	closed   bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSessionPool creates the pool's sessions and starts replacing them as
+// they near their timeout. If any session can't be created, the ones
+// already made are released and the error is returned.
+func (c *AsyncWebCrawler) NewSessionPool(ctx context.Context, opts *SessionPoolOptions) (*SessionPool, error) {
+	o := SessionPoolOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Size <= 0 {
+		o.Size = 2
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Minute
+	}
+	if o.ReplaceBefore <= 0 || o.ReplaceBefore >= o.Timeout {
+		o.ReplaceBefore = o.Timeout / 5
+	}
+
+	p := &SessionPool{
+		c:        c,
+		opts:     o,
+		ready:    make(chan struct{}, o.Size),
+		all:      map[*BrowserSession]bool{},
+		replaced: map[*BrowserSession]*BrowserSession{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	for i := 0; i < o.Size; i++ {
+		if err := ctx.Err(); err != nil {
+			p.releaseAll()
+			return nil, err
+		}
+		s, err := p.create()
+		if err != nil {
+			p.releaseAll()
+			return nil, fmt.Errorf("session pool: %w", err)
+		}
+		p.all[s] = true
+		p.idle = append(p.idle, s)
+		p.ready <- struct{}{}
+	}
+	go p.replaceLoop()
+	return p, nil
+}
+
+// Acquire takes an idle session, waiting until one is released or ctx is
+// done. Every acquired session must be given back with Release.
+func (p *SessionPool) Acquire(ctx context.Context) (*BrowserSession, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-p.stop:
+		return nil, ErrSessionPoolClosed
+	case <-p.ready:
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, ErrSessionPoolClosed
+	}
+	s := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return s, nil
+}
+
+// Release returns a session to the pool. A session that was replaced
+// while in use is released on the server and its replacement goes back
+// in its place.
+func (p *SessionPool) Release(s *BrowserSession) {
+	p.mu.Lock()
+	next, swapped := p.replaced[s]
+	if swapped {
+		delete(p.replaced, s)
+	} else {
+		next = s
+	}
+	if !p.closed && p.all[next] {
+		p.idle = append(p.idle, next)
+		p.ready <- struct{}{}
+	}
+	p.mu.Unlock()
+	if swapped {
+		_ = p.release(s)
+	}
+}
+
+// Close stops the replacement loop and releases every session on the
+// server. Sessions still in use are released too; their CDP connections
+// will drop.
+func (p *SessionPool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.stop)
+	p.mu.Unlock()
+	<-p.done
+	return p.releaseAll()
+}
+
+func (p *SessionPool) releaseAll() error {
+	p.mu.Lock()
+	sessions := make([]*BrowserSession, 0, len(p.all)+len(p.replaced))
+	for s := range p.all {
+		sessions = append(sessions, s)
+	}
+	for s := range p.replaced {
+		sessions = append(sessions, s)
+	}
+	p.all = map[*BrowserSession]bool{}
+	p.replaced = map[*BrowserSession]*BrowserSession{}
+	p.idle = nil
+	p.mu.Unlock()
+
+	var firstErr error
+	for _, s := range sessions {
+		if err := p.release(s); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// release deletes s on the server; a session that already timed out is
+// not an error.
+func (p *SessionPool) release(s *BrowserSession) error {
+	_, err := p.c.http.Delete(fmt.Sprintf("/v1/sessions/%s", s.ID))
+	var nf *NotFoundError
+	if errors.As(err, &nf) {
+		return nil
+	}
+	return err
+}
+
+func (p *SessionPool) create() (*BrowserSession, error) {
+	body := map[string]interface{}{"timeout": int(p.opts.Timeout.Seconds())}
+	data, err := p.c.http.Post("/v1/sessions", body, 0)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := data["session_id"].(string)
+	if id == "" {
+		return nil, fmt.Errorf("create session: response has no session_id")
+	}
+	wsURL, _ := data["ws_url"].(string)
+	return &BrowserSession{ID: id, WSURL: wsURL, ExpiresAt: p.expiry(data)}, nil
+}
+
+// expiry reads expires_in (seconds) or expires_at from a session response,
+// falling back to now+Timeout.
+func (p *SessionPool) expiry(data map[string]interface{}) time.Time {
+	if secs, ok := data["expires_in"].(float64); ok && secs > 0 {
+		return time.Now().Add(time.Duration(secs * float64(time.Second)))
+	}
+	if s, ok := data["expires_at"].(string); ok {
+		if t, err := parseAPITime(s); err == nil {
+			return t
+		}
+	}
+	return time.Now().Add(p.opts.Timeout)
+}
+
+func (p *SessionPool) replaceLoop() {
+	defer close(p.done)
+	ticker := time.NewTicker(p.opts.ReplaceBefore / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.replaceDue()
+		}
+	}
+}
+
+// replaceDue creates a fresh session for every one within ReplaceBefore
+// of its timeout. Creation failures are retried on the next tick.
+func (p *SessionPool) replaceDue() {
+	p.mu.Lock()
+	var due []*BrowserSession
+	deadline := time.Now().Add(p.opts.ReplaceBefore)
+	for s := range p.all {
+		if s.ExpiresAt.Before(deadline) {
+			due = append(due, s)
+		}
+	}
+	p.mu.Unlock()
+
+	for _, s := range due {
+		fresh, err := p.create()
+		if err != nil {
+			continue
+		}
+		p.replace(s, fresh)
+	}
+}
+
+func (p *SessionPool) replace(old, fresh *BrowserSession) {
+	p.mu.Lock()
+	if p.closed {
+		// Close is waiting on this loop; don't leak the new session.
+		p.mu.Unlock()
+		_ = p.release(fresh)
+		return
+	}
+	delete(p.all, old)
+	p.all[fresh] = true
+	for i, s := range p.idle {
+		if s == old {
+			p.idle[i] = fresh
+			p.mu.Unlock()
+			_ = p.release(old)
+			return
+		}
+	}
+	p.replaced[old] = fresh
+	p.mu.Unlock()
+}
@@ -0,0 +1,133 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// sessionServer fakes POST /v1/sessions and DELETE /v1/sessions/{id}.
+type sessionServer struct {
+	mu       sync.Mutex
+	created  int
+	timeouts []float64 // "timeout" per create
+	deleted  []string
+	lifetime time.Duration
+}
+
+func newSessionPoolCrawler(t *testing.T, s *sessionServer) *AsyncWebCrawler {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v1/sessions":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			timeout, _ := body["timeout"].(float64)
+			s.timeouts = append(s.timeouts, timeout)
+			s.created++
+			id := fmt.Sprintf("s%d", s.created)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"session_id": id, "ws_url": "wss://browser.test/" + id, "expires_in": s.lifetime.Seconds(),
+			})
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/v1/sessions/"):
+			s.deleted = append(s.deleted, strings.TrimPrefix(r.URL.Path, "/v1/sessions/"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestSessionPool_AcquireRelease(t *testing.T) {
+	s := &sessionServer{lifetime: time.Hour}
+	c := newSessionPoolCrawler(t, s)
+	pool, err := c.NewSessionPool(context.Background(), &SessionPoolOptions{Size: 2, Timeout: 5 * time.Minute})
+	if err != nil {
+		t.Fatalf("NewSessionPool: %v", err)
+	}
+
+	a, _ := pool.Acquire(context.Background())
+	b, _ := pool.Acquire(context.Background())
+	if a.WSURL != "wss://browser.test/"+a.ID || a.ExpiresAt.Before(time.Now().Add(50*time.Minute)) {
+		t.Fatalf("expected ws_url and expiry from the response, got %+v", a)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected Acquire to block while the pool is empty, got %v", err)
+	}
+	pool.Release(a)
+	pool.Release(b)
+
+	if err := pool.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := pool.Acquire(context.Background()); err != ErrSessionPoolClosed {
+		t.Fatalf("expected ErrSessionPoolClosed, got %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.created != 2 || s.timeouts[0] != 300 {
+		t.Fatalf("expected 2 sessions with a 300s timeout, got created=%d timeouts=%v", s.created, s.timeouts)
+	}
+	if len(s.deleted) != 2 {
+		t.Fatalf("expected both sessions released on Close, got %v", s.deleted)
+	}
+}
+
+func TestSessionPool_ReplacesBeforeTimeout(t *testing.T) {
+	s := &sessionServer{lifetime: 60 * time.Millisecond}
+	c := newSessionPoolCrawler(t, s)
+	pool, err := c.NewSessionPool(context.Background(), &SessionPoolOptions{
+		Size: 2, Timeout: time.Minute, ReplaceBefore: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewSessionPool: %v", err)
+	}
+	held, _ := pool.Acquire(context.Background())
+	time.Sleep(60 * time.Millisecond)
+
+	s.mu.Lock()
+	deletedWhileHeld := append([]string(nil), s.deleted...)
+	s.mu.Unlock()
+	for _, id := range deletedWhileHeld {
+		if id == held.ID {
+			t.Fatal("a session in use must not be released under its caller")
+		}
+	}
+
+	pool.Release(held)
+	got, _ := pool.Acquire(context.Background())
+	pool.Release(got)
+	pool.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.created < 4 {
+		t.Fatalf("expected both sessions replaced, created=%d", s.created)
+	}
+	if got.ID == "s1" || got.ID == "s2" {
+		t.Fatalf("expected a replacement session, got %s", got.ID)
+	}
+	released := strings.Join(s.deleted, ",")
+	if !strings.Contains(released, held.ID) {
+		t.Fatalf("expected the replaced session released once idle, got %v", s.deleted)
+	}
+}
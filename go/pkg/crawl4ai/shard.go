@@ -0,0 +1,161 @@
+package crawl4ai
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ShardSettings override the crawl settings for one domain's shards.
+type ShardSettings struct {
+	Strategy string
+	Proxy    interface{}
+	Config   *CrawlerRunConfig
+}
+
+// ShardOptions configure RunSharded.
+type ShardOptions struct {
+	// Base applies to every shard. Wait is implied; Timeout bounds each
+	// shard's job.
+	Base RunManyOptions
+	// PerDomain overrides Base for a domain (e.g. a residential proxy for
+	// a site that blocks datacenter IPs). Keys are hosts without "www.".
+	PerDomain map[string]ShardSettings
+	// MaxURLsPerJob splits a domain with more URLs into several jobs.
+	// Default 1000.
+	MaxURLsPerJob int
+	// Parallelism is how many shard jobs run at once. Default 4.
+	Parallelism int
+	// OnShardDone, when set, is called as each shard finishes. Calls are
+	// serialised, so the callback needs no locking of its own.
+	OnShardDone func(ShardOutcome)
+}
+
+// Shard is one planned job: URLs from a single domain.
+type Shard struct {
+	Domain string
+	URLs   []string
+}
+
+// ShardOutcome is a shard's job and results, or the error that stopped it.
+type ShardOutcome struct {
+	Shard  Shard
+	Job    *CrawlJob
+	Result *RunManyResult
+	Err    error
+}
+
+// ShardedResult merges every shard's results.
+type ShardedResult struct {
+	// Shards is in plan order.
+	Shards []ShardOutcome
+	// Results holds every successful shard's results, in plan order.
+	Results []*CrawlResult
+}
+
+// PlanShards groups urls by domain (ignoring "www.") and splits each group
+// into chunks of at most maxPerJob URLs. Domains appear in order of first
+// occurrence, so the plan is stable for a given input.
+func PlanShards(urls []string, maxPerJob int) []Shard {
+	if maxPerJob <= 0 {
+		maxPerJob = 1000
+	}
+	groups := map[string][]string{}
+	var order []string
+	for _, u := range urls {
+		d := profileDomain(u)
+		if _, ok := groups[d]; !ok {
+			order = append(order, d)
+		}
+		groups[d] = append(groups[d], u)
+	}
+	var shards []Shard
+	for _, d := range order {
+		g := groups[d]
+		for len(g) > 0 {
+			n := len(g)
+			if n > maxPerJob {
+				n = maxPerJob
+			}
+			shards = append(shards, Shard{Domain: d, URLs: g[:n]})
+			g = g[n:]
+		}
+	}
+	return shards
+}
+
+// RunSharded crawls a large URL list as one async job per domain chunk,
+// running at most Parallelism jobs at a time, and merges the results.
+// Each domain can get its own strategy and proxy through PerDomain, and
+// one slow or blocked site no longer holds up the rest.
+//
+//	res, err := crawler.RunSharded(urls, &crawl4ai.ShardOptions{
+//	    Base:        crawl4ai.RunManyOptions{Strategy: "http"},
+//	    PerDomain:   map[string]crawl4ai.ShardSettings{"shop.example.com": {Strategy: "browser", Proxy: "residential"}},
+//	    Parallelism: 8,
+//	})
+//
+// The returned error joins every shard's failure; results from the shards
+// that succeeded are returned alongside it.
+func (c *AsyncWebCrawler) RunSharded(urls []string, opts *ShardOptions) (*ShardedResult, error) {
+	if opts == nil {
+		opts = &ShardOptions{}
+	}
+	if err := c.CheckAllowedDomains(urls...); err != nil {
+		return nil, err
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = 4
+	}
+	shards := PlanShards(urls, opts.MaxURLsPerJob)
+	outcomes := make([]ShardOutcome, len(shards))
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	var doneMu sync.Mutex
+	for i, shard := range shards {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, shard Shard) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			run := opts.Base
+			run.Wait = true
+			if s, ok := opts.PerDomain[shard.Domain]; ok {
+				if s.Strategy != "" {
+					run.Strategy = s.Strategy
+				}
+				if s.Proxy != nil {
+					run.Proxy = s.Proxy
+				}
+				if s.Config != nil {
+					run.Config = s.Config
+				}
+			}
+			out := ShardOutcome{Shard: shard}
+			out.Result, out.Err = c.RunMany(shard.URLs, &run)
+			if out.Result != nil {
+				out.Job = out.Result.Job
+			}
+			outcomes[i] = out
+			if opts.OnShardDone != nil {
+				doneMu.Lock()
+				opts.OnShardDone(out)
+				doneMu.Unlock()
+			}
+		}(i, shard)
+	}
+	wg.Wait()
+
+	res := &ShardedResult{Shards: outcomes}
+	var errs []error
+	for _, o := range outcomes {
+		if o.Err != nil {
+			errs = append(errs, fmt.Errorf("shard %s (%d URLs): %w", o.Shard.Domain, len(o.Shard.URLs), o.Err))
+			continue
+		}
+		res.Results = append(res.Results, o.Result.results()...)
+	}
+	return res, errors.Join(errs...)
+}
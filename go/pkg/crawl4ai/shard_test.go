@@ -0,0 +1,89 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestPlanShards_GroupsAndSplits(t *testing.T) {
+	shards := PlanShards([]string{
+		"https://a.com/1", "https://b.com/1", "https://www.a.com/2", "https://a.com/3",
+	}, 2)
+	if len(shards) != 3 {
+		t.Fatalf("expected a.com split in two plus b.com, got %+v", shards)
+	}
+	if shards[0].Domain != "a.com" || len(shards[0].URLs) != 2 || shards[1].Domain != "a.com" || shards[1].URLs[0] != "https://a.com/3" || shards[2].Domain != "b.com" {
+		t.Fatalf("unexpected plan: %+v", shards)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunSharded_PerDomainSettingsAndMerge(t *testing.T) {
+	var mu sync.Mutex
+	jobs := map[string][]string{}
+	strategies := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v1/crawl/async":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			var urls []string
+			for _, u := range body["urls"].([]interface{}) {
+				urls = append(urls, u.(string))
+			}
+			if strings.Contains(urls[0], "bad.com") {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"detail": "blocked domain"})
+				return
+			}
+			id := fmt.Sprintf("job_%d", len(jobs)+1)
+			jobs[id] = urls
+			strategies[profileDomain(urls[0])], _ = body["strategy"].(string)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": id, "status": "pending"})
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/v1/crawl/jobs/"):
+			id := strings.TrimPrefix(r.URL.Path, "/v1/crawl/jobs/")
+			var results []interface{}
+			for _, u := range jobs[id] {
+				results = append(results, map[string]interface{}{"url": u, "success": true})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": id, "status": "completed", "results": results})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var done int
+	res, err := c.RunSharded([]string{"https://a.com/1", "https://b.com/1", "https://a.com/2", "https://bad.com/1"}, &ShardOptions{
+		Base:        RunManyOptions{Strategy: "http", PollInterval: 1},
+		PerDomain:   map[string]ShardSettings{"b.com": {Strategy: "browser"}},
+		Parallelism: 2,
+		OnShardDone: func(ShardOutcome) { done++ },
+	})
+	if err == nil || !strings.Contains(err.Error(), "shard bad.com (1 URLs)") {
+		t.Fatalf("expected the bad.com shard to fail, got %v", err)
+	}
+	if len(res.Shards) != 3 || done != 3 {
+		t.Fatalf("expected 3 shards reported, got %d (%d callbacks)", len(res.Shards), done)
+	}
+	if len(res.Results) != 3 || res.Results[0].URL != "https://a.com/1" || res.Results[2].URL != "https://b.com/1" {
+		t.Fatalf("unexpected merged results: %+v", res.Results)
+	}
+	if strategies["a.com"] != "http" || strategies["b.com"] != "browser" {
+		t.Fatalf("unexpected per-domain strategies: %v", strategies)
+	}
+}
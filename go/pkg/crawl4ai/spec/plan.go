@@ -0,0 +1,272 @@
+package spec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// State records what was applied, by crawl name. Commit it next to the
+// spec, or keep it wherever the pipeline keeps build artifacts.
+type State struct {
+	Crawls map[string]Applied `json:"crawls"`
+}
+
+// Applied is the last applied run of a crawl.
+type Applied struct {
+	Hash    string    `json:"hash"`
+	JobID   string    `json:"job_id,omitempty"`
+	LastRun time.Time `json:"last_run"`
+}
+
+// LoadState reads a state file; a missing file is an empty state.
+func LoadState(path string) (*State, error) {
+	st := &State{Crawls: map[string]Applied{}}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, st); err != nil {
+		return nil, fmt.Errorf("spec state %s: %w", path, err)
+	}
+	if st.Crawls == nil {
+		st.Crawls = map[string]Applied{}
+	}
+	return st, nil
+}
+
+// Save writes the state atomically, indented so it diffs well in review.
+func (st *State) Save(path string) error {
+	raw, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(raw, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Action is what a plan does with one crawl.
+type Action string
+
+const (
+	// ActionCreate runs a crawl that has never been applied.
+	ActionCreate Action = "create"
+	// ActionUpdate re-runs a crawl whose definition changed.
+	ActionUpdate Action = "update"
+	// ActionRun re-runs a scheduled crawl that fell due.
+	ActionRun Action = "run"
+	// ActionDelete drops a crawl removed from the spec from the state.
+	// Jobs already run are not undone.
+	ActionDelete Action = "delete"
+	// ActionNone leaves a crawl alone.
+	ActionNone Action = "no-op"
+)
+
+// Change is one line of a plan.
+type Change struct {
+	Name   string
+	Action Action
+	// Reason explains the action, e.g. "due: last run 26h ago".
+	Reason string
+
+	crawl *Crawl
+}
+
+// Plan is the set of changes Apply makes, in spec order with deletions
+// last.
+type Plan struct {
+	Changes []Change
+	now     time.Time
+}
+
+// Plan compares the spec with the state as of now.
+func (s *Spec) Plan(st *State, now time.Time) *Plan {
+	if st == nil {
+		st = &State{}
+	}
+	p := &Plan{now: now}
+	inSpec := map[string]bool{}
+	for i := range s.Crawls {
+		c := &s.Crawls[i]
+		inSpec[c.Name] = true
+		ch := Change{Name: c.Name, Action: ActionNone, crawl: c}
+		prev, ok := st.Crawls[c.Name]
+		every, _ := c.interval()
+		switch {
+		case !ok:
+			ch.Action, ch.Reason = ActionCreate, "new crawl"
+		case prev.Hash != c.hash():
+			ch.Action, ch.Reason = ActionUpdate, "definition changed"
+		case every > 0 && !now.Before(prev.LastRun.Add(every)):
+			ch.Action = ActionRun
+			ch.Reason = fmt.Sprintf("due: last run %s ago, schedule %s", now.Sub(prev.LastRun).Round(time.Minute), c.Schedule)
+		case every > 0:
+			ch.Reason = "next run " + prev.LastRun.Add(every).Format(time.RFC3339)
+		}
+		p.Changes = append(p.Changes, ch)
+	}
+	var gone []string
+	for name := range st.Crawls {
+		if !inSpec[name] {
+			gone = append(gone, name)
+		}
+	}
+	sort.Strings(gone)
+	for _, name := range gone {
+		p.Changes = append(p.Changes, Change{Name: name, Action: ActionDelete, Reason: "removed from spec"})
+	}
+	return p
+}
+
+// HasChanges reports whether applying the plan would do anything.
+func (p *Plan) HasChanges() bool {
+	for _, ch := range p.Changes {
+		if ch.Action != ActionNone {
+			return true
+		}
+	}
+	return false
+}
+
+// String renders the plan for review, one line per change marked + for
+// create, ~ update, > run and - delete, then a summary such as "Plan: 1 to
+// create, 0 to update, 2 to run, 0 to delete."
+func (p *Plan) String() string {
+	var b strings.Builder
+	counts := map[Action]int{}
+	marks := map[Action]string{ActionCreate: "+", ActionUpdate: "~", ActionRun: ">", ActionDelete: "-", ActionNone: " "}
+	for _, ch := range p.Changes {
+		counts[ch.Action]++
+		if ch.Action == ActionNone {
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s (%s: %s)\n", marks[ch.Action], ch.Name, ch.Action, ch.Reason)
+	}
+	if !p.HasChanges() {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to run, %d to delete.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionRun], counts[ActionDelete])
+	return b.String()
+}
+
+// Apply carries out a plan through c and records each success in st,
+// which the caller then saves. A crawl that fails is left as it was in st,
+// so the next plan proposes it again; the failures are returned joined.
+func Apply(ctx context.Context, c *crawl4ai.AsyncWebCrawler, p *Plan, st *State) error {
+	if st.Crawls == nil {
+		st.Crawls = map[string]Applied{}
+	}
+	c = c.WithContext(ctx)
+	var errs []error
+	for _, ch := range p.Changes {
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		switch ch.Action {
+		case ActionNone:
+		case ActionDelete:
+			delete(st.Crawls, ch.Name)
+		default:
+			jobID, err := run(c, ch.crawl)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("crawl %q: %w", ch.Name, err))
+				continue
+			}
+			st.Crawls[ch.Name] = Applied{Hash: ch.crawl.hash(), JobID: jobID, LastRun: p.now}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run starts one crawl, waiting for it only when a sink needs its results.
+func run(c *crawl4ai.AsyncWebCrawler, cr *Crawl) (string, error) {
+	wait := cr.needsResults()
+	var jobID string
+	var results []*crawl4ai.CrawlResult
+	if cr.Deep != nil {
+		res, err := c.DeepCrawl(cr.URLs[0], &crawl4ai.DeepCrawlOptions{
+			Strategy: cr.Deep.Strategy, MaxDepth: cr.Deep.MaxDepth, MaxURLs: cr.Deep.MaxURLs,
+			IncludePatterns: cr.Deep.IncludePatterns, ExcludePatterns: cr.Deep.ExcludePatterns,
+			Config: cr.runConfig(), BrowserConfig: cr.BrowserConfig, CrawlStrategy: cr.Strategy,
+			WebhookURL: cr.webhook(), Wait: wait,
+		})
+		if err != nil {
+			return "", err
+		}
+		if res.DeepResult != nil {
+			jobID = res.DeepResult.JobID
+		}
+		if res.CrawlJob != nil {
+			jobID = res.CrawlJob.JobID
+			results = res.CrawlJob.Results
+		}
+	} else {
+		res, err := c.RunMany(cr.URLs, &crawl4ai.RunManyOptions{
+			Config: cr.runConfig(), BrowserConfig: cr.BrowserConfig, Strategy: cr.Strategy,
+			WebhookURL: cr.webhook(), Wait: wait,
+		})
+		if err != nil {
+			return "", err
+		}
+		if res.Job != nil {
+			jobID = res.Job.JobID
+		}
+		results = res.Results
+	}
+	for _, s := range cr.Sinks {
+		if s.JSONL == "" {
+			continue
+		}
+		if err := appendJSONL(s.JSONL, results); err != nil {
+			return jobID, fmt.Errorf("sink %s: %w", s.JSONL, err)
+		}
+	}
+	return jobID, nil
+}
+
+func appendJSONL(path string, results []*crawl4ai.CrawlResult) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	for _, r := range results {
+		if r == nil {
+			continue
+		}
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
@@ -0,0 +1,167 @@
+package spec
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func mustParse(t *testing.T, doc string) *Spec {
+	t.Helper()
+	s, err := Parse([]byte(doc), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestPlan(t *testing.T) {
+	s := mustParse(t, `
+version: 1
+crawls:
+  - name: new
+    urls: [https://example.com/new]
+  - name: changed
+    urls: [https://example.com/changed]
+  - name: due
+    urls: [https://example.com/due]
+    schedule: 6h
+  - name: fresh
+    urls: [https://example.com/fresh]
+    schedule: daily
+  - name: same
+    urls: [https://example.com/same]
+`)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	hashOf := func(name string) string {
+		for i := range s.Crawls {
+			if s.Crawls[i].Name == name {
+				return s.Crawls[i].hash()
+			}
+		}
+		return ""
+	}
+	st := &State{Crawls: map[string]Applied{
+		"changed": {Hash: "old", LastRun: now.Add(-time.Hour)},
+		"due":     {Hash: hashOf("due"), LastRun: now.Add(-7 * time.Hour)},
+		"fresh":   {Hash: hashOf("fresh"), LastRun: now.Add(-time.Hour)},
+		"same":    {Hash: hashOf("same"), LastRun: now.Add(-1000 * time.Hour)},
+		"gone":    {Hash: "x"},
+	}}
+	p := s.Plan(st, now)
+	want := map[string]Action{
+		"new": ActionCreate, "changed": ActionUpdate, "due": ActionRun,
+		"fresh": ActionNone, "same": ActionNone, "gone": ActionDelete,
+	}
+	if len(p.Changes) != len(want) {
+		t.Fatalf("changes = %+v", p.Changes)
+	}
+	for _, ch := range p.Changes {
+		if ch.Action != want[ch.Name] {
+			t.Errorf("%s: action = %s, want %s", ch.Name, ch.Action, want[ch.Name])
+		}
+	}
+	if last := p.Changes[len(p.Changes)-1]; last.Name != "gone" {
+		t.Errorf("deletions should come last, got %s", last.Name)
+	}
+	out := p.String()
+	for _, line := range []string{"+ new (create", "~ changed (update", "> due (run: due: last run 7h0m0s ago", "- gone (delete", "Plan: 1 to create, 1 to update, 1 to run, 1 to delete."} {
+		if !strings.Contains(out, line) {
+			t.Errorf("plan output missing %q:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, "same") {
+		t.Errorf("no-op crawls should not be listed:\n%s", out)
+	}
+}
+
+func TestPlan_ScheduleChangeDoesNotRerun(t *testing.T) {
+	a := mustParse(t, "version: 1\ncrawls:\n  - name: a\n    urls: [https://example.com]\n    schedule: daily\n")
+	b := mustParse(t, "version: 1\ncrawls:\n  - name: a\n    urls: [https://example.com]\n    schedule: weekly\n")
+	now := time.Now()
+	st := &State{Crawls: map[string]Applied{"a": {Hash: a.Crawls[0].hash(), LastRun: now.Add(-2 * time.Hour)}}}
+	if p := b.Plan(st, now); p.HasChanges() {
+		t.Errorf("plan = %s", p)
+	}
+	if p := b.Plan(st, now); p.String() != "No changes.\n" {
+		t.Errorf("String() = %q", p.String())
+	}
+}
+
+func TestState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	st, err := LoadState(path)
+	if err != nil || len(st.Crawls) != 0 {
+		t.Fatalf("missing state = %+v, %v", st, err)
+	}
+	when := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	st.Crawls["docs"] = Applied{Hash: "h", JobID: "job_1", LastRun: when}
+	if err := st.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := got.Crawls["docs"]; a.JobID != "job_1" || !a.LastRun.Equal(when) {
+		t.Errorf("loaded %+v", got)
+	}
+}
+
+// ─── Unit tests (in-process sandbox) ─────────────────────────────────────
+
+func TestApply(t *testing.T) {
+	c, err := crawl4ai.NewAsyncWebCrawler(crawl4ai.CrawlerOptions{APIKey: "sk_test_sandbox", Sandbox: true, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "out", "docs.jsonl")
+	s := mustParse(t, `
+version: 1
+crawls:
+  - name: docs
+    urls: [https://example.com/a, https://example.com/b]
+    sinks:
+      - jsonl: `+out+`
+  - name: fire-and-forget
+    urls: [https://example.com/c]
+`)
+	st := &State{Crawls: map[string]Applied{"old": {Hash: "x"}}}
+	now := time.Now()
+	if err := Apply(context.Background(), c, s.Plan(st, now), st); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := st.Crawls["old"]; ok {
+		t.Error("deleted crawl still in state")
+	}
+	for _, name := range []string{"docs", "fire-and-forget"} {
+		if a := st.Crawls[name]; a.JobID == "" || !a.LastRun.Equal(now) {
+			t.Errorf("%s state = %+v", name, a)
+		}
+	}
+
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("jsonl sink has %d lines, want 2", lines)
+	}
+
+	if p := s.Plan(st, now); p.HasChanges() {
+		t.Errorf("re-plan after apply = %s", p)
+	}
+}
@@ -0,0 +1,257 @@
+// Package spec manages crawls declaratively: a YAML (or JSON) file lists
+// the crawls, deep crawls and their schedules, and Plan compares it with
+// what was last applied — like terraform plan — before Apply runs only
+// what changed or fell due. Keep the spec and the state file in git and a
+// CI job becomes the crawling pipeline.
+//
+//	# crawls.yaml
+//	version: 1
+//	crawls:
+//	  - name: docs
+//	    urls: [https://example.com/docs, https://example.com/api]
+//	    config: {word_count_threshold: 10}
+//	    extraction: {type: json_css, schema: {baseSelector: article}}
+//	    schedule: daily
+//	    sinks:
+//	      - jsonl: out/docs.jsonl
+//	  - name: blog
+//	    urls: [https://example.com/blog]
+//	    deep: {strategy: bfs, max_depth: 2, max_urls: 200}
+//	    sinks:
+//	      - webhook: https://hooks.internal/crawl-done
+//
+//	s, err := spec.Load("crawls.yaml")
+//	st, err := spec.LoadState("crawls.state.json")
+//	plan := s.Plan(st, time.Now())
+//	fmt.Print(plan)
+//	err = spec.Apply(ctx, crawler, plan, st)
+//	err = st.Save("crawls.state.json")
+package spec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai/internal/yaml"
+)
+
+// Spec is a parsed spec file.
+type Spec struct {
+	// Version is the spec format version; only 1 exists.
+	Version int     `json:"version"`
+	Crawls  []Crawl `json:"crawls"`
+}
+
+// Crawl is one managed crawl: a batch of URLs, or a deep crawl from one.
+type Crawl struct {
+	// Name identifies the crawl in the state; renaming it re-creates it.
+	Name string   `json:"name"`
+	URLs []string `json:"urls"`
+	// Deep, when set, deep-crawls from the single URL in URLs.
+	Deep *Deep `json:"deep,omitempty"`
+	// Strategy is "browser" or "http" ("auto" too for deep crawls).
+	Strategy      string                     `json:"strategy,omitempty"`
+	Config        *crawl4ai.CrawlerRunConfig `json:"config,omitempty"`
+	BrowserConfig *crawl4ai.BrowserConfig    `json:"browser_config,omitempty"`
+	// Extraction is shorthand for config.extraction_strategy.
+	Extraction map[string]interface{} `json:"extraction,omitempty"`
+	// Schedule re-runs the crawl once this long has passed since its last
+	// run: a Go duration ("6h") or hourly, daily or weekly. Without one a
+	// crawl runs when it is created or changed.
+	Schedule string `json:"schedule,omitempty"`
+	Sinks    []Sink `json:"sinks,omitempty"`
+}
+
+// Deep configures a deep crawl.
+type Deep struct {
+	// Strategy is bfs (default), dfs, best_first or map.
+	Strategy        string   `json:"strategy,omitempty"`
+	MaxDepth        int      `json:"max_depth,omitempty"`
+	MaxURLs         int      `json:"max_urls,omitempty"`
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+}
+
+// Sink is where a crawl's results go. Set exactly one field.
+type Sink struct {
+	// JSONL appends each result as a JSON line to this file. Apply waits
+	// for the crawl to finish to write it.
+	JSONL string `json:"jsonl,omitempty"`
+	// Webhook is called by the API when the job finishes.
+	Webhook string `json:"webhook,omitempty"`
+}
+
+// Load reads and validates a spec file. Files ending in .json are read as
+// JSON, anything else as YAML.
+func Load(path string) (*Spec, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(raw, strings.HasSuffix(strings.ToLower(path), ".json"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Parse decodes and validates a spec. Unknown fields are errors, so a
+// misspelt option fails the plan instead of being silently ignored.
+func Parse(data []byte, isJSON bool) (*Spec, error) {
+	if !isJSON {
+		v, err := yaml.Unmarshal(data)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var s Spec
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("spec: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks the spec without calling the API.
+func (s *Spec) Validate() error {
+	if s.Version != 1 {
+		return fmt.Errorf("spec: unsupported version %d (want 1)", s.Version)
+	}
+	seen := map[string]bool{}
+	for i, c := range s.Crawls {
+		if c.Name == "" {
+			return fmt.Errorf("spec: crawls[%d]: name is required", i)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("spec: duplicate crawl name %q", c.Name)
+		}
+		seen[c.Name] = true
+		if err := c.validate(); err != nil {
+			return fmt.Errorf("spec: crawl %q: %w", c.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Crawl) validate() error {
+	if len(c.URLs) == 0 {
+		return fmt.Errorf("urls is required")
+	}
+	for _, u := range c.URLs {
+		p, err := url.Parse(u)
+		if err != nil || (p.Scheme != "http" && p.Scheme != "https") || p.Host == "" {
+			return fmt.Errorf("invalid url %q", u)
+		}
+	}
+	if c.Deep != nil {
+		if len(c.URLs) != 1 {
+			return fmt.Errorf("a deep crawl takes exactly one url, got %d", len(c.URLs))
+		}
+		switch c.Deep.Strategy {
+		case "", "bfs", "dfs", "best_first", "map":
+		default:
+			return fmt.Errorf("unknown deep strategy %q", c.Deep.Strategy)
+		}
+	}
+	switch c.Strategy {
+	case "", "browser", "http":
+	case "auto":
+		if c.Deep == nil {
+			return fmt.Errorf("strategy auto is only available for deep crawls")
+		}
+	default:
+		return fmt.Errorf("unknown strategy %q", c.Strategy)
+	}
+	if c.Extraction != nil && c.Config != nil && c.Config.ExtractionStrategy != nil {
+		return fmt.Errorf("set extraction or config.extraction_strategy, not both")
+	}
+	if _, err := c.interval(); err != nil {
+		return err
+	}
+	webhooks := 0
+	for i, sink := range c.Sinks {
+		switch {
+		case (sink.JSONL == "") == (sink.Webhook == ""):
+			return fmt.Errorf("sinks[%d]: set exactly one of jsonl, webhook", i)
+		case sink.Webhook != "":
+			webhooks++
+		}
+	}
+	if webhooks > 1 {
+		return fmt.Errorf("a crawl can have at most one webhook sink")
+	}
+	return nil
+}
+
+// interval parses Schedule; 0 means unscheduled.
+func (c *Crawl) interval() (time.Duration, error) {
+	switch c.Schedule {
+	case "":
+		return 0, nil
+	case "hourly":
+		return time.Hour, nil
+	case "daily":
+		return 24 * time.Hour, nil
+	case "weekly":
+		return 7 * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(c.Schedule)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid schedule %q: use a duration such as 6h, or hourly, daily, weekly", c.Schedule)
+	}
+	return d, nil
+}
+
+// hash fingerprints what the crawl does. The name and schedule are left
+// out: rescheduling a crawl should not re-run it.
+func (c *Crawl) hash() string {
+	cp := *c
+	cp.Name, cp.Schedule = "", ""
+	raw, _ := json.Marshal(cp)
+	return fmt.Sprintf("%x", sha256.Sum256(raw))
+}
+
+// runConfig is Config with Extraction folded in.
+func (c *Crawl) runConfig() *crawl4ai.CrawlerRunConfig {
+	if c.Extraction == nil {
+		return c.Config
+	}
+	cfg := crawl4ai.CrawlerRunConfig{}
+	if c.Config != nil {
+		cfg = *c.Config
+	}
+	cfg.ExtractionStrategy = c.Extraction
+	return &cfg
+}
+
+func (c *Crawl) webhook() string {
+	for _, s := range c.Sinks {
+		if s.Webhook != "" {
+			return s.Webhook
+		}
+	}
+	return ""
+}
+
+func (c *Crawl) needsResults() bool {
+	for _, s := range c.Sinks {
+		if s.JSONL != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+const docsSpec = `
+version: 1
+crawls:
+  - name: docs
+    urls: [https://example.com/docs, https://example.com/api]
+    config:
+      word_count_threshold: 10
+      wait_for: css:main
+    extraction: {type: json_css, schema: {baseSelector: article}}
+    schedule: daily
+    sinks:
+      - jsonl: out/docs.jsonl
+  - name: blog
+    urls: [https://example.com/blog]
+    strategy: auto
+    deep: {strategy: bfs, max_depth: 2, max_urls: 50}
+    sinks:
+      - webhook: https://hooks.example.com/done
+`
+
+func TestParse(t *testing.T) {
+	s, err := Parse([]byte(docsSpec), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Crawls) != 2 {
+		t.Fatalf("crawls = %d", len(s.Crawls))
+	}
+	docs := s.Crawls[0]
+	if docs.Config.WordCountThreshold != 10 || docs.Config.WaitFor != "css:main" {
+		t.Errorf("config = %+v", docs.Config)
+	}
+	cfg := docs.runConfig()
+	if cfg.ExtractionStrategy["type"] != "json_css" || cfg.WaitFor != "css:main" || docs.Config.ExtractionStrategy != nil {
+		t.Errorf("runConfig = %+v (spec config %+v)", cfg, docs.Config)
+	}
+	if every, _ := docs.interval(); every.Hours() != 24 {
+		t.Errorf("interval = %v", every)
+	}
+	blog := s.Crawls[1]
+	if blog.Deep == nil || blog.Deep.MaxDepth != 2 || blog.webhook() != "https://hooks.example.com/done" || blog.needsResults() {
+		t.Errorf("blog = %+v", blog)
+	}
+}
+
+func TestParse_JSON(t *testing.T) {
+	s, err := Parse([]byte(`{"version":1,"crawls":[{"name":"a","urls":["https://example.com"]}]}`), true)
+	if err != nil || s.Crawls[0].Name != "a" {
+		t.Fatalf("s = %+v, err = %v", s, err)
+	}
+}
+
+func TestParse_Rejects(t *testing.T) {
+	cases := map[string]string{
+		"version: 2\n": "unsupported version",
+		"version: 1\ncrawls:\n  - urls: [https://a.com]\n":                                                      "name is required",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [ftp://a.com]\n":                                           "invalid url",
+		"version: 1\ncrawls:\n  - name: a\n    url: https://a.com\n":                                            "unknown field",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [https://a.com]\n    config: {word_count: 3}\n":            "unknown field",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [https://a.com]\n  - name: a\n    urls: [https://b.com]\n": "duplicate crawl name",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [https://a.com, https://b.com]\n    deep: {}\n":            "exactly one url",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [https://a.com]\n    schedule: fortnightly\n":              "invalid schedule",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [https://a.com]\n    strategy: auto\n":                     "only available for deep",
+		"version: 1\ncrawls:\n  - name: a\n    urls: [https://a.com]\n    sinks: [{jsonl: a, webhook: b}]\n":    "exactly one of",
+	}
+	for doc, want := range cases {
+		_, err := Parse([]byte(doc), false)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%q: err = %v, want %q", doc, err, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crawls.yaml")
+	if err := os.WriteFile(path, []byte(docsSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("version: 1\ncrawls: [\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("err = %v, want it to name the file", err)
+	}
+}
@@ -0,0 +1,244 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StorageJanitorOptions is the policy a StorageJanitor enforces. Only
+// finished jobs are ever deleted, oldest first.
+type StorageJanitorOptions struct {
+	// MaxPercentUsed deletes the oldest jobs while storage is above this
+	// percentage of the quota. 0 = no limit.
+	MaxPercentUsed float64
+	// MaxAge deletes jobs that finished more than this long ago. 0 = no
+	// limit.
+	MaxAge time.Duration
+	// Interval between sweeps. Default: 10m.
+	Interval time.Duration
+	// DryRun reports what each sweep would delete without deleting it.
+	// Jobs that don't report their result size are assumed to free
+	// nothing, so a dry run may list more jobs than a real sweep deletes.
+	DryRun bool
+	// OnSweep, when set, receives every sweep's report.
+	OnSweep func(StorageSweep)
+	// OnError, when set, receives sweep errors; the next sweep retries.
+	OnError func(error)
+}
+
+// StorageSweep reports one janitor pass.
+type StorageSweep struct {
+	At     time.Time
+	DryRun bool
+	// Before is the storage usage at the start of the sweep.
+	Before StorageUsage
+	// Deleted are the IDs of the jobs deleted, or that would be in a dry
+	// run, oldest first.
+	Deleted []string
+	// FreedBytes sums the deleted jobs' result sizes as the API reported
+	// them.
+	FreedBytes int64
+}
+
+// StorageJanitorMetrics counts a StorageJanitor's work since it started.
+type StorageJanitorMetrics struct {
+	Sweeps      int64
+	JobsDeleted int64
+	FreedBytes  int64
+	Errors      int64
+	LastSweep   time.Time
+	LastError   error
+}
+
+// StorageJanitor deletes stored results on a schedule to keep an account
+// within a storage policy. Create one with StartStorageJanitor.
+type StorageJanitor struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	metrics StorageJanitorMetrics
+}
+
+// StartStorageJanitor sweeps storage now and then every opts.Interval, in
+// the background, until ctx is done or Stop is called. Each sweep deletes
+// finished jobs older than MaxAge, then the oldest remaining ones until
+// usage is back under MaxPercentUsed. Deleted jobs are also dropped from
+// client-side retention tracking.
+//
+//	janitor := crawler.StartStorageJanitor(ctx, crawl4ai.StorageJanitorOptions{
+//	    MaxPercentUsed: 80,
+//	    MaxAge:         7 * 24 * time.Hour,
+//	    OnError:        func(err error) { log.Printf("janitor: %v", err) },
+//	})
+//	defer janitor.Stop()
+func (c *AsyncWebCrawler) StartStorageJanitor(ctx context.Context, opts StorageJanitorOptions) *StorageJanitor {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	j := &StorageJanitor{cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			sweep, err := c.WithContext(ctx).SweepStorage(opts)
+			if ctx.Err() != nil {
+				return
+			}
+			j.record(sweep, err, opts)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return j
+}
+
+// Stop ends the janitor, cancelling a sweep in progress, and waits for it
+// to exit.
+func (j *StorageJanitor) Stop() {
+	j.cancel()
+	<-j.done
+}
+
+// Metrics returns the janitor's counters.
+func (j *StorageJanitor) Metrics() StorageJanitorMetrics {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.metrics
+}
+
+func (j *StorageJanitor) record(sweep *StorageSweep, err error, opts StorageJanitorOptions) {
+	j.mu.Lock()
+	j.metrics.Sweeps++
+	if sweep != nil {
+		j.metrics.LastSweep = sweep.At
+		if !sweep.DryRun {
+			j.metrics.JobsDeleted += int64(len(sweep.Deleted))
+			j.metrics.FreedBytes += sweep.FreedBytes
+		}
+	}
+	if err != nil {
+		j.metrics.Errors++
+		j.metrics.LastError = err
+	}
+	j.mu.Unlock()
+
+	if sweep != nil && opts.OnSweep != nil {
+		opts.OnSweep(*sweep)
+	}
+	if err != nil && opts.OnError != nil {
+		opts.OnError(err)
+	}
+}
+
+// SweepStorage applies opts' policy once; StartStorageJanitor calls it on
+// a schedule. The returned sweep lists what was deleted even when err
+// reports a later failure.
+func (c *AsyncWebCrawler) SweepStorage(opts StorageJanitorOptions) (*StorageSweep, error) {
+	sweep := &StorageSweep{At: time.Now(), DryRun: opts.DryRun}
+	usage, err := c.Storage()
+	if err != nil {
+		return nil, fmt.Errorf("storage janitor: %w", err)
+	}
+	sweep.Before = *usage
+	if opts.MaxAge <= 0 && (opts.MaxPercentUsed <= 0 || usage.PercentUsed <= opts.MaxPercentUsed) {
+		return sweep, nil
+	}
+
+	jobs, err := c.finishedJobs()
+	if err != nil {
+		return sweep, fmt.Errorf("storage janitor: %w", err)
+	}
+
+	// Bytes still to free to get under MaxPercentUsed.
+	var excess float64
+	if opts.MaxPercentUsed > 0 && usage.MaxMB > 0 {
+		excess = (usage.UsedMB - usage.MaxMB*opts.MaxPercentUsed/100) * 1024 * 1024
+	}
+	for _, job := range jobs {
+		done := finishedAt(job)
+		tooOld := opts.MaxAge > 0 && !done.IsZero() && sweep.At.Sub(done) > opts.MaxAge
+		if !tooOld && excess <= 0 {
+			break
+		}
+		if !opts.DryRun {
+			if err := c.deleteJobResults(job.JobID); err != nil {
+				var notFound *NotFoundError
+				if !errors.As(err, &notFound) {
+					return sweep, fmt.Errorf("storage janitor: delete job %s: %w", job.JobID, err)
+				}
+			}
+			c.untrackRetention(job.JobID)
+		}
+		sweep.Deleted = append(sweep.Deleted, job.JobID)
+		sweep.FreedBytes += int64(job.ResultSizeBytes)
+		excess -= float64(job.ResultSizeBytes)
+
+		// Without a reported size, ask the API how much is left.
+		if job.ResultSizeBytes == 0 && excess > 0 && !opts.DryRun {
+			if now, err := c.Storage(); err == nil {
+				excess = (now.UsedMB - now.MaxMB*opts.MaxPercentUsed/100) * 1024 * 1024
+			}
+		}
+	}
+	return sweep, nil
+}
+
+// finishedJobs lists every finished job, oldest first.
+func (c *AsyncWebCrawler) finishedJobs() ([]*CrawlJob, error) {
+	const pageSize = 100
+	var jobs []*CrawlJob
+	seen := map[string]bool{}
+	offset := 0
+	for i := 0; i < maxListJobsPages; i++ {
+		page, err := c.ListJobs(&ListJobsOptions{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		// Stop on an empty page, or one the server repeated because it
+		// ignores offset; a short page may just be the server's cap.
+		fresh := 0
+		for _, job := range page {
+			if seen[job.JobID] {
+				continue
+			}
+			seen[job.JobID] = true
+			fresh++
+			if job.IsComplete() {
+				jobs = append(jobs, job)
+			}
+		}
+		if fresh == 0 {
+			break
+		}
+		offset += len(page)
+	}
+	sort.SliceStable(jobs, func(i, k int) bool { return finishedAt(jobs[i]).Before(finishedAt(jobs[k])) })
+	return jobs, nil
+}
+
+// finishedAt is when a job finished, or its creation time when the API
+// didn't report one.
+func finishedAt(job *CrawlJob) time.Time {
+	if !job.CompletedAt.IsZero() {
+		return job.CompletedAt
+	}
+	return job.CreatedAt
+}
+
+// untrackRetention stops client-side retention for a deleted job.
+func (c *AsyncWebCrawler) untrackRetention(jobID string) {
+	c.retention.mu.Lock()
+	defer c.retention.mu.Unlock()
+	delete(c.retention.jobs, jobID)
+}
@@ -0,0 +1,144 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// janitorServer serves storage usage computed from its jobs and deletes
+// them on DELETE ?delete_results=true; a bare DELETE only cancels, so it
+// is rejected.
+type janitorServer struct {
+	mu      sync.Mutex
+	jobs    []map[string]interface{}
+	deleted []string
+}
+
+func (s *janitorServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/crawl/storage":
+		var used float64
+		for _, j := range s.jobs {
+			used += j["result_size_bytes"].(float64)
+		}
+		usedMB := used / (1024 * 1024)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"used_mb": usedMB, "max_mb": 10.0, "percent_used": usedMB * 10})
+	case r.Method == http.MethodGet && r.URL.Path == "/v1/crawl/jobs":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jobs": s.jobs})
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/crawl/jobs/"):
+		id := strings.TrimPrefix(r.URL.Path, "/v1/crawl/jobs/")
+		if r.URL.Query().Get("delete_results") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"detail": "cancel only applies to running jobs"}`))
+			return
+		}
+		for i, j := range s.jobs {
+			if j["job_id"] == id {
+				s.jobs = append(s.jobs[:i], s.jobs[i+1:]...)
+				s.deleted = append(s.deleted, id)
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"detail": "not found"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newJanitorServer(t *testing.T) (*janitorServer, *AsyncWebCrawler) {
+	t.Helper()
+	now := time.Now().UTC()
+	mb := float64(1024 * 1024)
+	job := func(id, status string, age time.Duration, size float64) map[string]interface{} {
+		return map[string]interface{}{"job_id": id, "status": status, "urls_count": 1,
+			"created_at": now.Add(-age - time.Minute).Format(time.RFC3339), "completed_at": now.Add(-age).Format(time.RFC3339),
+			"result_size_bytes": size}
+	}
+	s := &janitorServer{jobs: []map[string]interface{}{
+		job("recent", "completed", time.Hour, 2*mb),
+		job("running", "running", 30*24*time.Hour, 1*mb),
+		job("old", "completed", 10*24*time.Hour, 1*mb),
+		job("older", "failed", 20*24*time.Hour, 1*mb),
+		job("mid", "completed", 3*24*time.Hour, 4*mb),
+	}}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, c
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestSweepStorage(t *testing.T) {
+	s, c := newJanitorServer(t)
+	c.TrackRetention("old", RetentionPolicy{TTL: time.Hour})
+
+	// 9 MB of 10 used. A dry run deletes nothing.
+	sweep, err := c.SweepStorage(StorageJanitorOptions{MaxAge: 7 * 24 * time.Hour, MaxPercentUsed: 50, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"older", "old", "mid"}; !reflect.DeepEqual(sweep.Deleted, want) || len(s.deleted) != 0 {
+		t.Errorf("dry run: %v (deleted %v), want %v", sweep.Deleted, s.deleted, want)
+	}
+
+	// Age deletes the two old jobs (2 MB); percent then needs 2 MB more,
+	// which the next oldest (4 MB) covers. Running jobs are never touched.
+	sweep, err = c.SweepStorage(StorageJanitorOptions{MaxAge: 7 * 24 * time.Hour, MaxPercentUsed: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"older", "old", "mid"}; !reflect.DeepEqual(s.deleted, want) {
+		t.Errorf("deleted %v, want %v", s.deleted, want)
+	}
+	if sweep.FreedBytes != 6*1024*1024 || sweep.Before.PercentUsed != 90 {
+		t.Errorf("sweep = %+v", sweep)
+	}
+	if ids := c.RetainedJobs(); len(ids) != 0 {
+		t.Errorf("deleted job still tracked for retention: %v", ids)
+	}
+
+	// Within policy: nothing to do.
+	if sweep, err := c.SweepStorage(StorageJanitorOptions{MaxPercentUsed: 50}); err != nil || len(sweep.Deleted) != 0 {
+		t.Errorf("within policy: %+v, %v", sweep, err)
+	}
+}
+
+func TestStartStorageJanitor(t *testing.T) {
+	_, c := newJanitorServer(t)
+	sweeps := make(chan StorageSweep, 10)
+	j := c.StartStorageJanitor(context.Background(), StorageJanitorOptions{
+		MaxAge:   7 * 24 * time.Hour,
+		Interval: time.Millisecond,
+		OnSweep: func(s StorageSweep) {
+			select {
+			case sweeps <- s:
+			default:
+			}
+		},
+	})
+	first := <-sweeps
+	<-sweeps
+	j.Stop()
+	if len(first.Deleted) != 2 {
+		t.Errorf("first sweep deleted %v", first.Deleted)
+	}
+	m := j.Metrics()
+	if m.Sweeps < 2 || m.JobsDeleted != 2 || m.FreedBytes != 2*1024*1024 || m.Errors != 0 {
+		t.Errorf("metrics = %+v", m)
+	}
+}
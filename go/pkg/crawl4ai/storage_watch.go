@@ -0,0 +1,80 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+// DefaultStorageThresholds are the PercentUsed levels WatchStorage alerts at
+// when none are given.
+var DefaultStorageThresholds = []float64{75, 90}
+
+// WatchStorage polls storage usage every interval and calls fn whenever
+// usage crosses one of thresholds (percent used; DefaultStorageThresholds
+// when omitted) on the way up. Each threshold fires once and re-arms when
+// usage falls back below it — after a cleanup, say — so fn is not called on
+// every poll while storage stays full. A poll that crosses several
+// thresholds at once calls fn once.
+//
+// WatchStorage blocks until ctx is done and then returns ctx.Err(). Failed
+// polls are skipped, except authentication errors, which end the watch.
+//
+//	go crawler.WatchStorage(ctx, time.Minute, func(u StorageUsage) {
+//	    log.Printf("storage at %.0f%% — pruning old jobs", u.PercentUsed)
+//	    pruneOldJobs()
+//	}, 80, 95)
+func (c *AsyncWebCrawler) WatchStorage(ctx context.Context, interval time.Duration, fn func(StorageUsage), thresholds ...float64) error {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if len(thresholds) == 0 {
+		thresholds = DefaultStorageThresholds
+	}
+	levels := append([]float64(nil), thresholds...)
+	sort.Float64s(levels)
+	watch := &storageWatch{levels: levels, fired: make([]bool, len(levels))}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		usage, err := c.Storage()
+		if err != nil {
+			var auth *AuthenticationError
+			if errors.As(err, &auth) {
+				return err
+			}
+		} else if watch.observe(usage.PercentUsed) {
+			fn(*usage)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// storageWatch tracks which thresholds have fired.
+type storageWatch struct {
+	levels []float64
+	fired  []bool
+}
+
+// observe records one reading and reports whether a threshold was newly
+// crossed.
+func (w *storageWatch) observe(percent float64) bool {
+	crossed := false
+	for i, level := range w.levels {
+		switch {
+		case percent >= level && !w.fired[i]:
+			w.fired[i] = true
+			crossed = true
+		case percent < level:
+			w.fired[i] = false
+		}
+	}
+	return crossed
+}
@@ -0,0 +1,54 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestWatchStorage_ThresholdsFireOnceAndRearm(t *testing.T) {
+	w := &storageWatch{levels: []float64{75, 90}, fired: make([]bool, 2)}
+	steps := []struct {
+		percent float64
+		want    bool
+	}{
+		{50, false},
+		{76, true},  // crosses 75
+		{80, false}, // still above 75, already fired
+		{95, true},  // crosses 90
+		{97, false},
+		{60, false}, // cleanup re-arms both
+		{92, true},  // crosses both at once — one call
+	}
+	for i, s := range steps {
+		if got := w.observe(s.percent); got != s.want {
+			t.Fatalf("step %d (%.0f%%): observe = %v, want %v", i, s.percent, got, s.want)
+		}
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestWatchStorage_CallsBackAndStops(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/storage": map[string]interface{}{
+			"used_mb": 800.0, "max_mb": 1000.0, "remaining_mb": 200.0, "percent_used": 80.0,
+		},
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	var calls []float64
+	err := c.WatchStorage(ctx, 10*time.Millisecond, func(u StorageUsage) {
+		calls = append(calls, u.PercentUsed)
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ctx error, got %v", err)
+	}
+	if len(calls) != 1 || calls[0] != 80 {
+		t.Fatalf("expected a single alert at 80%%, got %v", calls)
+	}
+}
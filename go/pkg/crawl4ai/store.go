@@ -0,0 +1,151 @@
+package crawl4ai
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResultStore persists crawl results in an embedded SQLite database for
+// local analysis of large crawls. The SDK stays dependency-free, so the
+// caller opens the database with the driver of their choice and hands over
+// the *sql.DB:
+//
+//	import _ "modernc.org/sqlite" // or github.com/mattn/go-sqlite3
+//
+//	db, _ := sql.Open("sqlite", "crawl.db")
+//	store, err := crawl4ai.NewResultStore(db)
+//	results, _ := crawler.JobResults(jobID)
+//	err = store.Save(jobID, results)
+//	failed, _ := store.FailedResults(jobID)
+//
+// Results are stored whole (as JSON) alongside indexed url, domain,
+// success and status_code columns.
+type ResultStore struct {
+	db *sql.DB
+}
+
+var resultStoreSchema = []string{
+	`CREATE TABLE IF NOT EXISTS crawl_results (
+		job_id            TEXT NOT NULL,
+		url               TEXT NOT NULL,
+		domain            TEXT NOT NULL,
+		success           INTEGER NOT NULL,
+		status_code       INTEGER NOT NULL,
+		error_message     TEXT NOT NULL,
+		markdown          TEXT NOT NULL,
+		extracted_content TEXT NOT NULL,
+		data              TEXT NOT NULL,
+		PRIMARY KEY (job_id, url)
+	)`,
+	`CREATE INDEX IF NOT EXISTS crawl_results_url ON crawl_results (url)`,
+	`CREATE INDEX IF NOT EXISTS crawl_results_domain ON crawl_results (domain)`,
+	`CREATE INDEX IF NOT EXISTS crawl_results_status ON crawl_results (success, status_code)`,
+}
+
+// NewResultStore creates the results table and its indexes if needed.
+func NewResultStore(db *sql.DB) (*ResultStore, error) {
+	for _, stmt := range resultStoreSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("result store: %w", err)
+		}
+	}
+	return &ResultStore{db: db}, nil
+}
+
+// Save upserts results under jobID in a single transaction. Saving the same
+// URL for a job again replaces the earlier row.
+func (s *ResultStore) Save(jobID string, results []*CrawlResult) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO crawl_results
+		(job_id, url, domain, success, status_code, error_message, markdown, extracted_content, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for _, r := range results {
+		data, err := json.Marshal(r)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		markdown := ""
+		if r.Markdown != nil {
+			markdown = r.Markdown.RawMarkdown
+		}
+		success := 0
+		if r.Success {
+			success = 1
+		}
+		if _, err := stmt.Exec(jobID, r.URL, resultDomain(r.URL), success, r.StatusCode,
+			r.ErrorMessage, markdown, r.ExtractedContent, string(data)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("result store: save %s: %w", r.URL, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// FailedResults returns the job's unsuccessful results. An empty jobID
+// searches every stored job.
+func (s *ResultStore) FailedResults(jobID string) ([]*CrawlResult, error) {
+	return s.query("success = 0", jobID)
+}
+
+// ByDomain returns stored results whose host is domain or one of its
+// subdomains. An empty jobID searches every stored job.
+func (s *ResultStore) ByDomain(jobID, domain string) ([]*CrawlResult, error) {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "www."))
+	return s.query("(domain = ? OR domain LIKE ?)", jobID, domain, "%."+domain)
+}
+
+// Search returns stored results whose markdown or extracted content
+// contains text (case-insensitive for ASCII). An empty jobID searches every
+// stored job.
+func (s *ResultStore) Search(jobID, text string) ([]*CrawlResult, error) {
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+	return s.query(`(markdown LIKE ? ESCAPE '\' OR extracted_content LIKE ? ESCAPE '\')`, jobID, pattern, pattern)
+}
+
+func (s *ResultStore) query(where, jobID string, args ...interface{}) ([]*CrawlResult, error) {
+	q := "SELECT data FROM crawl_results WHERE " + where
+	if jobID != "" {
+		q += " AND job_id = ?"
+		args = append(args, jobID)
+	}
+	q += " ORDER BY job_id, url"
+	rows, err := s.db.Query(q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("result store: %w", err)
+	}
+	defer rows.Close()
+	var out []*CrawlResult
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		r := &CrawlResult{}
+		if err := json.Unmarshal([]byte(data), r); err != nil {
+			return nil, fmt.Errorf("result store: corrupt row: %w", err)
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// resultDomain is the lower-cased host of rawURL without a leading "www.".
+func resultDomain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
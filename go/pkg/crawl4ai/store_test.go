@@ -0,0 +1,288 @@
+package crawl4ai
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingDriver is a minimal database/sql driver that records every
+// statement and answers queries with canned rows. The SDK has no SQLite
+// dependency, so the store is tested against the SQL it issues.
+type recordingDriver struct {
+	mu    sync.Mutex
+	execs []recordedStmt
+	query recordedStmt
+	rows  []string
+}
+
+type recordedStmt struct {
+	sql  string
+	args []driver.Value
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return &recordingConn{d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c *recordingConn) Prepare(q string) (driver.Stmt, error) { return &recordingStmt{c.d, q}, nil }
+func (c *recordingConn) Close() error                          { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)             { return c, nil }
+func (c *recordingConn) Commit() error                         { return nil }
+func (c *recordingConn) Rollback() error                       { return nil }
+
+type recordingStmt struct {
+	d *recordingDriver
+	q string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.execs = append(s.d.execs, recordedStmt{s.q, args})
+	return driver.RowsAffected(1), nil
+}
+
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.query = recordedStmt{s.q, args}
+	return &recordingRows{rows: s.d.rows}, nil
+}
+
+type recordingRows struct {
+	rows []string
+	i    int
+}
+
+func (r *recordingRows) Columns() []string { return []string{"data"} }
+func (r *recordingRows) Close() error      { return nil }
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	dest[0] = r.rows[r.i]
+	r.i++
+	return nil
+}
+
+func newRecordingStore(t *testing.T) (*ResultStore, *recordingDriver) {
+	t.Helper()
+	d := &recordingDriver{}
+	name := fmt.Sprintf("crawl4ai-recording-%s", t.Name())
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	store, err := NewResultStore(db)
+	if err != nil {
+		t.Fatalf("NewResultStore: %v", err)
+	}
+	return store, d
+}
+
+// replaySQLite runs the recorded statements and then query through the
+// sqlite3 shell, so the SQL the store issues is checked against real SQLite
+// semantics without a Go driver dependency. It returns the selected data
+// column and skips the test when sqlite3 is not installed.
+func replaySQLite(t *testing.T, execs []recordedStmt, query recordedStmt) []string {
+	t.Helper()
+	bin, err := exec.LookPath("sqlite3")
+	if err != nil {
+		t.Skip("sqlite3 not on PATH")
+	}
+	var script strings.Builder
+	for _, st := range append(execs, query) {
+		script.WriteString(bindSQL(t, st))
+		script.WriteString(";\n")
+	}
+	cmd := exec.Command(bin, "-json", ":memory:")
+	cmd.Stdin = strings.NewReader(script.String())
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("sqlite3: %v\n%s", err, out)
+	}
+	if len(strings.TrimSpace(string(out))) == 0 {
+		return nil
+	}
+	var rows []struct {
+		Data string `json:"data"`
+	}
+	if err := json.Unmarshal(out, &rows); err != nil {
+		t.Fatalf("sqlite3 output: %v\n%s", err, out)
+	}
+	data := make([]string, len(rows))
+	for i, r := range rows {
+		data[i] = r.Data
+	}
+	return data
+}
+
+// bindSQL inlines st's arguments as SQL literals in place of its "?"
+// placeholders (those outside string literals).
+func bindSQL(t *testing.T, st recordedStmt) string {
+	t.Helper()
+	var b strings.Builder
+	quoted, next := false, 0
+	for _, ch := range st.sql {
+		switch {
+		case ch == '\'':
+			quoted = !quoted
+		case ch == '?' && !quoted:
+			if next >= len(st.args) {
+				t.Fatalf("too few args for %q", st.sql)
+			}
+			b.WriteString(sqlLiteral(st.args[next]))
+			next++
+			continue
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+func sqlLiteral(v driver.Value) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'"
+	default:
+		return "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+	}
+}
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestResultStore_CreatesSchemaAndSaves(t *testing.T) {
+	store, d := newRecordingStore(t)
+	if len(d.execs) != len(resultStoreSchema) || !strings.Contains(d.execs[2].sql, "crawl_results_domain") {
+		t.Fatalf("expected table + index DDL, got %v", d.execs)
+	}
+
+	err := store.Save("job_1", []*CrawlResult{
+		{URL: "https://www.Shop.com/a", Success: true, StatusCode: 200, Markdown: &MarkdownResult{RawMarkdown: "# A"}},
+	})
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	insert := d.execs[len(d.execs)-1]
+	if !strings.HasPrefix(insert.sql, "INSERT OR REPLACE") {
+		t.Fatalf("expected upsert, got %q", insert.sql)
+	}
+	if insert.args[2] != "shop.com" || insert.args[3] != int64(1) || insert.args[6] != "# A" {
+		t.Fatalf("unexpected insert args: %v", insert.args)
+	}
+}
+
+func TestResultStore_Queries(t *testing.T) {
+	store, d := newRecordingStore(t)
+	d.rows = []string{`{"url": "https://a.com", "success": false, "error_message": "timeout"}`}
+
+	failed, err := store.FailedResults("job_1")
+	if err != nil || len(failed) != 1 || failed[0].ErrorMessage != "timeout" {
+		t.Fatalf("FailedResults: %v %v", failed, err)
+	}
+	if !strings.Contains(d.query.sql, "success = 0 AND job_id = ?") || d.query.args[0] != "job_1" {
+		t.Fatalf("unexpected failed query: %+v", d.query)
+	}
+
+	if _, err := store.ByDomain("", "www.Example.com"); err != nil {
+		t.Fatalf("ByDomain: %v", err)
+	}
+	if strings.Contains(d.query.sql, "job_id = ?") || d.query.args[0] != "example.com" || d.query.args[1] != "%.example.com" {
+		t.Fatalf("unexpected domain query: %+v", d.query)
+	}
+
+	if _, err := store.Search("", "100%_off"); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if d.query.args[0] != `%100\%\_off%` {
+		t.Fatalf("expected escaped LIKE pattern, got %v", d.query.args[0])
+	}
+}
+
+// ─── Integration tests (sqlite3 shell) ───────────────────────────────────
+
+func TestResultStore_SQLiteUpsertReplacesRow(t *testing.T) {
+	store, d := newRecordingStore(t)
+	md := func(s string) *MarkdownResult { return &MarkdownResult{RawMarkdown: s} }
+	if err := store.Save("job_1", []*CrawlResult{{URL: "https://a.com", Success: true, Markdown: md("v1")}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("job_1", []*CrawlResult{
+		{URL: "https://a.com", Success: true, Markdown: md("it's v2")},
+		{URL: "https://b.com", Success: true, Markdown: md("b")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save("job_2", []*CrawlResult{{URL: "https://a.com", Success: true, Markdown: md("other job")}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Search("job_1", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := replaySQLite(t, d.execs, d.query)
+	if len(rows) != 2 {
+		t.Fatalf("expected a.com replaced in place plus b.com, got %v", rows)
+	}
+	var got CrawlResult
+	if err := json.Unmarshal([]byte(rows[0]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != "https://a.com" || got.Markdown == nil || got.Markdown.RawMarkdown != "it's v2" {
+		t.Fatalf("expected the later save to win, got %+v", got)
+	}
+}
+
+func TestResultStore_SQLiteSearchAndDomainMatching(t *testing.T) {
+	store, d := newRecordingStore(t)
+	err := store.Save("job_1", []*CrawlResult{
+		{URL: "https://shop.com/1", Markdown: &MarkdownResult{RawMarkdown: "Now 100%_off today"}},
+		{URL: "https://eu.shop.com/2", Markdown: &MarkdownResult{RawMarkdown: "Now 100 % off"}},
+		{URL: "https://myshop.com/3", ExtractedContent: `{"deal": "1000_off"}`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// % and _ in the query are literal, not LIKE wildcards.
+	if _, err := store.Search("", "100%_off"); err != nil {
+		t.Fatal(err)
+	}
+	if rows := replaySQLite(t, d.execs, d.query); len(rows) != 1 || !strings.Contains(rows[0], "https://shop.com/1") {
+		t.Fatalf("expected only the literal match, got %v", rows)
+	}
+
+	if _, err := store.ByDomain("", "shop.com"); err != nil {
+		t.Fatal(err)
+	}
+	rows := replaySQLite(t, d.execs, d.query)
+	if len(rows) != 2 || strings.Contains(strings.Join(rows, " "), "myshop.com") {
+		t.Fatalf("expected shop.com and its subdomain only, got %v", rows)
+	}
+}
@@ -0,0 +1,404 @@
+package crawl4ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fields RunToWriter can stream.
+const (
+	// StreamMarkdown is the raw markdown.
+	StreamMarkdown = "markdown"
+	// StreamFitMarkdown is the pruned markdown; nothing is written when
+	// the crawl produced none.
+	StreamFitMarkdown = "fit_markdown"
+	StreamHTML        = "html"
+	StreamCleanedHTML = "cleaned_html"
+)
+
+// streamTarget locates a streamed field in the result JSON.
+type streamTarget struct {
+	key string
+	// sub is the member to stream when key holds an object.
+	sub string
+	// plain is whether a string value of key is the field itself.
+	plain bool
+}
+
+var streamTargets = map[string]streamTarget{
+	StreamMarkdown:    {key: "markdown", sub: "raw_markdown", plain: true},
+	StreamFitMarkdown: {key: "markdown", sub: "fit_markdown"},
+	StreamHTML:        {key: "html", plain: true},
+	StreamCleanedHTML: {key: "cleaned_html", plain: true},
+}
+
+// RunToWriter is Run for very large pages: field (StreamMarkdown,
+// StreamHTML, ...) is written to w as the response arrives instead of
+// being held as a string, so memory stays flat however long the page. The
+// returned result has every other field; add the heavy ones you don't need
+// to opts.OmitFields and they are skipped, not buffered.
+//
+// If the connection fails midway, w has received part of the field and
+// the error says so; the request is not retried. opts.Validator is not
+// supported, as escalating would write the field twice.
+//
+//	f, _ := os.Create("page.md")
+//	defer f.Close()
+//	result, err := crawler.RunToWriter(url, crawl4ai.StreamMarkdown, f, &crawl4ai.RunOptions{
+//	    OmitFields: crawl4ai.HeavyResultFields,
+//	})
+func (c *AsyncWebCrawler) RunToWriter(url, field string, w io.Writer, opts *RunOptions) (*CrawlResult, error) {
+	target, ok := streamTargets[field]
+	if !ok {
+		return nil, fmt.Errorf("run to writer: unknown field %q", field)
+	}
+	o := RunOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Validator != nil {
+		return nil, fmt.Errorf("run to writer: Validator is not supported")
+	}
+	o.stream = func(body io.Reader) (map[string]interface{}, error) {
+		return decodeStreamed(body, target, o.OmitFields, w)
+	}
+	return c.runOnce(url, &o)
+}
+
+// decodeStreamed reads a result object from r, writing target's string
+// value to w and decoding the other members, except omit, into a map.
+func decodeStreamed(r io.Reader, target streamTarget, omit []string, w io.Writer) (map[string]interface{}, error) {
+	s := &jsonStream{r: bufio.NewReaderSize(r, 32<<10)}
+	bw := bufio.NewWriterSize(w, 32<<10)
+	skip := make(map[string]bool, len(omit))
+	for _, k := range omit {
+		skip[k] = true
+	}
+	if c, err := s.next(); err != nil || c != '{' {
+		return nil, s.syntaxError(err, "result is not a JSON object")
+	}
+	raw, err := s.object(target, skip, bw, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("stream result: %w", err)
+	}
+	return data, nil
+}
+
+// jsonStream scans JSON from a reader without holding whole values.
+type jsonStream struct {
+	r   *bufio.Reader
+	off int64
+}
+
+func (s *jsonStream) readByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err == nil {
+		s.off++
+	}
+	return b, err
+}
+
+func (s *jsonStream) unreadByte() {
+	_ = s.r.UnreadByte()
+	s.off--
+}
+
+// next returns the next byte that isn't whitespace.
+func (s *jsonStream) next() (byte, error) {
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		if b != ' ' && b != '\t' && b != '\n' && b != '\r' {
+			return b, nil
+		}
+	}
+}
+
+func (s *jsonStream) syntaxError(err error, msg string) error {
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("stream result at byte %d: %w", s.off, err)
+	}
+	if err == io.EOF {
+		msg = "unexpected end of response"
+	}
+	return fmt.Errorf("stream result at byte %d: %s", s.off, msg)
+}
+
+// object reads the members of an object whose '{' was consumed. The string
+// value of target.key is written to w; an object value of it is searched
+// for target.sub. Other members, less skip, are appended to raw as JSON
+// when keep is set.
+func (s *jsonStream) object(target streamTarget, skip map[string]bool, w io.Writer, raw []byte, keep bool) ([]byte, error) {
+	if keep {
+		raw = append(raw, '{')
+	}
+	members := 0
+	for {
+		c, err := s.next()
+		if err != nil {
+			return nil, s.syntaxError(err, "")
+		}
+		switch c {
+		case '}':
+			if keep {
+				raw = append(raw, '}')
+			}
+			return raw, nil
+		case ',':
+			continue
+		case '"':
+		default:
+			return nil, s.syntaxError(nil, fmt.Sprintf("unexpected %q in object", c))
+		}
+		var key strings.Builder
+		if err := s.writeString(&key); err != nil {
+			return nil, err
+		}
+		if c, err := s.next(); err != nil || c != ':' {
+			return nil, s.syntaxError(err, "missing ':' after key")
+		}
+		c, err = s.next()
+		if err != nil {
+			return nil, s.syntaxError(err, "")
+		}
+
+		name := key.String()
+		switch {
+		case name == target.key && c == '"' && target.plain:
+			err = s.writeString(w)
+		case name == target.key && c == '{' && target.sub != "":
+			// The field is streamed even when omitted; only its
+			// siblings are dropped.
+			sub := streamTarget{key: target.sub, plain: true}
+			if skip[name] || !keep {
+				_, err = s.object(sub, nil, w, nil, false)
+				break
+			}
+			raw = s.appendKey(raw, name, &members)
+			raw, err = s.object(sub, nil, w, raw, true)
+		case skip[name] || !keep:
+			_, err = s.value(c, nil, false)
+		default:
+			raw = s.appendKey(raw, name, &members)
+			raw, err = s.value(c, raw, true)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// appendKey appends a member's key to raw, after a comma unless it is
+// the first.
+func (s *jsonStream) appendKey(raw []byte, key string, members *int) []byte {
+	if *members > 0 {
+		raw = append(raw, ',')
+	}
+	*members++
+	quoted, _ := json.Marshal(key)
+	return append(append(raw, quoted...), ':')
+}
+
+// value reads the value starting with c, appending its JSON to raw when
+// keep is set.
+func (s *jsonStream) value(c byte, raw []byte, keep bool) ([]byte, error) {
+	if keep {
+		raw = append(raw, c)
+	}
+	switch c {
+	case '"':
+		return s.rawString(raw, keep)
+	case '{', '[':
+		depth := 1
+		for depth > 0 {
+			b, err := s.readByte()
+			if err != nil {
+				return nil, s.syntaxError(err, "")
+			}
+			if keep {
+				raw = append(raw, b)
+			}
+			switch b {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			case '"':
+				if raw, err = s.rawString(raw, keep); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return raw, nil
+	}
+	// A number or literal runs to the next delimiter.
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return nil, s.syntaxError(err, "")
+		}
+		if b == ',' || b == '}' || b == ']' || b == ' ' || b == '\t' || b == '\n' || b == '\r' {
+			s.unreadByte()
+			return raw, nil
+		}
+		if keep {
+			raw = append(raw, b)
+		}
+	}
+}
+
+// rawString copies a string's remaining bytes, escapes and closing quote
+// included, after its opening quote was read.
+func (s *jsonStream) rawString(raw []byte, keep bool) ([]byte, error) {
+	escaped := false
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return nil, s.syntaxError(err, "")
+		}
+		if keep {
+			raw = append(raw, b)
+		}
+		switch {
+		case escaped:
+			escaped = false
+		case b == '\\':
+			escaped = true
+		case b == '"':
+			return raw, nil
+		}
+	}
+}
+
+// writeString decodes a string whose opening quote was read, writing it to
+// w as it goes.
+func (s *jsonStream) writeString(w io.Writer) error {
+	var out [utf8.UTFMax]byte
+	bw, ok := w.(io.ByteWriter)
+	if !ok {
+		bw = &byteWriter{w: w}
+	}
+	for {
+		b, err := s.readByte()
+		if err != nil {
+			return s.syntaxError(err, "")
+		}
+		switch b {
+		case '"':
+			return nil
+		case '\\':
+		default:
+			if err := bw.WriteByte(b); err != nil {
+				return err
+			}
+			continue
+		}
+		e, err := s.readByte()
+		if err != nil {
+			return s.syntaxError(err, "")
+		}
+		switch e {
+		case '"', '\\', '/':
+			err = bw.WriteByte(e)
+		case 'b':
+			err = bw.WriteByte('\b')
+		case 'f':
+			err = bw.WriteByte('\f')
+		case 'n':
+			err = bw.WriteByte('\n')
+		case 'r':
+			err = bw.WriteByte('\r')
+		case 't':
+			err = bw.WriteByte('\t')
+		case 'u':
+			r, rerr := s.escapedRune()
+			if rerr != nil {
+				return rerr
+			}
+			n := utf8.EncodeRune(out[:], r)
+			_, err = w.Write(out[:n])
+		default:
+			return s.syntaxError(nil, fmt.Sprintf("invalid escape \\%c", e))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// escapedRune reads the hex digits of a \u escape, joining a surrogate
+// pair into one rune.
+func (s *jsonStream) escapedRune() (rune, error) {
+	r, err := s.hex4()
+	if err != nil {
+		return 0, err
+	}
+	if r < 0xD800 || r > 0xDBFF {
+		return r, nil
+	}
+	// A high surrogate: the low half follows as another \u escape.
+	if b, err := s.readByte(); err != nil || b != '\\' {
+		if err == nil {
+			s.unreadByte()
+		}
+		return utf8.RuneError, nil
+	}
+	if b, err := s.readByte(); err != nil || b != 'u' {
+		return 0, s.syntaxError(err, "invalid surrogate pair")
+	}
+	lo, err := s.hex4()
+	if err != nil {
+		return 0, err
+	}
+	if lo < 0xDC00 || lo > 0xDFFF {
+		return utf8.RuneError, nil
+	}
+	return (r-0xD800)<<10 + (lo - 0xDC00) + 0x10000, nil
+}
+
+func (s *jsonStream) hex4() (rune, error) {
+	var r rune
+	for i := 0; i < 4; i++ {
+		b, err := s.readByte()
+		if err != nil {
+			return 0, s.syntaxError(err, "")
+		}
+		switch {
+		case '0' <= b && b <= '9':
+			b -= '0'
+		case 'a' <= b && b <= 'f':
+			b -= 'a' - 10
+		case 'A' <= b && b <= 'F':
+			b -= 'A' - 10
+		default:
+			return 0, s.syntaxError(nil, "invalid \\u escape")
+		}
+		r = r<<4 | rune(b)
+	}
+	return r, nil
+}
+
+// byteWriter adapts an io.Writer without WriteByte.
+type byteWriter struct {
+	w   io.Writer
+	buf [1]byte
+}
+
+func (b *byteWriter) WriteByte(c byte) error {
+	b.buf[0] = c
+	_, err := b.w.Write(b.buf[:])
+	return err
+}
@@ -0,0 +1,141 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestDecodeStreamed(t *testing.T) {
+	body := `{"url": "https://example.com", "success": true, "status_code": 200,
+		"html": "<p>a \"quoted\" \\ line\nnext</p> café 😀 é",
+		"markdown": {"raw_markdown": "# Title", "fit_markdown": "fit", "references_markdown": "refs"},
+		"links": {"internal": [{"href": "/a", "text": "}]{["}], "external": []},
+		"metadata": null, "screenshot": "aGVsbG8="}`
+
+	var html bytes.Buffer
+	data, err := decodeStreamed(strings.NewReader(body), streamTargets[StreamHTML], []string{"screenshot"}, &html)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>a \"quoted\" \\ line\nnext</p> café 😀 é"; html.String() != want {
+		t.Errorf("html = %q, want %q", html.String(), want)
+	}
+	if _, ok := data["html"]; ok {
+		t.Error("streamed field kept in the map")
+	}
+	if _, ok := data["screenshot"]; ok {
+		t.Error("omitted field kept in the map")
+	}
+	r := CrawlResultFromMap(data)
+	if r.URL != "https://example.com" || !r.Success || r.Markdown == nil || r.Markdown.FitMarkdown != "fit" {
+		t.Errorf("result = %+v", r)
+	}
+	if links, _ := data["links"].(map[string]interface{}); len(links["internal"].([]interface{})) != 1 {
+		t.Errorf("links = %v", data["links"])
+	}
+
+	for _, tc := range []struct {
+		field, body, want, rest string
+	}{
+		{StreamMarkdown, body, "# Title", "fit"},
+		{StreamFitMarkdown, body, "fit", "# Title"},
+		{StreamMarkdown, `{"markdown": "plain string"}`, "plain string", ""},
+		{StreamFitMarkdown, `{"markdown": "plain string"}`, "", "plain string"},
+	} {
+		var out bytes.Buffer
+		data, err := decodeStreamed(strings.NewReader(tc.body), streamTargets[tc.field], nil, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tc.want {
+			t.Errorf("%s: wrote %q, want %q", tc.field, out.String(), tc.want)
+		}
+		if !strings.Contains(fmt.Sprint(data["markdown"]), tc.rest) {
+			t.Errorf("%s: markdown left = %v, want it to hold %q", tc.field, data["markdown"], tc.rest)
+		}
+	}
+
+	// An omitted markdown object is still streamed, its siblings dropped.
+	var out bytes.Buffer
+	data, err = decodeStreamed(strings.NewReader(body), streamTargets[StreamMarkdown], []string{"markdown"}, &out)
+	if err != nil || out.String() != "# Title" || data["markdown"] != nil {
+		t.Errorf("omitted markdown: wrote %q, map %v, err %v", out.String(), data["markdown"], err)
+	}
+
+	for _, bad := range []string{`[]`, `{"html": "unterminated`, `{"html" "x"}`, `{"html": "\q"}`} {
+		if _, err := decodeStreamed(strings.NewReader(bad), streamTargets[StreamHTML], nil, io.Discard); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+// TestDecodeStreamed_MemoryIsFlat checks that streaming an 8 MiB page
+// allocates a small, fixed amount rather than a copy of the page.
+func TestDecodeStreamed_MemoryIsFlat(t *testing.T) {
+	if testing.Short() {
+		t.Skip("allocation counts are slow to measure")
+	}
+	raw, _ := json.Marshal(map[string]interface{}{
+		"url":      "https://example.com",
+		"success":  true,
+		"markdown": map[string]interface{}{"raw_markdown": strings.Repeat("Crawl4AI streams large pages.\n", 8<<20/30)},
+	})
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := decodeStreamed(bytes.NewReader(raw), streamTargets[StreamMarkdown], nil, io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	runtime.ReadMemStats(&after)
+	if n := after.TotalAlloc - before.TotalAlloc; n > 256<<10 {
+		t.Errorf("allocated %d bytes streaming a %d byte page", n, len(raw))
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRunToWriter(t *testing.T) {
+	page := strings.Repeat("<p>x</p>", 1<<16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "req_stream")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "https://example.com", "success": true, "html": page,
+			"cleaned_html": page, "markdown": "md",
+		})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	result, err := c.RunToWriter("https://example.com", StreamHTML, &out, &RunOptions{OmitFields: []string{"cleaned_html"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != page {
+		t.Errorf("wrote %d bytes, want %d", out.Len(), len(page))
+	}
+	if result.HTML != "" || result.CleanedHTML != "" || result.Markdown == nil || result.Markdown.RawMarkdown != "md" {
+		t.Errorf("result = %+v", result)
+	}
+	if result.RequestID != "req_stream" {
+		t.Errorf("RequestID = %q", result.RequestID)
+	}
+
+	if _, err := c.RunToWriter("https://example.com", "links", &out, nil); err == nil {
+		t.Error("expected an error for a field that can't be streamed")
+	}
+	if _, err := c.RunToWriter("https://example.com", StreamHTML, &out, &RunOptions{Validator: func(*CrawlResult) error { return nil }}); err == nil {
+		t.Error("expected an error for a Validator")
+	}
+}
@@ -0,0 +1,223 @@
+package crawl4ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LLM answers a prompt. Use PlatformLLM to bill the account's LLM tokens,
+// OpenAIChat for your own provider, or implement it for anything else.
+type LLM interface {
+	Complete(ctx context.Context, system, prompt string) (string, error)
+}
+
+// PlatformLLM returns an LLM backed by the platform's POST /v1/llm/complete
+// endpoint. llmConfig picks the provider and model as in the other
+// llm_config options; nil uses the platform default.
+func (c *AsyncWebCrawler) PlatformLLM(llmConfig map[string]interface{}) LLM {
+	return &platformLLM{c: c, config: llmConfig}
+}
+
+type platformLLM struct {
+	c      *AsyncWebCrawler
+	config map[string]interface{}
+}
+
+func (p *platformLLM) Complete(ctx context.Context, system, prompt string) (string, error) {
+	body := map[string]interface{}{"system": system, "prompt": prompt}
+	if p.config != nil {
+		body["llm_config"] = p.config
+	}
+	data, err := p.c.WithContext(ctx).http.Post("/v1/llm/complete", body, 120*time.Second)
+	if err != nil {
+		return "", err
+	}
+	text, ok := data["text"].(string)
+	if !ok {
+		return "", NewCloudError("LLM response has no text", 0, data, nil)
+	}
+	return text, nil
+}
+
+// OpenAIChat calls an OpenAI-compatible POST /chat/completions endpoint:
+// OpenAI itself, or a local server such as Ollama, vLLM or LM Studio.
+type OpenAIChat struct {
+	// BaseURL defaults to https://api.openai.com/v1.
+	BaseURL string
+	// APIKey is sent as a bearer token; defaults to $OPENAI_API_KEY.
+	APIKey string
+	// Model defaults to gpt-4o-mini.
+	Model      string
+	HTTPClient *http.Client
+}
+
+// Complete implements LLM.
+func (o *OpenAIChat) Complete(ctx context.Context, system, prompt string) (string, error) {
+	base := o.BaseURL
+	if base == "" {
+		base = "https://api.openai.com/v1"
+	}
+	model := o.Model
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+	key := o.APIKey
+	if key == "" {
+		key = os.Getenv("OPENAI_API_KEY")
+	}
+	client := o.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 120 * time.Second}
+	}
+
+	messages := []map[string]string{}
+	if system != "" {
+		messages = append(messages, map[string]string{"role": "system", "content": system})
+	}
+	messages = append(messages, map[string]string{"role": "user", "content": prompt})
+	body, err := json.Marshal(map[string]interface{}{"model": model, "messages": messages})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(base, "/")+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("chat completion: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("chat completion: %w", err)
+	}
+	var out struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	decodeErr := json.Unmarshal(raw, &out)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		if decodeErr == nil && out.Error != nil && out.Error.Message != "" {
+			return "", fmt.Errorf("chat completion: HTTP %d: %s", resp.StatusCode, out.Error.Message)
+		}
+		return "", fmt.Errorf("chat completion: HTTP %d", resp.StatusCode)
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("chat completion: decode response: %w", decodeErr)
+	}
+	if len(out.Choices) == 0 {
+		return "", fmt.Errorf("chat completion: no choices in response")
+	}
+	return out.Choices[0].Message.Content, nil
+}
+
+// Summary is an LLM summary of one page.
+type Summary struct {
+	URL       string   `json:"url"`
+	Title     string   `json:"title"`
+	Summary   string   `json:"summary"`
+	KeyPoints []string `json:"key_points"`
+	// Error is set by SummarizeJob for pages that could not be summarized.
+	Error string `json:"error,omitempty"`
+}
+
+// summarizeMaxChars caps the markdown sent per page, keeping a long page
+// inside common context windows.
+const summarizeMaxChars = 48000
+
+const summarizeSystem = `You summarize web pages. Reply with JSON only, no prose around it:
+{"title": "<page title>", "summary": "<summary>", "key_points": ["<point>", ...]}`
+
+// Summarize asks llm to summarize the result's FitMarkdown (RawMarkdown
+// when empty). instruction steers the summary — length, audience, focus;
+// empty asks for a short general summary. A reply that is not the expected
+// JSON is kept whole in Summary.Summary.
+//
+//	s, err := result.Summarize(ctx, crawler.PlatformLLM(nil), "Three sentences for a sales team.")
+func (r *CrawlResult) Summarize(ctx context.Context, llm LLM, instruction string) (*Summary, error) {
+	if llm == nil {
+		return nil, fmt.Errorf("summarize: LLM is required")
+	}
+	text := embedText(r)
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("summarize %s: result has no markdown", r.URL)
+	}
+	if runes := []rune(text); len(runes) > summarizeMaxChars {
+		text = string(runes[:summarizeMaxChars]) + "\n\n[truncated]"
+	}
+	if instruction == "" {
+		instruction = "Summarize this page in a few sentences."
+	}
+	prompt := fmt.Sprintf("%s\n\nURL: %s\n\n%s", instruction, r.URL, text)
+	reply, err := llm.Complete(ctx, summarizeSystem, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("summarize %s: %w", r.URL, err)
+	}
+	return parseSummary(r.URL, reply), nil
+}
+
+// parseSummary decodes the JSON reply, tolerating code fences and text
+// around the object.
+func parseSummary(url, reply string) *Summary {
+	s := &Summary{}
+	start, end := strings.Index(reply, "{"), strings.LastIndex(reply, "}")
+	if start < 0 || end < start || json.Unmarshal([]byte(reply[start:end+1]), s) != nil || s.Summary == "" {
+		s = &Summary{Summary: strings.TrimSpace(reply)}
+	}
+	s.URL = url
+	return s
+}
+
+// SummarizeJob summarizes every result of an async crawl job, four pages
+// at a time, in result order. Pages that failed to crawl or summarize get
+// a Summary with Error set; only failing to load the job is an error.
+func (c *AsyncWebCrawler) SummarizeJob(ctx context.Context, jobID string, llm LLM, instruction string) ([]Summary, error) {
+	if llm == nil {
+		return nil, fmt.Errorf("summarize: LLM is required")
+	}
+	results, err := c.WithContext(ctx).JobResults(jobID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Summary, len(results))
+	sem := make(chan struct{}, 4)
+	var wg sync.WaitGroup
+	for i, r := range results {
+		if !r.Success {
+			out[i] = Summary{URL: r.URL, Error: "crawl failed: " + r.ErrorMessage}
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, r *CrawlResult) {
+			defer func() { <-sem; wg.Done() }()
+			s, err := r.Summarize(ctx, llm, instruction)
+			if err != nil {
+				out[i] = Summary{URL: r.URL, Error: err.Error()}
+				return
+			}
+			out[i] = *s
+		}(i, r)
+	}
+	wg.Wait()
+	return out, nil
+}
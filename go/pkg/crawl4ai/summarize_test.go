@@ -0,0 +1,154 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+// scriptedLLM replies with reply, or fails for prompts containing failOn.
+type scriptedLLM struct {
+	mu      sync.Mutex
+	reply   string
+	failOn  string
+	prompts []string
+}
+
+func (l *scriptedLLM) Complete(_ context.Context, _, prompt string) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prompts = append(l.prompts, prompt)
+	if l.failOn != "" && strings.Contains(prompt, l.failOn) {
+		return "", errors.New("model overloaded")
+	}
+	return l.reply, nil
+}
+
+func TestSummarize_ParsesJSONReply(t *testing.T) {
+	llm := &scriptedLLM{reply: "```json\n{\"title\":\"Pricing\",\"summary\":\"Three plans.\",\"key_points\":[\"Free tier\",\"Pro $20\"]}\n```"}
+	r := &CrawlResult{URL: "https://a.com/pricing", Markdown: &MarkdownResult{RawMarkdown: "raw", FitMarkdown: "# Pricing"}}
+	s, err := r.Summarize(context.Background(), llm, "For a sales team.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.URL != "https://a.com/pricing" || s.Title != "Pricing" || s.Summary != "Three plans." || len(s.KeyPoints) != 2 {
+		t.Errorf("summary = %+v", s)
+	}
+	if p := llm.prompts[0]; !strings.HasPrefix(p, "For a sales team.") || !strings.Contains(p, "# Pricing") || strings.Contains(p, "raw") {
+		t.Errorf("prompt = %q", p)
+	}
+}
+
+func TestSummarize_PlainReplyKept(t *testing.T) {
+	r := &CrawlResult{URL: "u", Markdown: &MarkdownResult{RawMarkdown: "text"}}
+	s, err := r.Summarize(context.Background(), &scriptedLLM{reply: "  Just a sentence. "}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Summary != "Just a sentence." || s.Title != "" {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+func TestSummarize_Errors(t *testing.T) {
+	r := &CrawlResult{URL: "u"}
+	if _, err := r.Summarize(context.Background(), nil, ""); err == nil {
+		t.Error("nil LLM accepted")
+	}
+	if _, err := r.Summarize(context.Background(), &scriptedLLM{}, ""); err == nil || !strings.Contains(err.Error(), "no markdown") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestSummarize_TruncatesLongPages(t *testing.T) {
+	llm := &scriptedLLM{reply: "ok"}
+	r := &CrawlResult{URL: "u", Markdown: &MarkdownResult{RawMarkdown: strings.Repeat("x", summarizeMaxChars+500)}}
+	if _, err := r.Summarize(context.Background(), llm, ""); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(llm.prompts[0], "[truncated]") || strings.Count(llm.prompts[0], "x") != summarizeMaxChars {
+		t.Error("long page not truncated")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+func TestSummarizeJob(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_s": map[string]interface{}{"job_id": "job_s", "status": "completed", "urls_count": 3, "results": []interface{}{
+			map[string]interface{}{"url": "https://a.com", "success": true, "markdown": map[string]interface{}{"raw_markdown": "alpha"}},
+			map[string]interface{}{"url": "https://b.com", "success": false, "error_message": "timeout"},
+			map[string]interface{}{"url": "https://c.com", "success": true, "markdown": map[string]interface{}{"raw_markdown": "gamma"}},
+		}},
+	})
+	llm := &scriptedLLM{reply: `{"summary":"fine"}`, failOn: "gamma"}
+	got, err := c.SummarizeJob(context.Background(), "job_s", llm, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("summaries = %d", len(got))
+	}
+	if got[0].Summary != "fine" || got[0].Error != "" {
+		t.Errorf("a = %+v", got[0])
+	}
+	if got[1].URL != "https://b.com" || got[1].Error != "crawl failed: timeout" {
+		t.Errorf("b = %+v", got[1])
+	}
+	if got[2].URL != "https://c.com" || !strings.Contains(got[2].Error, "model overloaded") {
+		t.Errorf("c = %+v", got[2])
+	}
+}
+
+func TestPlatformLLM(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/llm/complete" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"text": "hello"})
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := c.PlatformLLM(map[string]interface{}{"provider": "openai/gpt-4o-mini"}).Complete(context.Background(), "sys", "hi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "hello" || body["system"] != "sys" || body["prompt"] != "hi" || body["llm_config"] == nil {
+		t.Errorf("out = %q body = %v", out, body)
+	}
+}
+
+func TestOpenAIChat(t *testing.T) {
+	var body struct {
+		Model    string              `json:"model"`
+		Messages []map[string]string `json:"messages"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"answer"}}]}`))
+	}))
+	defer srv.Close()
+
+	out, err := (&OpenAIChat{BaseURL: srv.URL, APIKey: "k", Model: "m"}).Complete(context.Background(), "sys", "q")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "answer" || body.Model != "m" || len(body.Messages) != 2 || body.Messages[0]["role"] != "system" {
+		t.Errorf("out = %q body = %+v", out, body)
+	}
+}
@@ -0,0 +1,310 @@
+package crawl4ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrUnknownTenant is returned by ClientManager for tenants that were
+// never registered or have been removed.
+var ErrUnknownTenant = errors.New("crawl4ai: unknown tenant")
+
+// TenantConfig is one tenant's settings in a ClientManager. Unset fields
+// fall back to the manager's base CrawlerOptions.
+type TenantConfig struct {
+	// APIKey is the tenant's own key, so usage and quotas are billed to
+	// it. Required.
+	APIKey string
+	// BrowserConfig, OmitFields and AllowedDomains replace the base
+	// options' when set.
+	BrowserConfig  *BrowserConfig
+	OmitFields     []string
+	AllowedDomains []string
+	// RequestsPerSecond caps the tenant's API calls; calls beyond it wait
+	// their turn. Burst is how many may go at once (default 1). 0 = no
+	// limit.
+	RequestsPerSecond float64
+	Burst             int
+	// CreditBudget refuses the tenant's calls with a *TenantBudgetError
+	// once this many credits have been spent through the manager. Credits
+	// are counted from the usage reported on crawl results and, once per
+	// job, on finished jobs (RunMany, RunAsync, DeepCrawl, WaitJob).
+	// 0 = no budget.
+	CreditBudget float64
+}
+
+// TenantUsage is what a tenant has used through a ClientManager since it
+// was registered or its budget was last reset.
+type TenantUsage struct {
+	Requests     int64
+	CreditsSpent float64
+	CreditBudget float64
+}
+
+// TenantBudgetError is returned for calls made after a tenant spent its
+// TenantConfig.CreditBudget.
+type TenantBudgetError struct {
+	Tenant string
+	Budget float64
+	Spent  float64
+}
+
+// Error implements error.
+func (e *TenantBudgetError) Error() string {
+	return fmt.Sprintf("tenant %s has spent %.2f of its %.2f credit budget", e.Tenant, e.Spent, e.Budget)
+}
+
+// ClientManager holds one crawler per tenant, each with its own API key,
+// rate limit, credit budget and defaults, for platforms that crawl on
+// behalf of their customers. Safe for concurrent use.
+//
+//	m := crawl4ai.NewClientManager(crawl4ai.CrawlerOptions{OmitFields: crawl4ai.HeavyResultFields})
+//	m.Register("acme", crawl4ai.TenantConfig{APIKey: acmeKey, RequestsPerSecond: 5, CreditBudget: 1000})
+//	crawler, err := m.Client("acme")
+type ClientManager struct {
+	base CrawlerOptions
+
+	mu      sync.Mutex
+	tenants map[string]*tenantClient
+}
+
+type tenantClient struct {
+	name    string
+	crawler *AsyncWebCrawler
+	limiter *tokenBucket
+	budget  float64
+
+	mu       sync.Mutex
+	requests int64
+	spent    float64
+	// charged holds the finished jobs already billed, oldest first in
+	// chargedOrder, so polling or re-fetching a job bills it once.
+	charged      map[string]bool
+	chargedOrder []string
+}
+
+// maxChargedJobs bounds how many billed job IDs a tenant remembers.
+const maxChargedJobs = 4096
+
+// NewClientManager creates a manager whose tenants start from base; its
+// APIKey is ignored.
+func NewClientManager(base CrawlerOptions) *ClientManager {
+	return &ClientManager{base: base, tenants: map[string]*tenantClient{}}
+}
+
+// Register adds tenant, or replaces its settings, and returns its
+// crawler. Replacing a tenant resets its usage.
+func (m *ClientManager) Register(tenant string, cfg TenantConfig) (*AsyncWebCrawler, error) {
+	if tenant == "" {
+		return nil, fmt.Errorf("tenant name is required")
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("tenant %s: API key is required", tenant)
+	}
+	opts := m.base
+	opts.APIKey = cfg.APIKey
+	if cfg.BrowserConfig != nil {
+		opts.BrowserConfig = cfg.BrowserConfig
+	}
+	if cfg.OmitFields != nil {
+		opts.OmitFields = cfg.OmitFields
+	}
+	if cfg.AllowedDomains != nil {
+		opts.AllowedDomains = cfg.AllowedDomains
+	}
+
+	t := &tenantClient{name: tenant, budget: cfg.CreditBudget}
+	if cfg.RequestsPerSecond > 0 {
+		t.limiter = newTokenBucket(cfg.RequestsPerSecond, cfg.Burst)
+	}
+	opts.ResultHooks = append([]ResultHook{t.countCredits}, m.base.ResultHooks...)
+	c, err := NewAsyncWebCrawler(opts)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %s: %w", tenant, err)
+	}
+	c.http.gate = t.admit
+	c.jobDone = t.countJobCredits
+	t.crawler = c
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tenants[tenant] = t
+	return c, nil
+}
+
+// Client returns tenant's crawler, or ErrUnknownTenant.
+func (m *ClientManager) Client(tenant string) (*AsyncWebCrawler, error) {
+	t, err := m.tenant(tenant)
+	if err != nil {
+		return nil, err
+	}
+	return t.crawler, nil
+}
+
+// Remove forgets tenant. Its crawler keeps working for callers that
+// still hold it.
+func (m *ClientManager) Remove(tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tenants, tenant)
+}
+
+// Tenants lists the registered tenants, sorted.
+func (m *ClientManager) Tenants() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.tenants))
+	for name := range m.tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Usage returns tenant's usage.
+func (m *ClientManager) Usage(tenant string) (TenantUsage, error) {
+	t, err := m.tenant(tenant)
+	if err != nil {
+		return TenantUsage{}, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return TenantUsage{Requests: t.requests, CreditsSpent: t.spent, CreditBudget: t.budget}, nil
+}
+
+// ResetUsage zeroes tenant's counters, starting a new budget period.
+func (m *ClientManager) ResetUsage(tenant string) error {
+	t, err := m.tenant(tenant)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests, t.spent = 0, 0
+	t.charged, t.chargedOrder = nil, nil
+	return nil
+}
+
+func (m *ClientManager) tenant(name string) (*tenantClient, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t, ok := m.tenants[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTenant, name)
+	}
+	return t, nil
+}
+
+// admit checks the budget and waits for the rate limiter before a call.
+func (t *tenantClient) admit(ctx context.Context) error {
+	t.mu.Lock()
+	if t.budget > 0 && t.spent >= t.budget {
+		err := &TenantBudgetError{Tenant: t.name, Budget: t.budget, Spent: t.spent}
+		t.mu.Unlock()
+		return err
+	}
+	t.mu.Unlock()
+	if t.limiter != nil {
+		if err := t.limiter.wait(ctx); err != nil {
+			return err
+		}
+	}
+	t.mu.Lock()
+	t.requests++
+	t.mu.Unlock()
+	return nil
+}
+
+// countCredits charges a result's usage. Results inlined in a job are
+// left to countJobCredits, which bills each job once.
+func (t *tenantClient) countCredits(r *CrawlResult) error {
+	if !r.inJob && r.Usage != nil && r.Usage.Crawl != nil {
+		t.mu.Lock()
+		t.spent += r.Usage.Crawl.CreditsUsed
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// countJobCredits charges a finished job's usage the first time the
+// tenant sees it: the job-level total, or the sum over its results when
+// the job reports none.
+func (t *tenantClient) countJobCredits(job *CrawlJob) {
+	if job.JobID == "" {
+		return
+	}
+	var credits float64
+	if job.Usage != nil && job.Usage.Crawl != nil {
+		credits = job.Usage.Crawl.CreditsUsed
+	} else {
+		for _, r := range job.Results {
+			if r.Usage != nil && r.Usage.Crawl != nil {
+				credits += r.Usage.Crawl.CreditsUsed
+			}
+		}
+	}
+	if credits == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.charged[job.JobID] {
+		return
+	}
+	if t.charged == nil {
+		t.charged = map[string]bool{}
+	}
+	if len(t.chargedOrder) >= maxChargedJobs {
+		delete(t.charged, t.chargedOrder[0])
+		t.chargedOrder = t.chargedOrder[1:]
+	}
+	t.charged[job.JobID] = true
+	t.chargedOrder = append(t.chargedOrder, job.JobID)
+	t.spent += credits
+}
+
+// tokenBucket allows rate events per second, up to burst at once.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait takes a token, blocking until one is free or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package crawl4ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(100, 2)
+	started := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Two from the burst, two more at 100/s.
+	if d := time.Since(started); d < 15*time.Millisecond {
+		t.Errorf("4 tokens in %s; the limit wasn't applied", d)
+	}
+
+	slow := newTokenBucket(0.01, 1)
+	_ = slow.wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := slow.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestClientManager(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get("X-API-Key")]++
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"url": "https://example.com", "success": true,
+			"usage": map[string]interface{}{"crawl": map[string]interface{}{"credits_used": 3.0}},
+		})
+	}))
+	defer srv.Close()
+
+	m := NewClientManager(CrawlerOptions{BaseURL: srv.URL, MaxRetries: 1, OmitFields: []string{"html"}})
+	if _, err := m.Register("acme", TenantConfig{APIKey: "sk_test_acme", CreditBudget: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Register("globex", TenantConfig{APIKey: "sk_test_globex", OmitFields: []string{}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Register("bad", TenantConfig{APIKey: "not-a-key"}); err == nil {
+		t.Error("expected an error for an invalid key")
+	}
+	if got := m.Tenants(); len(got) != 2 || got[0] != "acme" || got[1] != "globex" {
+		t.Errorf("Tenants = %v", got)
+	}
+
+	acme, _ := m.Client("acme")
+	globex, _ := m.Client("globex")
+	if len(acme.omitFields) != 1 || len(globex.omitFields) != 0 {
+		t.Errorf("tenant options not applied: %v / %v", acme.omitFields, globex.omitFields)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := acme.Run("https://example.com", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 6 credits spent of 5: the next call is refused without a request.
+	_, err := acme.Run("https://example.com", nil)
+	var budgetErr *TenantBudgetError
+	if !errors.As(err, &budgetErr) || budgetErr.Tenant != "acme" || budgetErr.Spent != 6 {
+		t.Fatalf("err = %v", err)
+	}
+	if _, err := globex.Run("https://example.com", nil); err != nil {
+		t.Errorf("one tenant's budget blocked another: %v", err)
+	}
+	if keys["sk_test_acme"] != 2 || keys["sk_test_globex"] != 1 {
+		t.Errorf("requests by key = %v", keys)
+	}
+	if u, _ := m.Usage("acme"); u.Requests != 2 || u.CreditsSpent != 6 || u.CreditBudget != 5 {
+		t.Errorf("usage = %+v", u)
+	}
+
+	if err := m.ResetUsage("acme"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := acme.Run("https://example.com", nil); err != nil {
+		t.Errorf("after reset: %v", err)
+	}
+
+	m.Remove("globex")
+	if _, err := m.Client("globex"); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestClientManager_ChargesJobUsageOnce(t *testing.T) {
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "pending", "urls_count": 2})
+			return
+		}
+		job := map[string]interface{}{"job_id": "job_1", "status": "running", "urls_count": 2}
+		if atomic.AddInt32(&polls, 1) > 1 {
+			job["status"] = "completed"
+			job["usage"] = map[string]interface{}{"crawl": map[string]interface{}{"credits_used": 4.0}}
+		}
+		_ = json.NewEncoder(w).Encode(job)
+	}))
+	defer srv.Close()
+
+	m := NewClientManager(CrawlerOptions{BaseURL: srv.URL, MaxRetries: 1})
+	acme, err := m.Register("acme", TenantConfig{APIKey: "sk_test_acme", CreditBudget: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	urls := []string{"https://example.com/a", "https://example.com/b"}
+	if _, err := acme.RunMany(urls, &RunManyOptions{Wait: true, PollInterval: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	// Fetching the finished job again does not bill it twice.
+	if _, err := acme.GetJob("job_1"); err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := m.Usage("acme"); u.CreditsSpent != 4 {
+		t.Errorf("credits spent = %v, want 4", u.CreditsSpent)
+	}
+}
+
+func TestClientManager_JobWithInlinedResultsBilledOnce(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := func(url string) map[string]interface{} {
+			return map[string]interface{}{"url": url, "success": true,
+				"usage": map[string]interface{}{"crawl": map[string]interface{}{"credits_used": 1.0}}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"job_id": "job_1", "status": "completed", "urls_count": 2,
+			"results": []interface{}{result("https://example.com/a"), result("https://example.com/b")},
+			"usage":   map[string]interface{}{"crawl": map[string]interface{}{"credits_used": 2.0}},
+		})
+	}))
+	defer srv.Close()
+
+	m := NewClientManager(CrawlerOptions{BaseURL: srv.URL, MaxRetries: 1})
+	acme, err := m.Register("acme", TenantConfig{APIKey: "sk_test_acme", CreditBudget: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := acme.GetJob("job_1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if u, _ := m.Usage("acme"); u.CreditsSpent != 2 {
+		t.Errorf("credits spent = %v, want the job's 2", u.CreditsSpent)
+	}
+}
@@ -0,0 +1,86 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestRun_PerCallTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-time.After(2 * time.Second):
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://a.com", "success": true})
+	}))
+	defer srv.Close()
+	defer close(release)
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, RunTimeout: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Run("https://a.com", &RunOptions{Timeout: 50 * time.Millisecond})
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected *TimeoutError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "after ") || !strings.Contains(err.Error(), "timeout 50ms") {
+		t.Fatalf("error should say how long was waited: %v", err)
+	}
+}
+
+func TestRunMany_DefaultWaitTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/crawl/async":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "pending", "urls_count": 2})
+		case "GET /v1/crawl/jobs/job_1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"job_id": "job_1", "status": "running", "urls_count": 2})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, WaitTimeout: 30 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.RunMany([]string{"https://a.com", "https://b.com"}, &RunManyOptions{Wait: true, PollInterval: 10 * time.Millisecond})
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected *TimeoutError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "timeout waiting for job job_1 after ") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+	if te.Job == nil || te.Job.JobID != "job_1" {
+		t.Fatalf("timeout should carry the last job snapshot: %+v", te.Job)
+	}
+}
+
+func TestWaitJob_TimeoutReturnsJob(t *testing.T) {
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs/job_1": map[string]interface{}{"job_id": "job_1", "status": "running", "progress": map[string]interface{}{"total": 4, "completed": 3}},
+	})
+	job, err := c.WaitJob("job_1", 5*time.Millisecond, 20*time.Millisecond)
+	var te *TimeoutError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected *TimeoutError, got %v", err)
+	}
+	if job == nil || job.Status != JobStatusRunning || job.Progress.Completed != 3 {
+		t.Fatalf("last snapshot = %+v", job)
+	}
+	if te.Job != job {
+		t.Error("the error should carry the same snapshot")
+	}
+}
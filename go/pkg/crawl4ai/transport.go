@@ -0,0 +1,88 @@
+package crawl4ai
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TransportOptions tunes the connection pool to the API for services
+// making thousands of calls a minute, where the net/http defaults (two idle
+// connections per host, no TLS session reuse configured) mean constant
+// re-dialing and full handshakes. Set as CrawlerOptions.Transport; zero
+// fields keep the defaults.
+type TransportOptions struct {
+	// MaxIdleConnsPerHost is how many idle keep-alive connections to the
+	// API are kept for reuse. Set it to about your peak concurrency.
+	// Default: 2 (net/http's).
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost caps connections to the API, idle or not; callers
+	// beyond it wait for a free one. Default: unlimited.
+	MaxConnsPerHost int
+	// IdleConnTimeout closes keep-alive connections idle this long.
+	// Default: 90s.
+	IdleConnTimeout time.Duration
+	// TLSSessionCacheSize keeps this many TLS sessions so new connections
+	// resume instead of doing a full handshake. Default: 64.
+	TLSSessionCacheSize int
+	// WarmUpConns opens this many connections when the crawler is created,
+	// in the background, so the first calls don't pay for the dial and
+	// handshake. See also AsyncWebCrawler.WarmUp. Default: none.
+	WarmUpConns int
+}
+
+// newTransport builds an *http.Transport from net/http's default with o
+// applied.
+func (o *TransportOptions) newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+		t.MaxIdleConns = max(t.MaxIdleConns, o.MaxIdleConnsPerHost)
+	}
+	if o.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	if o.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = o.IdleConnTimeout
+	}
+	size := o.TLSSessionCacheSize
+	if size <= 0 {
+		size = 64
+	}
+	// Setting TLSClientConfig would turn HTTP/2 off if the clone didn't
+	// force it; DefaultTransport's does.
+	t.TLSClientConfig = &tls.Config{ClientSessionCache: tls.NewLRUClientSessionCache(size)}
+	return t
+}
+
+// WarmUp opens up to n connections to the API now, by sending n health
+// checks at once, so later calls find them idle in the pool. Returns the
+// first error; connections that did open stay pooled, up to
+// TransportOptions.MaxIdleConnsPerHost. Over HTTP/2 every call shares one
+// connection, so n > 1 only matters for HTTP/1.1.
+func (c *AsyncWebCrawler) WarmUp(ctx context.Context, n int) error {
+	if n <= 0 {
+		n = 1
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.http.Request(RequestOptions{Method: "GET", Path: "/health", Context: ctx})
+			mu.Lock()
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
@@ -0,0 +1,84 @@
+package crawl4ai
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestTransportOptions_NewTransport(t *testing.T) {
+	tr := (&TransportOptions{MaxIdleConnsPerHost: 256, MaxConnsPerHost: 512, IdleConnTimeout: time.Minute}).newTransport()
+	if tr.MaxIdleConnsPerHost != 256 || tr.MaxIdleConns < 256 || tr.MaxConnsPerHost != 512 || tr.IdleConnTimeout != time.Minute {
+		t.Errorf("pool settings not applied: %+v", tr)
+	}
+	if tr.TLSClientConfig == nil || tr.TLSClientConfig.ClientSessionCache == nil {
+		t.Error("no TLS session cache")
+	}
+	if !tr.ForceAttemptHTTP2 {
+		t.Error("HTTP/2 disabled")
+	}
+
+	def := (&TransportOptions{}).newTransport()
+	std := http.DefaultTransport.(*http.Transport)
+	if def.MaxIdleConnsPerHost != std.MaxIdleConnsPerHost || def.IdleConnTimeout != std.IdleConnTimeout {
+		t.Error("zero options changed the defaults")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestWarmUp_ConnectionsAreReused(t *testing.T) {
+	var dials int32
+	release := make(chan struct{})
+	var once sync.Once
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			// Hold the warm-up calls until all are in flight, so each
+			// gets its own connection.
+			select {
+			case <-release:
+			case <-time.After(2 * time.Second):
+			}
+		}
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, s http.ConnState) {
+		if s == http.StateNew && atomic.AddInt32(&dials, 1) == 4 {
+			once.Do(func() { close(release) })
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1,
+		Transport: &TransportOptions{MaxIdleConnsPerHost: 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.WarmUp(context.Background(), 4); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&dials); n != 4 {
+		t.Fatalf("warm-up opened %d connections, want 4", n)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.http.Get("/v1/crawl/jobs", nil)
+		}()
+	}
+	wg.Wait()
+	if n := atomic.LoadInt32(&dials); n != 4 {
+		t.Errorf("%d connections after warm-up, want the 4 pooled ones reused", n)
+	}
+}
@@ -0,0 +1,124 @@
+package crawl4ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// URLSnapshot is one stored crawl of a URL.
+type URLSnapshot struct {
+	JobID string
+	// At is when the job finished.
+	At     time.Time
+	Result *CrawlResult
+	// ContentHash fingerprints the page's markdown (its HTML when there is
+	// no markdown).
+	ContentHash string
+	// Changed reports whether the content differs from the previous
+	// snapshot; false for the first.
+	Changed bool
+}
+
+// URLHistoryOptions are options for URLHistory.
+type URLHistoryOptions struct {
+	// Since / Until bound the jobs' creation time. Zero = unbounded.
+	Since time.Time
+	Until time.Time
+	// MaxJobs caps how many jobs are searched, newest first. Default 50.
+	MaxJobs int
+}
+
+// URLHistory lists the stored results for url across past jobs, oldest
+// first, for content history views without an external database. Only
+// jobs whose results are still retained can contribute; results that have
+// expired are skipped.
+//
+//	history, err := crawler.URLHistory("https://example.com/pricing", nil)
+//	for _, s := range history {
+//	    if s.Changed {
+//	        fmt.Println("changed on", s.At.Format(time.DateOnly), "in job", s.JobID)
+//	    }
+//	}
+//
+// Jobs are found with ListJobs' URL filter; URLs are compared ignoring
+// host case, fragment and a trailing slash.
+func (c *AsyncWebCrawler) URLHistory(url string, opts *URLHistoryOptions) ([]URLSnapshot, error) {
+	if opts == nil {
+		opts = &URLHistoryOptions{}
+	}
+	maxJobs := opts.MaxJobs
+	if maxJobs <= 0 {
+		maxJobs = 50
+	}
+	jobs, err := c.ListJobs(&ListJobsOptions{
+		Limit:         maxJobs,
+		URLContains:   url,
+		CreatedAfter:  opts.Since,
+		CreatedBefore: opts.Until,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("url history: %w", err)
+	}
+
+	var history []URLSnapshot
+	for _, job := range jobs {
+		if job.Status != JobStatusCompleted && job.Status != JobStatusPartial {
+			continue
+		}
+		result, err := c.jobResultFor(job, url)
+		var notFound *NotFoundError
+		if errors.As(err, &notFound) || errors.Is(err, ErrSkipResult) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("url history: job %s: %w", job.JobID, err)
+		}
+		if result != nil {
+			history = append(history, URLSnapshot{JobID: job.JobID, At: finishedAt(job), Result: result, ContentHash: contentHash(result)})
+		}
+	}
+	sort.SliceStable(history, func(i, k int) bool { return history[i].At.Before(history[k].At) })
+	for i := 1; i < len(history); i++ {
+		history[i].Changed = history[i].ContentHash != history[i-1].ContentHash
+	}
+	return history, nil
+}
+
+// jobResultFor returns job's result for url: from its inlined results, or
+// fetched by the URL's index. nil when the job didn't crawl url.
+func (c *AsyncWebCrawler) jobResultFor(job *CrawlJob, url string) (*CrawlResult, error) {
+	if len(job.URLs) == 0 && len(job.Results) == 0 {
+		full, err := c.GetJob(job.JobID)
+		if err != nil {
+			return nil, err
+		}
+		job = full
+	}
+	if len(job.Results) > 0 {
+		if i := matchResult(url, job.Results, nil); i >= 0 {
+			return job.Results[i], nil
+		}
+		return nil, nil
+	}
+	key := linkKey(url)
+	for i, u := range job.URLs {
+		if linkKey(u) == key {
+			return c.GetPerUrlResult(job.JobID, i)
+		}
+	}
+	return nil, nil
+}
+
+// contentHash fingerprints r's markdown, or its HTML without markdown.
+func contentHash(r *CrawlResult) string {
+	content := r.HTML
+	if r.Markdown != nil && r.Markdown.RawMarkdown != "" {
+		content = r.Markdown.RawMarkdown
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,43 @@
+package crawl4ai
+
+import "testing"
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestURLHistory(t *testing.T) {
+	page := func(md string) map[string]interface{} {
+		return map[string]interface{}{"url": "https://a.com/pricing", "success": true, "markdown": md}
+	}
+	c := newMockCrawler(t, map[string]interface{}{
+		"GET /v1/crawl/jobs": map[string]interface{}{"jobs": []interface{}{
+			map[string]interface{}{"job_id": "job_new", "status": "completed", "completed_at": "2024-03-01T00:00:00Z",
+				"urls": []interface{}{"https://a.com/", "https://A.com/pricing/"}},
+			map[string]interface{}{"job_id": "job_failed", "status": "failed", "completed_at": "2024-02-15T00:00:00Z",
+				"urls": []interface{}{"https://a.com/pricing"}},
+			map[string]interface{}{"job_id": "job_expired", "status": "completed", "completed_at": "2024-02-10T00:00:00Z",
+				"urls": []interface{}{"https://a.com/pricing"}},
+			map[string]interface{}{"job_id": "job_mid", "status": "partial", "completed_at": "2024-02-01T00:00:00Z",
+				"results": []interface{}{page("$10")}},
+			map[string]interface{}{"job_id": "job_old", "status": "completed", "completed_at": "2024-01-01T00:00:00Z",
+				"results": []interface{}{page("$10")}},
+			map[string]interface{}{"job_id": "job_other", "status": "completed", "completed_at": "2024-01-05T00:00:00Z",
+				"urls": []interface{}{"https://a.com/pricing-old"}},
+		}},
+		"GET /v1/crawl/jobs/job_new/result/1": page("$12"),
+	})
+
+	history, err := c.URLHistory("https://a.com/pricing", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, s := range history {
+		ids = append(ids, s.JobID)
+	}
+	if len(history) != 3 || ids[0] != "job_old" || ids[1] != "job_mid" || ids[2] != "job_new" {
+		t.Fatalf("history = %v", ids)
+	}
+	if history[1].Changed || !history[2].Changed || history[2].Result.Markdown.RawMarkdown != "$12" {
+		t.Errorf("changes = %v %v", history[1].Changed, history[2].Changed)
+	}
+}
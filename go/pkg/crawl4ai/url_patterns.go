@@ -0,0 +1,163 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// MatchPattern reports whether rawURL matches a deep-crawl URL pattern the
+// way the server's filters apply it:
+//
+//   - a pattern with no wildcard ("docs") matches anywhere in the URL;
+//   - a pattern starting with "/" ("/docs/*") is matched against the path;
+//   - anything else ("*/example*", "docs.example.com/*",
+//     "https://example.com/*") is matched against the whole URL, with or
+//     without its scheme.
+//
+// "*" matches any run of characters including "/", "?" one character, and
+// "[...]" a character class. Use it with FilterPreview to check filters
+// against a scan's discovered URLs before paying for the crawl phase.
+func MatchPattern(pattern, rawURL string) bool {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return strings.Contains(rawURL, pattern)
+	}
+	re, err := globRegexp(pattern)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return re.MatchString(rawURL)
+	}
+	if strings.HasPrefix(pattern, "/") {
+		path := u.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		return re.MatchString(path)
+	}
+	if re.MatchString(rawURL) {
+		return true
+	}
+	schemeless := strings.TrimPrefix(rawURL, u.Scheme+"://")
+	return re.MatchString(schemeless)
+}
+
+// globRegexp compiles a glob into an anchored regexp.
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; ch {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("pattern %q: unterminated [", pattern)
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// FilterDecision explains why FilterPreview dropped a URL.
+type FilterDecision struct {
+	URL    string
+	Reason string
+}
+
+// FilterPreviewResult is the outcome of applying filters to a URL list.
+type FilterPreviewResult struct {
+	// Included are the URLs the filters keep, in input order.
+	Included []string
+	// Excluded are the dropped URLs with the first rule that dropped each.
+	Excluded []FilterDecision
+	// Ignored lists filter keys the preview doesn't evaluate locally; the
+	// server may still drop URLs because of them.
+	Ignored []string
+}
+
+// FilterPreview applies deep-crawl filters (the DeepCrawlOptions.Filters or
+// SiteOptions.Filters map) to urls locally. Understood keys are patterns /
+// include_patterns (keep URLs matching any), exclude_patterns (drop URLs
+// matching any), allowed_domains / blocked_domains and their
+// domains.allowed / domains.blocked form.
+//
+//	scan, _ := crawler.DeepCrawl(url, &crawl4ai.DeepCrawlOptions{ScanOnly: true, Wait: true})
+//	preview := crawl4ai.FilterPreview(filters, discoveredURLs)
+//	for _, d := range preview.Excluded {
+//	    fmt.Println(d.URL, "—", d.Reason)
+//	}
+func FilterPreview(filters map[string]interface{}, urls []string) *FilterPreviewResult {
+	include := append(toStringSlice(filters["patterns"]), toStringSlice(filters["include_patterns"])...)
+	exclude := toStringSlice(filters["exclude_patterns"])
+	allowed := toStringSlice(filters["allowed_domains"])
+	blocked := toStringSlice(filters["blocked_domains"])
+	if domains, ok := filters["domains"].(map[string]interface{}); ok {
+		allowed = append(allowed, toStringSlice(domains["allowed"])...)
+		blocked = append(blocked, toStringSlice(domains["blocked"])...)
+	}
+
+	out := &FilterPreviewResult{}
+	for k := range filters {
+		switch k {
+		case "patterns", "include_patterns", "exclude_patterns", "allowed_domains", "blocked_domains", "domains":
+		default:
+			out.Ignored = append(out.Ignored, k)
+		}
+	}
+	sort.Strings(out.Ignored)
+
+	for _, raw := range urls {
+		if reason := filterReason(raw, include, exclude, allowed, blocked); reason != "" {
+			out.Excluded = append(out.Excluded, FilterDecision{URL: raw, Reason: reason})
+		} else {
+			out.Included = append(out.Included, raw)
+		}
+	}
+	return out
+}
+
+func filterReason(raw string, include, exclude, allowed, blocked []string) string {
+	host := ""
+	if u, err := url.Parse(raw); err == nil {
+		host = u.Hostname()
+	}
+	for _, d := range blocked {
+		if domainAllowed(host, []string{d}) {
+			return fmt.Sprintf("blocked domain %q", d)
+		}
+	}
+	if len(allowed) > 0 && !domainAllowed(host, allowed) {
+		return fmt.Sprintf("domain %q not in allowed domains", host)
+	}
+	for _, p := range exclude {
+		if MatchPattern(p, raw) {
+			return fmt.Sprintf("matches exclude pattern %q", p)
+		}
+	}
+	if len(include) == 0 {
+		return ""
+	}
+	for _, p := range include {
+		if MatchPattern(p, raw) {
+			return ""
+		}
+	}
+	return "matches no include pattern"
+}
@@ -0,0 +1,63 @@
+package crawl4ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		pattern, url string
+		want         bool
+	}{
+		{"docs", "https://example.com/docs/intro", true},
+		{"/docs/*", "https://example.com/docs/intro", true},
+		{"/docs/*", "https://example.com/blog/docs/intro", false},
+		{"*/example*", "https://example.com/guide/examples", true},
+		{"*changelog*", "https://example.com/CHANGELOG", false},
+		{"docs.example.com/*", "https://docs.example.com/a", true},
+		{"https://example.com/*.pdf", "https://example.com/files/a.pdf", true},
+		{"/v[12]/*", "https://api.com/v2/users", true},
+		{"/v[!12]/*", "https://api.com/v2/users", false},
+		{"/page?", "https://x.com/page1", true},
+		{"/broken[", "https://x.com/broken[", false},
+	}
+	for _, tc := range cases {
+		if got := MatchPattern(tc.pattern, tc.url); got != tc.want {
+			t.Errorf("MatchPattern(%q, %q) = %v, want %v", tc.pattern, tc.url, got, tc.want)
+		}
+	}
+}
+
+func TestFilterPreview(t *testing.T) {
+	filters := map[string]interface{}{
+		"patterns":         []interface{}{"/docs/*", "/api/*"},
+		"exclude_patterns": []string{"*changelog*"},
+		"domains":          map[string]interface{}{"blocked": []string{"github.com"}},
+		"content_types":    []string{"text/html"},
+	}
+	urls := []string{
+		"https://example.com/docs/a",
+		"https://example.com/docs/changelog",
+		"https://example.com/blog/b",
+		"https://github.com/docs/x",
+		"https://example.com/api/v1",
+	}
+	got := FilterPreview(filters, urls)
+	if !reflect.DeepEqual(got.Included, []string{"https://example.com/docs/a", "https://example.com/api/v1"}) {
+		t.Fatalf("unexpected included: %v", got.Included)
+	}
+	want := []FilterDecision{
+		{URL: "https://example.com/docs/changelog", Reason: `matches exclude pattern "*changelog*"`},
+		{URL: "https://example.com/blog/b", Reason: "matches no include pattern"},
+		{URL: "https://github.com/docs/x", Reason: `blocked domain "github.com"`},
+	}
+	if !reflect.DeepEqual(got.Excluded, want) {
+		t.Fatalf("unexpected excluded:\n%v\nwant:\n%v", got.Excluded, want)
+	}
+	if !reflect.DeepEqual(got.Ignored, []string{"content_types"}) {
+		t.Fatalf("expected content_types reported as ignored, got %v", got.Ignored)
+	}
+}
@@ -0,0 +1,272 @@
+package crawl4ai
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// Devices for UserAgentOptions.Device.
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+)
+
+// Rotation policies for NewUserAgentRotator.
+const (
+	// RotatePerRequest draws a new fingerprint for every crawl. RunMany
+	// jobs use UserAgentMode "random" so the cloud rotates per URL.
+	RotatePerRequest = "per_request"
+	// RotatePerDomain keeps one fingerprint per host, so a site sees one
+	// consistent visitor across requests.
+	RotatePerDomain = "per_domain"
+	// RotateFixed draws one fingerprint and keeps it for the rotator's
+	// lifetime.
+	RotateFixed = "fixed"
+)
+
+// UserAgentOptions narrows the agents RandomFingerprint draws from. The
+// zero value draws any desktop or mobile browser with an en-US locale.
+type UserAgentOptions struct {
+	// Device is DeviceDesktop, DeviceMobile, or "" for either.
+	Device string
+	// Browser is "chrome", "edge", "firefox", "safari", or "" for any.
+	Browser string
+	// Locale sets Accept-Language, e.g. "de-DE". Default "en-US".
+	Locale string
+}
+
+// Fingerprint is a client profile whose parts agree with each other: a
+// user agent, a viewport sized for its device, and the headers that
+// browser sends (client hints for Chromium browsers, Accept-Language).
+// Mismatched parts, say a mobile agent on a 1920px viewport, are a common
+// reason sites block crawlers.
+type Fingerprint struct {
+	UserAgent string
+	Device    string
+	Browser   string
+	// Platform is the OS as client hints report it, e.g. "Windows".
+	Platform       string
+	ViewportWidth  int
+	ViewportHeight int
+	Headers        map[string]string
+}
+
+// Apply returns a copy of bc carrying the fingerprint. Viewport and
+// headers bc already sets are kept; its user agent is replaced and
+// UserAgentMode cleared, since the agent is now fixed. bc may be nil.
+func (f Fingerprint) Apply(bc *BrowserConfig) *BrowserConfig {
+	out := &BrowserConfig{}
+	if bc != nil {
+		*out = *bc
+	}
+	out.UserAgent, out.UserAgentMode = f.UserAgent, ""
+	if out.ViewportWidth == 0 && out.ViewportHeight == 0 {
+		out.ViewportWidth, out.ViewportHeight = f.ViewportWidth, f.ViewportHeight
+	}
+	headers := make(map[string]string, len(f.Headers)+len(out.Headers))
+	for k, v := range f.Headers {
+		headers[k] = v
+	}
+	for k, v := range out.Headers {
+		headers[k] = v
+	}
+	out.Headers = headers
+	return out
+}
+
+// requestHeaders is the fingerprint as plain request headers, for the
+// http strategy, which has no browser to configure.
+func (f Fingerprint) requestHeaders() map[string]string {
+	out := make(map[string]string, len(f.Headers)+1)
+	for k, v := range f.Headers {
+		out[k] = v
+	}
+	out["User-Agent"] = f.UserAgent
+	return out
+}
+
+type agentProfile struct {
+	device, browser, platform string
+	// format takes the major version, as many times as it has verbs.
+	format   string
+	min, max int
+}
+
+// agentProfiles span current stable releases; versions are drawn from
+// [min, max].
+var agentProfiles = []agentProfile{
+	{DeviceDesktop, "chrome", "Windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 Safari/537.36", 124, 131},
+	{DeviceDesktop, "chrome", "macOS", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 Safari/537.36", 124, 131},
+	{DeviceDesktop, "chrome", "Linux", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 Safari/537.36", 124, 131},
+	{DeviceDesktop, "edge", "Windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%[1]d.0.0.0 Safari/537.36 Edg/%[1]d.0.0.0", 124, 131},
+	{DeviceDesktop, "firefox", "Windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:%[1]d.0) Gecko/20100101 Firefox/%[1]d.0", 125, 132},
+	{DeviceDesktop, "firefox", "macOS", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:%[1]d.0) Gecko/20100101 Firefox/%[1]d.0", 125, 132},
+	{DeviceDesktop, "safari", "macOS", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.%d Safari/605.1.15", 0, 6},
+	{DeviceMobile, "chrome", "Android", "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 Mobile Safari/537.36", 124, 131},
+	{DeviceMobile, "safari", "iOS", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_%[1]d like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.%[1]d Mobile/15E148 Safari/604.1", 0, 6},
+}
+
+var (
+	desktopViewports = [][2]int{{1920, 1080}, {1536, 864}, {1440, 900}, {1366, 768}, {2560, 1440}}
+	mobileViewports  = [][2]int{{390, 844}, {393, 873}, {412, 915}, {375, 812}}
+)
+
+// RandomFingerprint draws a realistic fingerprint matching opts, which may
+// be nil.
+func RandomFingerprint(opts *UserAgentOptions) Fingerprint {
+	return randomFingerprint(opts, rand.Intn)
+}
+
+// RandomUserAgent draws a realistic user agent matching opts, which may be
+// nil. Prefer RandomFingerprint, whose viewport and headers match it.
+func RandomUserAgent(opts *UserAgentOptions) string {
+	return RandomFingerprint(opts).UserAgent
+}
+
+func randomFingerprint(opts *UserAgentOptions, intn func(int) int) Fingerprint {
+	var o UserAgentOptions
+	if opts != nil {
+		o = *opts
+	}
+	var candidates []agentProfile
+	for _, p := range agentProfiles {
+		if (o.Device == "" || o.Device == p.device) && (o.Browser == "" || strings.EqualFold(o.Browser, p.browser)) {
+			candidates = append(candidates, p)
+		}
+	}
+	if len(candidates) == 0 {
+		// No such combination (e.g. mobile Firefox): relax the browser.
+		return randomFingerprint(&UserAgentOptions{Device: o.Device, Locale: o.Locale}, intn)
+	}
+	p := candidates[intn(len(candidates))]
+	version := p.min + intn(p.max-p.min+1)
+
+	viewports := desktopViewports
+	if p.device == DeviceMobile {
+		viewports = mobileViewports
+	}
+	vp := viewports[intn(len(viewports))]
+
+	locale := o.Locale
+	if locale == "" {
+		locale = "en-US"
+	}
+	headers := map[string]string{"Accept-Language": acceptLanguage(locale)}
+	if brand := chromiumBrand(p.browser); brand != "" {
+		for k, v := range clientHints(brand, version, p.device == DeviceMobile, p.platform) {
+			headers[k] = v
+		}
+	}
+	return Fingerprint{
+		UserAgent:      fmt.Sprintf(p.format, version),
+		Device:         p.device,
+		Browser:        p.browser,
+		Platform:       p.platform,
+		ViewportWidth:  vp[0],
+		ViewportHeight: vp[1],
+		Headers:        headers,
+	}
+}
+
+// chromiumBrand is the brand a Chromium browser reports in sec-ch-ua, or
+// "" for browsers that send no client hints.
+func chromiumBrand(browser string) string {
+	switch browser {
+	case "chrome":
+		return "Google Chrome"
+	case "edge":
+		return "Microsoft Edge"
+	}
+	return ""
+}
+
+// clientHints are the sec-ch-ua headers a Chromium browser sends.
+func clientHints(brand string, version int, mobile bool, platform string) map[string]string {
+	m := "?0"
+	if mobile {
+		m = "?1"
+	}
+	return map[string]string{
+		"sec-ch-ua":          fmt.Sprintf(`"Chromium";v="%[1]d", "%[2]s";v="%[1]d", "Not?A_Brand";v="99"`, version, brand),
+		"sec-ch-ua-mobile":   m,
+		"sec-ch-ua-platform": `"` + platform + `"`,
+	}
+}
+
+// acceptLanguage expands a locale the way browsers do: "de-DE" becomes
+// "de-DE,de;q=0.9".
+func acceptLanguage(locale string) string {
+	lang, _, found := strings.Cut(locale, "-")
+	if !found {
+		return locale
+	}
+	return locale + "," + lang + ";q=0.9"
+}
+
+// UserAgentRotator hands out fingerprints under a rotation policy. Set it
+// as CrawlerOptions.UserAgents to apply one to every crawl whose
+// BrowserConfig doesn't pick its own user agent. Safe for concurrent use.
+type UserAgentRotator struct {
+	policy string
+	opts   UserAgentOptions
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	fixed  *Fingerprint
+	byHost map[string]Fingerprint
+}
+
+// NewUserAgentRotator creates a rotator. policy is RotatePerRequest,
+// RotatePerDomain or RotateFixed; opts may be nil.
+func NewUserAgentRotator(policy string, opts *UserAgentOptions) (*UserAgentRotator, error) {
+	switch policy {
+	case RotatePerRequest, RotatePerDomain, RotateFixed:
+	default:
+		return nil, fmt.Errorf("user agent rotator: unknown policy %q", policy)
+	}
+	r := &UserAgentRotator{policy: policy, rng: rand.New(rand.NewSource(rand.Int63())), byHost: map[string]Fingerprint{}}
+	if opts != nil {
+		r.opts = *opts
+	}
+	return r, nil
+}
+
+// Policy returns the rotator's rotation policy.
+func (r *UserAgentRotator) Policy() string {
+	return r.policy
+}
+
+// Next returns the fingerprint to crawl rawURL with.
+func (r *UserAgentRotator) Next(rawURL string) Fingerprint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch r.policy {
+	case RotateFixed:
+		if r.fixed == nil {
+			fp := randomFingerprint(&r.opts, r.rng.Intn)
+			r.fixed = &fp
+		}
+		return *r.fixed
+	case RotatePerDomain:
+		host := rawURL
+		if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+			host = strings.ToLower(u.Hostname())
+		}
+		fp, ok := r.byHost[host]
+		if !ok {
+			fp = randomFingerprint(&r.opts, r.rng.Intn)
+			r.byHost[host] = fp
+		}
+		return fp
+	}
+	return randomFingerprint(&r.opts, r.rng.Intn)
+}
+
+// picksUserAgent reports whether bc already chooses the user agent, in
+// which case the rotator leaves it alone.
+func picksUserAgent(bc *BrowserConfig) bool {
+	return bc != nil && (bc.UserAgent != "" || bc.UserAgentMode != "")
+}
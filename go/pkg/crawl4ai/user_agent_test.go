@@ -0,0 +1,141 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// ─── Pure unit tests (no network) ───────────────────────────────────────────
+
+func TestRandomFingerprint_Consistent(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		fp := RandomFingerprint(nil)
+		mobile := strings.Contains(fp.UserAgent, "Mobile")
+		if mobile != (fp.Device == DeviceMobile) {
+			t.Fatalf("device %s for %s", fp.Device, fp.UserAgent)
+		}
+		if mobile != (fp.ViewportWidth < 500) {
+			t.Fatalf("viewport %dx%d for %s", fp.ViewportWidth, fp.ViewportHeight, fp.UserAgent)
+		}
+		hints := fp.Headers["sec-ch-ua"]
+		if (hints != "") != (fp.Browser == "chrome" || fp.Browser == "edge") {
+			t.Fatalf("sec-ch-ua %q for %s", hints, fp.UserAgent)
+		}
+		if hints != "" && !strings.Contains(fp.UserAgent, "Chrome/"+versionFromHints(hints)+".") {
+			t.Fatalf("sec-ch-ua %q does not match %s", hints, fp.UserAgent)
+		}
+		if fp.Headers["Accept-Language"] != "en-US,en;q=0.9" {
+			t.Fatalf("Accept-Language = %q", fp.Headers["Accept-Language"])
+		}
+	}
+}
+
+func versionFromHints(hints string) string {
+	_, rest, _ := strings.Cut(hints, `"Chromium";v="`)
+	v, _, _ := strings.Cut(rest, `"`)
+	return v
+}
+
+func TestRandomFingerprint_Options(t *testing.T) {
+	fp := RandomFingerprint(&UserAgentOptions{Device: DeviceMobile, Browser: "safari", Locale: "de-DE"})
+	if !strings.Contains(fp.UserAgent, "iPhone") || fp.Headers["Accept-Language"] != "de-DE,de;q=0.9" {
+		t.Errorf("fp = %+v", fp)
+	}
+	// There is no mobile Firefox profile: the browser is relaxed, not the
+	// device.
+	if fp := RandomFingerprint(&UserAgentOptions{Device: DeviceMobile, Browser: "firefox"}); fp.Device != DeviceMobile {
+		t.Errorf("fp = %+v", fp)
+	}
+	if ua := RandomUserAgent(&UserAgentOptions{Browser: "Edge"}); !strings.Contains(ua, "Edg/") {
+		t.Errorf("ua = %s", ua)
+	}
+}
+
+func TestFingerprint_ApplyKeepsCallerSettings(t *testing.T) {
+	fp := RandomFingerprint(&UserAgentOptions{Device: DeviceDesktop})
+	bc := &BrowserConfig{ViewportWidth: 800, ViewportHeight: 600, Headers: map[string]string{"Accept-Language": "fr"}}
+	out := fp.Apply(bc)
+	if out.UserAgent != fp.UserAgent || out.ViewportWidth != 800 || out.Headers["Accept-Language"] != "fr" {
+		t.Errorf("out = %+v", out)
+	}
+	if bc.UserAgent != "" || len(bc.Headers) != 1 {
+		t.Errorf("input modified: %+v", bc)
+	}
+}
+
+func TestUserAgentRotator_Policies(t *testing.T) {
+	if _, err := NewUserAgentRotator("sometimes", nil); err == nil {
+		t.Error("unknown policy: want error")
+	}
+	perDomain, _ := NewUserAgentRotator(RotatePerDomain, nil)
+	a := perDomain.Next("https://a.example.com/1")
+	if perDomain.Next("https://A.example.com/2").UserAgent != a.UserAgent {
+		t.Error("per_domain: same host got a different agent")
+	}
+	fixed, _ := NewUserAgentRotator(RotateFixed, nil)
+	if fixed.Next("https://a.example").UserAgent != fixed.Next("https://b.example").UserAgent {
+		t.Error("fixed: agent changed")
+	}
+	perRequest, _ := NewUserAgentRotator(RotatePerRequest, nil)
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		seen[perRequest.Next("https://a.example").UserAgent] = true
+	}
+	if len(seen) < 2 {
+		t.Error("per_request: agent never rotated")
+	}
+}
+
+// ─── Unit tests (in-process mock server) ─────────────────────────────────
+
+func TestUserAgents_AppliedToRequests(t *testing.T) {
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"url": "https://example.com", "success": true, "job_id": "job_1"})
+	}))
+	defer srv.Close()
+	rotator, _ := NewUserAgentRotator(RotatePerRequest, &UserAgentOptions{Browser: "chrome", Device: DeviceDesktop})
+	c, err := NewAsyncWebCrawler(CrawlerOptions{APIKey: "sk_test_mock", BaseURL: srv.URL, MaxRetries: 1, UserAgents: rotator})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Run("https://example.com", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Run("https://example.com", &RunOptions{Strategy: "http", Headers: map[string]string{"Accept-Language": "nl"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Run("https://example.com", &RunOptions{BrowserConfig: &BrowserConfig{UserAgent: "mine/1.0"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.RunMany([]string{"https://example.com"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	bc, _ := bodies[0]["browser_config"].(map[string]interface{})
+	if ua, _ := bc["user_agent"].(string); !strings.Contains(ua, "Chrome/") || bc["viewport_width"] == nil {
+		t.Errorf("browser run: browser_config = %v", bc)
+	}
+	hc, _ := bodies[1]["http_config"].(map[string]interface{})
+	headers, _ := hc["headers"].(map[string]interface{})
+	if ua, _ := headers["User-Agent"].(string); !strings.Contains(ua, "Chrome/") || headers["Accept-Language"] != "nl" {
+		t.Errorf("http run: headers = %v", headers)
+	}
+	if bc, _ := bodies[2]["browser_config"].(map[string]interface{}); bc["user_agent"] != "mine/1.0" {
+		t.Errorf("caller agent replaced: %v", bc)
+	}
+	if bc, _ := bodies[3]["browser_config"].(map[string]interface{}); bc["user_agent_mode"] != "random" || bc["user_agent"] != nil {
+		t.Errorf("run many: browser_config = %v", bc)
+	}
+}
@@ -0,0 +1,105 @@
+package crawl4ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WaitCondition is a typed page-ready condition for
+// CrawlerRunConfig.WaitFor. Build one with WaitForSelector, WaitForJS,
+// WaitForNetworkIdle or WaitForElementCount and apply it with SetWaitFor,
+// which validates it and serializes it to the API's "css:" / "js:" form.
+//
+//	cfg := &crawl4ai.CrawlerRunConfig{}
+//	if err := cfg.SetWaitFor(crawl4ai.WaitForElementCount(".product-card", 20)); err != nil {
+//	    return err
+//	}
+type WaitCondition struct {
+	kind     string
+	selector string
+	js       string
+	idle     time.Duration
+	count    int
+}
+
+// WaitForSelector waits until an element matches the CSS selector.
+func WaitForSelector(selector string) WaitCondition {
+	return WaitCondition{kind: "selector", selector: selector}
+}
+
+// WaitForJS waits until a JavaScript predicate returns true, e.g.
+// "() => window.dataLoaded".
+func WaitForJS(predicate string) WaitCondition {
+	return WaitCondition{kind: "js", js: predicate}
+}
+
+// WaitForNetworkIdle waits until no resource has finished loading for the
+// given quiet period.
+func WaitForNetworkIdle(quiet time.Duration) WaitCondition {
+	return WaitCondition{kind: "network_idle", idle: quiet}
+}
+
+// WaitForElementCount waits until at least n elements match the CSS
+// selector — useful for lists that render in batches.
+func WaitForElementCount(selector string, n int) WaitCondition {
+	return WaitCondition{kind: "count", selector: selector, count: n}
+}
+
+// Validate reports whether the condition can be serialized.
+func (w WaitCondition) Validate() error {
+	switch w.kind {
+	case "selector", "count":
+		if strings.TrimSpace(w.selector) == "" {
+			return fmt.Errorf("wait condition: selector is required")
+		}
+		if w.kind == "count" && w.count < 1 {
+			return fmt.Errorf("wait condition: element count must be at least 1, got %d", w.count)
+		}
+	case "js":
+		js := strings.TrimSpace(w.js)
+		if js == "" {
+			return fmt.Errorf("wait condition: JS predicate is required")
+		}
+		if !strings.Contains(js, "=>") && !strings.HasPrefix(js, "function") && !strings.HasPrefix(js, "async") {
+			return fmt.Errorf("wait condition: JS predicate must be a function, e.g. \"() => window.ready\"")
+		}
+	case "network_idle":
+		if w.idle < time.Millisecond {
+			return fmt.Errorf("wait condition: network idle period must be at least 1ms, got %s", w.idle)
+		}
+	default:
+		return fmt.Errorf("wait condition: empty condition; use a WaitFor* constructor")
+	}
+	return nil
+}
+
+// String returns the condition in the API's wait_for format.
+func (w WaitCondition) String() string {
+	switch w.kind {
+	case "selector":
+		return "css:" + w.selector
+	case "js":
+		return "js:" + strings.TrimSpace(w.js)
+	case "network_idle":
+		return fmt.Sprintf(`js:() => {
+  const done = performance.getEntriesByType("resource").map(e => e.responseEnd);
+  const last = done.length ? Math.max(...done) : 0;
+  return performance.now() - last >= %d;
+}`, w.idle.Milliseconds())
+	case "count":
+		sel, _ := json.Marshal(w.selector)
+		return fmt.Sprintf("js:() => document.querySelectorAll(%s).length >= %d", sel, w.count)
+	}
+	return ""
+}
+
+// SetWaitFor validates cond and stores it in WaitFor.
+func (c *CrawlerRunConfig) SetWaitFor(cond WaitCondition) error {
+	if err := cond.Validate(); err != nil {
+		return err
+	}
+	c.WaitFor = cond.String()
+	return nil
+}
@@ -0,0 +1,55 @@
+package crawl4ai
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ────────────────────────────────────────
+
+func TestWaitConditions(t *testing.T) {
+	cases := []struct {
+		cond WaitCondition
+		want string
+	}{
+		{WaitForSelector("#app .loaded"), "css:#app .loaded"},
+		{WaitForJS(" () => window.dataLoaded "), "js:() => window.dataLoaded"},
+		{WaitForElementCount(`a[href="x"]`, 5), `js:() => document.querySelectorAll("a[href=\"x\"]").length >= 5`},
+	}
+	for _, tc := range cases {
+		cfg := &CrawlerRunConfig{}
+		if err := cfg.SetWaitFor(tc.cond); err != nil {
+			t.Fatalf("SetWaitFor(%v): %v", tc.cond, err)
+		}
+		if cfg.WaitFor != tc.want {
+			t.Errorf("got %q, want %q", cfg.WaitFor, tc.want)
+		}
+		if SanitizeCrawlerConfig(cfg)["wait_for"] != tc.want {
+			t.Errorf("wait_for not serialized for %q", tc.want)
+		}
+	}
+
+	idle := WaitForNetworkIdle(500 * time.Millisecond).String()
+	if !strings.HasPrefix(idle, "js:() =>") || !strings.Contains(idle, ">= 500;") {
+		t.Fatalf("unexpected network idle predicate: %s", idle)
+	}
+}
+
+func TestWaitConditions_Invalid(t *testing.T) {
+	for _, cond := range []WaitCondition{
+		{},
+		WaitForSelector(" "),
+		WaitForJS("window.ready"),
+		WaitForNetworkIdle(0),
+		WaitForElementCount(".item", 0),
+	} {
+		cfg := &CrawlerRunConfig{WaitFor: "css:.keep"}
+		if err := cfg.SetWaitFor(cond); err == nil {
+			t.Errorf("expected error for %+v", cond)
+		}
+		if cfg.WaitFor != "css:.keep" {
+			t.Errorf("invalid condition overwrote WaitFor: %q", cfg.WaitFor)
+		}
+	}
+}
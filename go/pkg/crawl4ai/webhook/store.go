@@ -0,0 +1,228 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists received events. Implementations must be safe for
+// concurrent use. MemoryStore and FileStore are provided; back it with a
+// database (Postgres, BoltDB, ...) by implementing these methods.
+type Store interface {
+	// Add saves ev unless an event with the same ID exists, and reports
+	// whether it was added. Redelivered webhooks are dropped this way.
+	Add(ctx context.Context, ev Event) (added bool, err error)
+	// Save replaces the stored event with ev's ID.
+	Save(ctx context.Context, ev Event) error
+	Get(ctx context.Context, id string) (Event, bool, error)
+	// List returns the events in state, or all events when state is "",
+	// oldest first.
+	List(ctx context.Context, state State) ([]Event, error)
+	// Prune deletes delivered events received before cutoff and reports
+	// how many it removed. Pending and dead events are never pruned.
+	Prune(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// MemoryStore keeps events in memory: for tests, and for consumers that
+// only need retries and fan-out, not durability across restarts.
+type MemoryStore struct {
+	mu     sync.Mutex
+	events map[string]Event
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{events: map[string]Event{}}
+}
+
+// Add implements Store.
+func (s *MemoryStore) Add(_ context.Context, ev Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.events[ev.ID]; ok {
+		return false, nil
+	}
+	s.events[ev.ID] = ev
+	return true, nil
+}
+
+// Save implements Store.
+func (s *MemoryStore) Save(_ context.Context, ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[ev.ID] = ev
+	return nil
+}
+
+// Get implements Store.
+func (s *MemoryStore) Get(_ context.Context, id string) (Event, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ev, ok := s.events[id]
+	return ev, ok, nil
+}
+
+// List implements Store.
+func (s *MemoryStore) List(_ context.Context, state State) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return listEvents(s.events, state), nil
+}
+
+// Prune implements Store.
+func (s *MemoryStore) Prune(_ context.Context, cutoff time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(pruneEvents(s.events, cutoff)), nil
+}
+
+// pruneEvents removes the delivered events received before cutoff from
+// events and returns them.
+func pruneEvents(events map[string]Event, cutoff time.Time) []Event {
+	var pruned []Event
+	for id, ev := range events {
+		if ev.State == StateDelivered && ev.ReceivedAt.Before(cutoff) {
+			pruned = append(pruned, ev)
+			delete(events, id)
+		}
+	}
+	return pruned
+}
+
+func listEvents(events map[string]Event, state State) []Event {
+	var out []Event
+	for _, ev := range events {
+		if state == "" || ev.State == state {
+			out = append(out, ev)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ReceivedAt.Equal(out[j].ReceivedAt) {
+			return out[i].ReceivedAt.Before(out[j].ReceivedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// FileStore is a MemoryStore persisted to a JSON file. Every change
+// rewrites the file atomically (temp file, fsync, rename) before it is
+// acknowledged, so an event a webhook call was answered 200 for survives a
+// crash. It suits a single consumer process with thousands of events, not
+// millions; a Receiver keeps it there by pruning delivered events (see
+// Options.DeliveredRetention).
+type FileStore struct {
+	mem  *MemoryStore
+	path string
+}
+
+// OpenFileStore loads the events stored at path, creating the file on the
+// first write if it does not exist.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{mem: NewMemoryStore(), path: path}
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	if err := json.Unmarshal(raw, &events); err != nil {
+		return nil, fmt.Errorf("webhook store %s: %w", path, err)
+	}
+	for _, ev := range events {
+		s.mem.events[ev.ID] = ev
+	}
+	return s, nil
+}
+
+// Add implements Store.
+func (s *FileStore) Add(ctx context.Context, ev Event) (bool, error) {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	if _, ok := s.mem.events[ev.ID]; ok {
+		return false, nil
+	}
+	s.mem.events[ev.ID] = ev
+	if err := s.flush(); err != nil {
+		delete(s.mem.events, ev.ID)
+		return false, err
+	}
+	return true, nil
+}
+
+// Save implements Store.
+func (s *FileStore) Save(ctx context.Context, ev Event) error {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	prev, had := s.mem.events[ev.ID]
+	s.mem.events[ev.ID] = ev
+	if err := s.flush(); err != nil {
+		if had {
+			s.mem.events[ev.ID] = prev
+		} else {
+			delete(s.mem.events, ev.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get implements Store.
+func (s *FileStore) Get(ctx context.Context, id string) (Event, bool, error) {
+	return s.mem.Get(ctx, id)
+}
+
+// List implements Store.
+func (s *FileStore) List(ctx context.Context, state State) ([]Event, error) {
+	return s.mem.List(ctx, state)
+}
+
+// Prune implements Store.
+func (s *FileStore) Prune(ctx context.Context, cutoff time.Time) (int, error) {
+	s.mem.mu.Lock()
+	defer s.mem.mu.Unlock()
+	pruned := pruneEvents(s.mem.events, cutoff)
+	if len(pruned) == 0 {
+		return 0, nil
+	}
+	if err := s.flush(); err != nil {
+		for _, ev := range pruned {
+			s.mem.events[ev.ID] = ev
+		}
+		return 0, err
+	}
+	return len(pruned), nil
+}
+
+// flush writes every event to the file; the caller holds the lock.
+func (s *FileStore) flush() error {
+	raw, err := json.Marshal(listEvents(s.mem.events, ""))
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,96 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func TestFileStore_PersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	ctx := context.Background()
+	s, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"b", "a"} {
+		ev := Event{ID: id, State: StatePending, ReceivedAt: base.Add(time.Duration(i) * time.Minute), Payload: []byte(`{"x":1}`)}
+		if added, err := s.Add(ctx, ev); !added || err != nil {
+			t.Fatalf("Add(%s) = %v, %v", id, added, err)
+		}
+	}
+	if added, _ := s.Add(ctx, Event{ID: "a"}); added {
+		t.Error("duplicate added")
+	}
+	ev, _, _ := s.Get(ctx, "a")
+	ev.State = StateDead
+	if err := s.Save(ctx, ev); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, _ := reopened.List(ctx, "")
+	if len(all) != 2 || all[0].ID != "b" || all[1].ID != "a" {
+		t.Errorf("all = %+v", all)
+	}
+	dead, _ := reopened.List(ctx, StateDead)
+	if len(dead) != 1 || dead[0].ID != "a" || string(dead[0].Payload) != `{"x":1}` {
+		t.Errorf("dead = %+v", dead)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("temp files left behind: %d entries", len(entries))
+	}
+}
+
+func TestOpenFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFileStore(path); err == nil {
+		t.Error("corrupt file accepted")
+	}
+}
+
+func TestFileStore_PruneDropsOldDeliveredEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	ctx := context.Background()
+	s, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cutoff := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	for _, ev := range []Event{
+		{ID: "old-delivered", State: StateDelivered, ReceivedAt: cutoff.Add(-time.Hour)},
+		{ID: "new-delivered", State: StateDelivered, ReceivedAt: cutoff.Add(time.Hour)},
+		{ID: "old-pending", State: StatePending, ReceivedAt: cutoff.Add(-time.Hour)},
+		{ID: "old-dead", State: StateDead, ReceivedAt: cutoff.Add(-time.Hour)},
+	} {
+		if _, err := s.Add(ctx, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n, err := s.Prune(ctx, cutoff); n != 1 || err != nil {
+		t.Fatalf("Prune = %d, %v", n, err)
+	}
+
+	reopened, err := OpenFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := reopened.List(ctx, ""); len(all) != 3 {
+		t.Errorf("after prune = %+v", all)
+	}
+	if _, ok, _ := reopened.Get(ctx, "old-delivered"); ok {
+		t.Error("pruned event persisted")
+	}
+}
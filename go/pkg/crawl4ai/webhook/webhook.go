@@ -0,0 +1,380 @@
+// Package webhook receives the API's job webhooks durably: each
+// notification is stored before it is acknowledged, then dispatched to
+// your handlers with retries, so a consumer outage delays completed-job
+// notifications instead of dropping them. Events that keep failing go to
+// a dead-letter state and can be replayed.
+//
+//	store, err := webhook.OpenFileStore("webhooks.json")
+//	recv := webhook.New(store, webhook.Options{MaxAttempts: 8})
+//	recv.Handle("index", func(ctx context.Context, ev webhook.Event) error {
+//	    job, err := ev.Job()
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return indexResults(ctx, job)
+//	})
+//	http.Handle("/crawl4ai/webhook", recv)
+//	go recv.Run(ctx)
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/unclecode/crawl4ai-cloud-sdk/go/pkg/crawl4ai"
+)
+
+// State is where an event is in its delivery.
+type State string
+
+const (
+	// StatePending events are waiting for (another) dispatch attempt.
+	StatePending State = "pending"
+	// StateDelivered events were handled by every handler.
+	StateDelivered State = "delivered"
+	// StateDead events ran out of attempts; Replay revives them.
+	StateDead State = "dead"
+)
+
+// Event is one received webhook call and its delivery state.
+type Event struct {
+	// ID is "<job_id>:<status>", so the API retrying a notification does
+	// not dispatch it twice.
+	ID         string          `json:"id"`
+	JobID      string          `json:"job_id"`
+	Status     string          `json:"status"`
+	Payload    json.RawMessage `json:"payload"`
+	ReceivedAt time.Time       `json:"received_at"`
+
+	State    State `json:"state"`
+	Attempts int   `json:"attempts"`
+	// Done lists the handlers that have succeeded; a retry only runs the
+	// others.
+	Done        []string  `json:"done,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	NextAttempt time.Time `json:"next_attempt"`
+}
+
+// Job decodes the payload as the job it reports on.
+func (e Event) Job() (*crawl4ai.CrawlJob, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(e.Payload, &m); err != nil {
+		return nil, fmt.Errorf("webhook event %s: %w", e.ID, err)
+	}
+	return crawl4ai.CrawlJobFromMap(m), nil
+}
+
+// Handler processes an event. Delivery is at least once: a handler may see
+// the same event again after a crash, so it should be idempotent.
+type Handler func(ctx context.Context, ev Event) error
+
+// Options configures a Receiver.
+type Options struct {
+	// MaxAttempts before an event is dead-lettered. Default 5.
+	MaxAttempts int
+	// Backoff is the delay after the first failure; it doubles per attempt
+	// up to MaxBackoff. Defaults 1s and 5m.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// PollInterval is how often Run looks for due retries. Default 1s.
+	PollInterval time.Duration
+	// OnDeadLetter is called when an event runs out of attempts.
+	OnDeadLetter func(ev Event)
+	// OnError sees store errors during Run, which retries on its next
+	// tick.
+	OnError func(err error)
+	// MaxBodyBytes caps a webhook body. Default 64 MiB.
+	MaxBodyBytes int64
+	// DeliveredRetention is how long Run keeps delivered events, counted
+	// from receipt, so they can still be replayed and redeliveries are
+	// still dropped; older ones are pruned from the store. Default 24h;
+	// negative keeps them forever.
+	DeliveredRetention time.Duration
+}
+
+// Receiver is an http.Handler for the webhook URL plus the dispatcher that
+// feeds stored events to handlers.
+type Receiver struct {
+	store Store
+	opts  Options
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	// dispatching serializes dispatch passes with Replay.
+	dispatching sync.Mutex
+
+	wake chan struct{}
+}
+
+// New returns a Receiver storing events in store.
+func New(store Store, opts Options) *Receiver {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 5 * time.Minute
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = 64 << 20
+	}
+	if opts.DeliveredRetention == 0 {
+		opts.DeliveredRetention = 24 * time.Hour
+	}
+	return &Receiver{store: store, opts: opts, handlers: map[string]Handler{}, wake: make(chan struct{}, 1)}
+}
+
+// Handle registers h under name. Every event is delivered to every handler;
+// the name records which ones have succeeded, so keep it stable across
+// restarts.
+func (r *Receiver) Handle(name string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[name] = h
+}
+
+// ServeHTTP stores a webhook call and answers 200 once it is persisted —
+// or 500 if it could not be, so the API retries. Duplicates are
+// acknowledged without being stored again.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, r.opts.MaxBodyBytes))
+	if err != nil {
+		http.Error(w, "body too large or unreadable", http.StatusRequestEntityTooLarge)
+		return
+	}
+	ev, err := newEvent(body, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := r.store.Add(req.Context(), ev); err != nil {
+		http.Error(w, "could not store event", http.StatusInternalServerError)
+		return
+	}
+	r.poke()
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"received": ev.ID})
+}
+
+func newEvent(body []byte, now time.Time) (Event, error) {
+	var head struct {
+		JobID  string `json:"job_id"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(body, &head); err != nil {
+		return Event{}, fmt.Errorf("invalid JSON payload: %w", err)
+	}
+	id := head.JobID + ":" + head.Status
+	if head.JobID == "" {
+		id = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+	return Event{
+		ID: id, JobID: head.JobID, Status: head.Status, Payload: body, ReceivedAt: now,
+		State: StatePending, NextAttempt: now,
+	}, nil
+}
+
+func (r *Receiver) poke() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run dispatches pending events as they arrive or fall due, and prunes
+// delivered events past Options.DeliveredRetention, until ctx ends; then
+// it returns ctx.Err(). Run one per store.
+func (r *Receiver) Run(ctx context.Context) error {
+	ticker := time.NewTicker(r.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.DispatchDue(ctx); err != nil && ctx.Err() == nil && r.opts.OnError != nil {
+			r.opts.OnError(err)
+		}
+		if _, err := r.PruneDelivered(ctx); err != nil && ctx.Err() == nil && r.opts.OnError != nil {
+			r.opts.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// PruneDelivered removes delivered events older than
+// Options.DeliveredRetention from the store and returns how many. Run
+// calls it; it does nothing when retention is negative.
+func (r *Receiver) PruneDelivered(ctx context.Context) (int, error) {
+	if r.opts.DeliveredRetention < 0 {
+		return 0, nil
+	}
+	return r.store.Prune(ctx, time.Now().Add(-r.opts.DeliveredRetention))
+}
+
+// DispatchDue makes one delivery attempt for every pending event whose
+// retry time has come. Run calls it; call it directly to drive dispatch
+// yourself.
+func (r *Receiver) DispatchDue(ctx context.Context) error {
+	r.dispatching.Lock()
+	defer r.dispatching.Unlock()
+	pending, err := r.store.List(ctx, StatePending)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, ev := range pending {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if ev.NextAttempt.After(now) {
+			continue
+		}
+		if err := r.deliver(ctx, ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliver runs the handlers ev has not yet succeeded with and saves the
+// outcome.
+func (r *Receiver) deliver(ctx context.Context, ev Event) error {
+	done := map[string]bool{}
+	for _, n := range ev.Done {
+		done[n] = true
+	}
+	r.mu.Lock()
+	names := make([]string, 0, len(r.handlers))
+	handlers := make(map[string]Handler, len(r.handlers))
+	for n, h := range r.handlers {
+		names = append(names, n)
+		handlers[n] = h
+	}
+	r.mu.Unlock()
+	if len(names) == 0 {
+		// Keep the event until a handler is registered.
+		return nil
+	}
+	sort.Strings(names)
+
+	var failed []string
+	var lastErr error
+	for _, n := range names {
+		if done[n] {
+			continue
+		}
+		if err := callHandler(ctx, handlers[n], ev); err != nil {
+			failed = append(failed, n)
+			lastErr = fmt.Errorf("%s: %w", n, err)
+			continue
+		}
+		ev.Done = append(ev.Done, n)
+	}
+
+	ev.Attempts++
+	switch {
+	case len(failed) == 0:
+		ev.State = StateDelivered
+		ev.LastError = ""
+	case ev.Attempts >= r.opts.MaxAttempts:
+		ev.State = StateDead
+		ev.LastError = lastErr.Error()
+	default:
+		ev.LastError = lastErr.Error()
+		ev.NextAttempt = time.Now().Add(r.backoff(ev.Attempts))
+	}
+	if err := r.store.Save(ctx, ev); err != nil {
+		return err
+	}
+	if ev.State == StateDead && r.opts.OnDeadLetter != nil {
+		r.opts.OnDeadLetter(ev)
+	}
+	return nil
+}
+
+// callHandler turns a handler panic into an error so one bad event cannot
+// stop dispatch.
+func callHandler(ctx context.Context, h Handler, ev Event) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return h(ctx, ev)
+}
+
+func (r *Receiver) backoff(attempts int) time.Duration {
+	d := r.opts.Backoff
+	for i := 1; i < attempts && d < r.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, r.opts.MaxBackoff)
+}
+
+// Replay puts an event — delivered or dead — back in the queue with fresh
+// attempts. Handlers that already succeeded run again.
+func (r *Receiver) Replay(ctx context.Context, id string) error {
+	r.dispatching.Lock()
+	defer r.dispatching.Unlock()
+	ev, ok, err := r.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("webhook event %q not found", id)
+	}
+	if err := r.store.Save(ctx, reset(ev)); err != nil {
+		return err
+	}
+	r.poke()
+	return nil
+}
+
+// ReplayDead requeues every dead-lettered event and returns how many.
+func (r *Receiver) ReplayDead(ctx context.Context) (int, error) {
+	r.dispatching.Lock()
+	defer r.dispatching.Unlock()
+	dead, err := r.store.List(ctx, StateDead)
+	if err != nil {
+		return 0, err
+	}
+	for i, ev := range dead {
+		if err := r.store.Save(ctx, reset(ev)); err != nil {
+			return i, err
+		}
+	}
+	r.poke()
+	return len(dead), nil
+}
+
+// DeadLetters lists the events that ran out of attempts, oldest first.
+func (r *Receiver) DeadLetters(ctx context.Context) ([]Event, error) {
+	return r.store.List(ctx, StateDead)
+}
+
+func reset(ev Event) Event {
+	ev.State = StatePending
+	ev.Attempts = 0
+	ev.Done = nil
+	ev.LastError = ""
+	ev.NextAttempt = time.Now()
+	return ev
+}
@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ─── Unit tests (in-process mock server) ──────────────────────────────────
+
+func post(t *testing.T, h http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(body)))
+	return rec
+}
+
+const completed = `{"job_id":"job_1","status":"completed","progress":{"total":2,"completed":2,"failed":0}}`
+
+func TestServeHTTP_StoresAndDedupes(t *testing.T) {
+	store := NewMemoryStore()
+	r := New(store, Options{})
+	if rec := post(t, r, completed); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"received":"job_1:completed"`) {
+		t.Fatalf("first = %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := post(t, r, completed); rec.Code != http.StatusOK {
+		t.Fatalf("duplicate = %d", rec.Code)
+	}
+	events, _ := store.List(context.Background(), "")
+	if len(events) != 1 || events[0].State != StatePending || events[0].JobID != "job_1" {
+		t.Errorf("events = %+v", events)
+	}
+
+	if rec := post(t, r, "not json"); rec.Code != http.StatusBadRequest {
+		t.Errorf("bad body = %d", rec.Code)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hook", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET = %d", rec.Code)
+	}
+}
+
+// failingStore fails every Add.
+type failingStore struct{ *MemoryStore }
+
+func (failingStore) Add(context.Context, Event) (bool, error) { return false, errors.New("disk full") }
+
+func TestServeHTTP_StoreErrorAsks500(t *testing.T) {
+	r := New(failingStore{NewMemoryStore()}, Options{})
+	if rec := post(t, r, completed); rec.Code != http.StatusInternalServerError {
+		t.Errorf("code = %d", rec.Code)
+	}
+}
+
+// ─── Pure unit tests (no network) ─────────────────────────────────────────
+
+func TestDispatch_FanOutRetriesOnlyFailedHandler(t *testing.T) {
+	store := NewMemoryStore()
+	r := New(store, Options{Backoff: time.Nanosecond})
+	ctx := context.Background()
+	post(t, r, completed)
+
+	var calls = map[string]int{}
+	r.Handle("index", func(_ context.Context, ev Event) error {
+		calls["index"]++
+		job, err := ev.Job()
+		if err != nil || job.JobID != "job_1" || job.Progress.Completed != 2 {
+			t.Errorf("job = %+v err = %v", job, err)
+		}
+		return nil
+	})
+	r.Handle("notify", func(context.Context, Event) error {
+		calls["notify"]++
+		if calls["notify"] < 3 {
+			return errors.New("slack down")
+		}
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond)
+		if err := r.DispatchDue(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ev, _, _ := store.Get(ctx, "job_1:completed")
+	if ev.State != StateDelivered || ev.Attempts != 3 || ev.LastError != "" {
+		t.Errorf("event = %+v", ev)
+	}
+	if calls["index"] != 1 || calls["notify"] != 3 {
+		t.Errorf("calls = %v", calls)
+	}
+}
+
+func TestDispatch_DeadLetterAndReplay(t *testing.T) {
+	store := NewMemoryStore()
+	var dead []Event
+	r := New(store, Options{MaxAttempts: 2, Backoff: time.Nanosecond, OnDeadLetter: func(ev Event) { dead = append(dead, ev) }})
+	ctx := context.Background()
+	post(t, r, completed)
+	fail := true
+	r.Handle("h", func(context.Context, Event) error {
+		if fail {
+			panic("boom")
+		}
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond)
+		_ = r.DispatchDue(ctx)
+	}
+	letters, _ := r.DeadLetters(ctx)
+	if len(letters) != 1 || len(dead) != 1 || letters[0].Attempts != 2 || !strings.Contains(letters[0].LastError, "h: panic: boom") {
+		t.Fatalf("dead letters = %+v, callback = %d", letters, len(dead))
+	}
+
+	fail = false
+	if n, err := r.ReplayDead(ctx); err != nil || n != 1 {
+		t.Fatalf("ReplayDead = %d, %v", n, err)
+	}
+	_ = r.DispatchDue(ctx)
+	ev, _, _ := store.Get(ctx, "job_1:completed")
+	if ev.State != StateDelivered || ev.Attempts != 1 {
+		t.Errorf("after replay = %+v", ev)
+	}
+	if err := r.Replay(ctx, "nope"); err == nil {
+		t.Error("unknown event replayed")
+	}
+}
+
+func TestDispatch_WaitsForBackoffAndHandlers(t *testing.T) {
+	store := NewMemoryStore()
+	r := New(store, Options{Backoff: time.Hour})
+	ctx := context.Background()
+	post(t, r, completed)
+
+	_ = r.DispatchDue(ctx)
+	if ev, _, _ := store.Get(ctx, "job_1:completed"); ev.Attempts != 0 {
+		t.Fatalf("dispatched with no handlers: %+v", ev)
+	}
+	calls := 0
+	r.Handle("h", func(context.Context, Event) error { calls++; return errors.New("no") })
+	_ = r.DispatchDue(ctx)
+	_ = r.DispatchDue(ctx)
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 (second attempt is an hour away)", calls)
+	}
+}
+
+func TestBackoff_DoublesToCap(t *testing.T) {
+	r := New(NewMemoryStore(), Options{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 50: 5 * time.Second} {
+		if got := r.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestRun_DispatchesOnArrival(t *testing.T) {
+	r := New(NewMemoryStore(), Options{PollInterval: time.Hour})
+	got := make(chan string, 1)
+	r.Handle("h", func(_ context.Context, ev Event) error { got <- ev.ID; return nil })
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() { defer wg.Done(); _ = r.Run(ctx) }()
+
+	post(t, r, completed)
+	select {
+	case id := <-got:
+		if id != "job_1:completed" {
+			t.Errorf("id = %q", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("event not dispatched")
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestPruneDelivered_HonoursRetention(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	_, _ = store.Add(ctx, Event{ID: "old", State: StateDelivered, ReceivedAt: time.Now().Add(-48 * time.Hour)})
+	_, _ = store.Add(ctx, Event{ID: "recent", State: StateDelivered, ReceivedAt: time.Now()})
+
+	if n, _ := New(store, Options{DeliveredRetention: -1}).PruneDelivered(ctx); n != 0 {
+		t.Errorf("negative retention pruned %d", n)
+	}
+	if n, err := New(store, Options{}).PruneDelivered(ctx); n != 1 || err != nil {
+		t.Fatalf("PruneDelivered = %d, %v", n, err)
+	}
+	if _, ok, _ := store.Get(ctx, "recent"); !ok {
+		t.Error("event inside retention pruned")
+	}
+}